@@ -166,5 +166,5 @@ func TestUserClusterSeccompProfiles(ctx context.Context, log *zap.SugaredLogger,
 }
 
 func rbacResourceNames() []string {
-	return []string{rbacusercluster.ResourceOwnerName, rbacusercluster.ResourceEditorName, rbacusercluster.ResourceViewerName}
+	return append([]string{rbacusercluster.ResourceOwnerName, rbacusercluster.ResourceEditorName, rbacusercluster.ResourceViewerName}, rbacusercluster.MachineDeploymentsResourceNames()...)
 }
@@ -66,6 +66,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/handler"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	"k8c.io/kubermatic/v2/pkg/handler/scim"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	v2 "k8c.io/kubermatic/v2/pkg/handler/v2"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
@@ -422,6 +423,7 @@ func createInitProviders(ctx context.Context, options serverRunOptions, masterCf
 		ruleGroupProviderGetter:                        ruleGroupProviderGetter,
 		clusterTemplateInstanceProviderGetter:          clusterTemplateInstanceProviderGetter,
 		privilegedAllowedRegistryProvider:              privilegedAllowedRegistryProvider,
+		privilegedAlertmanagerReceiverTemplateProvider: kubernetesprovider.NewPrivilegedAlertmanagerReceiverTemplateProvider(mgr.GetClient()),
 		etcdBackupConfigProviderGetter:                 etcdBackupConfigProviderGetter,
 		etcdRestoreProviderGetter:                      etcdRestoreProviderGetter,
 		etcdBackupConfigProjectProviderGetter:          etcdBackupConfigProjectProviderGetter,
@@ -546,6 +548,7 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		ClusterTemplateInstanceProviderGetter:          prov.clusterTemplateInstanceProviderGetter,
 		RuleGroupProviderGetter:                        prov.ruleGroupProviderGetter,
 		PrivilegedAllowedRegistryProvider:              prov.privilegedAllowedRegistryProvider,
+		PrivilegedAlertmanagerReceiverTemplateProvider: prov.privilegedAlertmanagerReceiverTemplateProvider,
 		EtcdBackupConfigProviderGetter:                 prov.etcdBackupConfigProviderGetter,
 		EtcdRestoreProviderGetter:                      prov.etcdRestoreProviderGetter,
 		EtcdBackupConfigProjectProviderGetter:          prov.etcdBackupConfigProjectProviderGetter,
@@ -601,6 +604,10 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 	r.RegisterV1Websocket(v1Router)
 	rv2.RegisterV2(v2Router, options.featureGates.Enabled(features.OIDCKubeCfgEndpoint), oidcConfiguration)
 
+	if options.scimToken != "" {
+		scim.New(log, mgr.GetClient(), prov.user, options.namespace, options.scimToken).Register(mainRouter.PathPrefix("/scim/v2").Subrouter())
+	}
+
 	mainRouter.Methods(http.MethodGet).
 		Path("/api/swagger.json").
 		HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -66,6 +66,9 @@ type serverRunOptions struct {
 	// service account configuration
 	serviceAccountSigningKey string
 
+	// scimToken is the bearer token for the SCIM provisioning endpoint
+	scimToken string
+
 	featureGates features.FeatureGate
 	versions     kubermatic.Versions
 }
@@ -99,6 +102,7 @@ func newServerRunOptions() (serverRunOptions, error) {
 	flag.Var(&s.featureGates, "feature-gates", "A set of key=value pairs that describe feature gates for various features.")
 	flag.StringVar(&s.domain, "domain", "localhost", "A domain name on which the server is deployed")
 	flag.StringVar(&s.serviceAccountSigningKey, "service-account-signing-key", "", "Signing key authenticates the service account's token value using HMAC. It is recommended to use a key with 32 bytes or longer.")
+	flag.StringVar(&s.scimToken, "scim-token", "", "The bearer token SCIM clients use to provision users and groups. The SCIM endpoint is disabled if no token is given.")
 	flag.StringVar(&rawExposeStrategy, "expose-strategy", "NodePort", "The strategy to expose the controlplane with, either \"NodePort\" which creates NodePorts with a \"nodeport-proxy.k8s.io/expose: true\" annotation or \"LoadBalancer\", which creates a LoadBalancer")
 	flag.StringVar(&s.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for datacenter custom resources")
 	flag.StringVar(&configFile, "kubermatic-configuration-file", "", "(for development only) path to a KubermaticConfiguration YAML file")
@@ -179,6 +183,7 @@ type providers struct {
 	clusterTemplateInstanceProviderGetter          provider.ClusterTemplateInstanceProviderGetter
 	ruleGroupProviderGetter                        provider.RuleGroupProviderGetter
 	privilegedAllowedRegistryProvider              provider.PrivilegedAllowedRegistryProvider
+	privilegedAlertmanagerReceiverTemplateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider
 	etcdBackupConfigProviderGetter                 provider.EtcdBackupConfigProviderGetter
 	etcdRestoreProviderGetter                      provider.EtcdRestoreProviderGetter
 	etcdBackupConfigProjectProviderGetter          provider.EtcdBackupConfigProjectProviderGetter
@@ -32,6 +32,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/util/cli"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	addonmutation "k8c.io/kubermatic/v2/pkg/webhook/addon/mutation"
+	addonvalidation "k8c.io/kubermatic/v2/pkg/webhook/addon/validation"
 	applicationdefinitionvalidation "k8c.io/kubermatic/v2/pkg/webhook/application/applicationdefinition/validation"
 	clustermutation "k8c.io/kubermatic/v2/pkg/webhook/cluster/mutation"
 	clustervalidation "k8c.io/kubermatic/v2/pkg/webhook/cluster/validation"
@@ -43,7 +44,10 @@ import (
 	mlaadminsettingmutation "k8c.io/kubermatic/v2/pkg/webhook/mlaadminsetting/mutation"
 	oscvalidation "k8c.io/kubermatic/v2/pkg/webhook/operatingsystemmanager/operatingsystemconfig/validation"
 	ospvalidation "k8c.io/kubermatic/v2/pkg/webhook/operatingsystemmanager/operatingsystemprofile/validation"
+	projectalertmanagervalidation "k8c.io/kubermatic/v2/pkg/webhook/projectalertmanager/validation"
+	projectrulegroupvalidation "k8c.io/kubermatic/v2/pkg/webhook/projectrulegroup/validation"
 	resourcequotavalidation "k8c.io/kubermatic/v2/pkg/webhook/resourcequota/validation"
+	rulegroupvalidation "k8c.io/kubermatic/v2/pkg/webhook/rulegroup/validation"
 	seedwebhook "k8c.io/kubermatic/v2/pkg/webhook/seed"
 	uservalidation "k8c.io/kubermatic/v2/pkg/webhook/user/validation"
 	usersshkeymutation "k8c.io/kubermatic/v2/pkg/webhook/usersshkey/mutation"
@@ -179,11 +183,37 @@ func main() {
 
 	addonmutation.NewAdmissionHandler(seedGetter, seedClientGetter).SetupWebhookWithManager(mgr)
 
+	addonValidator := addonvalidation.NewValidator(mgr.GetClient())
+	if err := builder.WebhookManagedBy(mgr).For(&kubermaticv1.Addon{}).WithValidator(addonValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup addon validation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// setup MLAAdminSetting webhooks
 
 	mlaadminsettingmutation.NewAdmissionHandler(seedGetter, seedClientGetter).SetupWebhookWithManager(mgr)
 
+	// /////////////////////////////////////////
+	// setup RuleGroup webhooks
+
+	ruleGroupValidator := rulegroupvalidation.NewValidator()
+	if err := builder.WebhookManagedBy(mgr).For(&kubermaticv1.RuleGroup{}).WithValidator(ruleGroupValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup RuleGroup validation webhook", zap.Error(err))
+	}
+
+	projectRuleGroupValidator := projectrulegroupvalidation.NewValidator()
+	if err := builder.WebhookManagedBy(mgr).For(&kubermaticv1.ProjectRuleGroup{}).WithValidator(projectRuleGroupValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup ProjectRuleGroup validation webhook", zap.Error(err))
+	}
+
+	// /////////////////////////////////////////
+	// setup ProjectAlertmanager webhooks
+
+	projectAlertmanagerValidator := projectalertmanagervalidation.NewValidator()
+	if err := builder.WebhookManagedBy(mgr).For(&kubermaticv1.ProjectAlertmanager{}).WithValidator(projectAlertmanagerValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup ProjectAlertmanager validation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// setup User webhooks
 
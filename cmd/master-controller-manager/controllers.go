@@ -23,6 +23,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	addonconfigsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/addonconfig-synchronizer"
+	applicationdefinitionschemacontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-definition-schema-controller"
 	applicationdefinitionsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-definition-synchronizer"
 	applicationsecretsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-secret-synchronizer"
 	clustertemplatesynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/cluster-template-synchronizer"
@@ -33,6 +35,7 @@ import (
 	masterconstrainttemplatecontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/master-constraint-template-controller"
 	presetsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/preset-synchronizer"
 	projectlabelsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/project-label-synchronizer"
+	projectlifecycle "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/project-lifecycle"
 	projectsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/project-synchronizer"
 	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	seedproxy "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/seed-proxy"
@@ -74,6 +77,7 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	applicationdefinitionsynchronizerFactory := applicationDefinitionSynchronizerFactoryCreator(ctrlCtx)
 	applicationSecretSynchronizerFactor := applicationSecretSynchronizerFactoryCreator(ctrlCtx)
 	presetSynchronizerFactory := presetSynchronizerFactoryCreator(ctrlCtx)
+	addonConfigSynchronizerFactory := addonConfigSynchronizerFactoryCreator(ctrlCtx)
 	resourceQuotaSynchronizerFactory := resourceQuotaSynchronizerFactoryCreator(ctrlCtx)
 	resourceQuotaControllerFactory := resourceQuotaControllerFactoryCreator(ctrlCtx)
 
@@ -95,6 +99,7 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 		applicationdefinitionsynchronizerFactory,
 		applicationSecretSynchronizerFactor,
 		presetSynchronizerFactory,
+		addonConfigSynchronizerFactory,
 		resourceQuotaSynchronizerFactory,
 		resourceQuotaControllerFactory,
 	); err != nil {
@@ -110,6 +115,9 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	if err := serviceaccount.Add(ctrlCtx.mgr, ctrlCtx.log); err != nil {
 		return fmt.Errorf("failed to create serviceaccount controller: %w", err)
 	}
+	if err := projectlifecycle.Add(ctrlCtx.mgr, ctrlCtx.log); err != nil {
+		return fmt.Errorf("failed to create project-lifecycle controller: %w", err)
+	}
 	if err := seedstatuscontroller.Add(ctrlCtx.ctx, ctrlCtx.mgr, 1, ctrlCtx.log, ctrlCtx.namespace, ctrlCtx.seedKubeconfigGetter, ctrlCtx.versions); err != nil {
 		return fmt.Errorf("failed to create seed status controller: %w", err)
 	}
@@ -125,6 +133,9 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	if err := kubeone.Add(ctrlCtx.ctx, ctrlCtx.mgr, ctrlCtx.log); err != nil {
 		return fmt.Errorf("failed to create kubeone controller: %w", err)
 	}
+	if err := applicationdefinitionschemacontroller.Add(ctrlCtx.mgr, ctrlCtx.log, ctrlCtx.namespace); err != nil {
+		return fmt.Errorf("failed to create application definition schema controller: %w", err)
+	}
 	if err := kcstatuscontroller.Add(ctrlCtx.ctx, ctrlCtx.mgr, 1, ctrlCtx.log, ctrlCtx.namespace, ctrlCtx.versions); err != nil {
 		return fmt.Errorf("failed to create kubermatic configuration controller: %w", err)
 	}
@@ -284,3 +295,13 @@ func presetSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontroller
 		)
 	}
 }
+
+func addonConfigSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, masterMgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return addonconfigsynchronizer.ControllerName, addonconfigsynchronizer.Add(
+			masterMgr,
+			seedManagerMap,
+			ctrlCtx.log,
+		)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	autoupdatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/auto-update-controller"
 	backupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/backup"
 	cloudcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
+	cloudresourceauditor "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud-resource-auditor"
 	clustercredentialscontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-credentials-controller"
 	clusterphasecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-phase-controller"
 	clusterstuckcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-stuck-controller"
@@ -83,6 +84,7 @@ var AllControllers = map[string]controllerCreator{
 	operatingsystemprofilesynchronizer.ControllerName:       createOperatingSystemProfileController,
 	clustercredentialscontroller.ControllerName:             createClusterCredentialsController,
 	applicationsecretclustercontroller.ControllerName:       createApplicationSecretClusterController,
+	cloudresourceauditor.ControllerName:                     createCloudResourceAuditor,
 }
 
 type controllerCreator func(*controllerContext) error
@@ -129,6 +131,23 @@ func createCloudController(ctrlCtx *controllerContext) error {
 	return nil
 }
 
+func createCloudResourceAuditor(ctrlCtx *controllerContext) error {
+	cloudresourceauditor.MustRegisterMetrics(prometheus.DefaultRegisterer)
+
+	if err := cloudresourceauditor.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.runOptions.namespace,
+		ctrlCtx.runOptions.seedName,
+		ctrlCtx.seedGetter,
+		ctrlCtx.runOptions.caBundle.CertPool(),
+	); err != nil {
+		return fmt.Errorf("failed to add cloud resource auditor to mgr: %w", err)
+	}
+	return nil
+}
+
 func createKubernetesController(ctrlCtx *controllerContext) error {
 	backupInterval, err := time.ParseDuration(ctrlCtx.runOptions.backupInterval)
 	if err != nil {
@@ -176,6 +195,9 @@ func createProjectController(ctrlCtx *controllerContext) error {
 		ctrlCtx.mgr,
 		ctrlCtx.log,
 		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.namespace,
+		ctrlCtx.seedGetter,
+		ctrlCtx.clientProvider,
 	)
 }
 
@@ -371,6 +393,8 @@ func createMLAController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.cortexAlertmanagerURL,
 		ctrlCtx.runOptions.cortexRulerURL,
 		ctrlCtx.runOptions.lokiRulerURL,
+		ctrlCtx.runOptions.cortexDistributorURL,
+		ctrlCtx.runOptions.lokiQuerierURL,
 		ctrlCtx.runOptions.enableUserClusterMLA,
 	)
 }
@@ -90,6 +90,8 @@ type controllerRunOptions struct {
 	cortexAlertmanagerURL string
 	cortexRulerURL        string
 	lokiRulerURL          string
+	cortexDistributorURL  string
+	lokiQuerierURL        string
 
 	// Machine Controller configuration
 	machineControllerImageTag        string
@@ -143,6 +145,8 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.StringVar(&c.cortexAlertmanagerURL, "cortex-alertmanager-url", "http://cortex-alertmanager.mla.svc.cluster.local:8080", "The URL of cortex alertmanager which is running for MLA stack.")
 	flag.StringVar(&c.cortexRulerURL, "cortex-ruler-url", "http://cortex-ruler.mla.svc.cluster.local:8080", "The URL of cortex ruler which is running for MLA stack.")
 	flag.StringVar(&c.lokiRulerURL, "loki-ruler-url", "http://loki-distributed-ruler.mla.svc.cluster.local:3100", "The URL of loki ruler which is running for MLA stack.")
+	flag.StringVar(&c.cortexDistributorURL, "cortex-distributor-url", "http://cortex-distributor.mla.svc.cluster.local:8080", "The URL of cortex distributor which is running for MLA stack.")
+	flag.StringVar(&c.lokiQuerierURL, "loki-querier-url", "http://loki-distributed-querier.mla.svc.cluster.local:3100", "The URL of loki querier which is running for MLA stack.")
 	flag.StringVar(&c.machineControllerImageTag, "machine-controller-image-tag", "", "The Machine Controller image tag.")
 	flag.StringVar(&c.machineControllerImageRepository, "machine-controller-image-repository", "", "The Machine Controller image repository.")
 	flag.StringVar(&configFile, "kubermatic-configuration-file", "", "(for development only) path to a KubermaticConfiguration YAML file")
@@ -32,6 +32,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	applicationinstallationvalidation "k8c.io/kubermatic/v2/pkg/webhook/application/applicationinstallation/validation"
 	machinevalidation "k8c.io/kubermatic/v2/pkg/webhook/machine/validation"
+	machinedeploymentmutation "k8c.io/kubermatic/v2/pkg/webhook/machinedeployment/mutation"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -132,6 +133,12 @@ func main() {
 		log.Fatalw("Failed to setup Machine validation webhook", zap.Error(err))
 	}
 
+	// Setup MachineDeployment Webhook in user manager.
+	machineDeploymentMutator := machinedeploymentmutation.NewMutator(seedMgr.GetClient(), log, options.clusterName)
+	if err := builder.WebhookManagedBy(userMgr).For(&clusterv1alpha1.MachineDeployment{}).WithDefaulter(machineDeploymentMutator).Complete(); err != nil {
+		log.Fatalw("Failed to setup MachineDeployment mutation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// Start managers
 
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+// SortAddons sorts the addons topologically, so that every addon comes after the addons it requires.
+// Requirements on addons that are not part of the list are ignored. Addons without dependencies
+// between each other keep their order. An error is returned if the requirements contain a cycle.
+func SortAddons(addons []kubermaticv1.Addon) ([]kubermaticv1.Addon, error) {
+	index := make(map[string]int, len(addons))
+	for i, addon := range addons {
+		index[addon.Name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(addons))
+	sorted := make([]kubermaticv1.Addon, 0, len(addons))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("addons have cyclic requirements: %s", strings.Join(append(path, addons[i].Name), " -> "))
+		}
+
+		state[i] = visiting
+		for _, name := range addons[i].Spec.RequiredAddons {
+			if j, ok := index[name]; ok {
+				if err := visit(j, append(path, addons[i].Name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = visited
+		sorted = append(sorted, addons[i])
+
+		return nil
+	}
+
+	for i := range addons {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortAddons(t *testing.T) {
+	newAddon := func(name string, requires ...string) kubermaticv1.Addon {
+		return kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubermaticv1.AddonSpec{RequiredAddons: requires},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		addons        []kubermaticv1.Addon
+		expected      []string
+		expectedError bool
+	}{
+		{
+			name:     "addons without requirements keep their order",
+			addons:   []kubermaticv1.Addon{newAddon("rbac"), newAddon("csi"), newAddon("openvpn")},
+			expected: []string{"rbac", "csi", "openvpn"},
+		},
+		{
+			name: "required addons are sorted first",
+			addons: []kubermaticv1.Addon{
+				newAddon("hubble", "cilium"),
+				newAddon("rbac"),
+				newAddon("metallb-config", "metallb"),
+				newAddon("cilium", "kube-proxy"),
+				newAddon("metallb"),
+				newAddon("kube-proxy"),
+			},
+			expected: []string{"kube-proxy", "cilium", "hubble", "rbac", "metallb", "metallb-config"},
+		},
+		{
+			name:     "requirements on unknown addons are ignored",
+			addons:   []kubermaticv1.Addon{newAddon("hubble", "cilium"), newAddon("rbac")},
+			expected: []string{"hubble", "rbac"},
+		},
+		{
+			name:          "cyclic requirements are rejected",
+			addons:        []kubermaticv1.Addon{newAddon("a", "b"), newAddon("b", "c"), newAddon("c", "a")},
+			expectedError: true,
+		},
+		{
+			name:          "addons requiring themselves are rejected",
+			addons:        []kubermaticv1.Addon{newAddon("a", "a")},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted, err := SortAddons(tc.addons)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got %v", tc.expectedError, err)
+			}
+			if tc.expectedError {
+				return
+			}
+
+			var names []string
+			for _, addon := range sorted {
+				names = append(names, addon.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expected, ",") {
+				t.Fatalf("expected order %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
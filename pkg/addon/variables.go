@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Types of AddonFormControls.
+const (
+	ControlTypeText     = "text"
+	ControlTypeTextArea = "text-area"
+	ControlTypeNumber   = "number"
+	ControlTypeBoolean  = "boolean"
+)
+
+// ValidateVariables validates the variables of an addon against the controls of its AddonConfig.
+// Variables without a control are allowed, because they can be used by the addon templates
+// without being exposed in the UI.
+func ValidateVariables(controls []kubermaticv1.AddonFormControl, variables *runtime.RawExtension, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	values, err := decodeVariables(variables)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, string(variables.Raw), err.Error()))
+	}
+
+	for _, control := range controls {
+		if control.InternalName == "" {
+			continue
+		}
+
+		path := fldPath.Key(control.InternalName)
+		value, ok := values[control.InternalName]
+		if !ok || value == nil {
+			if control.Required {
+				allErrs = append(allErrs, field.Required(path, fmt.Sprintf("%s is required", displayName(control))))
+			}
+			continue
+		}
+
+		allErrs = append(allErrs, validateValue(control, value, path)...)
+	}
+
+	return allErrs
+}
+
+func validateValue(control kubermaticv1.AddonFormControl, value interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch control.Type {
+	case ControlTypeText, ControlTypeTextArea:
+		s, ok := value.(string)
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a string"))
+		}
+		if control.Required && s == "" {
+			allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("%s is required", displayName(control))))
+		}
+		if control.Pattern != "" {
+			pattern, err := regexp.Compile(control.Pattern)
+			if err != nil {
+				return append(allErrs, field.InternalError(fldPath, fmt.Errorf("invalid pattern %q in AddonConfig: %w", control.Pattern, err)))
+			}
+			if !pattern.MatchString(s) {
+				allErrs = append(allErrs, field.Invalid(fldPath, s, fmt.Sprintf("must match %q", control.Pattern)))
+			}
+		}
+
+	case ControlTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a number"))
+		}
+
+	case ControlTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a boolean"))
+		}
+	}
+
+	if len(control.Enum) > 0 {
+		formatted := formatValue(value)
+
+		found := false
+		for _, allowed := range control.Enum {
+			if allowed == formatted {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotSupported(fldPath, formatted, control.Enum))
+		}
+	}
+
+	return allErrs
+}
+
+// DefaultVariables sets the variables that are not set to the defaults of their controls.
+func DefaultVariables(controls []kubermaticv1.AddonFormControl, variables *runtime.RawExtension) (*runtime.RawExtension, error) {
+	values, err := decodeVariables(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	defaulted := false
+	for _, control := range controls {
+		if control.InternalName == "" || control.Default == "" {
+			continue
+		}
+		if value, ok := values[control.InternalName]; ok && value != nil {
+			continue
+		}
+
+		value, err := parseDefault(control)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default of %s: %w", control.InternalName, err)
+		}
+
+		if values == nil {
+			values = map[string]interface{}{}
+		}
+		values[control.InternalName] = value
+		defaulted = true
+	}
+
+	if !defaulted {
+		return variables, nil
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variables: %w", err)
+	}
+
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
+func parseDefault(control kubermaticv1.AddonFormControl) (interface{}, error) {
+	switch control.Type {
+	case ControlTypeNumber:
+		return strconv.ParseFloat(control.Default, 64)
+	case ControlTypeBoolean:
+		return strconv.ParseBool(control.Default)
+	default:
+		return control.Default, nil
+	}
+}
+
+func decodeVariables(variables *runtime.RawExtension) (map[string]interface{}, error) {
+	if variables == nil || len(variables.Raw) == 0 {
+		return nil, nil
+	}
+
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(variables.Raw, &values); err != nil {
+		return nil, fmt.Errorf("variables must be an object: %w", err)
+	}
+
+	return values, nil
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func displayName(control kubermaticv1.AddonFormControl) string {
+	if control.DisplayName != "" {
+		return control.DisplayName
+	}
+	return control.InternalName
+}
+
+// GetAddonConfig returns the AddonConfig of the addon, or nil if the addon has none. AddonConfigs
+// are synced from the master cluster, so on seeds without the AddonConfig CRD there is none either.
+func GetAddonConfig(ctx context.Context, client ctrlruntimeclient.Client, name string) (*kubermaticv1.AddonConfig, error) {
+	config := &kubermaticv1.AddonConfig{}
+	if err := client.Get(ctx, types.NamespacedName{Name: name}, config); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get AddonConfig %s: %w", name, err)
+	}
+
+	return config, nil
+}
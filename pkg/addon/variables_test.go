@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var testControls = []kubermaticv1.AddonFormControl{
+	{InternalName: "hostname", Type: ControlTypeText, Required: true, Pattern: `^[a-z0-9.-]+$`},
+	{InternalName: "replicas", Type: ControlTypeNumber, Enum: []string{"1", "3", "5"}},
+	{InternalName: "enabled", Type: ControlTypeBoolean, Default: "true"},
+	{InternalName: "mode", Type: ControlTypeText, Enum: []string{"strict", "permissive"}, Default: "strict"},
+	{InternalName: "config", Type: ControlTypeTextArea},
+}
+
+func TestValidateVariables(t *testing.T) {
+	testCases := []struct {
+		name           string
+		variables      string
+		expectedFields []string
+	}{
+		{
+			name:      "valid variables",
+			variables: `{"hostname":"example.com","replicas":3,"enabled":false,"mode":"permissive","config":"foo: bar","unknown":"ignored"}`,
+		},
+		{
+			name:           "missing required variable",
+			variables:      `{"replicas":1}`,
+			expectedFields: []string{"spec.variables[hostname]"},
+		},
+		{
+			name:           "empty required text variable",
+			variables:      `{"hostname":""}`,
+			expectedFields: []string{"spec.variables[hostname]", "spec.variables[hostname]"},
+		},
+		{
+			name:           "no variables at all",
+			expectedFields: []string{"spec.variables[hostname]"},
+		},
+		{
+			name:           "wrong types",
+			variables:      `{"hostname":1,"replicas":"3","enabled":"yes","config":false}`,
+			expectedFields: []string{"spec.variables[hostname]", "spec.variables[replicas]", "spec.variables[enabled]", "spec.variables[config]"},
+		},
+		{
+			name:           "value not matching the pattern",
+			variables:      `{"hostname":"Example.com"}`,
+			expectedFields: []string{"spec.variables[hostname]"},
+		},
+		{
+			name:           "values not in the enum",
+			variables:      `{"hostname":"example.com","replicas":2,"mode":"lenient"}`,
+			expectedFields: []string{"spec.variables[replicas]", "spec.variables[mode]"},
+		},
+		{
+			name:           "variables that are not an object",
+			variables:      `["hostname"]`,
+			expectedFields: []string{"spec.variables"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var variables *runtime.RawExtension
+			if tc.variables != "" {
+				variables = &runtime.RawExtension{Raw: []byte(tc.variables)}
+			}
+
+			errs := ValidateVariables(testControls, variables, field.NewPath("spec", "variables"))
+			if len(errs) != len(tc.expectedFields) {
+				t.Fatalf("expected %d errors, got %v", len(tc.expectedFields), errs)
+			}
+			for i, err := range errs {
+				if err.Field != tc.expectedFields[i] {
+					t.Errorf("expected error %d for field %s, got %v", i, tc.expectedFields[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultVariables(t *testing.T) {
+	testCases := []struct {
+		name      string
+		variables string
+		expected  string
+	}{
+		{
+			name:     "defaults are set without variables",
+			expected: `{"enabled":true,"mode":"strict"}`,
+		},
+		{
+			name:      "set variables are not overwritten",
+			variables: `{"enabled":false,"hostname":"example.com"}`,
+			expected:  `{"enabled":false,"hostname":"example.com","mode":"strict"}`,
+		},
+		{
+			name:      "null variables are defaulted",
+			variables: `{"enabled":null,"mode":"permissive"}`,
+			expected:  `{"enabled":true,"mode":"permissive"}`,
+		},
+		{
+			name:      "variables are unchanged if nothing is defaulted",
+			variables: `{"enabled": false, "mode": "permissive"}`,
+			expected:  `{"enabled": false, "mode": "permissive"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var variables *runtime.RawExtension
+			if tc.variables != "" {
+				variables = &runtime.RawExtension{Raw: []byte(tc.variables)}
+			}
+
+			defaulted, err := DefaultVariables(testControls, variables)
+			if err != nil {
+				t.Fatalf("failed to default variables: %v", err)
+			}
+			if string(defaulted.Raw) != tc.expected {
+				t.Errorf("expected variables %s, got %s", tc.expected, string(defaulted.Raw))
+			}
+		})
+	}
+
+	if _, err := DefaultVariables([]kubermaticv1.AddonFormControl{{InternalName: "enabled", Type: ControlTypeBoolean, Default: "maybe"}}, nil); err == nil {
+		t.Error("expected an invalid default to be rejected")
+	}
+}
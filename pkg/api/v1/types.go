@@ -2711,10 +2711,12 @@ type MeteringReport struct {
 // MeteringReportConfiguration holds report configuration
 // swagger:model MeteringReportConfiguration
 type MeteringReportConfiguration struct {
-	Name      string  `json:"name"`
-	Schedule  string  `json:"schedule"`
-	Interval  uint32  `json:"interval"`
-	Retention *uint32 `json:"retention,omitempty"`
+	Name      string   `json:"name"`
+	Schedule  string   `json:"schedule"`
+	Interval  uint32   `json:"interval"`
+	Retention *uint32  `json:"retention,omitempty"`
+	Formats   []string `json:"formats,omitempty"`
+	Metrics   []string `json:"metrics,omitempty"`
 }
 
 // ReportURL represent an S3 pre signed URL to download a report
@@ -27,6 +27,7 @@ import (
 	ksemver "k8c.io/kubermatic/v2/pkg/semver"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ConstraintTemplate represents a gatekeeper ConstraintTemplate
@@ -252,6 +253,42 @@ type RuleGroup struct {
 	Type kubermaticv1.RuleGroupType `json:"type"`
 }
 
+// RuleGroupTest contains a rule group together with unit tests to run against it.
+// swagger:model RuleGroupTest
+type RuleGroupTest struct {
+	// contains the RuleGroup data. Ref: https://prometheus.io/docs/prometheus/latest/configuration/recording_rules/#rule_group
+	Data []byte `json:"data"`
+	// contains the unit tests in the format of `promtool test rules`, without the `rule_files` field.
+	// Ref: https://prometheus.io/docs/prometheus/latest/configuration/unit_testing_rules/
+	Tests []byte `json:"tests"`
+}
+
+// RuleGroupTestResult is the result of running the unit tests of a rule group.
+// swagger:model RuleGroupTestResult
+type RuleGroupTestResult struct {
+	// Passed is true if all unit tests passed.
+	Passed bool `json:"passed"`
+	// Failures contains a description of every failed test.
+	Failures []string `json:"failures,omitempty"`
+	// FiringAlerts contains the alerts firing at the evaluation times of the alert rule tests.
+	FiringAlerts []RuleGroupTestAlert `json:"firingAlerts,omitempty"`
+}
+
+// RuleGroupTestAlert is an alert that fired during a rule group unit test.
+// swagger:model RuleGroupTestAlert
+type RuleGroupTestAlert struct {
+	// TestGroup is the name of the test group the alert fired in.
+	TestGroup string `json:"testGroup,omitempty"`
+	// EvalTime is the evaluation time at which the alert was firing.
+	EvalTime string `json:"evalTime"`
+	// Alertname is the name of the alert.
+	Alertname string `json:"alertname"`
+	// Labels are the labels of the alert.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the annotations of the alert.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 // AllowedRegistry represents a object containing a allowed image registry prefix
 // swagger:model AllowedRegistry
 type AllowedRegistry struct {
@@ -260,6 +297,24 @@ type AllowedRegistry struct {
 	Spec kubermaticv1.AllowedRegistrySpec `json:"spec"`
 }
 
+// AlertmanagerReceiverTemplate represents a receiver that is defined by an admin
+// and can be attached to the Alertmanager configuration of clusters
+// swagger:model AlertmanagerReceiverTemplate
+type AlertmanagerReceiverTemplate struct {
+	Name string `json:"name"`
+
+	Spec kubermaticv1.AlertmanagerReceiverTemplateSpec `json:"spec"`
+}
+
+// AlertmanagerReceiverAttachment is used to attach a receiver template to the Alertmanager of a cluster
+// swagger:model AlertmanagerReceiverAttachment
+type AlertmanagerReceiverAttachment struct {
+	// Template is the name of the receiver template.
+	Template string `json:"template"`
+	// Name is the name of the receiver in the Alertmanager configuration, defaults to the template name.
+	Name string `json:"name,omitempty"`
+}
+
 // EtcdBackupConfig represents an object holding the configuration for etcd backups
 // swagger:model EtcdBackupConfig
 type EtcdBackupConfig struct {
@@ -393,6 +448,12 @@ type MLAAdminSetting struct {
 	MonitoringRateLimits *kubermaticv1.MonitoringRateLimitSettings `json:"monitoringRateLimits,omitempty"`
 	// LoggingRateLimits contains rate-limiting configuration logging in the user cluster.
 	LoggingRateLimits *kubermaticv1.LoggingRateLimitSettings `json:"loggingRateLimits,omitempty"`
+	// MonitoringRetention is the period for which metrics of the user cluster are kept, e.g. `30d`.
+	MonitoringRetention string `json:"monitoringRetention,omitempty"`
+	// LoggingRetention is the period for which logs of the user cluster are kept, e.g. `7d`.
+	LoggingRetention string `json:"loggingRetention,omitempty"`
+	// Usage contains the current resource usage of the user cluster. It is read-only.
+	Usage *kubermaticv1.MLAUsage `json:"usage,omitempty"`
 }
 
 // ExternalCluster represents an object holding cluster details
@@ -1570,6 +1631,10 @@ type ResourceQuotaStatus struct {
 	GlobalUsage Quota `json:"globalUsage,omitempty"`
 	// LocalUsage is holds the current usage of resources for the local seed.
 	LocalUsage Quota `json:"localUsage,omitempty"`
+	// GlobalCost holds the estimated monthly cost of the clusters on all seeds, keyed by currency.
+	GlobalCost map[string]float64 `json:"globalCost,omitempty"`
+	// LocalCost holds the estimated monthly cost of the clusters on the local seed, keyed by currency.
+	LocalCost map[string]float64 `json:"localCost,omitempty"`
 }
 
 // swagger:model Quota
@@ -1582,6 +1647,33 @@ type Quota struct {
 	Storage float64 `json:"storage,omitempty"`
 }
 
+// CostEstimate is the estimated monthly cost of a cluster.
+// swagger:model CostEstimate
+type CostEstimate struct {
+	// Currency of all amounts; empty if nothing could be priced.
+	Currency string `json:"currency,omitempty"`
+	// Monthly is the total monthly cost, rounded to 2 decimal places.
+	Monthly float64 `json:"monthly"`
+	// ControlPlane is the monthly cost of the control plane.
+	ControlPlane float64 `json:"controlPlane"`
+	// MachineDeployments are the estimates of the individual machine deployments.
+	MachineDeployments []MachineDeploymentCostEstimate `json:"machineDeployments"`
+}
+
+// MachineDeploymentCostEstimate is the estimated monthly cost of a machine deployment.
+// swagger:model MachineDeploymentCostEstimate
+type MachineDeploymentCostEstimate struct {
+	Name        string `json:"name,omitempty"`
+	MachineType string `json:"machineType,omitempty"`
+	Replicas    int32  `json:"replicas"`
+	// PricePerMachine is the monthly price of a single machine. It is not set if the
+	// price of the machine type is unknown, in which case the machine deployment is
+	// not part of the total.
+	PricePerMachine *float64 `json:"pricePerMachine,omitempty"`
+	// Monthly is the monthly cost of all replicas.
+	Monthly float64 `json:"monthly"`
+}
+
 // swagger:model GroupProjectBinding
 type GroupProjectBinding struct {
 	Name      string `json:"name"`
@@ -1624,6 +1716,29 @@ type ApplicationInstallationStatus struct {
 
 	// Method used to install the application
 	Method appskubermaticv1.TemplateMethod `json:"method"`
+
+	// HelmReleaseHistory holds the last revisions of the helm release, newest first. This field is only filled if template method is 'helm'.
+	HelmReleaseHistory []HelmReleaseRevision `json:"helmReleaseHistory,omitempty"`
+}
+
+// HelmReleaseRevision is a revision of the helm release of an application.
+// swagger:model HelmReleaseRevision
+type HelmReleaseRevision struct {
+	// Revision of the release.
+	Revision int `json:"revision"`
+	// Status is the state of the release.
+	Status string `json:"status,omitempty"`
+	// Description is human-friendly "log entry" about this release.
+	Description string `json:"description,omitempty"`
+	// LastDeployed is when the revision was deployed.
+	LastDeployed apiv1.Time `json:"lastDeployed,omitempty"`
+}
+
+// ApplicationInstallationRollbackBody is the object representing the POST payload to rollback the helm release of an ApplicationInstallation.
+// swagger:model ApplicationInstallationRollbackBody
+type ApplicationInstallationRollbackBody struct {
+	// Revision of the helm release to rollback to.
+	Revision int `json:"revision"`
 }
 
 type ApplicationInstallationCondition struct {
@@ -1665,9 +1780,47 @@ type ApplicationDefinition struct {
 	Spec *appskubermaticv1.ApplicationDefinitionSpec `json:"spec"`
 }
 
+// ApplicationValuesSchema is the JSON schema of the values of an application version. It can be used to generate
+// forms for the values of an ApplicationInstallation.
+// swagger:model ApplicationValuesSchema
+type ApplicationValuesSchema struct {
+	// Schema is the JSON schema of the values. It is empty if the version does not define a schema or if the schema
+	// has not been resolved from the chart yet.
+	Schema *runtime.RawExtension `json:"schema,omitempty"`
+
+	// DefaultValues are the default values of the ApplicationDefinition with the defaults declared in the schema
+	// applied.
+	DefaultValues *runtime.RawExtension `json:"defaultValues,omitempty"`
+}
+
 // swagger:model OperatingSystemProfile
 type OperatingSystemProfile struct {
 	Name                    string   `json:"name"`
 	OperatingSystem         string   `json:"operatingSystem"`
 	SupportedCloudProviders []string `json:"supportedCloudProviders,omitempty"`
 }
+
+// MeteringUsage is the usage of all projects and clusters aggregated from metering reports for a time range.
+// swagger:model MeteringUsage
+type MeteringUsage struct {
+	From     time.Time              `json:"from"`
+	To       time.Time              `json:"to"`
+	Projects []MeteringProjectUsage `json:"projects"`
+}
+
+// MeteringProjectUsage is the aggregated usage of a single project.
+// swagger:model MeteringProjectUsage
+type MeteringProjectUsage struct {
+	ProjectID   string                 `json:"projectID"`
+	ProjectName string                 `json:"projectName,omitempty"`
+	Metrics     map[string]float64     `json:"metrics"`
+	Clusters    []MeteringClusterUsage `json:"clusters"`
+}
+
+// MeteringClusterUsage is the aggregated usage of a single cluster.
+// swagger:model MeteringClusterUsage
+type MeteringClusterUsage struct {
+	ClusterID   string             `json:"clusterID"`
+	ClusterName string             `json:"clusterName,omitempty"`
+	Metrics     map[string]float64 `json:"metrics"`
+}
@@ -130,19 +130,121 @@ type GitSource struct {
 	Credentials *GitCredentials `json:"credentials,omitempty"`
 }
 
+type OCICredentials struct {
+	// Username holds the ref and key in the secret for the username credential. Secret must exist in the namespace where
+	// KKP is installed.
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+
+	// Password holds the ref and key in the secret for the Password credential. Secret must exist in the namespace where
+	// KKP is installed.
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+
+	// RegistryConfigFile holds the ref and key in the secret for the registry credential file. The value is dockercfg
+	// file that follows the same format rules as ~/.docker/config.json
+	// The Secret must exist in the namespace where KKP is installed.
+	RegistryConfigFile *corev1.SecretKeySelector `json:"registryConfigFile,omitempty"`
+}
+
+type OCISource struct {
+	// Reference of the artifact in the OCI registry without tag or digest (e.g. oci://localhost:5000/myrepo/mybundle).
+	// +kubebuilder:validation:Pattern="^oci://.+"
+	URL string `json:"url"`
+
+	// Tag of the artifact.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Digest of the artifact's manifest (e.g. sha256:...). It pins the artifact to an immutable version and takes
+	// precedence over the tag. The digest of the pulled manifest and of all its layers is verified.
+	// +kubebuilder:validation:Pattern:=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Path of the "source" in the artifact. default is the root of the artifact
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PlainHTTP uses HTTP instead of HTTPS to connect to the registry.
+	// +optional
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+
+	// Credentials hold the ref to the secret with registry credentials
+	// +optional
+	Credentials *OCICredentials `json:"credentials,omitempty"`
+}
+
+type TarballCredentials struct {
+	// Username holds the ref and key in the secret for the username used for HTTP basic authentication. Secret must
+	// exist in the namespace where KKP is installed.
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+
+	// Password holds the ref and key in the secret for the password used for HTTP basic authentication. Secret must
+	// exist in the namespace where KKP is installed.
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+
+	// Token holds the ref and key in the secret for the bearer token used for HTTP authentication. Secret must exist
+	// in the namespace where KKP is installed.
+	Token *corev1.SecretKeySelector `json:"token,omitempty"`
+
+	// AccessKeyID holds the ref and key in the secret for the access key ID of the S3 bucket. Secret must exist in the
+	// namespace where KKP is installed.
+	AccessKeyID *corev1.SecretKeySelector `json:"accessKeyID,omitempty"`
+
+	// SecretAccessKey holds the ref and key in the secret for the secret access key of the S3 bucket. Secret must
+	// exist in the namespace where KKP is installed.
+	SecretAccessKey *corev1.SecretKeySelector `json:"secretAccessKey,omitempty"`
+}
+
+type TarballSource struct {
+	// URL of the tarball (.tar or .tar.gz). It can be an HTTP(s) URL (e.g. https://localhost/bundle.tar.gz) or an
+	// object in an S3-compatible bucket (e.g. s3://mybucket/path/bundle.tar.gz).
+	// +kubebuilder:validation:Pattern="^(http|https|s3)://.+"
+	URL string `json:"url"`
+
+	// Endpoint of the S3-compatible storage (e.g. https://s3.amazonaws.com). It is required for s3 URLs.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Checksum of the tarball in the form sha256:<hex>. The tarball is rejected if its checksum does not match.
+	// +kubebuilder:validation:Pattern:=`^sha256:[a-f0-9]{64}$`
+	Checksum string `json:"checksum"`
+
+	// Path of the "source" in the tarball. default is the root of the tarball
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Credentials hold the ref to the secret with the credentials to download the tarball
+	// +optional
+	Credentials *TarballCredentials `json:"credentials,omitempty"`
+}
+
 type ApplicationSource struct {
 	// Get application to install from a Helm repository
 	Helm *HelmSource `json:"helm,omitempty"`
 
 	// Get application to install from a Git repository
 	Git *GitSource `json:"git,omitempty"`
+
+	// Get application to install from an OCI artifact
+	OCI *OCISource `json:"oci,omitempty"`
+
+	// Get application to install from a tarball served over HTTP(s) or stored in an S3-compatible bucket
+	Tarball *TarballSource `json:"tarball,omitempty"`
 }
 
 const (
 	HelmTemplateMethod TemplateMethod = "helm"
+
+	// KustomizeTemplateMethod renders the kustomization located at the root of the source and applies the result
+	// with server-side apply. It can not be used with a Helm source.
+	KustomizeTemplateMethod TemplateMethod = "kustomize"
+
+	// ManifestsTemplateMethod applies all YAML manifests found in the source directory with server-side apply.
+	// It can not be used with a Helm source.
+	ManifestsTemplateMethod TemplateMethod = "manifests"
 )
 
-// +kubebuilder:validation:Enum=helm
+// +kubebuilder:validation:Enum=helm;kustomize;manifests
 type TemplateMethod string
 
 type ApplicationTemplate struct {
@@ -156,6 +258,42 @@ type ApplicationVersion struct {
 
 	// Template defines how application is installed (source provenance, Method...)
 	Template ApplicationTemplate `json:"template"`
+
+	// DependsOn is a list of applications that must be installed and ready in the user cluster before this version
+	// of the application is installed.
+	// +optional
+	DependsOn []ApplicationDependency `json:"dependsOn,omitempty"`
+
+	// ValuesSchema defines the JSON schema the values of an ApplicationInstallation using this version must satisfy.
+	// Defaults declared in the schema are applied to the values before the application is installed.
+	// +optional
+	ValuesSchema *ValuesSchema `json:"valuesSchema,omitempty"`
+}
+
+// ValuesSchema defines where the JSON schema for the values of an application version comes from.
+// Inline and FromChart are mutually exclusive.
+type ValuesSchema struct {
+	// Inline is a JSON schema (draft 7) declared directly in the ApplicationDefinition.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Inline *runtime.RawExtension `json:"inline,omitempty"`
+
+	// FromChart uses the values.schema.json shipped with the Helm chart of this version. The schema is resolved
+	// by KKP and published in the status of the ApplicationDefinition. It requires the helm method and a Helm source.
+	// +optional
+	FromChart bool `json:"fromChart,omitempty"`
+}
+
+// ApplicationDependency describes an application another application depends on.
+type ApplicationDependency struct {
+	// Name of the ApplicationDefinition.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Version is a SemVer constraint the installed version of the application must satisfy (e.g. ">= 1.8"). If empty,
+	// any version satisfies the dependency.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // ApplicationDefinitionSpec defines the desired state of ApplicationDefinition.
@@ -170,10 +308,68 @@ type ApplicationDefinitionSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	DefaultValues *runtime.RawExtension `json:"defaultValues,omitempty"`
 
+	// Verification configures the verification of the application's sources before they are installed. If set, sources
+	// that can not be verified are refused.
+	// +optional
+	Verification *SourceVerification `json:"verification,omitempty"`
+
 	// available version for this application
 	Versions []ApplicationVersion `json:"versions"`
 }
 
+// SourceVerification configures how the sources of an application are verified. Besides the keys referenced here,
+// the keys of the secret named application-trusted-keys are trusted for every application.
+type SourceVerification struct {
+	// Cosign verifies the cosign signature of charts and artifacts pulled from OCI registries.
+	// +optional
+	Cosign *CosignVerification `json:"cosign,omitempty"`
+
+	// Provenance verifies the provenance file (.prov) of charts downloaded from Helm HTTP repositories.
+	// +optional
+	Provenance *ProvenanceVerification `json:"provenance,omitempty"`
+}
+
+type CosignVerification struct {
+	// PublicKeys holds the refs and keys in the secrets for the PEM encoded public keys (ECDSA, RSA or Ed25519) trusted
+	// to sign the artifacts. The signature must be valid for at least one of the trusted keys.
+	// Secrets must exist in the namespace where KKP is installed.
+	// +optional
+	PublicKeys []corev1.SecretKeySelector `json:"publicKeys,omitempty"`
+}
+
+type ProvenanceVerification struct {
+	// Keyring holds the ref and key in the secret for the PGP public keyring (binary format) trusted to sign the
+	// charts. Secret must exist in the namespace where KKP is installed.
+	// +optional
+	Keyring *corev1.SecretKeySelector `json:"keyring,omitempty"`
+}
+
+// ApplicationDefinitionStatus defines the observed state of ApplicationDefinition.
+type ApplicationDefinitionStatus struct {
+	// ValuesSchemas holds the values schemas resolved from the charts of the versions using ValuesSchema.FromChart.
+	// +optional
+	ValuesSchemas []ResolvedValuesSchema `json:"valuesSchemas,omitempty"`
+}
+
+// ResolvedValuesSchema is the values schema of an application version resolved from its source.
+type ResolvedValuesSchema struct {
+	// Version of the application the schema belongs to.
+	Version string `json:"version"`
+
+	// Source identifies the chart (url, name and version) the schema was resolved from. It is used to detect
+	// changes of the source that require resolving the schema again.
+	Source string `json:"source"`
+
+	// Schema is the JSON schema shipped with the chart. It is empty if the chart does not provide a schema.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Schema *runtime.RawExtension `json:"schema,omitempty"`
+
+	// Message holds the reason why the schema could not be resolved.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:scope=Cluster
 
@@ -183,6 +379,8 @@ type ApplicationDefinition struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec ApplicationDefinitionSpec `json:"spec,omitempty"`
+
+	Status ApplicationDefinitionStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
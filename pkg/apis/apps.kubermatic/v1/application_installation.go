@@ -69,6 +69,84 @@ type ApplicationInstallationSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Values runtime.RawExtension `json:"values,omitempty"`
 	// As kubebuilder does not support interface{} as a type, deferring json decoding, seems to be our best option (see https://github.com/kubernetes-sigs/controller-tools/issues/294#issuecomment-518379253)
+
+	// DependsOn is a list of ApplicationInstallations that must be ready before this application is installed.
+	// On deletion, this application is uninstalled before its dependencies.
+	// +optional
+	DependsOn []ApplicationInstallationReference `json:"dependsOn,omitempty"`
+
+	// DeployOptions holds the settings specific to the templating method used to deploy the application.
+	// +optional
+	DeployOptions *DeployOptions `json:"deployOptions,omitempty"`
+
+	// DriftDetection configures the periodic comparison of the desired state of the application with the live objects
+	// in the user cluster. Drift is only detected if this is set, as each detection downloads the application source.
+	// +optional
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+}
+
+// DriftDetection configures the periodic comparison of the desired state of the application with the live objects.
+type DriftDetection struct {
+	// Disabled turns off drift detection for this application.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Interval between two drift detections. Defaults to 10m.
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// SelfHeal re-applies the desired state of the application when drift is detected.
+	// If not set, drift is only reported with the Drifted condition.
+	// +optional
+	SelfHeal bool `json:"selfHeal,omitempty"`
+}
+
+// DeployOptions holds the settings specific to the templating method used to deploy the application.
+type DeployOptions struct {
+	// Helm holds the deployment settings when templating method is Helm.
+	// +optional
+	Helm *HelmDeployOptions `json:"helm,omitempty"`
+}
+
+// HelmDeployOptions holds the deployment settings when templating method is Helm.
+type HelmDeployOptions struct {
+	// Wait corresponds to the --wait flag on Helm cli.
+	// If set, the release is marked as successful only once all Pods, PVCs, Services and the minimum number of Pods
+	// of Deployments, StatefulSets and ReplicaSets are ready. It waits for at most timeout.
+	// +optional
+	Wait bool `json:"wait,omitempty"`
+
+	// Timeout corresponds to the --timeout flag on Helm cli.
+	// It's the time to wait for any individual Kubernetes operation (like Jobs for hooks). Defaults to 5m.
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// Atomic corresponds to the --atomic flag on Helm cli.
+	// If set, a failed installation is uninstalled and a failed upgrade is rolled back. It requires wait.
+	// +optional
+	Atomic bool `json:"atomic,omitempty"`
+
+	// MaxHistory corresponds to the --history-max flag on Helm cli.
+	// It limits the maximum number of revisions saved per release. 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxHistory int `json:"maxHistory,omitempty"`
+
+	// AutoRollback rolls back the release to the last successfully deployed revision if an upgrade fails.
+	// Unlike atomic, it does not wait for the resources to be ready.
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
+}
+
+// ApplicationInstallationReference references an ApplicationInstallation in the user cluster.
+type ApplicationInstallationReference struct {
+	// Name of the ApplicationInstallation.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the ApplicationInstallation. Defaults to the namespace of the referencing ApplicationInstallation.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // NamespaceSpec describe the desired state of the namespace where application will be created.
@@ -133,8 +211,53 @@ type ApplicationInstallationStatus struct {
 	// Method used to install the application
 	Method TemplateMethod `json:"method"`
 
+	// Verification configures the verification of the application's source. It is copied from the ApplicationDefinition.
+	// +optional
+	Verification *SourceVerification `json:"verification,omitempty"`
+
 	// HelmRelease holds the information about the helm release installed by this application. This field is only filled if template method is 'helm'.
 	HelmRelease *HelmRelease `json:"helmRelease,omitempty"`
+
+	// HelmReleaseHistory holds the last revisions of the helm release, newest first. This field is only filled if template method is 'helm'.
+	// +optional
+	HelmReleaseHistory []HelmRelease `json:"helmReleaseHistory,omitempty"`
+
+	// RolledBackGeneration is the generation of the ApplicationInstallation for which the helm release has been rolled
+	// back on request. As long as the spec is not changed, the rolled back revision is kept.
+	// +optional
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+
+	// ObservedGeneration is the generation of the ApplicationInstallation that has last been successfully installed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Inventory holds the objects applied into the user cluster by this application. Objects that are no longer part
+	// of the rendered manifests are pruned. This field is only filled if template method is 'kustomize' or 'manifests'.
+	Inventory *ApplicationInventory `json:"inventory,omitempty"`
+}
+
+// ApplicationInventory is the list of objects applied into the user cluster by an application.
+type ApplicationInventory struct {
+	// Objects applied into the user cluster.
+	Objects []InventoryObject `json:"objects,omitempty"`
+}
+
+// InventoryObject identifies an object applied into the user cluster.
+type InventoryObject struct {
+	// Group of the object. Empty for the core group.
+	Group string `json:"group,omitempty"`
+
+	// Version of the object.
+	Version string `json:"version"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object. Empty for cluster-scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
 }
 
 type HelmRelease struct {
@@ -185,7 +308,7 @@ type ApplicationInstallationCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=ManifestsRetrieved;Ready
+// +kubebuilder:validation:Enum=ManifestsRetrieved;Ready;Drifted
 
 // swagger:enum ApplicationInstallationConditionType
 // All condition types must be registered within the `AllApplicationInstallationConditionTypes` variable.
@@ -197,9 +320,14 @@ const (
 
 	// Ready describes all components have been successfully rolled out and are ready.
 	Ready ApplicationInstallationConditionType = "Ready"
+
+	// Drifted indicates that live objects in the user cluster differ from the desired state of the application.
+	// The message lists the drifted objects.
+	Drifted ApplicationInstallationConditionType = "Drifted"
 )
 
 var AllApplicationInstallationConditionTypes = []ApplicationInstallationConditionType{
 	ManifestsRetrieved,
 	Ready,
+	Drifted,
 }
@@ -23,4 +23,27 @@ const (
 	// ApplicationInstallationCleanupFinalizer indicates that application installed on user-cluster need cleanup
 	// ie uninstall the application, remove  namespace where application were installed ...
 	ApplicationInstallationCleanupFinalizer = "kubermatic.k8c.io/cleanup-application-installation"
+
+	// ApplicationInstallationRollbackAnnotation requests the rollback of the Helm release of an ApplicationInstallation
+	// to the revision set as value. The annotation is removed once the rollback has been performed.
+	ApplicationInstallationRollbackAnnotation = "apps.kubermatic.k8c.io/rollback-to-revision"
+
+	// ApplicationInstallationReinstallAnnotation forces the installation of an ApplicationInstallation even if its spec
+	// has not changed since the last successful installation. The annotation is removed once the application has been
+	// installed again.
+	ApplicationInstallationReinstallAnnotation = "apps.kubermatic.k8c.io/reinstall"
+
+	// ApplicationTrustedKeysSecretName is the name of the secret holding the keys trusted to verify the sources of
+	// all applications. Keys of the secret ending with ApplicationTrustedKeysCosignSuffix hold PEM encoded cosign public
+	// keys and the key ApplicationTrustedKeysKeyringKey holds a PGP public keyring used to verify Helm provenance files.
+	// The secret must exist in the namespace where KKP is installed.
+	ApplicationTrustedKeysSecretName = "application-trusted-keys"
+
+	// ApplicationTrustedKeysCosignSuffix is the suffix of the keys of the ApplicationTrustedKeysSecretName secret holding
+	// cosign public keys.
+	ApplicationTrustedKeysCosignSuffix = ".pub"
+
+	// ApplicationTrustedKeysKeyringKey is the key of the ApplicationTrustedKeysSecretName secret holding the PGP public
+	// keyring.
+	ApplicationTrustedKeysKeyringKey = "pubring.gpg"
 )
@@ -30,6 +30,11 @@ const (
 	AddonKindName = "Addon"
 
 	AddonResourcesCreated AddonConditionType = "AddonResourcesCreatedSuccessfully"
+	// AddonDependenciesReady is true when all addons required by the addon are ready.
+	AddonDependenciesReady AddonConditionType = "AddonDependenciesReady"
+	// AddonReady is true when the resources of the addon have been created and all its readiness
+	// checks pass.
+	AddonReady AddonConditionType = "AddonReady"
 )
 
 // +kubebuilder:object:generate=true
@@ -68,8 +73,43 @@ type AddonSpec struct {
 	// apiserver must be installed before this addon can be installed. The addon will not
 	// be installed until that resource is served.
 	RequiredResourceTypes []GroupVersionKind `json:"requiredResourceTypes,omitempty"`
+	// RequiredAddons are the names of the addons of the same cluster that must be ready before this
+	// addon can be installed.
+	RequiredAddons []string `json:"requiredAddons,omitempty"`
+	// ReadinessChecks are the resources in the user cluster that must be ready before this addon is
+	// considered ready. Without checks, the addon is ready as soon as its resources have been created.
+	ReadinessChecks []AddonReadinessCheck `json:"readinessChecks,omitempty"`
 	// IsDefault indicates whether the addon is default
 	IsDefault bool `json:"isDefault,omitempty"`
+	// DryRun applies the manifests of the addon with a server-side dry-run. The result is reported in
+	// the status, but no object is created, updated or pruned in the cluster.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Deployment;DaemonSet;CustomResourceDefinition
+
+// AddonReadinessCheckKind is the kind of resource checked by an AddonReadinessCheck.
+type AddonReadinessCheckKind string
+
+const (
+	// AddonReadinessCheckDeployment is ready when all replicas of the Deployment are updated and available.
+	AddonReadinessCheckDeployment AddonReadinessCheckKind = "Deployment"
+	// AddonReadinessCheckDaemonSet is ready when all pods of the DaemonSet are updated and ready.
+	AddonReadinessCheckDaemonSet AddonReadinessCheckKind = "DaemonSet"
+	// AddonReadinessCheckCustomResourceDefinition is ready when the CustomResourceDefinition is established.
+	AddonReadinessCheckCustomResourceDefinition AddonReadinessCheckKind = "CustomResourceDefinition"
+)
+
+// AddonReadinessCheck references a resource in the user cluster whose readiness signals that the
+// addon is ready.
+type AddonReadinessCheck struct {
+	// Kind of the resource, one of Deployment, DaemonSet or CustomResourceDefinition.
+	Kind AddonReadinessCheckKind `json:"kind"`
+	// Namespace of the resource. Must be empty for CustomResourceDefinitions.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the resource.
+	Name string `json:"name"`
 }
 
 // +kubebuilder:object:generate=true
@@ -85,9 +125,43 @@ type AddonList struct {
 
 type AddonStatus struct {
 	Conditions map[AddonConditionType]AddonCondition `json:"conditions,omitempty"`
+	// Objects holds the status of the objects applied into the cluster by the addon. Objects that are
+	// not part of the manifests anymore are pruned.
+	Objects []AddonObjectStatus `json:"objects,omitempty"`
+	// DryRunObjects holds the result of the last dry-run. It is only set if dry-run is enabled.
+	DryRunObjects []AddonObjectStatus `json:"dryRunObjects,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=AddonResourcesCreatedSuccessfully
+// +kubebuilder:validation:Enum=Applied;Failed;Pruned
+
+// AddonObjectPhase is the phase of an object of an addon.
+type AddonObjectPhase string
+
+const (
+	// AddonObjectApplied means that the object has been applied into the cluster.
+	AddonObjectApplied AddonObjectPhase = "Applied"
+	// AddonObjectFailed means that the object could not be applied or pruned.
+	AddonObjectFailed AddonObjectPhase = "Failed"
+	// AddonObjectPruned means that the object has been pruned. It's only used for dry-runs, because
+	// pruned objects are removed from the status.
+	AddonObjectPruned AddonObjectPhase = "Pruned"
+)
+
+// AddonObjectStatus is the status of an object of an addon.
+type AddonObjectStatus struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Phase of the object.
+	Phase AddonObjectPhase `json:"phase"`
+	// Message holds the error if the object could not be applied or pruned.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=AddonResourcesCreatedSuccessfully;AddonDependenciesReady;AddonReady
 
 type AddonConditionType string
 
@@ -99,4 +173,10 @@ type AddonCondition struct {
 	// Last time the condition transit from one status to another.
 	// +optional
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
@@ -58,8 +58,15 @@ type AddonFormControl struct {
 	HelpText string `json:"helpText,omitempty"`
 	// Required indicates if the control has to be set
 	Required bool `json:"required,omitempty"`
-	// Type of displayed control
+	// Type of displayed control, i.e. text, text-area, number or boolean. The variable of the control
+	// has to be of the matching JSON type.
 	Type string `json:"type,omitempty"`
+	// Enum restricts the control to the given values
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression that the value of a text control has to match
+	Pattern string `json:"pattern,omitempty"`
+	// Default is the value of the control if it is not set in the addon, e.g. "true" for a boolean control
+	Default string `json:"default,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
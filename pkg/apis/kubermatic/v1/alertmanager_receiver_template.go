@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// AlertmanagerReceiverTemplateResourceName represents "Resource" defined in Kubernetes.
+	AlertmanagerReceiverTemplateResourceName = "alertmanagerreceivertemplates"
+
+	// AlertmanagerReceiverTemplateKindName represents "Kind" defined in Kubernetes.
+	AlertmanagerReceiverTemplateKindName = "AlertmanagerReceiverTemplate"
+)
+
+// +kubebuilder:validation:Enum=slack;email;webhook;pagerduty
+
+// AlertmanagerReceiverType is the notification integration of a receiver template.
+type AlertmanagerReceiverType string
+
+const (
+	AlertmanagerReceiverTypeSlack     AlertmanagerReceiverType = "slack"
+	AlertmanagerReceiverTypeEmail     AlertmanagerReceiverType = "email"
+	AlertmanagerReceiverTypeWebhook   AlertmanagerReceiverType = "webhook"
+	AlertmanagerReceiverTypePagerDuty AlertmanagerReceiverType = "pagerduty"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:JSONPath=".spec.type",name="Type",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// AlertmanagerReceiverTemplate is a receiver that is defined once by an admin and can be
+// attached to the Alertmanager configuration of user clusters.
+type AlertmanagerReceiverTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertmanagerReceiverTemplateSpec `json:"spec,omitempty"`
+}
+
+// AlertmanagerReceiverTemplateSpec specifies the data for an Alertmanager receiver template.
+type AlertmanagerReceiverTemplateSpec struct {
+	// Type is the notification integration used by the receiver.
+	Type AlertmanagerReceiverType `json:"type"`
+	// Description is shown to users when choosing a template.
+	Description string `json:"description,omitempty"`
+	// Receiver is the receiver configuration in the Alertmanager format, without the
+	// receiver name, e.g. a YAML document containing `slack_configs`. The integration
+	// configured here must match the type.
+	Receiver string `json:"receiver"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// AlertmanagerReceiverTemplateList specifies a list of Alertmanager receiver templates.
+type AlertmanagerReceiverTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AlertmanagerReceiverTemplate `json:"items"`
+}
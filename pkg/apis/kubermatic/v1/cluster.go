@@ -921,10 +921,21 @@ type FakeCloudSpec struct {
 }
 
 // DigitaloceanCloudSpec specifies access data to DigitalOcean.
+// Droplets are always placed into the default VPC of the region, as the
+// machine-controller can not place them into a dedicated VPC.
 type DigitaloceanCloudSpec struct {
 	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
 
 	Token string `json:"token,omitempty"` // Token is used to authenticate with the DigitalOcean API.
+
+	// FirewallID is the ID of the cloud firewall that is applied to all droplets
+	// carrying the cluster tag. If empty, a firewall that allows all traffic between
+	// the droplets, and ICMP, SSH and the NodePort range from outside is created
+	// for the cluster.
+	FirewallID string `json:"firewallID,omitempty"`
+	// ProjectID is the ID of the project that all droplets and volumes of the
+	// cluster are assigned to. If empty, a project is created for the cluster.
+	ProjectID string `json:"projectID,omitempty"`
 }
 
 // HetznerCloudSpec specifies access data to hetzner cloud.
@@ -937,7 +948,16 @@ type HetznerCloudSpec struct {
 	// While machines can be in multiple networks, a single one must be chosen for the
 	// HCloud CCM to work.
 	// If this is empty, the network configured on the datacenter will be used.
+	// If neither is set, a dedicated network is created for the cluster.
 	Network string `json:"network,omitempty"`
+	// Firewall is the name of the Hetzner firewall that is attached to all machines.
+	// If empty and the machines are attached to a private network, a firewall that
+	// only allows ICMP, SSH and the NodePort range is created for the cluster.
+	Firewall string `json:"firewall,omitempty"`
+	// PlacementGroupPrefix is the name prefix of the spread placement groups the
+	// machines are assigned to, so that they are not scheduled on the same host.
+	// If empty, a placement group is created for the cluster.
+	PlacementGroupPrefix string `json:"placementGroupPrefix,omitempty"`
 }
 
 // AzureCloudSpec defines cloud resource references for Microsoft Azure.
@@ -1248,6 +1268,14 @@ type AnexiaCloudSpec struct {
 	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
 
 	Token string `json:"token,omitempty"`
+
+	// VLANID is the identifier of the VLAN the machines of the cluster are attached to.
+	// If empty, a dedicated VLAN is created for the cluster and removed when the
+	// cluster is deleted.
+	VLANID string `json:"vlanID,omitempty"`
+	// PrefixID is the identifier of the IP prefix assigned to the VLAN. It is only
+	// created automatically if the VLAN was created by KKP as well.
+	PrefixID string `json:"prefixID,omitempty"`
 }
 
 // NutanixCSIConfig contains credentials and the endpoint for the Nutanix Prism Element to which the CSI driver connects.
@@ -125,6 +125,9 @@ type KubermaticAuthConfiguration struct {
 	IssuerCookieKey          string `json:"issuerCookieKey,omitempty"`
 	ServiceAccountKey        string `json:"serviceAccountKey,omitempty"`
 	SkipTokenIssuerTLSVerify bool   `json:"skipTokenIssuerTLSVerify,omitempty"`
+	// SCIMToken is the bearer token that SCIM clients must use to provision users and groups
+	// through the SCIM 2.0 endpoint of the KKP API. The endpoint is disabled if no token is configured.
+	SCIMToken string `json:"scimToken,omitempty"`
 }
 
 // KubermaticAPIConfiguration configures the dashboard.
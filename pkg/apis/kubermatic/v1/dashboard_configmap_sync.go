@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DashboardConfigMapSyncResourceName represents "Resource" defined in Kubernetes.
+	DashboardConfigMapSyncResourceName = "dashboardconfigmapsyncs"
+
+	// DashboardConfigMapSyncKindName represents "Kind" defined in Kubernetes.
+	DashboardConfigMapSyncKindName = "DashboardConfigMapSync"
+)
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// DashboardConfigMapSync records the Grafana dashboards that were synced from the ConfigMap of the
+// same name in the MLA namespace. It is managed by the seed-controller-manager and owned by the
+// ConfigMap.
+type DashboardConfigMapSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DashboardConfigMapSyncStatus `json:"status,omitempty"`
+}
+
+// DashboardConfigMapSyncStatus stores status information about a DashboardConfigMapSync.
+type DashboardConfigMapSyncStatus struct {
+	// Organizations contains the dashboards that were synced, keyed by Grafana organization ID.
+	Organizations map[string]GrafanaDashboardsSyncState `json:"organizations,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// DashboardConfigMapSyncList specifies a list of dashboard ConfigMap syncs.
+type DashboardConfigMapSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DashboardConfigMapSync `json:"items"`
+}
@@ -284,6 +284,23 @@ type SeedSpec struct {
 	// EtcdBackupRestore holds the configuration of the automatic etcd backup restores for the Seed;
 	// if this is set, the new backup/restore controllers are enabled for this Seed.
 	EtcdBackupRestore *EtcdBackupRestore `json:"etcdBackupRestore,omitempty"`
+	// Optional: CloudResourceAudit configures the periodic audit for cloud resources that
+	// were created for clusters which no longer exist on this seed.
+	CloudResourceAudit *CloudResourceAuditSettings `json:"cloudResourceAudit,omitempty"`
+}
+
+// CloudResourceAuditSettings configures the detection of orphaned cloud resources, like security
+// groups or networks, that stay behind when a cluster was deleted without its cleanup finalizers
+// being processed.
+type CloudResourceAuditSettings struct {
+	// Disable disables the audit. Orphaned resources are reported as metrics by default.
+	Disable bool `json:"disable,omitempty"`
+	// Interval is the time between two audits of a datacenter. Defaults to 6h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// DeleteOrphanedResources enables the deletion of resources that were found to be orphaned
+	// in two consecutive audits. This must only be enabled if the cloud accounts used by this
+	// seed are not shared with clusters of other seeds, as those are unknown to this seed.
+	DeleteOrphanedResources bool `json:"deleteOrphanedResources,omitempty"`
 }
 
 // EtcdBackupRestore holds the configuration of the automatic backup and restores.
@@ -413,6 +430,27 @@ type DatacenterSpec struct {
 	// too high means that *if* a resource at a cloud provider is removed/changed outside
 	// of KKP, it will take this long to fix it.
 	ProviderReconciliationInterval *metav1.Duration `json:"providerReconciliationInterval,omitempty"`
+
+	// Optional: Pricing configures the prices that are used to estimate the cost of
+	// clusters and MachineDeployments in this datacenter. Prices configured here take
+	// precedence over those fetched from the provider API (only Hetzner and DigitalOcean
+	// support fetching prices).
+	Pricing *DatacenterPricing `json:"pricing,omitempty"`
+}
+
+// DatacenterPricing configures the static prices of a datacenter. All prices are
+// monthly and given as decimal numbers, e.g. "12.50".
+type DatacenterPricing struct {
+	// Currency of all prices, e.g. "EUR". Prices fetched from the API of the
+	// cloud provider are only used if they are in the same currency.
+	Currency string `json:"currency"`
+	// Optional: ControlPlane is the price of the control plane of a single cluster,
+	// to account for the resources it uses on the seed.
+	// +kubebuilder:validation:Pattern:=`^[0-9]+(\.[0-9]+)?$`
+	ControlPlane string `json:"controlPlane,omitempty"`
+	// Optional: MachineTypes maps machine types (e.g. instance types, sizes or
+	// flavors) to the price of a single machine.
+	MachineTypes map[string]string `json:"machineTypes,omitempty"`
 }
 
 var (
@@ -684,6 +722,11 @@ type DatacenterSpecAlibaba struct {
 type DatacenterSpecAnexia struct {
 	// LocationID the location of the region
 	LocationID string `json:"locationID"`
+	// PrefixNetmask is the netmask of the private IPv4 prefix that is created
+	// for each cluster in this datacenter. Defaults to 24.
+	// +kubebuilder:validation:Minimum:=16
+	// +kubebuilder:validation:Maximum:=29
+	PrefixNetmask int `json:"prefixNetmask,omitempty"`
 }
 
 type ProxyValue string
@@ -810,8 +853,33 @@ type MeteringReportConfiguration struct {
 
 	// Types of reports to generate. Available report types are cluster and namespace. By default, all types of reports are generated.
 	Types []string `json:"type,omitempty"`
+
+	// +optional
+	// +kubebuilder:default:={"csv"}
+
+	// Formats of the report files to generate. Available formats are csv, json (JSON Lines) and parquet. By default, only CSV files are generated.
+	Formats []MeteringReportFormat `json:"formats,omitempty"`
+
+	// +optional
+
+	// Metrics to include in the report. If not set, all metrics known to the metering tool are included.
+	Metrics []string `json:"metrics,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=csv;json;parquet
+
+// MeteringReportFormat is the file format of a metering report.
+type MeteringReportFormat string
+
+const (
+	// MeteringReportFormatCSV generates comma-separated value files.
+	MeteringReportFormatCSV MeteringReportFormat = "csv"
+	// MeteringReportFormatJSON generates JSON Lines files, one record per line.
+	MeteringReportFormatJSON MeteringReportFormat = "json"
+	// MeteringReportFormatParquet generates Apache Parquet files.
+	MeteringReportFormatParquet MeteringReportFormat = "parquet"
+)
+
 // IsDefaultEtcdAutomaticBackupEnabled returns true if etcd automatic backup is configured for the seed.
 func (s *Seed) IsDefaultEtcdAutomaticBackupEnabled() bool {
 	if cfg := s.Spec.EtcdBackupRestore; cfg != nil {
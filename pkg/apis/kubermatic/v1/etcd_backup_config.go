@@ -39,6 +39,10 @@ const (
 
 	// BackupStatusPhase value indicating that the corresponding job has completed with an error.
 	BackupStatusPhaseFailed = "Failed"
+
+	// EtcdBackupConfigRetainBackupsAnnotation can be set to "true" on an EtcdBackupConfig to
+	// keep its backups in the backup destination when the EtcdBackupConfig is deleted.
+	EtcdBackupConfigRetainBackupsAnnotation = "kubermatic.k8c.io/retain-backups"
 )
 
 // +kubebuilder:object:generate=true
@@ -55,6 +59,12 @@ type EtcdBackupConfig struct {
 	Status EtcdBackupConfigStatus `json:"status,omitempty"`
 }
 
+// RetainsBackups returns true if the backups of this config should be kept
+// in the backup destination after the config has been deleted.
+func (b *EtcdBackupConfig) RetainsBackups() bool {
+	return b.Annotations[EtcdBackupConfigRetainBackupsAnnotation] == "true"
+}
+
 // EtcdBackupConfigSpec specifies details of an etcd backup.
 type EtcdBackupConfigSpec struct {
 	// Name defines the name of the backup
@@ -39,6 +39,7 @@ const (
 // +kubebuilder:printcolumn:JSONPath=".spec.projectID",name="ProjectID",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.group",name="Group",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.role",name="Role",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.projectRole",name="ProjectRole",type="string"
 // +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
 
 // GroupProjectBinding specifies a binding between a group and a project
@@ -68,7 +69,14 @@ type GroupProjectBindingSpec struct {
 	// "viewers" - allowed to get/list project resources
 	// "editors" - allowed to edit all project resources
 	// "owners" - same as editors, but also can manage users in the project
-	Role string `json:"role"`
+	// Can be left empty if ProjectRole is set.
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// ProjectRole is the name of a ProjectRole whose permissions are granted to the group
+	// within the project, in addition to the permissions of the role.
+	// +optional
+	ProjectRole string `json:"projectRole,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -30,6 +30,7 @@ const (
 
 // +kubebuilder:object:generate=true
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
 
 // MLAAdminSetting is the object representing cluster-specific administrator settings
@@ -38,7 +39,8 @@ type MLAAdminSetting struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec MLAAdminSettingSpec `json:"spec,omitempty"`
+	Spec   MLAAdminSettingSpec   `json:"spec,omitempty"`
+	Status MLAAdminSettingStatus `json:"status,omitempty"`
 }
 
 // MLAAdminSettingSpec specifies the cluster-specific administrator settings
@@ -50,6 +52,35 @@ type MLAAdminSettingSpec struct {
 	MonitoringRateLimits *MonitoringRateLimitSettings `json:"monitoringRateLimits,omitempty"`
 	// LoggingRateLimits contains rate-limiting configuration logging in the user cluster.
 	LoggingRateLimits *LoggingRateLimitSettings `json:"loggingRateLimits,omitempty"`
+	// MonitoringRetention is the period for which metrics of the user cluster are kept, e.g. `30d`
+	// (Cortex `compactor_blocks_retention_period`). If not set, the global retention of the seed applies.
+	// +kubebuilder:validation:Pattern:=`^([0-9]+(y|w|d|h|m|s|ms))+$`
+	MonitoringRetention string `json:"monitoringRetention,omitempty"`
+	// LoggingRetention is the period for which logs of the user cluster are kept, e.g. `7d`
+	// (Loki `retention_period`). If not set, the global retention of the seed applies.
+	// Loki only enforces it if its compactor is configured with `retention_enabled: true`.
+	// +kubebuilder:validation:Pattern:=`^([0-9]+(y|w|d|h|m|s|ms))+$`
+	LoggingRetention string `json:"loggingRetention,omitempty"`
+}
+
+// MLAAdminSettingStatus stores the current resource usage of the user cluster MLA stack.
+type MLAAdminSettingStatus struct {
+	// Usage contains the resource usage of the user cluster, which can be compared against the configured limits.
+	Usage *MLAUsage `json:"usage,omitempty"`
+}
+
+// MLAUsage contains the resource usage of a user cluster in the MLA stack.
+type MLAUsage struct {
+	// Series is the number of active series of the user cluster in Cortex (limited by `maxSeriesTotal`).
+	Series int64 `json:"series"`
+	// IngestionRate is the metrics ingestion rate in samples per second (limited by `monitoringRateLimits.ingestionRate`).
+	IngestionRate int64 `json:"ingestionRate"`
+	// LogStreams is the number of log streams which received entries within the last hour (limited by `maxStreamsTotal`).
+	LogStreams int64 `json:"logStreams"`
+	// LogIngestionRate is the log ingestion rate in bytes per second (limited by `ingestionRateMB`).
+	LogIngestionRate int64 `json:"logIngestionRate"`
+	// LastUpdated stores the last time the usage was collected.
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
 }
 
 // MonitoringRateLimitSettings contains rate-limiting configuration for monitoring in the user cluster.
@@ -84,6 +115,11 @@ type LoggingRateLimitSettings struct {
 	QueryRate int32 `json:"queryRate,omitempty"`
 	// QueryBurstSize represents query burst size in number of requests (nginx `burst`).
 	QueryBurstSize int32 `json:"queryBurstSize,omitempty"`
+
+	// MaxStreamsTotal represents maximum number of active log streams in this user cluster (Loki `max_global_streams_per_user`).
+	MaxStreamsTotal int32 `json:"maxStreamsTotal,omitempty"`
+	// IngestionRateMB represents the log volume budget in megabytes per second (Loki `ingestion_rate_mb`).
+	IngestionRateMB int32 `json:"ingestionRateMB,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
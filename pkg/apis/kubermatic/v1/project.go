@@ -17,10 +17,12 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// +kubebuilder:validation:Enum=Active;Inactive;Terminating
+// +kubebuilder:validation:Enum=Active;Inactive;Terminating;Suspended
 
 type ProjectPhase string
 
@@ -34,6 +36,35 @@ const (
 
 	// ProjectTerminating means the project is undergoing graceful termination.
 	ProjectTerminating ProjectPhase = "Terminating"
+
+	// ProjectSuspended means the project has been suspended, all of its clusters are paused
+	// and no new clusters can be created in it.
+	ProjectSuspended ProjectPhase = "Suspended"
+)
+
+// +kubebuilder:validation:Enum=Suspend;Delete
+
+// ProjectExpirationPolicy determines what happens to a project once it has expired.
+type ProjectExpirationPolicy string
+
+const (
+	// ProjectExpirationPolicySuspend suspends the project once it expires.
+	ProjectExpirationPolicySuspend ProjectExpirationPolicy = "Suspend"
+
+	// ProjectExpirationPolicyDelete deletes the project once it expires.
+	ProjectExpirationPolicyDelete ProjectExpirationPolicy = "Delete"
+)
+
+const (
+	// ProjectSuspendedAnnotation is put on Clusters that have been paused because their
+	// project was suspended. Its value is the cluster's pause state before the suspension,
+	// so it can be restored when the project is resumed.
+	ProjectSuspendedAnnotation = "kubermatic.k8c.io/project-suspended"
+
+	// SuspendedReplicasAnnotation is put on MachineDeployments in user clusters that have
+	// been scaled to zero because their project was suspended. Its value is the number of
+	// replicas before the suspension.
+	SuspendedReplicasAnnotation = "kubermatic.k8c.io/suspended-replicas"
 )
 
 const (
@@ -65,11 +96,107 @@ type Project struct {
 type ProjectSpec struct {
 	// Name is the human-readable name given to the project.
 	Name string `json:"name"`
+
+	// Suspended pauses all clusters in the project, scales their MachineDeployments
+	// to zero and prevents new clusters from being created in the project.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// ExpiresAt is the optional point in time at which the project expires. Once
+	// expired, the ExpirationPolicy is applied. To resume an expired and suspended
+	// project, ExpiresAt has to be moved into the future or removed.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// ExpirationPolicy determines what happens to the project once it has expired,
+	// either `Suspend` (default) or `Delete`.
+	// +optional
+	ExpirationPolicy ProjectExpirationPolicy `json:"expirationPolicy,omitempty"`
+
+	// Archive enables taking a snapshot of the cluster specs and etcd data of all
+	// clusters in the project before they are deleted together with the project.
+	// etcd snapshots require automatic etcd backups to be enabled on the seed.
+	// +optional
+	Archive bool `json:"archive,omitempty"`
+
+	// ClusterPolicy configures defaults and locked settings that are applied
+	// to all clusters in this project.
+	// +optional
+	ClusterPolicy *ProjectClusterPolicy `json:"clusterPolicy,omitempty"`
+}
+
+// ProjectClusterPolicy configures how clusters in a project are defaulted and
+// restricted. It is enforced by the cluster admission webhooks whenever a cluster
+// is created or updated. Note that the project's labels are always inherited by
+// its clusters through the project label synchronizer, regardless of this policy.
+type ProjectClusterPolicy struct {
+	// Annotations are added to all clusters in the project, overriding annotations
+	// with the same key on the clusters.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AllowedDatacenters restricts the datacenters in which new clusters can be
+	// created in this project. If empty, all datacenters are allowed.
+	// +optional
+	AllowedDatacenters []string `json:"allowedDatacenters,omitempty"`
+
+	// Defaults are applied to clusters that do not configure the respective
+	// settings themselves.
+	// +optional
+	Defaults *ProjectClusterSettings `json:"defaults,omitempty"`
+
+	// Locked settings are enforced on all clusters and cannot be changed on
+	// individual clusters.
+	// +optional
+	Locked *ProjectClusterSettings `json:"locked,omitempty"`
+}
+
+// ProjectClusterSettings are the cluster settings that can be defaulted or
+// locked on the project level. Unset fields are not defaulted or locked.
+type ProjectClusterSettings struct {
+	// AuditLogging configures Kubernetes API audit logging.
+	// +optional
+	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
+	// OPAIntegration enables or disables the OPA Gatekeeper integration.
+	// +optional
+	OPAIntegration *bool `json:"opaIntegration,omitempty"`
+
+	// MonitoringEnabled enables or disables user cluster monitoring.
+	// +optional
+	MonitoringEnabled *bool `json:"monitoringEnabled,omitempty"`
+
+	// LoggingEnabled enables or disables user cluster logging.
+	// +optional
+	LoggingEnabled *bool `json:"loggingEnabled,omitempty"`
+
+	// AdmissionPlugins is a list of additional admission plugins. When locked,
+	// these plugins are always enabled and cannot be removed from clusters.
+	// +optional
+	AdmissionPlugins []string `json:"admissionPlugins,omitempty"`
+}
+
+// IsExpired returns true if the project has an expiry date that has passed.
+func (p *Project) IsExpired(now time.Time) bool {
+	return p.Spec.ExpiresAt != nil && !now.Before(p.Spec.ExpiresAt.Time)
+}
+
+// GetExpirationPolicy returns the project's expiration policy, defaulting to Suspend.
+func (p *Project) GetExpirationPolicy() ProjectExpirationPolicy {
+	if p.Spec.ExpirationPolicy == "" {
+		return ProjectExpirationPolicySuspend
+	}
+
+	return p.Spec.ExpirationPolicy
 }
 
 // ProjectStatus represents the current status of a project.
 type ProjectStatus struct {
 	Phase ProjectPhase `json:"phase"`
+
+	// SuspendedAt is the time at which the project entered the Suspended phase.
+	// +optional
+	SuspendedAt *metav1.Time `json:"suspendedAt,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
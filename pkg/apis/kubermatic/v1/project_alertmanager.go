@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectAlertmanagerResourceName represents "Resource" defined in Kubernetes.
+	ProjectAlertmanagerResourceName = "projectalertmanagers"
+
+	// ProjectAlertmanagerKindName represents "Kind" defined in Kubernetes.
+	ProjectAlertmanagerKindName = "ProjectAlertmanager"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ProjectAlertmanager is an Alertmanager configuration that is used for every MLA-enabled
+// cluster of a project. The name of the object must be the ID of the project. While it
+// exists, it takes precedence over the configuration in the clusters' Alertmanager objects.
+// It is only used for the clusters of the Seed it is created on and is not synchronized
+// from the master cluster, so it has to be created on every Seed that hosts clusters of
+// the project.
+type ProjectAlertmanager struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectAlertmanagerSpec   `json:"spec,omitempty"`
+	Status ProjectAlertmanagerStatus `json:"status,omitempty"`
+}
+
+// ProjectAlertmanagerSpec specifies the Alertmanager configuration for a project.
+type ProjectAlertmanagerSpec struct {
+	// Config contains the Alertmanager configuration. Ref: https://prometheus.io/docs/alerting/latest/configuration/
+	Config []byte `json:"config"`
+	// ClusterOverrides allows to replace the configuration or to opt out individual clusters.
+	ClusterOverrides []ProjectAlertmanagerClusterOverride `json:"clusterOverrides,omitempty"`
+}
+
+// ProjectAlertmanagerClusterOverride changes how a ProjectAlertmanager is applied to a single cluster.
+type ProjectAlertmanagerClusterOverride struct {
+	// ClusterName is the name of the cluster this override applies to.
+	ClusterName string `json:"clusterName"`
+	// Disabled makes the cluster use the configuration of its own Alertmanager object instead.
+	Disabled bool `json:"disabled,omitempty"`
+	// Config replaces the Alertmanager configuration for the cluster, if set.
+	Config []byte `json:"config,omitempty"`
+}
+
+// ProjectAlertmanagerStatus stores status information about a ProjectAlertmanager.
+type ProjectAlertmanagerStatus struct {
+	// Clusters contains the sync status of the configuration, keyed by cluster name.
+	Clusters map[string]MLAClusterSyncStatus `json:"clusters,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// ProjectAlertmanagerList specifies a list of project Alertmanagers.
+type ProjectAlertmanagerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ProjectAlertmanager `json:"items"`
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectDashboardSourceResourceName represents "Resource" defined in Kubernetes.
+	ProjectDashboardSourceResourceName = "projectdashboardsources"
+
+	// ProjectDashboardSourceKindName represents "Kind" defined in Kubernetes.
+	ProjectDashboardSourceKindName = "ProjectDashboardSource"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.project.name",name="Project",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.git.remote",name="Remote",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ProjectDashboardSource is a Git repository with Grafana dashboards that are synced into the
+// Grafana organization of a project.
+type ProjectDashboardSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectDashboardSourceSpec   `json:"spec,omitempty"`
+	Status ProjectDashboardSourceStatus `json:"status,omitempty"`
+}
+
+// ProjectDashboardSourceSpec specifies the repository and the project the dashboards are synced to.
+type ProjectDashboardSourceSpec struct {
+	// Project is the reference to the project whose Grafana organization the dashboards are synced to.
+	// All fields except for the name are ignored.
+	Project corev1.ObjectReference `json:"project"`
+	// Git is the repository containing the dashboards. Every `*.json` file below its path is synced
+	// as a dashboard. Credentials are read from Secrets in the MLA namespace.
+	Git appskubermaticv1.GitSource `json:"git"`
+	// Folder is the title of the Grafana folder the dashboards are put in. The folder is created if
+	// it does not exist yet. If not set, the dashboards are put in the General folder.
+	Folder string `json:"folder,omitempty"`
+}
+
+// ProjectDashboardSourceStatus stores status information about a ProjectDashboardSource.
+type ProjectDashboardSourceStatus struct {
+	// Organizations contains the dashboards that were synced, keyed by Grafana organization ID.
+	Organizations map[string]GrafanaDashboardsSyncState `json:"organizations,omitempty"`
+}
+
+// GrafanaDashboardsSyncState records the dashboards that were synced into a Grafana organization,
+// so that they can be removed again once they are no longer part of their source.
+type GrafanaDashboardsSyncState struct {
+	// FolderUID is the UID of the folder the dashboards were put in. It is empty for the General folder.
+	FolderUID string `json:"folderUID,omitempty"`
+	// Dashboards contains the synced dashboards, keyed by dashboard UID.
+	Dashboards map[string]GrafanaDashboardSyncState `json:"dashboards,omitempty"`
+}
+
+// GrafanaDashboardSyncState records a single synced dashboard.
+type GrafanaDashboardSyncState struct {
+	// Hash is the SHA-256 hash of the dashboard definition that was synced.
+	Hash string `json:"hash,omitempty"`
+	// Version is the version Grafana assigned to the dashboard when it was synced. Changes made
+	// in Grafana increase the version and are reverted on the next sync.
+	Version int `json:"version,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// ProjectDashboardSourceList specifies a list of project dashboard sources.
+type ProjectDashboardSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ProjectDashboardSource `json:"items"`
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectRoleResourceName represents "Resource" defined in Kubernetes.
+	ProjectRoleResourceName = "projectroles"
+
+	// ProjectRoleKindName represents "Kind" defined in Kubernetes.
+	ProjectRoleKindName = "ProjectRole"
+
+	// AuthZProjectRoleLabel references the ProjectRole that a ClusterRole/Role was created for.
+	AuthZProjectRoleLabel = "authz.k8c.io/project-role"
+)
+
+// +kubebuilder:validation:Enum=projects;clusters;externalclusters;clustertemplateinstances;usersshkeys;userprojectbindings;groupprojectbindings;users;resourcequotas;addons;constraints;alertmanagers;rulegroups;etcdbackupconfigs;etcdrestores;machinedeployments;kubeconfig;"*"
+
+// ProjectRoleResource is a KKP resource that permissions can be granted on by a ProjectRole.
+type ProjectRoleResource string
+
+const (
+	ProjectRoleResourceProjects                 ProjectRoleResource = "projects"
+	ProjectRoleResourceClusters                 ProjectRoleResource = "clusters"
+	ProjectRoleResourceExternalClusters         ProjectRoleResource = "externalclusters"
+	ProjectRoleResourceClusterTemplateInstances ProjectRoleResource = "clustertemplateinstances"
+	ProjectRoleResourceUserSSHKeys              ProjectRoleResource = "usersshkeys"
+	ProjectRoleResourceUserProjectBindings      ProjectRoleResource = "userprojectbindings"
+	ProjectRoleResourceGroupProjectBindings     ProjectRoleResource = "groupprojectbindings"
+	ProjectRoleResourceServiceAccounts          ProjectRoleResource = "users"
+	ProjectRoleResourceResourceQuotas           ProjectRoleResource = "resourcequotas"
+	ProjectRoleResourceAddons                   ProjectRoleResource = "addons"
+	ProjectRoleResourceConstraints              ProjectRoleResource = "constraints"
+	ProjectRoleResourceAlertmanagers            ProjectRoleResource = "alertmanagers"
+	ProjectRoleResourceRuleGroups               ProjectRoleResource = "rulegroups"
+	ProjectRoleResourceEtcdBackupConfigs        ProjectRoleResource = "etcdbackupconfigs"
+	ProjectRoleResourceEtcdRestores             ProjectRoleResource = "etcdrestores"
+
+	// ProjectRoleResourceMachineDeployments grants access to the MachineDeployments
+	// inside the project's user clusters. "get" also allows to read the machines of
+	// a MachineDeployment, "delete" also allows to delete single machines and nodes.
+	ProjectRoleResourceMachineDeployments ProjectRoleResource = "machinedeployments"
+	// ProjectRoleResourceKubeconfig grants access to the (viewer) kubeconfig of
+	// the project's user clusters.
+	ProjectRoleResourceKubeconfig ProjectRoleResource = "kubeconfig"
+
+	ProjectRoleResourceAll ProjectRoleResource = "*"
+)
+
+// +kubebuilder:validation:Enum=get;list;create;update;patch;delete;"*"
+
+// ProjectRoleVerb is an action that can be performed on a ProjectRoleResource.
+type ProjectRoleVerb string
+
+const (
+	ProjectRoleVerbGet    ProjectRoleVerb = "get"
+	ProjectRoleVerbList   ProjectRoleVerb = "list"
+	ProjectRoleVerbCreate ProjectRoleVerb = "create"
+	ProjectRoleVerbUpdate ProjectRoleVerb = "update"
+	ProjectRoleVerbPatch  ProjectRoleVerb = "patch"
+	ProjectRoleVerbDelete ProjectRoleVerb = "delete"
+	ProjectRoleVerbAll    ProjectRoleVerb = "*"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:JSONPath=".spec.displayName",name="DisplayName",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ProjectRole is a custom role for project members. It can be bound to users and groups
+// via the projectRole field of UserProjectBindings and GroupProjectBindings and grants
+// the given verbs on the given KKP resources of the project the binding belongs to.
+type ProjectRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProjectRoleSpec `json:"spec,omitempty"`
+}
+
+// ProjectRoleSpec specifies the permissions granted by a ProjectRole.
+type ProjectRoleSpec struct {
+	// DisplayName is a human-readable name for the role.
+	DisplayName string `json:"displayName,omitempty"`
+	// Description explains the purpose of the role.
+	Description string `json:"description,omitempty"`
+	// Rules is the list of permissions granted by this role.
+	Rules []ProjectRoleRule `json:"rules"`
+}
+
+// ProjectRoleRule grants a set of verbs on a set of resources.
+type ProjectRoleRule struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// Resources is the list of KKP resources the verbs are granted on.
+	Resources []ProjectRoleResource `json:"resources"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Verbs is the list of actions that are granted on the resources.
+	Verbs []ProjectRoleVerb `json:"verbs"`
+}
+
+// Allows returns true if the rule grants the given verb on the given resource.
+func (r *ProjectRoleRule) Allows(resource ProjectRoleResource, verb ProjectRoleVerb) bool {
+	return r.hasResource(resource) && r.hasVerb(verb)
+}
+
+func (r *ProjectRoleRule) hasResource(resource ProjectRoleResource) bool {
+	for _, res := range r.Resources {
+		if res == resource || res == ProjectRoleResourceAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ProjectRoleRule) hasVerb(verb ProjectRoleVerb) bool {
+	for _, v := range r.Verbs {
+		if v == verb || v == ProjectRoleVerbAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows returns true if any of the role's rules grants the given verb on the given resource.
+func (r *ProjectRole) Allows(resource ProjectRoleResource, verb ProjectRoleVerb) bool {
+	for i := range r.Spec.Rules {
+		if r.Spec.Rules[i].Allows(resource, verb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// ProjectRoleList is a list of project roles.
+type ProjectRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ProjectRole `json:"items"`
+}
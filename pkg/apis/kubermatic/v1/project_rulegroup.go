@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectRuleGroupResourceName represents "Resource" defined in Kubernetes.
+	ProjectRuleGroupResourceName = "projectrulegroups"
+
+	// ProjectRuleGroupKindName represents "Kind" defined in Kubernetes.
+	ProjectRuleGroupKindName = "ProjectRuleGroup"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.project.name",name="Project",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.ruleGroupType",name="Type",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ProjectRuleGroup is a RuleGroup that is synced to every MLA-enabled cluster of a project.
+// It is only applied to the clusters of the Seed it is created on and is not synchronized
+// from the master cluster, so it has to be created on every Seed that hosts clusters of
+// the project.
+type ProjectRuleGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectRuleGroupSpec   `json:"spec,omitempty"`
+	Status ProjectRuleGroupStatus `json:"status,omitempty"`
+}
+
+// ProjectRuleGroupSpec specifies the rule group and the project it applies to.
+type ProjectRuleGroupSpec struct {
+	// Project is the reference to the project whose clusters the ruleGroup should be created in.
+	// All fields except for the name are ignored.
+	Project corev1.ObjectReference `json:"project"`
+	// RuleGroupType is the type of this ruleGroup applies to. It can be `Metrics` or `Logs`.
+	RuleGroupType RuleGroupType `json:"ruleGroupType"`
+	// Data contains the RuleGroup data. Ref: https://prometheus.io/docs/prometheus/latest/configuration/recording_rules/#rule_group
+	Data []byte `json:"data"`
+	// ClusterOverrides allows to replace the data or to skip the ruleGroup for individual clusters.
+	ClusterOverrides []ProjectRuleGroupClusterOverride `json:"clusterOverrides,omitempty"`
+}
+
+// ProjectRuleGroupClusterOverride changes how a ProjectRuleGroup is applied to a single cluster.
+type ProjectRuleGroupClusterOverride struct {
+	// ClusterName is the name of the cluster this override applies to.
+	ClusterName string `json:"clusterName"`
+	// Disabled prevents the ruleGroup from being created in the cluster.
+	Disabled bool `json:"disabled,omitempty"`
+	// Data replaces the RuleGroup data for the cluster, if set.
+	Data []byte `json:"data,omitempty"`
+}
+
+// ProjectRuleGroupStatus stores status information about a ProjectRuleGroup.
+type ProjectRuleGroupStatus struct {
+	// Clusters contains the sync status of the ruleGroup, keyed by cluster name.
+	Clusters map[string]MLAClusterSyncStatus `json:"clusters,omitempty"`
+}
+
+// MLAClusterSyncStatus stores whether a project-scoped MLA object was successfully synced to a cluster.
+type MLAClusterSyncStatus struct {
+	// Status of whether the object was synced, one of True, False.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastUpdated stores the last time the status changed.
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+	// ErrorMessage contains the reason why the object could not be synced.
+	// Will be reset if the error was resolved and the status becomes True.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// ProjectRuleGroupList specifies a list of project rule groups.
+type ProjectRuleGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ProjectRuleGroup `json:"items"`
+}
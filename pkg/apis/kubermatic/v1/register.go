@@ -85,12 +85,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ConstraintList{},
 		&Alertmanager{},
 		&AlertmanagerList{},
+		&AlertmanagerReceiverTemplate{},
+		&AlertmanagerReceiverTemplateList{},
+		&ProjectAlertmanager{},
+		&ProjectAlertmanagerList{},
 		&ClusterTemplate{},
 		&ClusterTemplateList{},
 		&ClusterTemplateInstance{},
 		&ClusterTemplateInstanceList{},
 		&RuleGroup{},
 		&RuleGroupList{},
+		&ProjectRuleGroup{},
+		&ProjectRuleGroupList{},
+		&ProjectDashboardSource{},
+		&ProjectDashboardSourceList{},
+		&DashboardConfigMapSync{},
+		&DashboardConfigMapSyncList{},
 		&AllowedRegistry{},
 		&AllowedRegistryList{},
 		&MLAAdminSetting{},
@@ -105,6 +115,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ResourceQuotaList{},
 		&GroupProjectBinding{},
 		&GroupProjectBindingList{},
+		&ProjectRole{},
+		&ProjectRoleList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -62,6 +62,12 @@ type ResourceQuotaStatus struct {
 	GlobalUsage ResourceDetails `json:"globalUsage,omitempty"`
 	// LocalUsage is holds the current usage of resources for the local seed.
 	LocalUsage ResourceDetails `json:"localUsage,omitempty"`
+	// GlobalCost holds the estimated monthly cost of the clusters on all seeds,
+	// keyed by currency. Amounts are decimal numbers, e.g. "123.45".
+	GlobalCost map[string]string `json:"globalCost,omitempty"`
+	// LocalCost holds the estimated monthly cost of the clusters on the local seed,
+	// keyed by currency. Amounts are decimal numbers, e.g. "123.45".
+	LocalCost map[string]string `json:"localCost,omitempty"`
 }
 
 // Subject describes the entity to which the quota applies to.
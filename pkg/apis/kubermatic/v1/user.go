@@ -30,6 +30,13 @@ const (
 
 	// UserKindName represents "Kind" defined in Kubernetes.
 	UserKindName = "User"
+
+	// UserSCIMManagedLabel marks users that are provisioned through the SCIM endpoint of the KKP API.
+	// The groups of these users are managed through SCIM and not taken from the token claims on login.
+	UserSCIMManagedLabel = "scim.k8c.io/managed"
+	// UserSCIMExternalIDAnnotation holds the identifier of a SCIM provisioned user in the
+	// provisioning client (the identity provider).
+	UserSCIMExternalIDAnnotation = "scim.k8c.io/external-id"
 )
 
 // +kubebuilder:resource:scope=Cluster
@@ -79,6 +86,11 @@ type UserSpec struct {
 	// +optional
 	Project string `json:"project,omitempty"`
 
+	// Deactivated defines whether this user is deactivated. Deactivated users cannot access the
+	// KKP API anymore and all of their tokens are invalidated.
+	// +optional
+	Deactivated bool `json:"deactivated,omitempty"`
+
 	Settings               *UserSettings                           `json:"settings,omitempty"`
 	InvalidTokensReference *providerconfig.GlobalSecretKeySelector `json:"invalidTokensReference,omitempty"`
 }
@@ -35,6 +35,7 @@ const (
 // +kubebuilder:printcolumn:JSONPath=".spec.projectID",name="ProjectID",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.group",name="Group",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.userEmail",name="UserEmail",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.projectRole",name="ProjectRole",type="string"
 // +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
 
 // UserProjectBinding specifies a binding between a user and a project
@@ -56,7 +57,14 @@ type UserProjectBindingSpec struct {
 	// TODO: add "Role" field and deprecate "Group" in favour of it to be in line with GroupProjectBinding resource.
 
 	// Group is the user's group, determining their permissions within the project.
-	Group string `json:"group"`
+	// Can be left empty if ProjectRole is set.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// ProjectRole is the name of a ProjectRole whose permissions are granted to the user
+	// within the project, in addition to the permissions of the group.
+	// +optional
+	ProjectRole string `json:"projectRole,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
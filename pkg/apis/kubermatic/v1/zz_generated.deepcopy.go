@@ -217,7 +217,9 @@ func (in *AddonConfigSpec) DeepCopyInto(out *AddonConfigSpec) {
 	if in.Controls != nil {
 		in, out := &in.Controls, &out.Controls
 		*out = make([]AddonFormControl, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -234,6 +236,11 @@ func (in *AddonConfigSpec) DeepCopy() *AddonConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonFormControl) DeepCopyInto(out *AddonFormControl) {
 	*out = *in
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonFormControl.
@@ -278,6 +285,36 @@ func (in *AddonList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonObjectStatus) DeepCopyInto(out *AddonObjectStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonObjectStatus.
+func (in *AddonObjectStatus) DeepCopy() *AddonObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonReadinessCheck) DeepCopyInto(out *AddonReadinessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonReadinessCheck.
+func (in *AddonReadinessCheck) DeepCopy() *AddonReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -292,6 +329,16 @@ func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 		*out = make([]GroupVersionKind, len(*in))
 		copy(*out, *in)
 	}
+	if in.RequiredAddons != nil {
+		in, out := &in.RequiredAddons, &out.RequiredAddons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadinessChecks != nil {
+		in, out := &in.ReadinessChecks, &out.ReadinessChecks
+		*out = make([]AddonReadinessCheck, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSpec.
@@ -314,6 +361,16 @@ func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRunObjects != nil {
+		in, out := &in.DryRunObjects, &out.DryRunObjects
+		*out = make([]AddonObjectStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
@@ -479,6 +536,79 @@ func (in *AlertmanagerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerReceiverTemplate) DeepCopyInto(out *AlertmanagerReceiverTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerReceiverTemplate.
+func (in *AlertmanagerReceiverTemplate) DeepCopy() *AlertmanagerReceiverTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerReceiverTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertmanagerReceiverTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerReceiverTemplateList) DeepCopyInto(out *AlertmanagerReceiverTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlertmanagerReceiverTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerReceiverTemplateList.
+func (in *AlertmanagerReceiverTemplateList) DeepCopy() *AlertmanagerReceiverTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerReceiverTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertmanagerReceiverTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerReceiverTemplateSpec) DeepCopyInto(out *AlertmanagerReceiverTemplateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerReceiverTemplateSpec.
+func (in *AlertmanagerReceiverTemplateSpec) DeepCopy() *AlertmanagerReceiverTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerReceiverTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertmanagerSpec) DeepCopyInto(out *AlertmanagerSpec) {
 	*out = *in
@@ -919,6 +1049,26 @@ func (in *CleanupOptions) DeepCopy() *CleanupOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceAuditSettings) DeepCopyInto(out *CloudResourceAuditSettings) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceAuditSettings.
+func (in *CloudResourceAuditSettings) DeepCopy() *CloudResourceAuditSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceAuditSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudSpec) DeepCopyInto(out *CloudSpec) {
 	*out = *in
@@ -1851,6 +2001,86 @@ func (in CustomLinks) DeepCopy() CustomLinks {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardConfigMapSync) DeepCopyInto(out *DashboardConfigMapSync) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfigMapSync.
+func (in *DashboardConfigMapSync) DeepCopy() *DashboardConfigMapSync {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardConfigMapSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DashboardConfigMapSync) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardConfigMapSyncList) DeepCopyInto(out *DashboardConfigMapSyncList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DashboardConfigMapSync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfigMapSyncList.
+func (in *DashboardConfigMapSyncList) DeepCopy() *DashboardConfigMapSyncList {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardConfigMapSyncList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DashboardConfigMapSyncList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardConfigMapSyncStatus) DeepCopyInto(out *DashboardConfigMapSyncStatus) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make(map[string]GrafanaDashboardsSyncState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfigMapSyncStatus.
+func (in *DashboardConfigMapSyncStatus) DeepCopy() *DashboardConfigMapSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardConfigMapSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Datacenter) DeepCopyInto(out *Datacenter) {
 	*out = *in
@@ -1872,6 +2102,28 @@ func (in *Datacenter) DeepCopy() *Datacenter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterPricing) DeepCopyInto(out *DatacenterPricing) {
+	*out = *in
+	if in.MachineTypes != nil {
+		in, out := &in.MachineTypes, &out.MachineTypes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterPricing.
+func (in *DatacenterPricing) DeepCopy() *DatacenterPricing {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterPricing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 	*out = *in
@@ -1960,6 +2212,11 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(DatacenterPricing)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpec.
@@ -3205,6 +3462,43 @@ func (in *GKE) DeepCopy() *GKE {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDashboardSyncState) DeepCopyInto(out *GrafanaDashboardSyncState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardSyncState.
+func (in *GrafanaDashboardSyncState) DeepCopy() *GrafanaDashboardSyncState {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardSyncState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDashboardsSyncState) DeepCopyInto(out *GrafanaDashboardsSyncState) {
+	*out = *in
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = make(map[string]GrafanaDashboardSyncState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardsSyncState.
+func (in *GrafanaDashboardsSyncState) DeepCopy() *GrafanaDashboardsSyncState {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardsSyncState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupProjectBinding) DeepCopyInto(out *GroupProjectBinding) {
 	*out = *in
@@ -4212,6 +4506,7 @@ func (in *MLAAdminSetting) DeepCopyInto(out *MLAAdminSetting) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLAAdminSetting.
@@ -4289,6 +4584,42 @@ func (in *MLAAdminSettingSpec) DeepCopy() *MLAAdminSettingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MLAAdminSettingStatus) DeepCopyInto(out *MLAAdminSettingStatus) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(MLAUsage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLAAdminSettingStatus.
+func (in *MLAAdminSettingStatus) DeepCopy() *MLAAdminSettingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MLAAdminSettingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MLAClusterSyncStatus) DeepCopyInto(out *MLAClusterSyncStatus) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLAClusterSyncStatus.
+func (in *MLAClusterSyncStatus) DeepCopy() *MLAClusterSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MLAClusterSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MLASettings) DeepCopyInto(out *MLASettings) {
 	*out = *in
@@ -4319,6 +4650,22 @@ func (in *MLASettings) DeepCopy() *MLASettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MLAUsage) DeepCopyInto(out *MLAUsage) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLAUsage.
+func (in *MLAUsage) DeepCopy() *MLAUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(MLAUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineControllerConfiguration) DeepCopyInto(out *MachineControllerConfiguration) {
 	*out = *in
@@ -4446,8 +4793,18 @@ func (in *MeteringReportConfiguration) DeepCopyInto(out *MeteringReportConfigura
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-}
-
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]MeteringReportFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringReportConfiguration.
 func (in *MeteringReportConfiguration) DeepCopy() *MeteringReportConfiguration {
 	if in == nil {
@@ -5067,8 +5424,8 @@ func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Project.
@@ -5089,6 +5446,309 @@ func (in *Project) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectAlertmanager) DeepCopyInto(out *ProjectAlertmanager) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertmanager.
+func (in *ProjectAlertmanager) DeepCopy() *ProjectAlertmanager {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectAlertmanager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectAlertmanager) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectAlertmanagerClusterOverride) DeepCopyInto(out *ProjectAlertmanagerClusterOverride) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertmanagerClusterOverride.
+func (in *ProjectAlertmanagerClusterOverride) DeepCopy() *ProjectAlertmanagerClusterOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectAlertmanagerClusterOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectAlertmanagerList) DeepCopyInto(out *ProjectAlertmanagerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectAlertmanager, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertmanagerList.
+func (in *ProjectAlertmanagerList) DeepCopy() *ProjectAlertmanagerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectAlertmanagerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectAlertmanagerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectAlertmanagerSpec) DeepCopyInto(out *ProjectAlertmanagerSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterOverrides != nil {
+		in, out := &in.ClusterOverrides, &out.ClusterOverrides
+		*out = make([]ProjectAlertmanagerClusterOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertmanagerSpec.
+func (in *ProjectAlertmanagerSpec) DeepCopy() *ProjectAlertmanagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectAlertmanagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectAlertmanagerStatus) DeepCopyInto(out *ProjectAlertmanagerStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make(map[string]MLAClusterSyncStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertmanagerStatus.
+func (in *ProjectAlertmanagerStatus) DeepCopy() *ProjectAlertmanagerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectAlertmanagerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectClusterPolicy) DeepCopyInto(out *ProjectClusterPolicy) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowedDatacenters != nil {
+		in, out := &in.AllowedDatacenters, &out.AllowedDatacenters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(ProjectClusterSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Locked != nil {
+		in, out := &in.Locked, &out.Locked
+		*out = new(ProjectClusterSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectClusterPolicy.
+func (in *ProjectClusterPolicy) DeepCopy() *ProjectClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectClusterSettings) DeepCopyInto(out *ProjectClusterSettings) {
+	*out = *in
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(AuditLoggingSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OPAIntegration != nil {
+		in, out := &in.OPAIntegration, &out.OPAIntegration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MonitoringEnabled != nil {
+		in, out := &in.MonitoringEnabled, &out.MonitoringEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LoggingEnabled != nil {
+		in, out := &in.LoggingEnabled, &out.LoggingEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdmissionPlugins != nil {
+		in, out := &in.AdmissionPlugins, &out.AdmissionPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectClusterSettings.
+func (in *ProjectClusterSettings) DeepCopy() *ProjectClusterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectClusterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDashboardSource) DeepCopyInto(out *ProjectDashboardSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDashboardSource.
+func (in *ProjectDashboardSource) DeepCopy() *ProjectDashboardSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDashboardSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectDashboardSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDashboardSourceList) DeepCopyInto(out *ProjectDashboardSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectDashboardSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDashboardSourceList.
+func (in *ProjectDashboardSourceList) DeepCopy() *ProjectDashboardSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDashboardSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectDashboardSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDashboardSourceSpec) DeepCopyInto(out *ProjectDashboardSourceSpec) {
+	*out = *in
+	out.Project = in.Project
+	in.Git.DeepCopyInto(&out.Git)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDashboardSourceSpec.
+func (in *ProjectDashboardSourceSpec) DeepCopy() *ProjectDashboardSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDashboardSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDashboardSourceStatus) DeepCopyInto(out *ProjectDashboardSourceStatus) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make(map[string]GrafanaDashboardsSyncState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDashboardSourceStatus.
+func (in *ProjectDashboardSourceStatus) DeepCopy() *ProjectDashboardSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDashboardSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectGroup) DeepCopyInto(out *ProjectGroup) {
 	*out = *in
@@ -5136,9 +5796,252 @@ func (in *ProjectList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRole) DeepCopyInto(out *ProjectRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRole.
+func (in *ProjectRole) DeepCopy() *ProjectRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleList) DeepCopyInto(out *ProjectRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleList.
+func (in *ProjectRoleList) DeepCopy() *ProjectRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleRule) DeepCopyInto(out *ProjectRoleRule) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ProjectRoleResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]ProjectRoleVerb, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleRule.
+func (in *ProjectRoleRule) DeepCopy() *ProjectRoleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleSpec) DeepCopyInto(out *ProjectRoleSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ProjectRoleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleSpec.
+func (in *ProjectRoleSpec) DeepCopy() *ProjectRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRuleGroup) DeepCopyInto(out *ProjectRuleGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRuleGroup.
+func (in *ProjectRuleGroup) DeepCopy() *ProjectRuleGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRuleGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRuleGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRuleGroupClusterOverride) DeepCopyInto(out *ProjectRuleGroupClusterOverride) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRuleGroupClusterOverride.
+func (in *ProjectRuleGroupClusterOverride) DeepCopy() *ProjectRuleGroupClusterOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRuleGroupClusterOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRuleGroupList) DeepCopyInto(out *ProjectRuleGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectRuleGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRuleGroupList.
+func (in *ProjectRuleGroupList) DeepCopy() *ProjectRuleGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRuleGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRuleGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRuleGroupSpec) DeepCopyInto(out *ProjectRuleGroupSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterOverrides != nil {
+		in, out := &in.ClusterOverrides, &out.ClusterOverrides
+		*out = make([]ProjectRuleGroupClusterOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRuleGroupSpec.
+func (in *ProjectRuleGroupSpec) DeepCopy() *ProjectRuleGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRuleGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRuleGroupStatus) DeepCopyInto(out *ProjectRuleGroupStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make(map[string]MLAClusterSyncStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRuleGroupStatus.
+func (in *ProjectRuleGroupStatus) DeepCopy() *ProjectRuleGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRuleGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterPolicy != nil {
+		in, out := &in.ClusterPolicy, &out.ClusterPolicy
+		*out = new(ProjectClusterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
@@ -5154,6 +6057,10 @@ func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
 	*out = *in
+	if in.SuspendedAt != nil {
+		in, out := &in.SuspendedAt, &out.SuspendedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectStatus.
@@ -5322,6 +6229,20 @@ func (in *ResourceQuotaStatus) DeepCopyInto(out *ResourceQuotaStatus) {
 	*out = *in
 	in.GlobalUsage.DeepCopyInto(&out.GlobalUsage)
 	in.LocalUsage.DeepCopyInto(&out.LocalUsage)
+	if in.GlobalCost != nil {
+		in, out := &in.GlobalCost, &out.GlobalCost
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LocalCost != nil {
+		in, out := &in.LocalCost, &out.LocalCost
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaStatus.
@@ -5599,6 +6520,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = new(EtcdBackupRestore)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CloudResourceAudit != nil {
+		in, out := &in.CloudResourceAudit, &out.CloudResourceAudit
+		*out = new(CloudResourceAuditSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedSpec.
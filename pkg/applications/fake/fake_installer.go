@@ -38,6 +38,12 @@ type ApplicationInstallerRecorder struct {
 
 	// DeleteEvents stores the call to delete function. Key is the name of the applicationInstallation.
 	DeleteEvents sync.Map
+
+	// DetectDriftEvents stores the call to detectDrift function. Key is the name of the applicationInstallation.
+	DetectDriftEvents sync.Map
+
+	// DriftedObjects is returned by the detectDrift function.
+	DriftedObjects []util.DriftedObject
 }
 
 func (a *ApplicationInstallerRecorder) GetAppCache() string {
@@ -54,6 +60,11 @@ func (a *ApplicationInstallerRecorder) Apply(ctx context.Context, log *zap.Sugar
 	return util.NoStatusUpdate, nil
 }
 
+func (a *ApplicationInstallerRecorder) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation, appSourcePath string) ([]util.DriftedObject, error) {
+	a.DetectDriftEvents.Store(applicationInstallation.Name, *applicationInstallation.DeepCopy())
+	return a.DriftedObjects, nil
+}
+
 func (a *ApplicationInstallerRecorder) Delete(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
 	a.DeleteEvents.Store(applicationInstallation.Name, *applicationInstallation.DeepCopy())
 	return util.NoStatusUpdate, nil
@@ -76,6 +87,11 @@ func (a ApplicationInstallerLogger) Apply(ctx context.Context, log *zap.SugaredL
 	return util.NoStatusUpdate, nil
 }
 
+func (a ApplicationInstallerLogger) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation, appSourcePath string) ([]util.DriftedObject, error) {
+	log.Debugf("Detect drift of application %s. applicationVersion=%v", applicationInstallation.Name, applicationInstallation.Status.ApplicationVersion)
+	return nil, nil
+}
+
 func (a ApplicationInstallerLogger) Delete(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
 	log.Debugf("Uninstall application %s. applicationVersion=%v", applicationInstallation.Name, applicationInstallation.Status.ApplicationVersion)
 	return util.NoStatusUpdate, nil
@@ -26,7 +26,9 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"helm.sh/helm/v3/pkg/action"
@@ -107,6 +109,32 @@ func (a *AuthSettings) registryClientAndGetterOptions() (*registry.Client, []get
 	return regClient, options, nil
 }
 
+// defaultTimeout is the time to wait for any individual Kubernetes operation when no timeout is set. It's the same
+// default as Helm cli.
+const defaultTimeout = 5 * time.Minute
+
+// DeployOpts holds the settings used to install, upgrade or rollback a release.
+type DeployOpts struct {
+	// Wait until all resources are ready before marking the release as successful.
+	Wait bool
+
+	// Timeout is the time to wait for any individual Kubernetes operation. Defaults to 5 minutes.
+	Timeout time.Duration
+
+	// Atomic uninstalls the release if installation fails and rolls back the release if upgrade fails.
+	Atomic bool
+
+	// MaxHistory limits the maximum number of revisions saved per release. 0 means no limit.
+	MaxHistory int
+}
+
+func (o DeployOpts) timeout() time.Duration {
+	if o.Timeout == 0 {
+		return defaultTimeout
+	}
+	return o.Timeout
+}
+
 // HelmClient is a client that allows interacting with Helm.
 // If you want to use it in a concurrency context, you must create several clients with different HelmSettings. Otherwise
 // writing repository.xml or download index file may fails as it will be written by several threads.
@@ -161,6 +189,18 @@ func NewClient(ctx context.Context, restClientGetter genericclioptions.RESTClien
 // DownloadChart from url into dest folder and return the chart location (eg /tmp/foo/apache-1.0.0.tgz)
 // The dest folder must exist.
 func (h HelmClient) DownloadChart(url string, chartName string, version string, dest string, auth AuthSettings) (string, error) {
+	return h.downloadChart(url, chartName, version, dest, downloader.VerifyNever, auth)
+}
+
+// DownloadChartWithProvenance downloads the chart like DownloadChart and also downloads its provenance file if it exists.
+// The provenance file is stored next to the chart (e.g. /tmp/foo/apache-1.0.0.tgz.prov). The chart is not verified, use
+// VerifyChart for that.
+func (h HelmClient) DownloadChartWithProvenance(url string, chartName string, version string, dest string, auth AuthSettings) (string, error) {
+	return h.downloadChart(url, chartName, version, dest, downloader.VerifyLater, auth)
+}
+
+// downloadChart downloads the chart into dest folder according to the verification strategy.
+func (h HelmClient) downloadChart(url string, chartName string, version string, dest string, verify downloader.VerificationStrategy, auth AuthSettings) (string, error) {
 	var repoName string
 	var err error
 	if strings.HasPrefix(url, "oci://") {
@@ -179,7 +219,7 @@ func (h HelmClient) DownloadChart(url string, chartName string, version string,
 	var out strings.Builder
 	chartDownloader := downloader.ChartDownloader{
 		Out:              &out,
-		Verify:           downloader.VerifyNever,
+		Verify:           verify,
 		RepositoryConfig: h.settings.RepositoryConfig,
 		RepositoryCache:  h.settings.RepositoryCache,
 		Getters:          h.getterProviders,
@@ -187,7 +227,6 @@ func (h HelmClient) DownloadChart(url string, chartName string, version string,
 		Options:          options,
 	}
 
-	// todo note: we may want to check the verificaton return by chartDownloader.DownloadTo. for the moment it's set to downloader.VerifyNever in struct init
 	chartRef := repoName + "/" + chartName
 	chartLoc, _, err := chartDownloader.DownloadTo(chartRef, version, dest)
 	if err != nil {
@@ -199,19 +238,26 @@ func (h HelmClient) DownloadChart(url string, chartName string, version string,
 	return chartLoc, nil
 }
 
+// VerifyChart verifies the chart archive located at chartLoc against its provenance file (chartLoc + ".prov") with the
+// PGP public keyring located at keyringPath.
+func VerifyChart(chartLoc string, keyringPath string) error {
+	_, err := downloader.VerifyChart(chartLoc, keyringPath)
+	return err
+}
+
 // InstallOrUpgrade installs the chart located at chartLoc into targetNamespace if it's not already installed.
 // Otherwise it upgrades the chart.
 // charLoc is the path to the chart archive (e.g. /tmp/foo/apache-1.0.0.tgz) or folder containing the chart (e.g. /tmp/mychart/apache).
-func (h HelmClient) InstallOrUpgrade(chartLoc string, releaseName string, values map[string]interface{}, auth AuthSettings) (*release.Release, error) {
+func (h HelmClient) InstallOrUpgrade(chartLoc string, releaseName string, values map[string]interface{}, deployOpts DeployOpts, auth AuthSettings) (*release.Release, error) {
 	if _, err := h.actionConfig.Releases.Last(releaseName); err != nil {
-		return h.Install(chartLoc, releaseName, values, auth)
+		return h.Install(chartLoc, releaseName, values, deployOpts, auth)
 	}
-	return h.Upgrade(chartLoc, releaseName, values, auth)
+	return h.Upgrade(chartLoc, releaseName, values, deployOpts, auth)
 }
 
 // Install the chart located at chartLoc into targetNamespace. If the chart was already installed, an error is returned.
 // charLoc is the path to the chart archive (eg /tmp/foo/apache-1.0.0.tgz) or folder containing the chart (e.g. /tmp/mychart/apache).
-func (h HelmClient) Install(chartLoc string, releaseName string, values map[string]interface{}, auth AuthSettings) (*release.Release, error) {
+func (h HelmClient) Install(chartLoc string, releaseName string, values map[string]interface{}, deployOpts DeployOpts, auth AuthSettings) (*release.Release, error) {
 	chartToInstall, err := h.buildDependencies(chartLoc, auth)
 	if err != nil {
 		return nil, err
@@ -220,6 +266,9 @@ func (h HelmClient) Install(chartLoc string, releaseName string, values map[stri
 	installClient := action.NewInstall(h.actionConfig)
 	installClient.Namespace = h.targetNamespace
 	installClient.ReleaseName = releaseName
+	installClient.Wait = deployOpts.Wait
+	installClient.Timeout = deployOpts.timeout()
+	installClient.Atomic = deployOpts.Atomic
 
 	rel, err := installClient.RunWithContext(h.ctx, chartToInstall, values)
 	if err != nil {
@@ -230,7 +279,7 @@ func (h HelmClient) Install(chartLoc string, releaseName string, values map[stri
 
 // Upgrade the chart located at chartLoc into targetNamespace. If the chart is not already installed, an error is returned.
 // charLoc is the path to the chart archive (e.g. /tmp/foo/apache-1.0.0.tgz) or folder containing the chart (e.g. /tmp/mychart/apache).
-func (h HelmClient) Upgrade(chartLoc string, releaseName string, values map[string]interface{}, auth AuthSettings) (*release.Release, error) {
+func (h HelmClient) Upgrade(chartLoc string, releaseName string, values map[string]interface{}, deployOpts DeployOpts, auth AuthSettings) (*release.Release, error) {
 	chartToUpgrade, err := h.buildDependencies(chartLoc, auth)
 	if err != nil {
 		return nil, err
@@ -238,12 +287,48 @@ func (h HelmClient) Upgrade(chartLoc string, releaseName string, values map[stri
 
 	upgradeClient := action.NewUpgrade(h.actionConfig)
 	upgradeClient.Namespace = h.targetNamespace
+	upgradeClient.Wait = deployOpts.Wait
+	upgradeClient.Timeout = deployOpts.timeout()
+	upgradeClient.Atomic = deployOpts.Atomic
+	upgradeClient.MaxHistory = deployOpts.MaxHistory
+
+	// in case of error, the failed release is returned so that its status can be reported.
+	return upgradeClient.RunWithContext(h.ctx, releaseName, chartToUpgrade, values)
+}
+
+// Rollback the release in targetNamespace to the revision. If revision is 0, the release is rolled back to the previous revision.
+func (h HelmClient) Rollback(releaseName string, revision int, deployOpts DeployOpts) (*release.Release, error) {
+	rollbackClient := action.NewRollback(h.actionConfig)
+	rollbackClient.Version = revision
+	rollbackClient.Wait = deployOpts.Wait
+	rollbackClient.Timeout = deployOpts.timeout()
+	rollbackClient.MaxHistory = deployOpts.MaxHistory
+
+	if err := rollbackClient.Run(releaseName); err != nil {
+		return nil, err
+	}
+	return h.actionConfig.Releases.Last(releaseName)
+}
+
+// Get returns the last revision of the release in targetNamespace.
+func (h HelmClient) Get(releaseName string) (*release.Release, error) {
+	getClient := action.NewGet(h.actionConfig)
+	return getClient.Run(releaseName)
+}
+
+// History returns the revisions of the release in targetNamespace, newest first.
+func (h HelmClient) History(releaseName string) ([]*release.Release, error) {
+	historyClient := action.NewHistory(h.actionConfig)
 
-	rel, err := upgradeClient.RunWithContext(h.ctx, releaseName, chartToUpgrade, values)
+	releases, err := historyClient.Run(releaseName)
 	if err != nil {
 		return nil, err
 	}
-	return rel, nil
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Version > releases[j].Version
+	})
+	return releases, nil
 }
 
 // Uninstall the release in targetNamespace.
@@ -18,9 +18,16 @@ package source
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"path"
+	"strings"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"go.uber.org/zap"
+	helmregistry "helm.sh/helm/v3/pkg/registry"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/applications/helmclient"
@@ -43,6 +50,9 @@ type HelmSource struct {
 
 	// SeedClient to seed cluster.
 	SeedClient ctrlruntimeclient.Client
+
+	// Verification of the chart. If nil, the chart is not verified.
+	Verification *appskubermaticv1.SourceVerification
 }
 
 // DownloadSource downloads the chart into destination folder and return the full path to the chart.
@@ -59,6 +69,10 @@ func (h HelmSource) DownloadSource(destination string) (string, error) {
 		return "", err
 	}
 
+	if h.Verification != nil && strings.HasPrefix(h.Source.URL, "oci://") {
+		return h.downloadVerifiedOCIChart(auth, destination)
+	}
+
 	// Namespace does not matter to downloading chart.
 	ns := "default"
 	restClientGetter := &genericclioptions.ConfigFlags{
@@ -76,5 +90,101 @@ func (h HelmSource) DownloadSource(destination string) (string, error) {
 		return "", err
 	}
 
+	if h.Verification != nil {
+		return h.downloadVerifiedChart(helmClient, helmCacheDir, auth, destination)
+	}
 	return helmClient.DownloadChart(h.Source.URL, h.Source.ChartName, h.Source.ChartVersion, destination, auth)
 }
+
+// downloadVerifiedChart downloads the chart from a Helm HTTP repository and verifies it against its provenance file.
+func (h HelmSource) downloadVerifiedChart(helmClient *helmclient.HelmClient, helmCacheDir string, auth helmclient.AuthSettings, destination string) (string, error) {
+	if h.Verification.Provenance == nil {
+		return "", unverifiedSourceError("charts from Helm HTTP repositories can only be verified with a provenance file")
+	}
+
+	keys, err := loadTrustedKeys(h.Ctx, h.SeedClient, h.SecretNamespace, h.Verification)
+	if err != nil {
+		return "", err
+	}
+	if len(keys.keyring) == 0 {
+		return "", unverifiedSourceError("no PGP keyring is trusted")
+	}
+
+	keyringPath := path.Join(helmCacheDir, "pubring.gpg")
+	if err := os.WriteFile(keyringPath, keys.keyring, 0600); err != nil {
+		return "", fmt.Errorf("failed to write keyring: %w", err)
+	}
+
+	chartLoc, err := helmClient.DownloadChartWithProvenance(h.Source.URL, h.Source.ChartName, h.Source.ChartVersion, destination, auth)
+	if err != nil {
+		return "", err
+	}
+
+	if err := helmclient.VerifyChart(chartLoc, keyringPath); err != nil {
+		return "", unverifiedSourceError("failed to verify provenance of chart %s:%s: %v", h.Source.ChartName, h.Source.ChartVersion, err)
+	}
+	return chartLoc, nil
+}
+
+// downloadVerifiedOCIChart verifies the cosign signature of the chart stored in an OCI registry and pulls the verified
+// manifest, so that the chart can not be changed between the verification and the download. It returns the path to the
+// chart archive.
+func (h HelmSource) downloadVerifiedOCIChart(auth helmclient.AuthSettings, destination string) (string, error) {
+	if h.Verification.Cosign == nil {
+		return "", unverifiedSourceError("charts from OCI registries can only be verified with cosign")
+	}
+
+	keys, err := loadTrustedKeys(h.Ctx, h.SeedClient, h.SecretNamespace, h.Verification)
+	if err != nil {
+		return "", err
+	}
+
+	opts := content.RegistryOptions{Username: auth.Username, Password: auth.Password}
+	if auth.RegistryConfigFile != "" {
+		opts.Configs = []string{auth.RegistryConfigFile}
+	}
+	registry, err := content.NewRegistry(opts)
+	if err != nil {
+		return "", err
+	}
+
+	repository := strings.TrimPrefix(h.Source.URL, "oci://") + "/" + h.Source.ChartName
+	// Helm replaces '+' with '_' in the tags because '+' is not allowed in OCI tags.
+	tag := strings.ReplaceAll(h.Source.ChartVersion, "+", "_")
+	manifestDigest, err := resolveDigest(h.Ctx, registry, repository+":"+tag)
+	if err != nil {
+		return "", err
+	}
+
+	if err := verifyCosignSignature(h.Ctx, registry, repository, manifestDigest, keys.cosign); err != nil {
+		return "", err
+	}
+
+	ref := repository + "@" + manifestDigest.String()
+	store := content.NewMemory()
+	var layers []ocispec.Descriptor
+	_, err = oras.Copy(h.Ctx, registry, ref, store, "",
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithLayerDescriptors(func(l []ocispec.Descriptor) {
+			layers = l
+		}))
+	if err != nil {
+		return "", fmt.Errorf("failed to pull chart %s: %w", ref, err)
+	}
+
+	for _, layer := range layers {
+		if layer.MediaType != helmregistry.ChartLayerMediaType {
+			continue
+		}
+		_, chartArchive, found := store.Get(layer)
+		if !found {
+			break
+		}
+		chartLoc := path.Join(destination, fmt.Sprintf("%s-%s.tgz", h.Source.ChartName, h.Source.ChartVersion))
+		if err := os.WriteFile(chartLoc, chartArchive, 0644); err != nil {
+			return "", fmt.Errorf("failed to write chart archive: %w", err)
+		}
+		return chartLoc, nil
+	}
+	return "", fmt.Errorf("chart %s does not contain a chart layer", ref)
+}
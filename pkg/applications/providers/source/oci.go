@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OCISource downloads the application's source from an OCI artifact.
+type OCISource struct {
+	Ctx context.Context
+
+	// SeedClient to seed cluster.
+	SeedClient ctrlruntimeclient.Client
+
+	Source *appskubermaticv1.OCISource
+
+	// Namespace where credential secrets are stored.
+	SecretNamespace string
+
+	// Verification of the artifact. If nil, the artifact is not verified.
+	Verification *appskubermaticv1.SourceVerification
+}
+
+// DownloadSource pulls the artifact and extracts its layers into destination. It returns the full path to the
+// application's sources. The destination folder must exist.
+func (o OCISource) DownloadSource(destination string) (string, error) {
+	// credentials are stored outside of the destination so that they can not leak into the application.
+	credDir, err := os.MkdirTemp("", "oci-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory for registry credentials: %w", err)
+	}
+	defer os.RemoveAll(credDir)
+
+	registry, err := o.newRegistry(path.Join(credDir, "reg-cred"))
+	if err != nil {
+		return "", err
+	}
+
+	ref := o.reference()
+	if o.Verification != nil {
+		ref, err = o.verify(registry)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	store := content.NewMemory()
+	var layers []ocispec.Descriptor
+	manifest, err := oras.Copy(o.Ctx, registry, ref, store, "",
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithLayerDescriptors(func(l []ocispec.Descriptor) {
+			layers = l
+		}))
+	if err != nil {
+		return "", fmt.Errorf("failed to pull artifact %s: %w", ref, err)
+	}
+
+	if o.Source.Digest != "" && manifest.Digest.String() != o.Source.Digest {
+		return "", fmt.Errorf("digest of artifact %s does not match: expected %s, got %s", ref, o.Source.Digest, manifest.Digest)
+	}
+
+	for _, layer := range layers {
+		if err := o.extractLayer(store, layer, destination); err != nil {
+			return "", err
+		}
+	}
+
+	return path.Join(destination, o.Source.Path), nil
+}
+
+// reference returns the reference of the artifact understood by the registry client (e.g. localhost:5000/repo@sha256:...).
+func (o OCISource) reference() string {
+	ref := strings.TrimPrefix(o.Source.URL, "oci://")
+	if o.Source.Digest != "" {
+		return ref + "@" + o.Source.Digest
+	}
+	return ref + ":" + o.Source.Tag
+}
+
+// verify verifies the cosign signature of the artifact and returns the reference of the verified manifest
+// (e.g. localhost:5000/repo@sha256:...). Pulling this reference ensures that the artifact can not be changed
+// between the verification and the download.
+func (o OCISource) verify(registry *content.Registry) (string, error) {
+	if o.Verification.Cosign == nil {
+		return "", unverifiedSourceError("OCI artifacts can only be verified with cosign")
+	}
+
+	keys, err := loadTrustedKeys(o.Ctx, o.SeedClient, o.SecretNamespace, o.Verification)
+	if err != nil {
+		return "", err
+	}
+
+	repository := strings.TrimPrefix(o.Source.URL, "oci://")
+	manifestDigest := digest.Digest(o.Source.Digest)
+	if manifestDigest == "" {
+		manifestDigest, err = resolveDigest(o.Ctx, registry, o.reference())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := verifyCosignSignature(o.Ctx, registry, repository, manifestDigest, keys.cosign); err != nil {
+		return "", err
+	}
+	return repository + "@" + manifestDigest.String(), nil
+}
+
+// newRegistry returns a registry store configured with the credentials defined in the OCISource.
+// registryConfigFilePath is the path of the file that stores the registry configuration file if any.
+func (o OCISource) newRegistry(registryConfigFilePath string) (*content.Registry, error) {
+	opts := content.RegistryOptions{PlainHTTP: o.Source.PlainHTTP}
+
+	credentials := o.Source.Credentials
+	if credentials != nil {
+		if credentials.Username != nil {
+			username, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, credentials.Username.Name, credentials.Username.Key)
+			if err != nil {
+				return nil, err
+			}
+			opts.Username = username
+		}
+		if credentials.Password != nil {
+			password, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, credentials.Password.Name, credentials.Password.Key)
+			if err != nil {
+				return nil, err
+			}
+			opts.Password = password
+		}
+		if credentials.RegistryConfigFile != nil {
+			registryConfigFile, err := util.GetCredentialFromSecret(o.Ctx, o.SeedClient, o.SecretNamespace, credentials.RegistryConfigFile.Name, credentials.RegistryConfigFile.Key)
+			if err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(registryConfigFilePath, []byte(registryConfigFile), 0600); err != nil {
+				return nil, fmt.Errorf("failed to write registryConfigFile: %w", err)
+			}
+			opts.Configs = []string{registryConfigFilePath}
+		}
+	}
+
+	return content.NewRegistry(opts)
+}
+
+// extractLayer extracts tar layers into destination. Other layers are written as a file named after the title
+// annotation of the layer.
+func (o OCISource) extractLayer(store *content.Memory, layer ocispec.Descriptor, destination string) error {
+	rc, err := store.Fetch(o.Ctx, layer)
+	if err != nil {
+		return fmt.Errorf("failed to read layer %s: %w", layer.Digest, err)
+	}
+	defer rc.Close()
+
+	if strings.Contains(layer.MediaType, "tar") {
+		if err := extractTarball(rc, destination); err != nil {
+			return fmt.Errorf("failed to extract layer %s: %w", layer.Digest, err)
+		}
+		return nil
+	}
+
+	name := layer.Annotations[ocispec.AnnotationTitle]
+	if name == "" {
+		// layers without name can not be referenced by the application.
+		return nil
+	}
+
+	target, err := securePath(destination, filepath.FromSlash(name))
+	if err != nil {
+		return err
+	}
+	return writeFile(target, rc, 0644)
+}
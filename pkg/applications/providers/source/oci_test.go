@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDownloadOCISource(t *testing.T) {
+	bundleLayer := createTarGz(t, map[string]string{"bundle/deploy.yaml": "kind: ConfigMap"})
+	readmeLayer := []byte("readme")
+
+	fakeRegistry := newFakeOCIRegistry(t, "apps/bundle", "", "")
+	manifestDigest := fakeRegistry.push(t, "1.0.0", []fakeLayer{
+		{mediaType: ocispec.MediaTypeImageLayerGzip, content: bundleLayer},
+		{mediaType: "text/markdown", content: readmeLayer, annotations: map[string]string{ocispec.AnnotationTitle: "README.md"}},
+	})
+	registry := fakeRegistry.host
+
+	authRegistry := newFakeOCIRegistry(t, "apps/bundle", "user", "pass")
+	authRegistry.push(t, "1.0.0", []fakeLayer{
+		{mediaType: ocispec.MediaTypeImageLayerGzip, content: bundleLayer},
+	})
+
+	secretName := "oci-cred"
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: secretName},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}
+
+	testCases := []struct {
+		name          string
+		source        *appskubermaticv1.OCISource
+		expectedFiles []string
+		expectedError string
+	}{
+		{
+			name:          "scenario 1: pull artifact by tag",
+			source:        &appskubermaticv1.OCISource{URL: "oci://" + registry + "/apps/bundle", Tag: "1.0.0", PlainHTTP: true},
+			expectedFiles: []string{"bundle/deploy.yaml", "README.md"},
+		},
+		{
+			name:          "scenario 2: pull artifact by digest with path",
+			source:        &appskubermaticv1.OCISource{URL: "oci://" + registry + "/apps/bundle", Digest: manifestDigest.String(), Path: "bundle", PlainHTTP: true},
+			expectedFiles: []string{"deploy.yaml"},
+		},
+		{
+			name:          "scenario 3: pull artifact by unknown digest",
+			source:        &appskubermaticv1.OCISource{URL: "oci://" + registry + "/apps/bundle", Digest: digest.FromString("unknown").String(), PlainHTTP: true},
+			expectedError: "failed to pull artifact",
+		},
+		{
+			name: "scenario 4: pull artifact with credentials",
+			source: &appskubermaticv1.OCISource{URL: "oci://" + authRegistry.host + "/apps/bundle", Tag: "1.0.0", PlainHTTP: true, Credentials: &appskubermaticv1.OCICredentials{
+				Username: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "username"},
+				Password: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "password"},
+			}},
+			expectedFiles: []string{"bundle/deploy.yaml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destination := t.TempDir()
+			source := OCISource{
+				Ctx:             context.Background(),
+				SeedClient:      fakectrlruntimeclient.NewClientBuilder().WithObjects(credentialsSecret).Build(),
+				Source:          tc.source,
+				SecretNamespace: "kubermatic",
+			}
+
+			appSourcePath, err := source.DownloadSource(destination)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to download artifact: %v", err)
+			}
+
+			for _, file := range tc.expectedFiles {
+				if _, err := os.Stat(path.Join(appSourcePath, file)); err != nil {
+					t.Fatalf("expected file %s to be extracted: %v", file, err)
+				}
+			}
+		})
+	}
+}
+
+type fakeLayer struct {
+	mediaType   string
+	content     []byte
+	annotations map[string]string
+}
+
+// fakeOCIRegistry is a minimal OCI registry serving the artifacts of a single repository.
+type fakeOCIRegistry struct {
+	// host of the registry (e.g. 127.0.0.1:5000).
+	host string
+
+	blobs map[digest.Digest][]byte
+	// manifests indexed by tag and by digest.
+	manifests map[string][]byte
+}
+
+// newFakeOCIRegistry starts a fake OCI registry serving the artifacts of repository. If username is not empty, the
+// registry requires basic authentication.
+func newFakeOCIRegistry(t *testing.T, repository string, username string, password string) *fakeOCIRegistry {
+	registry := &fakeOCIRegistry{
+		blobs:     map[digest.Digest][]byte{},
+		manifests: map[string][]byte{},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username != "" {
+			if user, pass, ok := r.BasicAuth(); !ok || user != username || pass != password {
+				w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		prefix := fmt.Sprintf("/v2/%s/", repository)
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var content []byte
+		var mediaType string
+		var found bool
+		switch kind, ref, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/"); kind {
+		case "manifests":
+			content, found = registry.manifests[ref]
+			mediaType = ocispec.MediaTypeImageManifest
+		case "blobs":
+			content, found = registry.blobs[digest.Digest(ref)]
+			mediaType = "application/octet-stream"
+		}
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(content).String())
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	registry.host = strings.TrimPrefix(server.URL, "http://")
+	return registry
+}
+
+// push stores an artifact made of layers under tag and returns the digest of its manifest.
+func (f *fakeOCIRegistry) push(t *testing.T, tag string, layers []fakeLayer) digest.Digest {
+	config := []byte("{}")
+	configDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromBytes(config), Size: int64(len(config))}
+	f.blobs[configDesc.Digest] = config
+
+	manifest := ocispec.Manifest{Config: configDesc}
+	manifest.SchemaVersion = 2
+	for _, layer := range layers {
+		desc := ocispec.Descriptor{MediaType: layer.mediaType, Digest: digest.FromBytes(layer.content), Size: int64(len(layer.content)), Annotations: layer.annotations}
+		f.blobs[desc.Digest] = layer.content
+		manifest.Layers = append(manifest.Layers, desc)
+	}
+
+	rawManifest, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+	manifestDigest := digest.FromBytes(rawManifest)
+	f.manifests[tag] = rawManifest
+	f.manifests[manifestDigest.String()] = rawManifest
+
+	return manifestDigest
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/opencontainers/go-digest"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	"k8c.io/kubermatic/v2/pkg/util/s3"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TarballSource downloads the application's source from a tarball served over HTTP(s) or stored in an S3-compatible
+// bucket.
+type TarballSource struct {
+	Ctx context.Context
+
+	// SeedClient to seed cluster.
+	SeedClient ctrlruntimeclient.Client
+
+	Source *appskubermaticv1.TarballSource
+
+	// Namespace where credential secrets are stored.
+	SecretNamespace string
+}
+
+// DownloadSource downloads the tarball, verifies its checksum and extracts it into destination. It returns the full
+// path to the application's sources. The destination folder must exist.
+func (t TarballSource) DownloadSource(destination string) (string, error) {
+	expected, err := digest.Parse(t.Source.Checksum)
+	if err != nil {
+		return "", fmt.Errorf("invalid checksum '%s': %w", t.Source.Checksum, err)
+	}
+
+	body, err := t.open()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	// the tarball is buffered so that nothing is extracted before the checksum has been verified.
+	archive, err := os.CreateTemp("", "tarball-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	verifier := expected.Verifier()
+	if _, err := io.Copy(io.MultiWriter(archive, verifier), body); err != nil {
+		return "", fmt.Errorf("failed to download tarball: %w", err)
+	}
+	if !verifier.Verified() {
+		return "", fmt.Errorf("checksum of tarball %s does not match %s", t.Source.URL, t.Source.Checksum)
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if err := extractTarball(archive, destination); err != nil {
+		return "", fmt.Errorf("failed to extract tarball: %w", err)
+	}
+
+	return path.Join(destination, t.Source.Path), nil
+}
+
+// open returns the content of the tarball.
+func (t TarballSource) open() (io.ReadCloser, error) {
+	u, err := url.Parse(t.Source.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+
+	if u.Scheme == "s3" {
+		return t.openS3Object(u)
+	}
+	return t.openHTTP()
+}
+
+func (t TarballSource) openHTTP() (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(t.Ctx, http.MethodGet, t.Source.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := t.Source.Credentials
+	if credentials != nil {
+		switch {
+		case credentials.Token != nil:
+			token, err := t.getCredential(credentials.Token)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+		case credentials.Username != nil && credentials.Password != nil:
+			username, err := t.getCredential(credentials.Username)
+			if err != nil {
+				return nil, err
+			}
+			password, err := t.getCredential(credentials.Password)
+			if err != nil {
+				return nil, err
+			}
+			req.SetBasicAuth(username, password)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download tarball: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download tarball: unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+func (t TarballSource) openS3Object(u *url.URL) (io.ReadCloser, error) {
+	var accessKeyID, secretAccessKey string
+	credentials := t.Source.Credentials
+	if credentials != nil && credentials.AccessKeyID != nil && credentials.SecretAccessKey != nil {
+		var err error
+		if accessKeyID, err = t.getCredential(credentials.AccessKeyID); err != nil {
+			return nil, err
+		}
+		if secretAccessKey, err = t.getCredential(credentials.SecretAccessKey); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := s3.NewClient(t.Source.Endpoint, accessKeyID, secretAccessKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	bucket := u.Host
+	key := strings.TrimPrefix(u.Path, "/")
+	object, err := client.GetObject(t.Ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s from bucket %s: %w", key, bucket, err)
+	}
+
+	return object, nil
+}
+
+func (t TarballSource) getCredential(selector *corev1.SecretKeySelector) (string, error) {
+	return util.GetCredentialFromSecret(t.Ctx, t.SeedClient, t.SecretNamespace, selector.Name, selector.Key)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDownloadTarballSource(t *testing.T) {
+	archive := createTarGz(t, map[string]string{
+		"bundle/deploy.yaml": "kind: ConfigMap",
+		"bundle/README.md":   "readme",
+	})
+	checksum := sha256Checksum(archive)
+
+	secretName := "tarball-cred"
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: secretName},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass"), "token": []byte("secret-token")},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/basic/bundle.tar.gz":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "/token/bundle.tar.gz":
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "/bundle.tar.gz":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		source        *appskubermaticv1.TarballSource
+		expectedFile  string
+		expectedError string
+	}{
+		{
+			name:         "scenario 1: download tarball over HTTP",
+			source:       &appskubermaticv1.TarballSource{URL: server.URL + "/bundle.tar.gz", Checksum: checksum},
+			expectedFile: "bundle/deploy.yaml",
+		},
+		{
+			name:         "scenario 2: download tarball with path",
+			source:       &appskubermaticv1.TarballSource{URL: server.URL + "/bundle.tar.gz", Checksum: checksum, Path: "bundle"},
+			expectedFile: "deploy.yaml",
+		},
+		{
+			name: "scenario 3: download tarball with basic auth",
+			source: &appskubermaticv1.TarballSource{URL: server.URL + "/basic/bundle.tar.gz", Checksum: checksum, Credentials: &appskubermaticv1.TarballCredentials{
+				Username: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "username"},
+				Password: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "password"},
+			}},
+			expectedFile: "bundle/deploy.yaml",
+		},
+		{
+			name: "scenario 4: download tarball with bearer token",
+			source: &appskubermaticv1.TarballSource{URL: server.URL + "/token/bundle.tar.gz", Checksum: checksum, Credentials: &appskubermaticv1.TarballCredentials{
+				Token: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "token"},
+			}},
+			expectedFile: "bundle/deploy.yaml",
+		},
+		{
+			name:          "scenario 5: checksum mismatch",
+			source:        &appskubermaticv1.TarballSource{URL: server.URL + "/bundle.tar.gz", Checksum: sha256Checksum([]byte("other"))},
+			expectedError: "does not match",
+		},
+		{
+			name:          "scenario 6: missing credentials",
+			source:        &appskubermaticv1.TarballSource{URL: server.URL + "/basic/bundle.tar.gz", Checksum: checksum},
+			expectedError: "unexpected status code 401",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destination := t.TempDir()
+			source := TarballSource{
+				Ctx:             context.Background(),
+				SeedClient:      fakectrlruntimeclient.NewClientBuilder().WithObjects(credentialsSecret).Build(),
+				Source:          tc.source,
+				SecretNamespace: "kubermatic",
+			}
+
+			appSourcePath, err := source.DownloadSource(destination)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to download tarball: %v", err)
+			}
+
+			if _, err := os.Stat(path.Join(appSourcePath, tc.expectedFile)); err != nil {
+				t.Fatalf("expected file %s to be extracted: %v", tc.expectedFile, err)
+			}
+		})
+	}
+}
+
+func TestExtractTarballRejectsPathTraversal(t *testing.T) {
+	destination := t.TempDir()
+	archive := createTarGz(t, map[string]string{"../../escaped.yaml": "kind: ConfigMap"})
+
+	if err := extractTarball(bytes.NewReader(archive), destination); err != nil {
+		t.Fatalf("failed to extract tarball: %v", err)
+	}
+
+	// the entry is extracted inside destination instead of escaping it.
+	if _, err := os.Stat(path.Join(destination, "escaped.yaml")); err != nil {
+		t.Fatalf("expected file to be extracted inside destination: %v", err)
+	}
+}
+
+func createTarGz(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
@@ -0,0 +1,245 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// cosignSignatureMediaType is the media type of the layers of a cosign signature.
+	cosignSignatureMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// cosignSignatureAnnotation is the annotation of a cosign signature layer holding the base64 encoded signature of
+	// the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// cosignPayload is the simple signing payload signed by cosign.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// unverifiedSourceError returns an error wrapping util.ErrUnverifiedSource.
+func unverifiedSourceError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", util.ErrUnverifiedSource, fmt.Sprintf(format, args...))
+}
+
+// trustedKeys are the keys trusted to verify the source of an application.
+type trustedKeys struct {
+	// cosign public keys.
+	cosign []crypto.PublicKey
+
+	// keyring is the PGP public keyring (binary format) used to verify Helm provenance files.
+	keyring []byte
+}
+
+// loadTrustedKeys returns the keys referenced by verification and the global ones stored in the secret
+// appskubermaticv1.ApplicationTrustedKeysSecretName. Only the keys of the verification methods enabled in
+// verification are loaded.
+func loadTrustedKeys(ctx context.Context, client ctrlruntimeclient.Client, secretNamespace string, verification *appskubermaticv1.SourceVerification) (*trustedKeys, error) {
+	keys := &trustedKeys{}
+
+	globalKeys := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: secretNamespace, Name: appskubermaticv1.ApplicationTrustedKeysSecretName}, globalKeys); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get trusted keys secret: %w", err)
+		}
+	}
+
+	if verification.Cosign != nil {
+		for _, selector := range verification.Cosign.PublicKeys {
+			pemKeys, err := util.GetCredentialFromSecret(ctx, client, secretNamespace, selector.Name, selector.Key)
+			if err != nil {
+				return nil, err
+			}
+			publicKeys, err := parsePublicKeys([]byte(pemKeys))
+			if err != nil {
+				return nil, fmt.Errorf("invalid cosign public key in secret '%s' key '%s': %w", selector.Name, selector.Key, err)
+			}
+			keys.cosign = append(keys.cosign, publicKeys...)
+		}
+
+		// sort the keys of the secret to load them in a stable order.
+		var names []string
+		for name := range globalKeys.Data {
+			if strings.HasSuffix(name, appskubermaticv1.ApplicationTrustedKeysCosignSuffix) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			publicKeys, err := parsePublicKeys(globalKeys.Data[name])
+			if err != nil {
+				return nil, fmt.Errorf("invalid cosign public key in secret '%s' key '%s': %w", appskubermaticv1.ApplicationTrustedKeysSecretName, name, err)
+			}
+			keys.cosign = append(keys.cosign, publicKeys...)
+		}
+	}
+
+	if verification.Provenance != nil {
+		if verification.Provenance.Keyring != nil {
+			keyring, err := util.GetCredentialFromSecret(ctx, client, secretNamespace, verification.Provenance.Keyring.Name, verification.Provenance.Keyring.Key)
+			if err != nil {
+				return nil, err
+			}
+			keys.keyring = append(keys.keyring, keyring...)
+		}
+		// binary keyrings are a sequence of packets, so they can be concatenated.
+		keys.keyring = append(keys.keyring, globalKeys.Data[appskubermaticv1.ApplicationTrustedKeysKeyringKey]...)
+	}
+
+	return keys, nil
+}
+
+// parsePublicKeys parses the PEM encoded public keys.
+func parsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	return keys, nil
+}
+
+// verifySignature returns true if signature is a valid signature of payload for one of the keys.
+func verifySignature(keys []crypto.PublicKey, payload []byte, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveDigest returns the digest of the manifest referenced by ref (e.g. localhost:5000/repo:tag).
+func resolveDigest(ctx context.Context, registry *content.Registry, ref string) (digest.Digest, error) {
+	_, desc, err := registry.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return desc.Digest, nil
+}
+
+// verifyCosignSignature verifies that the manifest with manifestDigest in repository (e.g. localhost:5000/repo) has
+// been signed with cosign by one of the keys. The signature is expected to be stored in the same repository under the
+// tag sha256-<digest>.sig, as cosign does by default.
+func verifyCosignSignature(ctx context.Context, registry *content.Registry, repository string, manifestDigest digest.Digest, keys []crypto.PublicKey) error {
+	if len(keys) == 0 {
+		return unverifiedSourceError("no cosign public key is trusted")
+	}
+
+	signatureRef := fmt.Sprintf("%s:%s-%s.sig", repository, manifestDigest.Algorithm(), manifestDigest.Encoded())
+	store := content.NewMemory()
+	var layers []ocispec.Descriptor
+	_, err := oras.Copy(ctx, registry, signatureRef, store, "",
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithLayerDescriptors(func(l []ocispec.Descriptor) {
+			layers = l
+		}))
+	if err != nil {
+		return unverifiedSourceError("failed to pull cosign signature %s: %v", signatureRef, err)
+	}
+
+	for _, layer := range layers {
+		if layer.MediaType != cosignSignatureMediaType {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+
+		_, payload, found := store.Get(layer)
+		if !found || !verifySignature(keys, payload, signature) {
+			continue
+		}
+
+		signedPayload := cosignPayload{}
+		if err := json.Unmarshal(payload, &signedPayload); err != nil {
+			continue
+		}
+		if signedPayload.Critical.Image.DockerManifestDigest == manifestDigest.String() {
+			return nil
+		}
+	}
+
+	return unverifiedSourceError("no valid cosign signature of %s@%s from a trusted key found", repository, manifestDigest)
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // Helm provenance files are signed with openpgp.
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/repo"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDownloadOCISourceWithCosignVerification(t *testing.T) {
+	bundleLayer := createTarGz(t, map[string]string{"bundle/deploy.yaml": "kind: ConfigMap"})
+	layers := []fakeLayer{{mediaType: ocispec.MediaTypeImageLayerGzip, content: bundleLayer}}
+
+	trustedKey, trustedPublicKey := generateCosignKey(t)
+	untrustedKey, _ := generateCosignKey(t)
+
+	registry := newFakeOCIRegistry(t, "apps/bundle", "", "")
+	signedDigest := registry.push(t, "signed", layers)
+	registry.push(t, cosignSignatureTag(signedDigest), []fakeLayer{cosignSignatureLayer(t, trustedKey, signedDigest)})
+
+	untrustedDigest := registry.push(t, "untrusted", append(layers, fakeLayer{mediaType: "text/plain", content: []byte("untrusted")}))
+	registry.push(t, cosignSignatureTag(untrustedDigest), []fakeLayer{cosignSignatureLayer(t, untrustedKey, untrustedDigest)})
+
+	// the signature is valid but has been made for another artifact.
+	copiedDigest := registry.push(t, "copied", append(layers, fakeLayer{mediaType: "text/plain", content: []byte("copied")}))
+	registry.push(t, cosignSignatureTag(copiedDigest), []fakeLayer{cosignSignatureLayer(t, trustedKey, signedDigest)})
+
+	registry.push(t, "unsigned", append(layers, fakeLayer{mediaType: "text/plain", content: []byte("unsigned")}))
+
+	keySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: "cosign"},
+		Data:       map[string][]byte{"cosign.pub": trustedPublicKey},
+	}
+	globalKeysSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: appskubermaticv1.ApplicationTrustedKeysSecretName},
+		Data:       map[string][]byte{"release" + appskubermaticv1.ApplicationTrustedKeysCosignSuffix: trustedPublicKey},
+	}
+	cosignVerification := &appskubermaticv1.SourceVerification{Cosign: &appskubermaticv1.CosignVerification{
+		PublicKeys: []corev1.SecretKeySelector{{LocalObjectReference: corev1.LocalObjectReference{Name: "cosign"}, Key: "cosign.pub"}},
+	}}
+
+	testCases := []struct {
+		name          string
+		tag           string
+		verification  *appskubermaticv1.SourceVerification
+		secrets       []ctrlruntimeclient.Object
+		expectedError string
+	}{
+		{
+			name:         "scenario 1: artifact signed by a key of the ApplicationDefinition",
+			tag:          "signed",
+			verification: cosignVerification,
+			secrets:      []ctrlruntimeclient.Object{keySecret},
+		},
+		{
+			name:         "scenario 2: artifact signed by a globally trusted key",
+			tag:          "signed",
+			verification: &appskubermaticv1.SourceVerification{Cosign: &appskubermaticv1.CosignVerification{}},
+			secrets:      []ctrlruntimeclient.Object{globalKeysSecret},
+		},
+		{
+			name:          "scenario 3: artifact signed by an untrusted key is refused",
+			tag:           "untrusted",
+			verification:  cosignVerification,
+			secrets:       []ctrlruntimeclient.Object{keySecret},
+			expectedError: "no valid cosign signature",
+		},
+		{
+			name:          "scenario 4: signature of another artifact is refused",
+			tag:           "copied",
+			verification:  cosignVerification,
+			secrets:       []ctrlruntimeclient.Object{keySecret},
+			expectedError: "no valid cosign signature",
+		},
+		{
+			name:          "scenario 5: unsigned artifact is refused",
+			tag:           "unsigned",
+			verification:  cosignVerification,
+			secrets:       []ctrlruntimeclient.Object{keySecret},
+			expectedError: "failed to pull cosign signature",
+		},
+		{
+			name:          "scenario 6: artifact is refused if no key is trusted",
+			tag:           "signed",
+			verification:  &appskubermaticv1.SourceVerification{Cosign: &appskubermaticv1.CosignVerification{}},
+			expectedError: "no cosign public key is trusted",
+		},
+		{
+			name:          "scenario 7: artifact is refused if cosign is not configured",
+			tag:           "signed",
+			verification:  &appskubermaticv1.SourceVerification{Provenance: &appskubermaticv1.ProvenanceVerification{}},
+			expectedError: "can only be verified with cosign",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destination := t.TempDir()
+			source := OCISource{
+				Ctx:             context.Background(),
+				SeedClient:      fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.secrets...).Build(),
+				Source:          &appskubermaticv1.OCISource{URL: "oci://" + registry.host + "/apps/bundle", Tag: tc.tag, PlainHTTP: true},
+				SecretNamespace: "kubermatic",
+				Verification:    tc.verification,
+			}
+
+			appSourcePath, err := source.DownloadSource(destination)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing '%s', got %v", tc.expectedError, err)
+				}
+				if !errors.Is(err, util.ErrUnverifiedSource) {
+					t.Fatalf("expected error to be an ErrUnverifiedSource, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to download artifact: %v", err)
+			}
+
+			if _, err := os.Stat(path.Join(appSourcePath, "bundle/deploy.yaml")); err != nil {
+				t.Fatalf("expected artifact to be extracted: %v", err)
+			}
+		})
+	}
+}
+
+func TestDownloadHelmSourceWithProvenanceVerification(t *testing.T) {
+	signer, keyring := generatePGPKey(t)
+	_, untrustedKeyring := generatePGPKey(t)
+
+	repoDir := t.TempDir()
+	signedChart := packageChart(t, repoDir, "signed", signer)
+	unsignedChart := packageChart(t, repoDir, "unsigned", nil)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(repoDir)))
+	t.Cleanup(server.Close)
+
+	index := repo.NewIndexFile()
+	for _, c := range []*chart.Chart{signedChart, unsignedChart} {
+		if err := index.MustAdd(c.Metadata, fmt.Sprintf("%s-%s.tgz", c.Name(), c.Metadata.Version), server.URL, ""); err != nil {
+			t.Fatalf("failed to add chart to index: %v", err)
+		}
+	}
+	if err := index.WriteFile(path.Join(repoDir, "index.yaml"), 0644); err != nil {
+		t.Fatalf("failed to write index: %v", err)
+	}
+
+	keyringSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: "keyring"},
+		Data:       map[string][]byte{"pubring.gpg": keyring},
+	}
+	untrustedKeyringSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: "keyring"},
+		Data:       map[string][]byte{"pubring.gpg": untrustedKeyring},
+	}
+	globalKeysSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubermatic", Name: appskubermaticv1.ApplicationTrustedKeysSecretName},
+		Data:       map[string][]byte{appskubermaticv1.ApplicationTrustedKeysKeyringKey: keyring},
+	}
+	provenanceVerification := &appskubermaticv1.SourceVerification{Provenance: &appskubermaticv1.ProvenanceVerification{
+		Keyring: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "keyring"}, Key: "pubring.gpg"},
+	}}
+
+	testCases := []struct {
+		name          string
+		chartName     string
+		verification  *appskubermaticv1.SourceVerification
+		secrets       []ctrlruntimeclient.Object
+		expectedError string
+	}{
+		{
+			name:         "scenario 1: chart signed by a key of the ApplicationDefinition keyring",
+			chartName:    "signed",
+			verification: provenanceVerification,
+			secrets:      []ctrlruntimeclient.Object{keyringSecret},
+		},
+		{
+			name:         "scenario 2: chart signed by a key of the global keyring",
+			chartName:    "signed",
+			verification: &appskubermaticv1.SourceVerification{Provenance: &appskubermaticv1.ProvenanceVerification{}},
+			secrets:      []ctrlruntimeclient.Object{globalKeysSecret},
+		},
+		{
+			name:          "scenario 3: chart signed by an untrusted key is refused",
+			chartName:     "signed",
+			verification:  provenanceVerification,
+			secrets:       []ctrlruntimeclient.Object{untrustedKeyringSecret},
+			expectedError: "failed to verify provenance",
+		},
+		{
+			name:          "scenario 4: chart without provenance file is refused",
+			chartName:     "unsigned",
+			verification:  provenanceVerification,
+			secrets:       []ctrlruntimeclient.Object{keyringSecret},
+			expectedError: "failed to verify provenance",
+		},
+		{
+			name:          "scenario 5: chart is refused if no keyring is trusted",
+			chartName:     "signed",
+			verification:  &appskubermaticv1.SourceVerification{Provenance: &appskubermaticv1.ProvenanceVerification{}},
+			expectedError: "no PGP keyring is trusted",
+		},
+		{
+			name:          "scenario 6: chart is refused if provenance is not configured",
+			chartName:     "signed",
+			verification:  &appskubermaticv1.SourceVerification{Cosign: &appskubermaticv1.CosignVerification{}},
+			expectedError: "can only be verified with a provenance file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := HelmSource{
+				Ctx:             context.Background(),
+				CacheDir:        t.TempDir(),
+				Log:             kubermaticlog.New(true, kubermaticlog.FormatJSON).Sugar(),
+				Source:          &appskubermaticv1.HelmSource{URL: server.URL, ChartName: tc.chartName, ChartVersion: "0.1.0"},
+				SecretNamespace: "kubermatic",
+				SeedClient:      fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.secrets...).Build(),
+				Verification:    tc.verification,
+			}
+
+			chartLoc, err := source.DownloadSource(t.TempDir())
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing '%s', got %v", tc.expectedError, err)
+				}
+				if !errors.Is(err, util.ErrUnverifiedSource) {
+					t.Fatalf("expected error to be an ErrUnverifiedSource, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to download chart: %v", err)
+			}
+
+			if _, err := os.Stat(chartLoc); err != nil {
+				t.Fatalf("expected chart to be downloaded: %v", err)
+			}
+		})
+	}
+}
+
+// generateCosignKey returns a new ECDSA key and its PEM encoded public key.
+func generateCosignKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// cosignSignatureTag returns the tag under which cosign stores the signature of the manifest.
+func cosignSignatureTag(manifestDigest digest.Digest) string {
+	return fmt.Sprintf("%s-%s.sig", manifestDigest.Algorithm(), manifestDigest.Encoded())
+}
+
+// cosignSignatureLayer returns a signature layer as created by cosign for the manifest.
+func cosignSignatureLayer(t *testing.T, key *ecdsa.PrivateKey, manifestDigest digest.Digest) fakeLayer {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"apps/bundle"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, manifestDigest))
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign payload: %v", err)
+	}
+
+	return fakeLayer{
+		mediaType:   cosignSignatureMediaType,
+		content:     payload,
+		annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	}
+}
+
+// generatePGPKey returns a new PGP entity and a binary keyring holding its public key.
+func generatePGPKey(t *testing.T) (*openpgp.Entity, []byte) {
+	entity, err := openpgp.NewEntity("test", "", "test@kubermatic.io", nil)
+	if err != nil {
+		t.Fatalf("failed to generate PGP key: %v", err)
+	}
+	keyring := &bytes.Buffer{}
+	if err := entity.Serialize(keyring); err != nil {
+		t.Fatalf("failed to serialize PGP key: %v", err)
+	}
+	return entity, keyring.Bytes()
+}
+
+// packageChart packages a chart named name into dir. If signer is not nil, the provenance file of the chart is
+// created.
+func packageChart(t *testing.T, dir string, name string, signer *openpgp.Entity) *chart.Chart {
+	c := &chart.Chart{Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: name, Version: "0.1.0"}}
+	chartLoc, err := chartutil.Save(c, dir)
+	if err != nil {
+		t.Fatalf("failed to package chart: %v", err)
+	}
+
+	if signer != nil {
+		signatory := provenance.Signatory{Entity: signer}
+		signature, err := signatory.ClearSign(chartLoc)
+		if err != nil {
+			t.Fatalf("failed to sign chart: %v", err)
+		}
+		if err := os.WriteFile(chartLoc+".prov", []byte(signature), 0644); err != nil {
+			t.Fatalf("failed to write provenance file: %v", err)
+		}
+	}
+	return c
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	"k8c.io/kubermatic/v2/pkg/util/apply"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// detectDrift compares the desired objects with the live objects in the user cluster and returns the objects that
+// differ. Only the fields set in the desired objects are compared, so fields defaulted by the API server or owned by
+// other controllers are not reported as drift.
+func detectDrift(ctx context.Context, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation, objects []*unstructured.Unstructured) ([]util.DriftedObject, error) {
+	var drifted []util.DriftedObject
+
+	for _, desired := range objects {
+		if err := apply.DefaultNamespace(userClient.RESTMapper(), desired, applicationInstallation.Spec.Namespace.Name); err != nil {
+			if apply.IsNoMatchError(err) {
+				drifted = append(drifted, util.DriftedObject{Object: toInventoryObject(desired), Missing: true})
+				continue
+			}
+			return nil, err
+		}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		if err := userClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(desired), live); err != nil {
+			if apierrors.IsNotFound(err) {
+				drifted = append(drifted, util.DriftedObject{Object: toInventoryObject(desired), Missing: true})
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s %s: %w", desired.GetKind(), ctrlruntimeclient.ObjectKeyFromObject(desired), err)
+		}
+
+		if fields := diffFields(comparableContent(desired), live.Object, ""); len(fields) > 0 {
+			drifted = append(drifted, util.DriftedObject{Object: toInventoryObject(desired), Fields: fields})
+		}
+	}
+
+	return drifted, nil
+}
+
+// comparableContent returns the content of the desired object that is compared with the live object. Metadata
+// managed by the API server and the status are ignored. The stringData of Secrets is converted into data as the API
+// server does.
+func comparableContent(obj *unstructured.Unstructured) map[string]interface{} {
+	content := obj.DeepCopy().Object
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	delete(content, "status")
+
+	metadata := map[string]interface{}{}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = toInterfaceMap(labels)
+	}
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		metadata["annotations"] = toInterfaceMap(annotations)
+	}
+	content["metadata"] = metadata
+
+	if obj.GetKind() == "Secret" && obj.GroupVersionKind().Group == "" {
+		if stringData, ok := content["stringData"].(map[string]interface{}); ok {
+			data, _ := content["data"].(map[string]interface{})
+			if data == nil {
+				data = map[string]interface{}{}
+			}
+			for k, v := range stringData {
+				data[k] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(v)))
+			}
+			content["data"] = data
+			delete(content, "stringData")
+		}
+	}
+
+	return content
+}
+
+// diffFields returns the paths of the fields set in desired that have a different value in live.
+func diffFields(desired, live interface{}, path string) []string {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			if live == nil && len(desiredValue) == 0 {
+				return nil
+			}
+			return []string{path}
+		}
+
+		keys := make([]string, 0, len(desiredValue))
+		for k := range desiredValue {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var fields []string
+		for _, k := range keys {
+			if desiredValue[k] == nil {
+				continue
+			}
+			fields = append(fields, diffFields(desiredValue[k], liveValue[k], joinPath(path, k))...)
+		}
+		return fields
+
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			if live == nil && len(desiredValue) == 0 {
+				return nil
+			}
+			return []string{path}
+		}
+		if len(liveValue) != len(desiredValue) {
+			return []string{path}
+		}
+
+		var fields []string
+		for i := range desiredValue {
+			fields = append(fields, diffFields(desiredValue[i], liveValue[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return fields
+
+	default:
+		if !scalarEqual(desired, live) {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+// scalarEqual compares two scalar values. Numbers are compared by value and resource quantities are compared
+// semantically (e.g. "1000m" and 1 are equal) as the API server normalizes them.
+func scalarEqual(a, b interface{}) bool {
+	if a == b {
+		return true
+	}
+
+	aNumber, aIsNumber := toFloat(a)
+	bNumber, bIsNumber := toFloat(b)
+	if aIsNumber && bIsNumber {
+		return aNumber == bNumber
+	}
+
+	if !isQuantityCandidate(a) || !isQuantityCandidate(b) {
+		return false
+	}
+
+	aQuantity, err := resource.ParseQuantity(fmt.Sprint(a))
+	if err != nil {
+		return false
+	}
+	bQuantity, err := resource.ParseQuantity(fmt.Sprint(b))
+	if err != nil {
+		return false
+	}
+	return aQuantity.Cmp(bQuantity) == 0
+}
+
+func isQuantityCandidate(v interface{}) bool {
+	if _, ok := v.(string); ok {
+		return true
+	}
+	_, ok := toFloat(v)
+	return ok
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const driftManifests = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+  labels:
+    app: example
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+stringData:
+  password: s3cr3t
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:1.0.0
+        resources:
+          requests:
+            cpu: 500m
+            memory: 1Gi
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: missing
+`
+
+func TestDetectDrift(t *testing.T) {
+	userClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns", Name: "unchanged", Labels: map[string]string{"app": "example", "extra": "label"}},
+				Data:       map[string]string{"key": "value"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns", Name: "changed"},
+				Data:       map[string]string{"key": "edited"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns", Name: "secret"},
+				Data:       map[string][]byte{"password": []byte("s3cr3t")},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns", Name: "deploy"},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: pointer.Int32(3),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:            "app",
+								Image:           "app:1.0.0",
+								ImagePullPolicy: corev1.PullIfNotPresent,
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("0.5"),
+										corev1.ResourceMemory: resource.MustParse("1024Mi"),
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		).
+		Build()
+
+	objects, err := decodeManifests([]byte(driftManifests))
+	if err != nil {
+		t.Fatalf("failed to decode manifests: %v", err)
+	}
+
+	app := &appskubermaticv1.ApplicationInstallation{
+		Spec: appskubermaticv1.ApplicationInstallationSpec{
+			Namespace: appskubermaticv1.NamespaceSpec{Name: "app-ns"},
+		},
+	}
+
+	drifted, err := detectDrift(context.Background(), userClient, app, objects)
+	if err != nil {
+		t.Fatalf("failed to detect drift: %v", err)
+	}
+
+	expected := []util.DriftedObject{
+		{
+			Object: appskubermaticv1.InventoryObject{Version: "v1", Kind: "ConfigMap", Namespace: "app-ns", Name: "changed"},
+			Fields: []string{"data.key"},
+		},
+		{
+			Object: appskubermaticv1.InventoryObject{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "app-ns", Name: "deploy"},
+			Fields: []string{"spec.replicas"},
+		},
+		{
+			Object:  appskubermaticv1.InventoryObject{Version: "v1", Kind: "ServiceAccount", Namespace: "app-ns", Name: "missing"},
+			Missing: true,
+		},
+	}
+	if diff := deep.Equal(drifted, expected); diff != nil {
+		t.Errorf("unexpected drift: %v", diff)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		desired  map[string]interface{}
+		live     map[string]interface{}
+		expected []string
+	}{
+		{
+			name:    "fields defaulted by the API server are ignored",
+			desired: map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": int64(80)}}}},
+			live:    map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": float64(80), "protocol": "TCP"}}, "type": "ClusterIP"}},
+		},
+		{
+			name:     "removed list item",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"args": []interface{}{"--a", "--b"}}},
+			live:     map[string]interface{}{"spec": map[string]interface{}{"args": []interface{}{"--a"}}},
+			expected: []string{"spec.args"},
+		},
+		{
+			name:     "changed list item",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"args": []interface{}{"--a", "--b"}}},
+			live:     map[string]interface{}{"spec": map[string]interface{}{"args": []interface{}{"--a", "--c"}}},
+			expected: []string{"spec.args[1]"},
+		},
+		{
+			name:    "null and empty values are ignored",
+			desired: map[string]interface{}{"spec": map[string]interface{}{"selector": nil, "template": map[string]interface{}{}}},
+			live:    map[string]interface{}{"spec": map[string]interface{}{}},
+		},
+		{
+			name:     "type mismatch",
+			desired:  map[string]interface{}{"data": map[string]interface{}{"enabled": true}},
+			live:     map[string]interface{}{"data": map[string]interface{}{"enabled": "true"}},
+			expected: []string{"data.enabled"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := deep.Equal(diffFields(tc.desired, tc.live, ""), tc.expected); diff != nil {
+				t.Errorf("unexpected fields: %v", diff)
+			}
+		})
+	}
+}
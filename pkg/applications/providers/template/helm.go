@@ -23,18 +23,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strconv"
 
 	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/release"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/applications/helmclient"
 	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	"k8c.io/kubermatic/v2/pkg/applications/valuesschema"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxHelmReleaseHistory is the number of revisions of the release kept in the status of the ApplicationInstallation.
+const maxHelmReleaseHistory = 5
+
 // HelmTemplate install upgrade or uninstall helm chart into cluster.
 type HelmTemplate struct {
 	Ctx context.Context
@@ -53,6 +59,9 @@ type HelmTemplate struct {
 
 	// SeedClient to seed cluster.
 	SeedClient ctrlruntimeclient.Client
+
+	// UserClient to user cluster.
+	UserClient ctrlruntimeclient.Client
 }
 
 // InstallOrUpgrade the chart located at chartLoc with parameters (releaseName, values) defined applicationInstallation into cluster.
@@ -84,6 +93,20 @@ func (h HelmTemplate) InstallOrUpgrade(chartLoc string, applicationInstallation
 		return util.NoStatusUpdate, err
 	}
 
+	releaseName := getReleaseName(applicationInstallation)
+	deployOpts := getDeployOpts(applicationInstallation)
+
+	// an explicit rollback has been requested.
+	if revision, requested := applicationInstallation.Annotations[appskubermaticv1.ApplicationInstallationRollbackAnnotation]; requested {
+		return h.rollback(helmClient, releaseName, revision, deployOpts, applicationInstallation)
+	}
+
+	// the rolled back revision is kept as long as the spec has not changed.
+	if applicationInstallation.Status.RolledBackGeneration != 0 && applicationInstallation.Status.RolledBackGeneration == applicationInstallation.Generation {
+		h.Log.Debugw("release has been rolled back, skipping upgrade until spec changes", "release", releaseName)
+		return util.NoStatusUpdate, nil
+	}
+
 	values := make(map[string]interface{})
 	if len(applicationInstallation.Spec.Values.Raw) > 0 {
 		if err := json.Unmarshal(applicationInstallation.Spec.Values.Raw, &values); err != nil {
@@ -91,28 +114,125 @@ func (h HelmTemplate) InstallOrUpgrade(chartLoc string, applicationInstallation
 		}
 	}
 
-	helmRelease, err := helmClient.InstallOrUpgrade(chartLoc, getReleaseName(applicationInstallation), values, auth)
-	statusUpdater := util.NoStatusUpdate
+	// Helm only validates the values against the values.schema.json shipped with the chart, it never applies the
+	// defaults of a schema. So the defaults of the version's schema are merged into the values here.
+	if appVersion := applicationInstallation.Status.ApplicationVersion; appVersion != nil && appVersion.ValuesSchema != nil && appVersion.ValuesSchema.Inline != nil {
+		if values, err = valuesschema.ApplyDefaults(appVersion.ValuesSchema.Inline.Raw, values); err != nil {
+			return util.NoStatusUpdate, fmt.Errorf("failed to apply defaults of values schema: %w", err)
+		}
+	}
+
+	helmRelease, err := helmClient.InstallOrUpgrade(chartLoc, releaseName, values, deployOpts, auth)
+
+	// Atomic upgrades are already rolled back by Helm.
+	helmOpts := helmDeployOptions(applicationInstallation)
+	if err != nil && helmRelease != nil && helmRelease.Version > 1 && helmOpts.AutoRollback && !helmOpts.Atomic {
+		rolledBackRelease, rollbackErr := rollbackToLastDeployedRevision(helmClient, releaseName, helmRelease.Version, deployOpts)
+		if rollbackErr != nil {
+			err = fmt.Errorf("%w (automatic rollback failed: %v)", err, rollbackErr)
+		} else {
+			h.Log.Infow("upgrade failed, release has been rolled back", "release", releaseName, "revision", rolledBackRelease.Version)
+			err = fmt.Errorf("upgrade failed, release has been rolled back: %w", err)
+			helmRelease = rolledBackRelease
+		}
+	}
 
 	// In some case, even if an error occurred, the helmRelease is updated.
-	if helmRelease != nil {
-		statusUpdater = func(status *appskubermaticv1.ApplicationInstallationStatus) {
-			status.HelmRelease = &appskubermaticv1.HelmRelease{
-				Name:    helmRelease.Name,
-				Version: helmRelease.Version,
-				Info: &appskubermaticv1.HelmReleaseInfo{
-					FirstDeployed: metav1.Time(helmRelease.Info.FirstDeployed),
-					LastDeployed:  metav1.Time(helmRelease.Info.LastDeployed),
-					Deleted:       metav1.Time(helmRelease.Info.Deleted),
-					Description:   helmRelease.Info.Description,
-					Status:        helmRelease.Info.Status,
-					Notes:         helmRelease.Info.Notes,
-				},
-			}
+	if helmRelease == nil {
+		return util.NoStatusUpdate, err
+	}
+
+	return h.releaseStatusUpdater(helmClient, releaseName, helmRelease, 0), err
+}
+
+// rollback the release to the revision requested with the rollback annotation.
+func (h HelmTemplate) rollback(helmClient *helmclient.HelmClient, releaseName string, revision string, deployOpts helmclient.DeployOpts, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	version, err := strconv.Atoi(revision)
+	if err != nil || version <= 0 {
+		return util.NoStatusUpdate, fmt.Errorf("invalid revision %q in annotation %s: must be a positive integer", revision, appskubermaticv1.ApplicationInstallationRollbackAnnotation)
+	}
+
+	h.Log.Infow("rolling back release", "release", releaseName, "revision", version)
+	helmRelease, err := helmClient.Rollback(releaseName, version, deployOpts)
+	if err != nil {
+		return util.NoStatusUpdate, fmt.Errorf("failed to rollback release to revision %d: %w", version, err)
+	}
+
+	return h.releaseStatusUpdater(helmClient, releaseName, helmRelease, applicationInstallation.Generation), nil
+}
+
+// releaseStatusUpdater returns a StatusUpdater that sets the release, its history and the generation for which the release has been rolled back.
+func (h HelmTemplate) releaseStatusUpdater(helmClient *helmclient.HelmClient, releaseName string, helmRelease *release.Release, rolledBackGeneration int64) util.StatusUpdater {
+	var history []appskubermaticv1.HelmRelease
+	releases, err := helmClient.History(releaseName)
+	if err != nil {
+		// history is informative only, so we don't fail.
+		h.Log.Warnw("failed to get release history", "release", releaseName, zap.Error(err))
+	}
+	for i := 0; i < len(releases) && i < maxHelmReleaseHistory; i++ {
+		history = append(history, toHelmRelease(releases[i]))
+	}
+
+	return func(status *appskubermaticv1.ApplicationInstallationStatus) {
+		rel := toHelmRelease(helmRelease)
+		status.HelmRelease = &rel
+		status.HelmReleaseHistory = history
+		status.RolledBackGeneration = rolledBackGeneration
+	}
+}
+
+// rollbackToLastDeployedRevision rolls back the release to the last revision before failedRevision that was successfully deployed.
+func rollbackToLastDeployedRevision(helmClient *helmclient.HelmClient, releaseName string, failedRevision int, deployOpts helmclient.DeployOpts) (*release.Release, error) {
+	releases, err := helmClient.History(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release history: %w", err)
+	}
+
+	for _, rel := range releases {
+		if rel.Version < failedRevision && (rel.Info.Status == release.StatusDeployed || rel.Info.Status == release.StatusSuperseded) {
+			return helmClient.Rollback(releaseName, rel.Version, deployOpts)
 		}
 	}
 
-	return statusUpdater, err
+	return nil, fmt.Errorf("no successfully deployed revision found")
+}
+
+// DetectDrift compares the manifest of the deployed release with the live objects of the user cluster. The chart is
+// not rendered again, so chartLoc is ignored.
+func (h HelmTemplate) DetectDrift(chartLoc string, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedObject, error) {
+	helmCacheDir, err := util.CreateHelmTempDir(h.CacheDir)
+	if err != nil {
+		return nil, err
+	}
+	defer util.CleanUpHelmTempDir(helmCacheDir, h.Log)
+
+	restClientGetter := &genericclioptions.ConfigFlags{
+		KubeConfig: &h.Kubeconfig,
+		Namespace:  &h.ApplicationInstallation.Spec.Namespace.Name,
+	}
+
+	helmClient, err := helmclient.NewClient(
+		h.Ctx,
+		restClientGetter,
+		helmclient.NewSettings(helmCacheDir),
+		h.ApplicationInstallation.Spec.Namespace.Name,
+		h.Log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	helmRelease, err := helmClient.Get(getReleaseName(applicationInstallation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	objects, err := decodeManifests([]byte(helmRelease.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode release manifest: %w", err)
+	}
+
+	return detectDrift(h.Ctx, h.UserClient, applicationInstallation, objects)
 }
 
 // Uninstall the chart from the user cluster.
@@ -144,24 +264,50 @@ func (h HelmTemplate) Uninstall(applicationInstallation *appskubermaticv1.Applic
 
 	if uninstallReleaseResponse != nil {
 		statusUpdater = func(status *appskubermaticv1.ApplicationInstallationStatus) {
-			status.HelmRelease = &appskubermaticv1.HelmRelease{
-				Name:    uninstallReleaseResponse.Release.Name,
-				Version: uninstallReleaseResponse.Release.Version,
-				Info: &appskubermaticv1.HelmReleaseInfo{
-					FirstDeployed: metav1.Time(uninstallReleaseResponse.Release.Info.FirstDeployed),
-					LastDeployed:  metav1.Time(uninstallReleaseResponse.Release.Info.LastDeployed),
-					Deleted:       metav1.Time(uninstallReleaseResponse.Release.Info.Deleted),
-					Description:   uninstallReleaseResponse.Release.Info.Description,
-					Status:        uninstallReleaseResponse.Release.Info.Status,
-					Notes:         uninstallReleaseResponse.Release.Info.Notes,
-				},
-			}
+			rel := toHelmRelease(uninstallReleaseResponse.Release)
+			status.HelmRelease = &rel
+			status.HelmReleaseHistory = nil
 		}
 	}
 
 	return statusUpdater, err
 }
 
+// toHelmRelease converts a Helm release into its API representation.
+func toHelmRelease(rel *release.Release) appskubermaticv1.HelmRelease {
+	return appskubermaticv1.HelmRelease{
+		Name:    rel.Name,
+		Version: rel.Version,
+		Info: &appskubermaticv1.HelmReleaseInfo{
+			FirstDeployed: metav1.Time(rel.Info.FirstDeployed),
+			LastDeployed:  metav1.Time(rel.Info.LastDeployed),
+			Deleted:       metav1.Time(rel.Info.Deleted),
+			Description:   rel.Info.Description,
+			Status:        rel.Info.Status,
+			Notes:         rel.Info.Notes,
+		},
+	}
+}
+
+// helmDeployOptions returns the Helm deploy options of the applicationInstallation or empty options if not set.
+func helmDeployOptions(applicationInstallation *appskubermaticv1.ApplicationInstallation) appskubermaticv1.HelmDeployOptions {
+	if applicationInstallation.Spec.DeployOptions == nil || applicationInstallation.Spec.DeployOptions.Helm == nil {
+		return appskubermaticv1.HelmDeployOptions{}
+	}
+	return *applicationInstallation.Spec.DeployOptions.Helm
+}
+
+// getDeployOpts builds the helmclient.DeployOpts from the deploy options of the applicationInstallation.
+func getDeployOpts(applicationInstallation *appskubermaticv1.ApplicationInstallation) helmclient.DeployOpts {
+	helmOpts := helmDeployOptions(applicationInstallation)
+	return helmclient.DeployOpts{
+		Wait:       helmOpts.Wait,
+		Timeout:    helmOpts.Timeout.Duration,
+		Atomic:     helmOpts.Atomic,
+		MaxHistory: helmOpts.MaxHistory,
+	}
+}
+
 // getReleaseName computes the release name from the applicationInstallation.
 // The releaseName length must be less or equal to 53. So we first start to compute this release Name:
 // 		releaseName := applicationInstallation.Namespace + "-" + applicationInstallation.Name
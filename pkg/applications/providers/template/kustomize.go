@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// KustomizeTemplate builds the kustomization located in the source directory and applies the result into the user
+// cluster using server-side apply. Objects that are not part of the kustomization anymore are pruned.
+type KustomizeTemplate struct {
+	Ctx context.Context
+
+	Log                     *zap.SugaredLogger
+	ApplicationInstallation *appskubermaticv1.ApplicationInstallation
+
+	// UserClient to user cluster.
+	UserClient ctrlruntimeclient.Client
+}
+
+// InstallOrUpgrade builds the kustomization located at source, applies it into the user cluster and prunes the objects that have been removed.
+func (k KustomizeTemplate) InstallOrUpgrade(source string, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	objects, err := buildKustomization(source)
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	return applyManifests(k.Ctx, k.Log, k.UserClient, applicationInstallation, objects)
+}
+
+// DetectDrift compares the kustomization located at source with the live objects of the user cluster.
+func (k KustomizeTemplate) DetectDrift(source string, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedObject, error) {
+	objects, err := buildKustomization(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return detectDrift(k.Ctx, k.UserClient, applicationInstallation, objects)
+}
+
+// Uninstall deletes all objects of the inventory from the user cluster.
+func (k KustomizeTemplate) Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	return deleteInventory(k.Ctx, k.Log, k.UserClient, applicationInstallation)
+}
+
+// buildKustomization runs kustomize on dir and returns the rendered objects.
+func buildKustomization(dir string) ([]*unstructured.Unstructured, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	content, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render kustomization: %w", err)
+	}
+
+	return decodeManifests(content)
+}
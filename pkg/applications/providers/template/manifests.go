@@ -0,0 +1,245 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	"k8c.io/kubermatic/v2/pkg/util/apply"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldManager is the field manager used to server-side apply the manifests of 'kustomize' and 'manifests' applications.
+const FieldManager = "kubermatic-application-installer"
+
+// ManifestsTemplate applies the plain YAML manifests found in the source directory into the user cluster using
+// server-side apply. Objects that are not part of the manifests anymore are pruned.
+type ManifestsTemplate struct {
+	Ctx context.Context
+
+	Log                     *zap.SugaredLogger
+	ApplicationInstallation *appskubermaticv1.ApplicationInstallation
+
+	// UserClient to user cluster.
+	UserClient ctrlruntimeclient.Client
+}
+
+// InstallOrUpgrade applies the manifests located at source into the user cluster and prunes the objects that have been removed.
+func (m ManifestsTemplate) InstallOrUpgrade(source string, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	objects, err := loadManifests(source)
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	return applyManifests(m.Ctx, m.Log, m.UserClient, applicationInstallation, objects)
+}
+
+// DetectDrift compares the manifests located at source with the live objects of the user cluster.
+func (m ManifestsTemplate) DetectDrift(source string, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedObject, error) {
+	objects, err := loadManifests(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return detectDrift(m.Ctx, m.UserClient, applicationInstallation, objects)
+}
+
+// Uninstall deletes all objects of the inventory from the user cluster.
+func (m ManifestsTemplate) Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	return deleteInventory(m.Ctx, m.Log, m.UserClient, applicationInstallation)
+}
+
+// loadManifests reads all YAML and JSON files in dir (recursively, in lexical order) and returns the objects they contain.
+// Hidden files and directories (e.g. .git) are ignored.
+func loadManifests(dir string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		fileObjects, err := decodeManifests(content)
+		if err != nil {
+			return fmt.Errorf("failed to decode manifest %s: %w", strings.TrimPrefix(path, dir), err)
+		}
+		objects = append(objects, fileObjects...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// decodeManifests decodes a (multi-document) YAML or JSON stream into objects. Empty documents are skipped and Lists
+// are flattened.
+func decodeManifests(content []byte) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("object %q has no apiVersion or kind", obj.GetName())
+		}
+
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// applyManifests server-side applies the objects into the user cluster and prunes the objects of the previous
+// inventory that are not part of objects anymore. The returned StatusUpdater records the new inventory.
+func applyManifests(ctx context.Context, log *zap.SugaredLogger, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation, objects []*unstructured.Unstructured) (util.StatusUpdater, error) {
+	apply.SortByInstallOrder(objects)
+
+	var inventory []apply.ObjectKey
+	if applicationInstallation.Status.Inventory != nil {
+		inventory = toObjectKeys(applicationInstallation.Status.Inventory.Objects)
+	}
+
+	results, err := apply.Apply(ctx, log, userClient, objects, inventory, apply.Options{
+		FieldManager:     FieldManager,
+		DefaultNamespace: applicationInstallation.Spec.Namespace.Name,
+	})
+
+	return setInventory(toInventoryObjects(apply.Inventory(results))), err
+}
+
+// deleteInventory deletes all objects of the inventory from the user cluster.
+func deleteInventory(ctx context.Context, log *zap.SugaredLogger, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	if applicationInstallation.Status.Inventory == nil {
+		return util.NoStatusUpdate, nil
+	}
+
+	remaining, err := apply.Delete(ctx, log, userClient, toObjectKeys(applicationInstallation.Status.Inventory.Objects))
+
+	return setInventory(toInventoryObjects(remaining)), err
+}
+
+// setInventory returns a StatusUpdater that sets the inventory to the objects.
+func setInventory(objects []appskubermaticv1.InventoryObject) util.StatusUpdater {
+	return func(status *appskubermaticv1.ApplicationInstallationStatus) {
+		if len(objects) == 0 {
+			status.Inventory = nil
+			return
+		}
+		status.Inventory = &appskubermaticv1.ApplicationInventory{Objects: objects}
+	}
+}
+
+func toInventoryObject(obj *unstructured.Unstructured) appskubermaticv1.InventoryObject {
+	gvk := obj.GroupVersionKind()
+	return appskubermaticv1.InventoryObject{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+func toInventoryObjects(keys []apply.ObjectKey) []appskubermaticv1.InventoryObject {
+	var objects []appskubermaticv1.InventoryObject
+	for _, key := range keys {
+		objects = append(objects, appskubermaticv1.InventoryObject{
+			Group:     key.Group,
+			Version:   key.Version,
+			Kind:      key.Kind,
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		})
+	}
+	return objects
+}
+
+func toObjectKeys(objects []appskubermaticv1.InventoryObject) []apply.ObjectKey {
+	var keys []apply.ObjectKey
+	for _, ref := range objects {
+		keys = append(keys, apply.ObjectKey{
+			Group:     ref.Group,
+			Version:   ref.Version,
+			Kind:      ref.Kind,
+			Namespace: ref.Namespace,
+			Name:      ref.Name,
+		})
+	}
+	return keys
+}
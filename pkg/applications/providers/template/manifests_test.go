@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/util/apply"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoadManifests(t *testing.T) {
+	objects, err := loadManifests("testdata/manifests")
+	if err != nil {
+		t.Fatalf("failed to load manifests: %v", err)
+	}
+
+	expected := []appskubermaticv1.InventoryObject{
+		{Version: "v1", Kind: "ConfigMap", Name: "testcm"},
+		{Version: "v1", Kind: "Namespace", Name: "testns"},
+		{Version: "v1", Kind: "ServiceAccount", Name: "sa1"},
+		{Version: "v1", Kind: "ServiceAccount", Name: "sa2"},
+	}
+	if diff := deep.Equal(objectRefs(objects), expected); diff != nil {
+		t.Errorf("unexpected objects loaded: %v", diff)
+	}
+
+	apply.SortByInstallOrder(objects)
+
+	expected = []appskubermaticv1.InventoryObject{
+		{Version: "v1", Kind: "Namespace", Name: "testns"},
+		{Version: "v1", Kind: "ServiceAccount", Name: "sa1"},
+		{Version: "v1", Kind: "ServiceAccount", Name: "sa2"},
+		{Version: "v1", Kind: "ConfigMap", Name: "testcm"},
+	}
+	if diff := deep.Equal(objectRefs(objects), expected); diff != nil {
+		t.Errorf("objects are not sorted by install order: %v", diff)
+	}
+}
+
+func TestBuildKustomization(t *testing.T) {
+	objects, err := buildKustomization("testdata/kustomize")
+	if err != nil {
+		t.Fatalf("failed to build kustomization: %v", err)
+	}
+
+	if len(objects) != 1 {
+		t.Fatalf("expected exactly one object, got %d", len(objects))
+	}
+
+	if name := objects[0].GetName(); name != "app-testcm" {
+		t.Errorf("expected name prefix to be applied, got name %q", name)
+	}
+	if label := objects[0].GetLabels()["app"]; label != "example" {
+		t.Errorf("expected common label to be applied, got label %q", label)
+	}
+}
+
+func TestApplyManifestsKeepsFailedObjects(t *testing.T) {
+	// CronJobs are not served, so applying the migrated object fails.
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	userClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRESTMapper(mapper).
+		WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "old"}}).
+		Build()
+
+	app := &appskubermaticv1.ApplicationInstallation{
+		Spec: appskubermaticv1.ApplicationInstallationSpec{
+			Namespace: appskubermaticv1.NamespaceSpec{Name: "default"},
+		},
+		Status: appskubermaticv1.ApplicationInstallationStatus{
+			Inventory: &appskubermaticv1.ApplicationInventory{
+				Objects: []appskubermaticv1.InventoryObject{
+					{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "old"},
+					{Group: "batch", Version: "v1beta1", Kind: "CronJob", Namespace: "default", Name: "migrated"},
+				},
+			},
+		},
+	}
+
+	migrated := &unstructured.Unstructured{}
+	migrated.SetAPIVersion("batch/v1")
+	migrated.SetKind("CronJob")
+	migrated.SetNamespace("default")
+	migrated.SetName("migrated")
+
+	statusUpdater, err := applyManifests(context.Background(), kubermaticlog.Logger, userClient, app, []*unstructured.Unstructured{migrated})
+	if err == nil {
+		t.Fatal("expected applying the CronJob to fail")
+	}
+
+	statusUpdater(&app.Status)
+	expected := []appskubermaticv1.InventoryObject{
+		{Group: "batch", Version: "v1", Kind: "CronJob", Namespace: "default", Name: "migrated"},
+	}
+	if diff := deep.Equal(app.Status.Inventory.Objects, expected); diff != nil {
+		t.Errorf("unexpected inventory: %v", diff)
+	}
+
+	if err := userClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Namespace: "default", Name: "old"}, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected removed configmap to be pruned, got %v", err)
+	}
+}
+
+func TestDeleteInventory(t *testing.T) {
+	userClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "testcm"}}).
+		Build()
+
+	app := &appskubermaticv1.ApplicationInstallation{
+		Status: appskubermaticv1.ApplicationInstallationStatus{
+			Inventory: &appskubermaticv1.ApplicationInventory{
+				Objects: []appskubermaticv1.InventoryObject{
+					{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "testcm"},
+					{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "already-deleted"},
+				},
+			},
+		},
+	}
+
+	statusUpdater, err := deleteInventory(context.Background(), kubermaticlog.Logger, userClient, app)
+	if err != nil {
+		t.Fatalf("failed to delete inventory: %v", err)
+	}
+
+	statusUpdater(&app.Status)
+	if app.Status.Inventory != nil {
+		t.Errorf("expected inventory to be empty, got %v", app.Status.Inventory.Objects)
+	}
+
+	cms := &corev1.ConfigMapList{}
+	if err := userClient.List(context.Background(), cms); err != nil {
+		t.Fatalf("failed to list configmaps: %v", err)
+	}
+	if len(cms.Items) != 0 {
+		t.Errorf("expected configmap to be deleted")
+	}
+}
+
+func objectRefs(objects []*unstructured.Unstructured) []appskubermaticv1.InventoryObject {
+	var refs []appskubermaticv1.InventoryObject
+	for _, obj := range objects {
+		refs = append(refs, toInventoryObject(obj))
+	}
+	return refs
+}
@@ -40,12 +40,23 @@ type SourceProvider interface {
 }
 
 // NewSourceProvider returns the concrete implementation of SourceProvider according to source defined in appSource.
-func NewSourceProvider(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, kubeconfig string, cacheDir string, appSource *appskubermaticv1.ApplicationSource, secretNamespace string) (SourceProvider, error) {
+// If verification is not nil, the provider verifies the source and sources that can not be verified are refused.
+func NewSourceProvider(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, kubeconfig string, cacheDir string, appSource *appskubermaticv1.ApplicationSource, secretNamespace string, verification *appskubermaticv1.SourceVerification) (SourceProvider, error) {
 	switch {
 	case appSource.Helm != nil:
-		return source.HelmSource{Ctx: ctx, SeedClient: client, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, Source: appSource.Helm, SecretNamespace: secretNamespace}, nil
+		return source.HelmSource{Ctx: ctx, SeedClient: client, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, Source: appSource.Helm, SecretNamespace: secretNamespace, Verification: verification}, nil
 	case appSource.Git != nil:
+		if verification != nil {
+			return nil, fmt.Errorf("%w: git sources can not be verified", util.ErrUnverifiedSource)
+		}
 		return source.GitSource{Ctx: ctx, SeedClient: client, Source: appSource.Git, SecretNamespace: secretNamespace}, nil
+	case appSource.OCI != nil:
+		return source.OCISource{Ctx: ctx, SeedClient: client, Source: appSource.OCI, SecretNamespace: secretNamespace, Verification: verification}, nil
+	case appSource.Tarball != nil:
+		if verification != nil {
+			return nil, fmt.Errorf("%w: tarball sources can not be verified", util.ErrUnverifiedSource)
+		}
+		return source.TarballSource{Ctx: ctx, SeedClient: client, Source: appSource.Tarball, SecretNamespace: secretNamespace}, nil
 	default: // This should not happen. The admission webhook prevents that.
 		return nil, errors.New("no source found")
 	}
@@ -57,15 +68,22 @@ type TemplateProvider interface {
 	// InstallOrUpgrade the application from the source.
 	InstallOrUpgrade(source string, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error)
 
+	// DetectDrift compares the desired state of the application with the live objects and returns the objects that differ.
+	DetectDrift(source string, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]util.DriftedObject, error)
+
 	// Uninstall the application.
 	Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error)
 }
 
 // NewTemplateProvider return the concrete implementation of TemplateProvider according to the templateMethod.
-func NewTemplateProvider(ctx context.Context, seedClient ctrlruntimeclient.Client, kubeconfig string, cacheDir string, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation, secretNamespace string) (TemplateProvider, error) {
+func NewTemplateProvider(ctx context.Context, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, kubeconfig string, cacheDir string, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation, secretNamespace string) (TemplateProvider, error) {
 	switch appInstallation.Status.Method {
 	case appskubermaticv1.HelmTemplateMethod:
-		return template.HelmTemplate{Ctx: ctx, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, ApplicationInstallation: appInstallation, SecretNamespace: secretNamespace, SeedClient: seedClient}, nil
+		return template.HelmTemplate{Ctx: ctx, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, ApplicationInstallation: appInstallation, SecretNamespace: secretNamespace, SeedClient: seedClient, UserClient: userClient}, nil
+	case appskubermaticv1.KustomizeTemplateMethod:
+		return template.KustomizeTemplate{Ctx: ctx, Log: log, ApplicationInstallation: appInstallation, UserClient: userClient}, nil
+	case appskubermaticv1.ManifestsTemplateMethod:
+		return template.ManifestsTemplate{Ctx: ctx, Log: log, ApplicationInstallation: appInstallation, UserClient: userClient}, nil
 	default:
 		return nil, fmt.Errorf("template method '%v' not implemented", appInstallation.Status.Method)
 	}
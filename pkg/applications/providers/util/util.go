@@ -18,8 +18,10 @@ package util
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -31,6 +33,9 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ErrUnverifiedSource is returned (wrapped) when the source of an application can not be verified.
+var ErrUnverifiedSource = errors.New("source verification failed")
+
 // CreateHelmTempDir creates a temporary directory inside cacheDir where helm caches will be download.
 func CreateHelmTempDir(cacheDir string) (string, error) {
 	// This will generate a directory like cacheDir-helm-<rand_number> (e.g. /cache/helm-/3012513704)
@@ -58,6 +63,29 @@ var NoStatusUpdate StatusUpdater = func(status *appskubermaticv1.ApplicationInst
 	// NO OP
 }
 
+// DriftedObject is an object of an application whose live state in the user cluster differs from the desired state.
+type DriftedObject struct {
+	Object appskubermaticv1.InventoryObject
+
+	// Missing is true if the object does not exist in the user cluster.
+	Missing bool
+
+	// Fields holds the paths of the fields that differ from the desired state.
+	Fields []string
+}
+
+func (d DriftedObject) String() string {
+	name := d.Object.Name
+	if d.Object.Namespace != "" {
+		name = d.Object.Namespace + "/" + name
+	}
+
+	if d.Missing {
+		return fmt.Sprintf("%s %s (missing)", d.Object.Kind, name)
+	}
+	return fmt.Sprintf("%s %s (%s)", d.Object.Kind, name, strings.Join(d.Fields, ", "))
+}
+
 // GetCredentialFromSecret get the secret and returns secret.Data[key].
 func GetCredentialFromSecret(ctx context.Context, client ctrlruntimeclient.Client, namespce string, name string, key string) (string, error) {
 	secret := &corev1.Secret{}
@@ -73,9 +101,11 @@ func GetCredentialFromSecret(ctx context.Context, client ctrlruntimeclient.Clien
 }
 
 // HelmAuthFromCredentials builds helmclient.AuthSettings from source.Credentials. registryConfigFilePath is the path of the file that stores credentials for OCI registry.
+// If source is nil, empty AuthSettings are returned.
 func HelmAuthFromCredentials(ctx context.Context, client ctrlruntimeclient.Client, registryConfigFilePath string, secretNamespace string, source *appskubermaticv1.HelmSource) (helmclient.AuthSettings, error) {
 	auth := helmclient.AuthSettings{}
-	if source.Credentials != nil {
+	// charts can also be retrieved from other sources than Helm repositories (e.g. Git or OCI artifacts).
+	if source != nil && source.Credentials != nil {
 		if source.Credentials.Username != nil {
 			username, err := GetCredentialFromSecret(ctx, client, secretNamespace, source.Credentials.Username.Name, source.Credentials.Username.Key)
 			if err != nil {
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package valuesschema validates the values of ApplicationInstallations against the JSON schema declared by the
+// ApplicationDefinition and computes the default values declared in the schema.
+package valuesschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ChartSource returns the identifier of the Helm chart the schema of a version is resolved from. It is empty if the
+// version is not sourced from a Helm repository.
+func ChartSource(version appskubermaticv1.ApplicationVersion) string {
+	helm := version.Template.Source.Helm
+	if helm == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(helm.URL, "/"), helm.ChartName, helm.ChartVersion)
+}
+
+// ForVersion returns the values schema of the given version of the application. It returns nil if the version does
+// not define a schema or if the schema has not been resolved from the chart yet.
+func ForVersion(appDef *appskubermaticv1.ApplicationDefinition, version string) []byte {
+	for _, v := range appDef.Spec.Versions {
+		if v.Version != version {
+			continue
+		}
+		if v.ValuesSchema == nil {
+			return nil
+		}
+		if v.ValuesSchema.Inline != nil {
+			return v.ValuesSchema.Inline.Raw
+		}
+		if v.ValuesSchema.FromChart {
+			for _, resolved := range appDef.Status.ValuesSchemas {
+				if resolved.Version == version && resolved.Source == ChartSource(v) && resolved.Schema != nil {
+					return resolved.Schema.Raw
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// Compile ensures the schema is a valid JSON schema.
+func Compile(schema []byte) error {
+	_, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	return err
+}
+
+// Validate validates values against the schema after the defaults declared in the schema have been applied.
+func Validate(schema []byte, values map[string]interface{}) error {
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	if err != nil {
+		return fmt.Errorf("invalid values schema: %w", err)
+	}
+
+	values, err = ApplyDefaults(schema, values)
+	if err != nil {
+		return err
+	}
+
+	result, err := compiled.Validate(gojsonschema.NewGoLoader(values))
+	if err != nil {
+		return fmt.Errorf("failed to validate values: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(result.Errors()))
+	for _, e := range result.Errors() {
+		msgs = append(msgs, e.String())
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// Defaults returns the values made of the defaults declared in the schema.
+func Defaults(schema []byte) (map[string]interface{}, error) {
+	return ApplyDefaults(schema, map[string]interface{}{})
+}
+
+// ApplyDefaults returns a copy of values where all properties that are not set are filled with the default declared
+// in the schema. Only the "properties" keyword is followed, references are not resolved.
+func ApplyDefaults(schema []byte, values map[string]interface{}) (map[string]interface{}, error) {
+	s := map[string]interface{}{}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse values schema: %w", err)
+	}
+
+	// work on a copy so that the values of the caller are not modified
+	copied := map[string]interface{}{}
+	if values != nil {
+		raw, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &copied); err != nil {
+			return nil, err
+		}
+	}
+
+	if result, ok := applyDefaults(s, copied).(map[string]interface{}); ok {
+		return result, nil
+	}
+	return copied, nil
+}
+
+// ValuesFromRawExtension decodes the values of an ApplicationInstallation.
+func ValuesFromRawExtension(raw runtime.RawExtension) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if len(raw.Raw) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(raw.Raw, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+	}
+	return values, nil
+}
+
+func applyDefaults(schema map[string]interface{}, value interface{}) interface{} {
+	if value == nil {
+		if def, ok := schema["default"]; ok {
+			value = def
+		}
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	obj, isObject := value.(map[string]interface{})
+	if value != nil && !isObject {
+		// let the validation report the type mismatch
+		return value
+	}
+
+	if obj == nil {
+		obj = map[string]interface{}{}
+	}
+	for name, propSchema := range properties {
+		ps, ok := propSchema.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v := applyDefaults(ps, obj[name]); v != nil {
+			obj[name] = v
+		}
+	}
+
+	// do not create empty objects for properties without any defaults
+	if value == nil && len(obj) == 0 {
+		return nil
+	}
+	return obj
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package valuesschema
+
+import (
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+)
+
+const testSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["replicaCount"],
+  "properties": {
+    "replicaCount": {"type": "integer", "minimum": 1, "default": 1},
+    "image": {
+      "type": "object",
+      "properties": {
+        "repository": {"type": "string"},
+        "pullPolicy": {"type": "string", "enum": ["Always", "IfNotPresent"], "default": "IfNotPresent"}
+      }
+    },
+    "ingress": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string"}
+      }
+    }
+  }
+}`
+
+func TestApplyDefaults(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:   "defaults are applied to empty values",
+			values: nil,
+			expected: map[string]interface{}{
+				"replicaCount": float64(1),
+				"image":        map[string]interface{}{"pullPolicy": "IfNotPresent"},
+			},
+		},
+		{
+			name: "values set by the user are kept",
+			values: map[string]interface{}{
+				"replicaCount": float64(3),
+				"image":        map[string]interface{}{"repository": "nginx", "pullPolicy": "Always"},
+			},
+			expected: map[string]interface{}{
+				"replicaCount": float64(3),
+				"image":        map[string]interface{}{"repository": "nginx", "pullPolicy": "Always"},
+			},
+		},
+		{
+			name:   "defaults are merged into nested objects",
+			values: map[string]interface{}{"image": map[string]interface{}{"repository": "nginx"}},
+			expected: map[string]interface{}{
+				"replicaCount": float64(1),
+				"image":        map[string]interface{}{"repository": "nginx", "pullPolicy": "IfNotPresent"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ApplyDefaults([]byte(testSchema), tc.values)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !diff.SemanticallyEqual(tc.expected, result) {
+				t.Fatalf("Result differs from expected:\n%v", diff.ObjectDiff(tc.expected, result))
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		values      map[string]interface{}
+		expectedErr bool
+	}{
+		{
+			name:        "empty values are valid thanks to defaults",
+			values:      map[string]interface{}{},
+			expectedErr: false,
+		},
+		{
+			name:        "wrong type is rejected",
+			values:      map[string]interface{}{"replicaCount": "three"},
+			expectedErr: true,
+		},
+		{
+			name:        "value not in enum is rejected",
+			values:      map[string]interface{}{"image": map[string]interface{}{"pullPolicy": "Never"}},
+			expectedErr: true,
+		},
+		{
+			name:        "minimum is enforced",
+			values:      map[string]interface{}{"replicaCount": 0},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate([]byte(testSchema), tc.values)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonconfigsynchronizer
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller syncs the kubermatic AddonConfigs on the master cluster to the seed clusters.
+	ControllerName = "kkp-addonconfig-synchronizer"
+
+	// cleanupFinalizer indicates that synced AddonConfigs on seed clusters need cleanup.
+	cleanupFinalizer = "kubermatic.k8c.io/cleanup-seed-addonconfig"
+)
+
+type reconciler struct {
+	log          *zap.SugaredLogger
+	masterClient ctrlruntimeclient.Client
+	seedClients  kuberneteshelper.SeedClientMap
+	recorder     record.EventRecorder
+}
+
+func Add(
+	masterMgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+) error {
+	log = log.Named(ControllerName)
+	r := &reconciler{
+		log:          log,
+		masterClient: masterMgr.GetClient(),
+		seedClients:  kuberneteshelper.SeedClientMap{},
+		recorder:     masterMgr.GetEventRecorderFor(ControllerName),
+	}
+
+	c, err := controller.New(ControllerName, masterMgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	for seedName, seedManager := range seedManagers {
+		r.seedClients[seedName] = seedManager.GetClient()
+	}
+
+	// Watch for changes to AddonConfig
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.AddonConfig{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch addonconfig: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("resource", request.Name)
+	log.Debug("Processing")
+
+	err := r.reconcile(ctx, log, request)
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, request reconcile.Request) error {
+	addonConfig := &kubermaticv1.AddonConfig{}
+	if err := r.masterClient.Get(ctx, request.NamespacedName, addonConfig); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	// handling deletion
+	if !addonConfig.DeletionTimestamp.IsZero() {
+		if err := r.handleDeletion(ctx, log, addonConfig); err != nil {
+			return fmt.Errorf("handling deletion of addonconfig: %w", err)
+		}
+		return nil
+	}
+
+	if err := kuberneteshelper.TryAddFinalizer(ctx, r.masterClient, addonConfig, cleanupFinalizer); err != nil {
+		return fmt.Errorf("failed to add finalizer: %w", err)
+	}
+
+	addonConfigCreatorGetters := []reconciling.NamedKubermaticV1AddonConfigCreatorGetter{
+		addonConfigCreatorGetter(addonConfig),
+	}
+
+	err := r.seedClients.Each(ctx, log, func(_ string, seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+		seedAddonConfig := &kubermaticv1.AddonConfig{}
+		if err := seedClient.Get(ctx, request.NamespacedName, seedAddonConfig); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to fetch addonconfig on seed cluster: %w", err)
+		}
+
+		// see project-synchronizer's syncAllSeeds comment
+		if seedAddonConfig.UID != "" && seedAddonConfig.UID == addonConfig.UID {
+			return nil
+		}
+
+		return reconciling.ReconcileKubermaticV1AddonConfigs(ctx, addonConfigCreatorGetters, "", seedClient)
+	})
+	if err != nil {
+		r.recorder.Event(addonConfig, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return fmt.Errorf("reconciled addonconfig: %s: %w", addonConfig.Name, err)
+	}
+	return nil
+}
+
+func (r *reconciler) handleDeletion(ctx context.Context, log *zap.SugaredLogger, addonConfig *kubermaticv1.AddonConfig) error {
+	if kuberneteshelper.HasFinalizer(addonConfig, cleanupFinalizer) {
+		if err := r.seedClients.Each(ctx, log, func(_ string, seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+			err := seedClient.Delete(ctx, &kubermaticv1.AddonConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: addonConfig.Name,
+				},
+			})
+
+			return ctrlruntimeclient.IgnoreNotFound(err)
+		}); err != nil {
+			return err
+		}
+
+		if err := kuberneteshelper.TryRemoveFinalizer(ctx, r.masterClient, addonConfig, cleanupFinalizer); err != nil {
+			return fmt.Errorf("failed to remove addonconfig finalizer %s: %w", addonConfig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func addonConfigCreatorGetter(addonConfig *kubermaticv1.AddonConfig) reconciling.NamedKubermaticV1AddonConfigCreatorGetter {
+	return func() (string, reconciling.KubermaticV1AddonConfigCreator) {
+		return addonConfig.Name, func(c *kubermaticv1.AddonConfig) (*kubermaticv1.AddonConfig, error) {
+			c.Name = addonConfig.Name
+			c.Spec = addonConfig.Spec
+			c.Labels = addonConfig.Labels
+			return c, nil
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationdefinitionschemacontroller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/chart/loader"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers"
+	"k8c.io/kubermatic/v2/pkg/applications/valuesschema"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kkp-application-definition-schema-controller"
+
+	// retryInterval is the interval after which schemas that could not be resolved are retried.
+	retryInterval = 5 * time.Minute
+)
+
+// schemaResolver returns the values schema shipped with the chart of the version. It returns nil if the chart does
+// not provide a schema.
+type schemaResolver func(ctx context.Context, log *zap.SugaredLogger, version appskubermaticv1.ApplicationVersion) ([]byte, error)
+
+type reconciler struct {
+	log           *zap.SugaredLogger
+	recorder      record.EventRecorder
+	masterClient  ctrlruntimeclient.Client
+	resolveSchema schemaResolver
+	namespace     string
+	cacheDir      string
+}
+
+func Add(
+	masterManager manager.Manager,
+	log *zap.SugaredLogger,
+	namespace string,
+) error {
+	r := &reconciler{
+		log:          log.Named(ControllerName),
+		recorder:     masterManager.GetEventRecorderFor(ControllerName),
+		masterClient: masterManager.GetClient(),
+		namespace:    namespace,
+		cacheDir:     os.TempDir(),
+	}
+	r.resolveSchema = r.resolveChartSchema
+
+	c, err := controller.New(ControllerName, masterManager, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	// Watch for changes to ApplicationDefinition
+	if err := c.Watch(&source.Kind{Type: &appskubermaticv1.ApplicationDefinition{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch for applicationDefinitions: %w", err)
+	}
+
+	return nil
+}
+
+// Reconcile resolves the values schemas of the versions that take their schema from the chart.
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("appdefinition", request.Name)
+	log.Debug("Processing")
+
+	applicationDef := &appskubermaticv1.ApplicationDefinition{}
+	if err := r.masterClient.Get(ctx, request.NamespacedName, applicationDef); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !applicationDef.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, applicationDef)
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+		r.recorder.Event(applicationDef, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, applicationDef *appskubermaticv1.ApplicationDefinition) (reconcile.Result, error) {
+	current := map[string]appskubermaticv1.ResolvedValuesSchema{}
+	for _, resolved := range applicationDef.Status.ValuesSchemas {
+		current[resolved.Version] = resolved
+	}
+
+	result := reconcile.Result{}
+	schemas := []appskubermaticv1.ResolvedValuesSchema{}
+	for _, version := range applicationDef.Spec.Versions {
+		if version.ValuesSchema == nil || !version.ValuesSchema.FromChart || version.Template.Source.Helm == nil {
+			continue
+		}
+
+		chartSource := valuesschema.ChartSource(version)
+
+		// charts are immutable once published, so a schema only needs to be resolved again if the source changed
+		// or the previous attempt failed.
+		if resolved, ok := current[version.Version]; ok && resolved.Source == chartSource && resolved.Message == "" {
+			schemas = append(schemas, resolved)
+			continue
+		}
+
+		resolved := appskubermaticv1.ResolvedValuesSchema{Version: version.Version, Source: chartSource}
+		schema, err := r.resolveSchema(ctx, log, version)
+		switch {
+		case err != nil:
+			log.Infow("failed to resolve values schema", "version", version.Version, zap.Error(err))
+			resolved.Message = err.Error()
+			result.RequeueAfter = retryInterval
+		case len(schema) > 0:
+			if err := valuesschema.Compile(schema); err != nil {
+				resolved.Message = fmt.Sprintf("chart contains an invalid values schema: %v", err)
+			} else {
+				resolved.Schema = &runtime.RawExtension{Raw: schema}
+			}
+		}
+		schemas = append(schemas, resolved)
+	}
+
+	if len(schemas) == 0 {
+		schemas = nil
+	}
+
+	if equality.Semantic.DeepEqual(applicationDef.Status.ValuesSchemas, schemas) {
+		return result, nil
+	}
+
+	oldApplicationDef := applicationDef.DeepCopy()
+	applicationDef.Status.ValuesSchemas = schemas
+	if err := r.masterClient.Patch(ctx, applicationDef, ctrlruntimeclient.MergeFrom(oldApplicationDef)); err != nil {
+		return result, fmt.Errorf("failed to update values schemas: %w", err)
+	}
+
+	return result, nil
+}
+
+// resolveChartSchema downloads the chart of the version and returns its values.schema.json.
+func (r *reconciler) resolveChartSchema(ctx context.Context, log *zap.SugaredLogger, version appskubermaticv1.ApplicationVersion) ([]byte, error) {
+	downloadDest, err := os.MkdirTemp(r.cacheDir, "appdef-schema-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(downloadDest); err != nil {
+			log.Errorw("failed to remove temporary directory", "dir", downloadDest, zap.Error(err))
+		}
+	}()
+
+	// the chart is only downloaded, so no kubeconfig is required.
+	sourceProvider, err := providers.NewSourceProvider(ctx, log, r.masterClient, "", r.cacheDir, &version.Template.Source, r.namespace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source provider: %w", err)
+	}
+
+	chartLoc, err := sourceProvider.DownloadSource(downloadDest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download chart: %w", err)
+	}
+
+	chart, err := loader.Load(chartLoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	return chart.Schema, nil
+}
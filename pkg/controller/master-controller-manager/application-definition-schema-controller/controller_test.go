@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationdefinitionschemacontroller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func init() {
+	utilruntime.Must(appskubermaticv1.AddToScheme(scheme.Scheme))
+}
+
+const (
+	applicationDefinitionName = "app-def-1"
+	chartSchema               = `{"properties":{"replicas":{"type":"integer"}},"type":"object"}`
+)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         appskubermaticv1.ApplicationDefinitionStatus
+		resolver       schemaResolver
+		expectedStatus appskubermaticv1.ApplicationDefinitionStatus
+		expectedResult reconcile.Result
+	}{
+		{
+			name:     "scenario 1: schema is resolved from the chart",
+			resolver: staticResolver([]byte(chartSchema), nil),
+			expectedStatus: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0", Schema: &runtime.RawExtension{Raw: []byte(chartSchema)}},
+				},
+			},
+		},
+		{
+			name:     "scenario 2: chart without schema",
+			resolver: staticResolver(nil, nil),
+			expectedStatus: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0"},
+				},
+			},
+		},
+		{
+			name:     "scenario 3: failure is reported and retried",
+			resolver: staticResolver(nil, errors.New("chart not found")),
+			expectedStatus: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0", Message: "chart not found"},
+				},
+			},
+			expectedResult: reconcile.Result{RequeueAfter: retryInterval},
+		},
+		{
+			name: "scenario 4: already resolved schema is not resolved again",
+			status: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0", Schema: &runtime.RawExtension{Raw: []byte(chartSchema)}},
+				},
+			},
+			resolver: staticResolver(nil, errors.New("must not be called")),
+			expectedStatus: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0", Schema: &runtime.RawExtension{Raw: []byte(chartSchema)}},
+				},
+			},
+		},
+		{
+			name: "scenario 5: schemas of removed versions are pruned",
+			status: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0", Schema: &runtime.RawExtension{Raw: []byte(chartSchema)}},
+					{Version: "0.9.0", Source: "https://charts.example.com/app:0.9.0", Schema: &runtime.RawExtension{Raw: []byte(chartSchema)}},
+				},
+			},
+			resolver: staticResolver(nil, errors.New("must not be called")),
+			expectedStatus: appskubermaticv1.ApplicationDefinitionStatus{
+				ValuesSchemas: []appskubermaticv1.ResolvedValuesSchema{
+					{Version: "1.0.0", Source: "https://charts.example.com/app:1.0.0", Schema: &runtime.RawExtension{Raw: []byte(chartSchema)}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			appDef := generateApplicationDef(tc.status)
+			masterClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(appDef).Build()
+
+			r := &reconciler{
+				log:           kubermaticlog.Logger,
+				recorder:      &record.FakeRecorder{},
+				masterClient:  masterClient,
+				resolveSchema: tc.resolver,
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: applicationDefinitionName}}
+			result, err := r.Reconcile(ctx, request)
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+			if result != tc.expectedResult {
+				t.Fatalf("expected result %v, got %v", tc.expectedResult, result)
+			}
+
+			updated := &appskubermaticv1.ApplicationDefinition{}
+			if err := masterClient.Get(ctx, request.NamespacedName, updated); err != nil {
+				t.Fatalf("failed to get application definition: %v", err)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedStatus, updated.Status) {
+				t.Fatalf("Status differs from expected:\n%v", diff.ObjectDiff(tc.expectedStatus, updated.Status))
+			}
+		})
+	}
+}
+
+func staticResolver(schema []byte, err error) schemaResolver {
+	return func(_ context.Context, _ *zap.SugaredLogger, _ appskubermaticv1.ApplicationVersion) ([]byte, error) {
+		return schema, err
+	}
+}
+
+func generateApplicationDef(status appskubermaticv1.ApplicationDefinitionStatus) *appskubermaticv1.ApplicationDefinition {
+	return &appskubermaticv1.ApplicationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: applicationDefinitionName,
+		},
+		Spec: appskubermaticv1.ApplicationDefinitionSpec{
+			Description: "sample app",
+			Method:      appskubermaticv1.HelmTemplateMethod,
+			Versions: []appskubermaticv1.ApplicationVersion{
+				{
+					Version: "1.0.0",
+					Template: appskubermaticv1.ApplicationTemplate{
+						Source: appskubermaticv1.ApplicationSource{
+							Helm: &appskubermaticv1.HelmSource{URL: "https://charts.example.com", ChartName: "app", ChartVersion: "1.0.0"},
+						},
+					},
+					ValuesSchema: &appskubermaticv1.ValuesSchema{FromChart: true},
+				},
+				{
+					Version: "0.9.0",
+					Template: appskubermaticv1.ApplicationTemplate{
+						Source: appskubermaticv1.ApplicationSource{
+							Helm: &appskubermaticv1.HelmSource{URL: "https://charts.example.com", ChartName: "app", ChartVersion: "0.9.0"},
+						},
+					},
+				},
+			},
+		},
+		Status: status,
+	}
+}
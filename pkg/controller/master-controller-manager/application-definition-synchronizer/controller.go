@@ -166,6 +166,8 @@ func applicationDefCreatorGetter(applicationDef *appskubermaticv1.ApplicationDef
 			a.Labels = applicationDef.Labels
 			a.Annotations = applicationDef.Annotations
 			a.Spec = applicationDef.Spec
+			// the status holds the resolved values schemas, which are used by the webhooks on the seeds.
+			a.Status = applicationDef.Status
 			return a, nil
 		}
 	}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectlifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ControllerName is the name of this controller.
+	ControllerName = "kkp-project-lifecycle-controller"
+)
+
+type reconciler struct {
+	ctrlruntimeclient.Client
+
+	log      *zap.SugaredLogger
+	recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+func Add(mgr manager.Manager, log *zap.SugaredLogger) error {
+	r := &reconciler{
+		Client:   mgr.GetClient(),
+		log:      log.Named(ControllerName),
+		recorder: mgr.GetEventRecorderFor(ControllerName),
+		clock:    clock.RealClock{},
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Project{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch for projects: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("project", request.Name)
+	log.Debug("Reconciling")
+
+	project := &kubermaticv1.Project{}
+	if err := r.Get(ctx, request.NamespacedName, project); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	result, err := r.reconcile(ctx, log, project)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(project, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+
+	return *result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project) (*reconcile.Result, error) {
+	// projects that are being deleted are handled by the rbac controller and the
+	// project-synchronizer; uninitialized projects are left alone until the rbac
+	// controller has activated them
+	if project.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	if project.Status.Phase != kubermaticv1.ProjectActive && project.Status.Phase != kubermaticv1.ProjectSuspended {
+		log.Debug("Project has not been initialized yet, skipping")
+		return nil, nil
+	}
+
+	now := r.clock.Now()
+
+	if project.IsExpired(now) {
+		expiredAt := project.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+
+		switch project.GetExpirationPolicy() {
+		case kubermaticv1.ProjectExpirationPolicyDelete:
+			log.Infow("Project has expired, deleting it", "expiresAt", expiredAt)
+			r.recorder.Eventf(project, corev1.EventTypeNormal, "ProjectExpired", "Project expired at %s and is being deleted.", expiredAt)
+
+			if err := r.Delete(ctx, project); err != nil {
+				return nil, ctrlruntimeclient.IgnoreNotFound(err)
+			}
+
+			return nil, nil
+
+		case kubermaticv1.ProjectExpirationPolicySuspend:
+			if !project.Spec.Suspended {
+				log.Infow("Project has expired, suspending it", "expiresAt", expiredAt)
+
+				oldProject := project.DeepCopy()
+				project.Spec.Suspended = true
+				if err := r.Patch(ctx, project, ctrlruntimeclient.MergeFrom(oldProject)); err != nil {
+					return nil, fmt.Errorf("failed to suspend project: %w", err)
+				}
+
+				r.recorder.Eventf(project, corev1.EventTypeNormal, "ProjectExpired", "Project expired at %s and has been suspended.", expiredAt)
+			}
+		}
+	}
+
+	if err := r.reconcilePhase(ctx, log, project, now); err != nil {
+		return nil, err
+	}
+
+	// make sure to come back once the project expires
+	if project.Spec.ExpiresAt != nil && !project.IsExpired(now) {
+		return &reconcile.Result{RequeueAfter: project.Spec.ExpiresAt.Sub(now)}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *reconciler) reconcilePhase(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project, now time.Time) error {
+	oldProject := project.DeepCopy()
+
+	switch {
+	case project.Spec.Suspended && project.Status.Phase == kubermaticv1.ProjectActive:
+		log.Info("Suspending project")
+
+		suspendedAt := metav1.NewTime(now)
+		project.Status.Phase = kubermaticv1.ProjectSuspended
+		project.Status.SuspendedAt = &suspendedAt
+		if err := r.Status().Patch(ctx, project, ctrlruntimeclient.MergeFrom(oldProject)); err != nil {
+			return fmt.Errorf("failed to set project phase to suspended: %w", err)
+		}
+
+		r.recorder.Event(project, corev1.EventTypeNormal, "ProjectSuspended", "Project has been suspended, all clusters will be paused.")
+
+	case !project.Spec.Suspended && project.Status.Phase == kubermaticv1.ProjectSuspended:
+		log.Info("Resuming project")
+
+		project.Status.Phase = kubermaticv1.ProjectActive
+		project.Status.SuspendedAt = nil
+		if err := r.Status().Patch(ctx, project, ctrlruntimeclient.MergeFrom(oldProject)); err != nil {
+			return fmt.Errorf("failed to set project phase to active: %w", err)
+		}
+
+		r.recorder.Event(project, corev1.EventTypeNormal, "ProjectResumed", "Project has been resumed, all clusters will be unpaused.")
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectlifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func init() {
+	utilruntime.Must(kubermaticv1.AddToScheme(scheme.Scheme))
+}
+
+const projectName = "project-test"
+
+var now = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name            string
+		project         *kubermaticv1.Project
+		expectedDeleted bool
+		expectedPhase   kubermaticv1.ProjectPhase
+		expectedSuspend bool
+		expectedResult  reconcile.Result
+	}{
+		{
+			name:          "scenario 1: uninitialized projects are not touched",
+			project:       genProject(kubermaticv1.ProjectInactive, true, nil, ""),
+			expectedPhase: kubermaticv1.ProjectInactive,
+			// spec is left as is
+			expectedSuspend: true,
+		},
+		{
+			name:            "scenario 2: suspend an active project",
+			project:         genProject(kubermaticv1.ProjectActive, true, nil, ""),
+			expectedPhase:   kubermaticv1.ProjectSuspended,
+			expectedSuspend: true,
+		},
+		{
+			name:            "scenario 3: resume a suspended project",
+			project:         genProject(kubermaticv1.ProjectSuspended, false, nil, ""),
+			expectedPhase:   kubermaticv1.ProjectActive,
+			expectedSuspend: false,
+		},
+		{
+			name:            "scenario 4: requeue projects that have not expired yet",
+			project:         genProject(kubermaticv1.ProjectActive, false, timePtr(now.Add(time.Hour)), ""),
+			expectedPhase:   kubermaticv1.ProjectActive,
+			expectedSuspend: false,
+			expectedResult:  reconcile.Result{RequeueAfter: time.Hour},
+		},
+		{
+			name:            "scenario 5: suspend expired projects by default",
+			project:         genProject(kubermaticv1.ProjectActive, false, timePtr(now.Add(-time.Hour)), ""),
+			expectedPhase:   kubermaticv1.ProjectSuspended,
+			expectedSuspend: true,
+		},
+		{
+			name:            "scenario 6: delete expired projects",
+			project:         genProject(kubermaticv1.ProjectActive, false, timePtr(now.Add(-time.Hour)), kubermaticv1.ProjectExpirationPolicyDelete),
+			expectedDeleted: true,
+		},
+		{
+			name:            "scenario 7: expired projects cannot be resumed",
+			project:         genProject(kubermaticv1.ProjectSuspended, false, timePtr(now.Add(-time.Hour)), kubermaticv1.ProjectExpirationPolicySuspend),
+			expectedPhase:   kubermaticv1.ProjectSuspended,
+			expectedSuspend: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tc.project).
+				Build()
+
+			r := &reconciler{
+				Client:   client,
+				log:      kubermaticlog.Logger,
+				recorder: &record.FakeRecorder{},
+				clock:    clocktesting.NewFakeClock(now),
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: projectName}}
+			result, err := r.Reconcile(ctx, request)
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if result != tc.expectedResult {
+				t.Errorf("expected result %v, got %v", tc.expectedResult, result)
+			}
+
+			project := &kubermaticv1.Project{}
+			err = client.Get(ctx, request.NamespacedName, project)
+			if tc.expectedDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected project to be deleted, but got: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to get project: %v", err)
+			}
+
+			if project.Status.Phase != tc.expectedPhase {
+				t.Errorf("expected phase %q, got %q", tc.expectedPhase, project.Status.Phase)
+			}
+
+			if project.Spec.Suspended != tc.expectedSuspend {
+				t.Errorf("expected spec.suspended to be %v, got %v", tc.expectedSuspend, project.Spec.Suspended)
+			}
+
+			if project.Status.Phase == kubermaticv1.ProjectActive && project.Status.SuspendedAt != nil {
+				t.Errorf("expected status.suspendedAt to be unset for active projects, got %v", project.Status.SuspendedAt)
+			}
+		})
+	}
+}
+
+func genProject(phase kubermaticv1.ProjectPhase, suspended bool, expiresAt *metav1.Time, policy kubermaticv1.ProjectExpirationPolicy) *kubermaticv1.Project {
+	return &kubermaticv1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: projectName,
+		},
+		Spec: kubermaticv1.ProjectSpec{
+			Name:             "my project",
+			Suspended:        suspended,
+			ExpiresAt:        expiresAt,
+			ExpirationPolicy: policy,
+		},
+		Status: kubermaticv1.ProjectStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func timePtr(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
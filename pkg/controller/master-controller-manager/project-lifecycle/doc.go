@@ -0,0 +1,26 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package projectlifecycle contains a controller that drives the lifecycle
+of projects on the master cluster: it suspends or deletes projects once
+their expiry date has passed and moves projects between the Active and
+Suspended phases based on spec.suspended.
+
+The project-synchronizer then propagates the phase to all seeds, where the
+project controller in the seed-ctrl-mgr pauses or resumes the clusters.
+*/
+package projectlifecycle
@@ -122,7 +122,7 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		projectCreatorGetter(project),
 	}
 
-	err := r.seedClients.Each(ctx, log, func(_ string, seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	err := r.seedClients.Each(ctx, log, func(seedName string, seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 		seedProject := &kubermaticv1.Project{}
 		if err := seedClient.Get(ctx, request.NamespacedName, seedProject); err != nil && !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to fetch project on seed cluster: %w", err)
@@ -160,6 +160,8 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 			if err := seedClient.Status().Patch(ctx, seedProject, ctrlruntimeclient.MergeFrom(oldProject)); err != nil {
 				return fmt.Errorf("failed to update project status on seed cluster: %w", err)
 			}
+
+			r.recordPhaseTransition(project, oldProject.Status.Phase, seedName)
 		}
 
 		return nil
@@ -173,6 +175,18 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return reconcile.Result{}, nil
 }
 
+// recordPhaseTransition emits an event whenever a project suspension or
+// resumption has been handed over to a seed, where the seed-ctrl-mgr then
+// takes care of pausing or resuming the project's clusters.
+func (r *reconciler) recordPhaseTransition(project *kubermaticv1.Project, oldPhase kubermaticv1.ProjectPhase, seedName string) {
+	switch {
+	case project.Status.Phase == kubermaticv1.ProjectSuspended && oldPhase != kubermaticv1.ProjectSuspended:
+		r.recorder.Eventf(project, corev1.EventTypeNormal, "ProjectSuspended", "Suspending clusters on seed %s.", seedName)
+	case project.Status.Phase != kubermaticv1.ProjectSuspended && oldPhase == kubermaticv1.ProjectSuspended:
+		r.recorder.Eventf(project, corev1.EventTypeNormal, "ProjectResumed", "Resuming clusters on seed %s.", seedName)
+	}
+}
+
 func (r *reconciler) handleDeletion(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project) error {
 	err := r.seedClients.Each(ctx, log, func(_ string, seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 		return ctrlruntimeclient.IgnoreNotFound(seedClient.Delete(ctx, project))
@@ -81,6 +81,21 @@ func TestReconcile(t *testing.T) {
 				WithObjects(generateProject(projectName, false), test.GenTestSeed()).
 				Build(),
 		},
+		{
+			name:            "scenario 3: sync project suspension from master cluster to seed cluster",
+			requestName:     projectName,
+			expectedProject: generateSuspendedProject(projectName),
+			masterClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generateSuspendedProject(projectName), test.GenTestSeed()).
+				Build(),
+			seedClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(generateProject(projectName, false)).
+				Build(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -142,3 +157,12 @@ func generateProject(name string, deleted bool) *kubermaticv1.Project {
 	}
 	return project
 }
+
+func generateSuspendedProject(name string) *kubermaticv1.Project {
+	project := generateProject(name, false)
+	project.Spec.Suspended = true
+	project.Spec.ExpiresAt = &metav1.Time{Time: time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)}
+	project.Status.Phase = kubermaticv1.ProjectSuspended
+	project.Status.SuspendedAt = &metav1.Time{Time: time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)}
+	return project
+}
@@ -44,8 +44,19 @@ const (
 
 	// RBACResourcesNamePrefix represents kubermatic group prefix.
 	RBACResourcesNamePrefix = "kubermatic"
+
+	// ProjectRoleGroupNamePrefix represents the prefix of the groups that ProjectRoles are bound to.
+	ProjectRoleGroupNamePrefix = "projectrole"
+
+	// MachineDeploymentsGroupNamePrefix represents the prefix of the groups inside user clusters that are
+	// allowed to perform a single verb on MachineDeployments. They are used for members whose ProjectRole
+	// grants access to MachineDeployments, see GenerateMachineDeploymentsGroupNameFor.
+	MachineDeploymentsGroupNamePrefix = "machinedeployments"
 )
 
+// MachineDeploymentsGroupVerbs are the verbs that have a MachineDeployments group inside user clusters.
+var MachineDeploymentsGroupVerbs = []string{"get", "list", "create", "update", "patch", "delete"}
+
 const (
 	saSecretsNamespaceName              = "kubermatic"
 	alertmanagerName                    = "alertmanager"
@@ -71,6 +82,18 @@ func GenerateActualGroupNameFor(projectName, groupName string) string {
 	return fmt.Sprintf("%s-%s", groupName, projectName)
 }
 
+// GenerateProjectRoleGroupNameFor generates the name of the group that members bound to
+// the given ProjectRole in the given project belong to.
+func GenerateProjectRoleGroupNameFor(projectName, projectRoleName string) string {
+	return fmt.Sprintf("%s:%s-%s", ProjectRoleGroupNamePrefix, projectRoleName, projectName)
+}
+
+// GenerateMachineDeploymentsGroupNameFor generates the name of the group inside user clusters
+// that is only allowed to perform the given verb on MachineDeployments.
+func GenerateMachineDeploymentsGroupNameFor(verb string) string {
+	return fmt.Sprintf("%s-%s", MachineDeploymentsGroupNamePrefix, verb)
+}
+
 // ExtractGroupPrefix extracts only group prefix from the given group name.
 func ExtractGroupPrefix(groupName string) string {
 	ret := strings.Split(groupName, "-")
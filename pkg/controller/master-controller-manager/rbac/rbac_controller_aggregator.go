@@ -170,6 +170,10 @@ func New(ctx context.Context, metrics *Metrics, mgr manager.Manager, seedManager
 		return nil, err
 	}
 
+	if err := newProjectRoleRBACController(ctx, mgr, seedManagerMap, log, workerPredicate); err != nil {
+		return nil, err
+	}
+
 	resourcesRBACCtrl, err := newResourcesControllers(ctx, metrics, mgr, log, seedManagerMap, projectResources)
 	if err != nil {
 		return nil, err
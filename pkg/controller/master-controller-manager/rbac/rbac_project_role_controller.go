@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type projectRoleController struct {
+	log           *zap.SugaredLogger
+	client        ctrlruntimeclient.Client
+	seedClientMap map[string]ctrlruntimeclient.Client
+}
+
+// newProjectRoleRBACController creates a new controller that is responsible for
+// turning the ProjectRoles bound within a project into RBAC ClusterRoles/Roles
+// and their bindings, on the master and on all seed clusters.
+//
+// The rules of the generated roles are derived from the roles generated for the
+// owners group of the project, so that a ProjectRole can never grant more than
+// an owner of the project has.
+func newProjectRoleRBACController(ctx context.Context, mgr manager.Manager, seedManagerMap map[string]manager.Manager, log *zap.SugaredLogger, workerPredicate predicate.Predicate) error {
+	seedClientMap := make(map[string]ctrlruntimeclient.Client)
+	for k, v := range seedManagerMap {
+		seedClientMap[k] = v.GetClient()
+	}
+
+	c := &projectRoleController{
+		log:           log.Named("project-role"),
+		client:        mgr.GetClient(),
+		seedClientMap: seedClientMap,
+	}
+
+	// Create a new controller
+	cc, err := controller.New("rbac_generator_for_project_roles", mgr, controller.Options{Reconciler: c})
+	if err != nil {
+		return err
+	}
+
+	if err := cc.Watch(&source.Kind{Type: &kubermaticv1.Project{}}, &handler.EnqueueRequestForObject{}, workerPredicate); err != nil {
+		return fmt.Errorf("failed to create Project watcher: %w", err)
+	}
+
+	if err := cc.Watch(&source.Kind{Type: &kubermaticv1.UserProjectBinding{}}, enqueueProjectForBinding()); err != nil {
+		return fmt.Errorf("failed to create UserProjectBinding watcher: %w", err)
+	}
+
+	if err := cc.Watch(&source.Kind{Type: &kubermaticv1.GroupProjectBinding{}}, enqueueProjectForBinding()); err != nil {
+		return fmt.Errorf("failed to create GroupProjectBinding watcher: %w", err)
+	}
+
+	if err := cc.Watch(&source.Kind{Type: &kubermaticv1.ProjectRole{}}, enqueueProjectsForProjectRole(mgr.GetClient())); err != nil {
+		return fmt.Errorf("failed to create ProjectRole watcher: %w", err)
+	}
+
+	// the generated roles are derived from the roles of the project owners, so whenever
+	// those change (e.g. because a new cluster was created), the project needs to be reconciled
+	roleKinds := []ctrlruntimeclient.Object{&rbacv1.ClusterRole{}, &rbacv1.Role{}}
+
+	for _, kind := range roleKinds {
+		if err := cc.Watch(&source.Kind{Type: kind}, enqueueProjectForOwnerRole(), predicateutil.ByLabelExists(kubermaticv1.AuthZRoleLabel)); err != nil {
+			return fmt.Errorf("failed to create %T watcher: %w", kind, err)
+		}
+
+		for seedName, seedManager := range seedManagerMap {
+			if err := cc.Watch(source.NewKindWithCache(kind, seedManager.GetCache()), enqueueProjectForOwnerRole(), predicateutil.ByLabelExists(kubermaticv1.AuthZRoleLabel)); err != nil {
+				return fmt.Errorf("failed to create %T watcher for seed %q: %w", kind, seedName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *projectRoleController) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return reconcile.Result{}, c.sync(ctx, req.NamespacedName)
+}
+
+// enqueueProjectForBinding enqueues the project a UserProjectBinding or GroupProjectBinding belongs to.
+func enqueueProjectForBinding() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		var projectID string
+
+		switch binding := o.(type) {
+		case *kubermaticv1.UserProjectBinding:
+			projectID = binding.Spec.ProjectID
+		case *kubermaticv1.GroupProjectBinding:
+			projectID = binding.Spec.ProjectID
+		}
+
+		if projectID == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: projectID}}}
+	})
+}
+
+// enqueueProjectsForProjectRole enqueues all projects in which the given ProjectRole is bound.
+func enqueueProjectsForProjectRole(client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		projects, err := getProjectsForProjectRole(context.Background(), client, o.GetName())
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to determine projects for ProjectRole %q: %w", o.GetName(), err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, project := range projects.List() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: project}})
+		}
+
+		return requests
+	})
+}
+
+// enqueueProjectForOwnerRole enqueues the project that a ClusterRole/Role was generated for
+// if it was generated for the owners group of the project.
+func enqueueProjectForOwnerRole() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		prefix := OwnerGroupNamePrefix + "-"
+
+		group := o.GetLabels()[kubermaticv1.AuthZRoleLabel]
+		if !strings.HasPrefix(group, prefix) {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: strings.TrimPrefix(group, prefix)}}}
+	})
+}
@@ -82,8 +82,12 @@ func (c *projectController) sync(ctx context.Context, key ctrlruntimeclient.Obje
 	if err := c.ensureRBACRoleBindingForResources(ctx, project.Name); err != nil {
 		return fmt.Errorf("failed to ensure that the RBAC RolesBindings for the project's resources exists: %w", err)
 	}
-	if err := c.ensureProjectPhase(ctx, project, kubermaticv1.ProjectActive); err != nil {
-		return fmt.Errorf("failed to set project phase to active: %w", err)
+	// suspended projects are fully initialized, but the transition between Active
+	// and Suspended is handled by the project-lifecycle-controller
+	if project.Status.Phase != kubermaticv1.ProjectSuspended {
+		if err := c.ensureProjectPhase(ctx, project, kubermaticv1.ProjectActive); err != nil {
+			return fmt.Errorf("failed to set project phase to active: %w", err)
+		}
 	}
 
 	return nil
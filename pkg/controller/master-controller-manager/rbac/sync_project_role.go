@@ -0,0 +1,473 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (c *projectRoleController) sync(ctx context.Context, key ctrlruntimeclient.ObjectKey) error {
+	projectName := key.Name
+	log := c.log.With("project", projectName)
+
+	var projectRoles []kubermaticv1.ProjectRole
+
+	project := &kubermaticv1.Project{}
+	if err := c.client.Get(ctx, key, project); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		project = nil
+	}
+
+	// once the project is gone, all RBAC generated for its ProjectRoles is pruned
+	if project != nil && project.DeletionTimestamp == nil {
+		var err error
+		projectRoles, err = c.getBoundProjectRoles(ctx, projectName)
+		if err != nil {
+			return fmt.Errorf("failed to get ProjectRoles bound in project: %w", err)
+		}
+	}
+
+	log.Debugw("reconciling RBAC for ProjectRoles", "count", len(projectRoles))
+
+	if err := ensureProjectRoleRBAC(ctx, c.client, projectName, projectRoles); err != nil {
+		return fmt.Errorf("failed to reconcile RBAC for ProjectRoles on master: %w", err)
+	}
+
+	for seedName, seedClient := range c.seedClientMap {
+		if err := ensureProjectRoleRBAC(ctx, seedClient, projectName, projectRoles); err != nil {
+			return fmt.Errorf("failed to reconcile RBAC for ProjectRoles on seed %q: %w", seedName, err)
+		}
+	}
+
+	return nil
+}
+
+// getBoundProjectRoles returns all ProjectRoles that are referenced by the
+// UserProjectBindings and GroupProjectBindings of the given project. Bindings
+// that reference non-existing ProjectRoles are ignored.
+func (c *projectRoleController) getBoundProjectRoles(ctx context.Context, projectName string) ([]kubermaticv1.ProjectRole, error) {
+	roleNames, err := getProjectRoleNamesForProject(ctx, c.client, projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectRoles []kubermaticv1.ProjectRole
+	for _, roleName := range roleNames.List() {
+		role := kubermaticv1.ProjectRole{}
+		if err := c.client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: roleName}, &role); err != nil {
+			if apierrors.IsNotFound(err) {
+				c.log.Debugw("ignoring binding to non-existing ProjectRole", "project", projectName, "projectrole", roleName)
+				continue
+			}
+			return nil, err
+		}
+
+		projectRoles = append(projectRoles, role)
+	}
+
+	return projectRoles, nil
+}
+
+func getProjectRoleNamesForProject(ctx context.Context, client ctrlruntimeclient.Client, projectName string) (sets.String, error) {
+	roleNames := sets.NewString()
+
+	userBindings := &kubermaticv1.UserProjectBindingList{}
+	if err := client.List(ctx, userBindings); err != nil {
+		return nil, fmt.Errorf("failed to list UserProjectBindings: %w", err)
+	}
+
+	for _, binding := range userBindings.Items {
+		if binding.Spec.ProjectID == projectName && binding.Spec.ProjectRole != "" {
+			roleNames.Insert(binding.Spec.ProjectRole)
+		}
+	}
+
+	groupBindings := &kubermaticv1.GroupProjectBindingList{}
+	if err := client.List(ctx, groupBindings); err != nil {
+		return nil, fmt.Errorf("failed to list GroupProjectBindings: %w", err)
+	}
+
+	for _, binding := range groupBindings.Items {
+		if binding.Spec.ProjectID == projectName && binding.Spec.ProjectRole != "" {
+			roleNames.Insert(binding.Spec.ProjectRole)
+		}
+	}
+
+	return roleNames, nil
+}
+
+// getProjectsForProjectRole returns the names of all projects in which the given ProjectRole is bound.
+func getProjectsForProjectRole(ctx context.Context, client ctrlruntimeclient.Client, roleName string) (sets.String, error) {
+	projects := sets.NewString()
+
+	userBindings := &kubermaticv1.UserProjectBindingList{}
+	if err := client.List(ctx, userBindings); err != nil {
+		return nil, fmt.Errorf("failed to list UserProjectBindings: %w", err)
+	}
+
+	for _, binding := range userBindings.Items {
+		if binding.Spec.ProjectRole == roleName {
+			projects.Insert(binding.Spec.ProjectID)
+		}
+	}
+
+	groupBindings := &kubermaticv1.GroupProjectBindingList{}
+	if err := client.List(ctx, groupBindings); err != nil {
+		return nil, fmt.Errorf("failed to list GroupProjectBindings: %w", err)
+	}
+
+	for _, binding := range groupBindings.Items {
+		if binding.Spec.ProjectRole == roleName {
+			projects.Insert(binding.Spec.ProjectID)
+		}
+	}
+
+	return projects, nil
+}
+
+// ensureProjectRoleRBAC reconciles the ClusterRoles, Roles and their bindings for the given
+// ProjectRoles in the given project and prunes those for ProjectRoles that are not bound anymore.
+func ensureProjectRoleRBAC(ctx context.Context, client ctrlruntimeclient.Client, projectName string, projectRoles []kubermaticv1.ProjectRole) error {
+	ownerGroups := []string{OwnerGroupNamePrefix, GenerateActualGroupNameFor(projectName, OwnerGroupNamePrefix)}
+
+	ownerClusterRoles := []rbacv1.ClusterRole{}
+	ownerRoles := map[string][]rbacv1.Role{}
+
+	for _, group := range ownerGroups {
+		clusterRoleList := &rbacv1.ClusterRoleList{}
+		if err := client.List(ctx, clusterRoleList, ctrlruntimeclient.MatchingLabels{kubermaticv1.AuthZRoleLabel: group}); err != nil {
+			return fmt.Errorf("failed to list ClusterRoles: %w", err)
+		}
+		ownerClusterRoles = append(ownerClusterRoles, clusterRoleList.Items...)
+
+		roleList := &rbacv1.RoleList{}
+		if err := client.List(ctx, roleList, ctrlruntimeclient.MatchingLabels{kubermaticv1.AuthZRoleLabel: group}); err != nil {
+			return fmt.Errorf("failed to list Roles: %w", err)
+		}
+		for _, role := range roleList.Items {
+			ownerRoles[role.Namespace] = append(ownerRoles[role.Namespace], role)
+		}
+	}
+
+	// sort to generate stable rules
+	sort.Slice(ownerClusterRoles, func(i, j int) bool { return ownerClusterRoles[i].Name < ownerClusterRoles[j].Name })
+
+	clusterRoleCreators := []reconciling.NamedClusterRoleCreatorGetter{}
+	clusterRoleBindingCreators := []reconciling.NamedClusterRoleBindingCreatorGetter{}
+	roleCreators := map[string][]reconciling.NamedRoleCreatorGetter{}
+	roleBindingCreators := map[string][]reconciling.NamedRoleBindingCreatorGetter{}
+
+	desiredClusterRoles := sets.NewString()
+	desiredRoles := map[string]sets.String{}
+
+	for i := range projectRoles {
+		projectRole := &projectRoles[i]
+		name := generateRBACRoleNameForProjectRole(projectRole.Name, projectName)
+
+		var sourceRules []rbacv1.PolicyRule
+		for _, clusterRole := range ownerClusterRoles {
+			sourceRules = append(sourceRules, clusterRole.Rules...)
+		}
+
+		if rules := generateProjectRoleRules(projectRole, projectName, sourceRules); len(rules) > 0 {
+			desiredClusterRoles.Insert(name)
+			clusterRoleCreators = append(clusterRoleCreators, projectRoleClusterRoleCreator(name, projectName, projectRole.Name, rules))
+			clusterRoleBindingCreators = append(clusterRoleBindingCreators, projectRoleClusterRoleBindingCreator(name, projectName, projectRole.Name))
+		}
+
+		for namespace, roles := range ownerRoles {
+			sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+			sourceRules = nil
+			for _, role := range roles {
+				sourceRules = append(sourceRules, role.Rules...)
+			}
+
+			if rules := generateProjectRoleRules(projectRole, projectName, sourceRules); len(rules) > 0 {
+				if desiredRoles[namespace] == nil {
+					desiredRoles[namespace] = sets.NewString()
+				}
+				desiredRoles[namespace].Insert(name)
+				roleCreators[namespace] = append(roleCreators[namespace], projectRoleRoleCreator(name, projectName, projectRole.Name, rules))
+				roleBindingCreators[namespace] = append(roleBindingCreators[namespace], projectRoleRoleBindingCreator(name, projectName, projectRole.Name))
+			}
+		}
+	}
+
+	if err := reconciling.ReconcileClusterRoles(ctx, clusterRoleCreators, "", client); err != nil {
+		return fmt.Errorf("failed to reconcile ClusterRoles: %w", err)
+	}
+
+	if err := reconciling.ReconcileClusterRoleBindings(ctx, clusterRoleBindingCreators, "", client); err != nil {
+		return fmt.Errorf("failed to reconcile ClusterRoleBindings: %w", err)
+	}
+
+	for namespace := range roleCreators {
+		if err := reconciling.ReconcileRoles(ctx, roleCreators[namespace], namespace, client); err != nil {
+			return fmt.Errorf("failed to reconcile Roles in namespace %q: %w", namespace, err)
+		}
+
+		if err := reconciling.ReconcileRoleBindings(ctx, roleBindingCreators[namespace], namespace, client); err != nil {
+			return fmt.Errorf("failed to reconcile RoleBindings in namespace %q: %w", namespace, err)
+		}
+	}
+
+	return pruneProjectRoleRBAC(ctx, client, projectName, desiredClusterRoles, desiredRoles)
+}
+
+// pruneProjectRoleRBAC removes all RBAC objects generated for ProjectRoles in the given
+// project that are not part of the desired set anymore.
+func pruneProjectRoleRBAC(ctx context.Context, client ctrlruntimeclient.Client, projectName string, desiredClusterRoles sets.String, desiredRoles map[string]sets.String) error {
+	projectReq, err := labels.NewRequirement(kubermaticv1.ProjectIDLabelKey, selection.Equals, []string{projectName})
+	if err != nil {
+		return fmt.Errorf("failed to construct project label selector: %w", err)
+	}
+	roleReq, err := labels.NewRequirement(kubermaticv1.AuthZProjectRoleLabel, selection.Exists, nil)
+	if err != nil {
+		return fmt.Errorf("failed to construct project role label selector: %w", err)
+	}
+	listOpts := &ctrlruntimeclient.ListOptions{LabelSelector: labels.NewSelector().Add(*projectReq, *roleReq)}
+
+	isDesiredRole := func(namespace, name string) bool {
+		return desiredRoles[namespace] != nil && desiredRoles[namespace].Has(name)
+	}
+
+	clusterRoles := &rbacv1.ClusterRoleList{}
+	if err := client.List(ctx, clusterRoles, listOpts); err != nil {
+		return fmt.Errorf("failed to list ClusterRoles: %w", err)
+	}
+	for i := range clusterRoles.Items {
+		if !desiredClusterRoles.Has(clusterRoles.Items[i].Name) {
+			if err := client.Delete(ctx, &clusterRoles.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete ClusterRole: %w", err)
+			}
+		}
+	}
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := client.List(ctx, clusterRoleBindings, listOpts); err != nil {
+		return fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+	for i := range clusterRoleBindings.Items {
+		if !desiredClusterRoles.Has(clusterRoleBindings.Items[i].Name) {
+			if err := client.Delete(ctx, &clusterRoleBindings.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete ClusterRoleBinding: %w", err)
+			}
+		}
+	}
+
+	roles := &rbacv1.RoleList{}
+	if err := client.List(ctx, roles, listOpts); err != nil {
+		return fmt.Errorf("failed to list Roles: %w", err)
+	}
+	for i := range roles.Items {
+		if !isDesiredRole(roles.Items[i].Namespace, roles.Items[i].Name) {
+			if err := client.Delete(ctx, &roles.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete Role: %w", err)
+			}
+		}
+	}
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := client.List(ctx, roleBindings, listOpts); err != nil {
+		return fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+	for i := range roleBindings.Items {
+		if !isDesiredRole(roleBindings.Items[i].Namespace, roleBindings.Items[i].Name) {
+			if err := client.Delete(ctx, &roleBindings.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete RoleBinding: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func generateRBACRoleNameForProjectRole(projectRoleName, projectName string) string {
+	return fmt.Sprintf("%s:%s-%s:%s", RBACResourcesNamePrefix, ProjectRoleGroupNamePrefix, projectRoleName, projectName)
+}
+
+// projectRoleImplicitResources maps the resources that only exist within the KKP API
+// to the KKP resources that are required to access them.
+var projectRoleImplicitResources = map[kubermaticv1.ProjectRoleResource]kubermaticv1.ProjectRoleResource{
+	kubermaticv1.ProjectRoleResourceMachineDeployments: kubermaticv1.ProjectRoleResourceClusters,
+	kubermaticv1.ProjectRoleResourceKubeconfig:         kubermaticv1.ProjectRoleResourceClusters,
+}
+
+// generateProjectRoleRules derives the rules for the given ProjectRole from the given rules
+// that were generated for the project owners. Only the verbs allowed by the ProjectRole are kept.
+// Every member of a project is implicitly allowed to get the project itself, and access to
+// MachineDeployments or kubeconfigs implicitly allows to get the project's clusters.
+func generateProjectRoleRules(projectRole *kubermaticv1.ProjectRole, projectName string, sourceRules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	var rules []rbacv1.PolicyRule
+
+	allows := func(resource, verb string) bool {
+		if projectRole.Allows(kubermaticv1.ProjectRoleResource(resource), kubermaticv1.ProjectRoleVerb(verb)) {
+			return true
+		}
+
+		if verb == "get" {
+			if resource == kubermaticv1.ProjectResourceName {
+				return true
+			}
+
+			for implicitResource, requiredResource := range projectRoleImplicitResources {
+				if string(requiredResource) == resource && projectRoleAllowsAnyVerb(projectRole, implicitResource) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, sourceRule := range sourceRules {
+		if len(sourceRule.APIGroups) != 1 || sourceRule.APIGroups[0] != kubermaticv1.GroupName {
+			continue
+		}
+
+		// the project itself is only ever accessible within the project's own RBAC
+		if len(sourceRule.ResourceNames) > 0 && sets.NewString(sourceRule.Resources...).Has(kubermaticv1.ProjectResourceName) &&
+			!sets.NewString(sourceRule.ResourceNames...).Has(projectName) {
+			continue
+		}
+
+		for _, resource := range sourceRule.Resources {
+			var verbs []string
+			for _, verb := range sourceRule.Verbs {
+				if allows(resource, verb) {
+					verbs = append(verbs, verb)
+				}
+			}
+
+			if len(verbs) == 0 {
+				continue
+			}
+
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups:     sourceRule.APIGroups,
+				Resources:     []string{resource},
+				ResourceNames: sourceRule.ResourceNames,
+				Verbs:         verbs,
+			})
+		}
+	}
+
+	return rules
+}
+
+func projectRoleAllowsAnyVerb(projectRole *kubermaticv1.ProjectRole, resource kubermaticv1.ProjectRoleResource) bool {
+	for _, verb := range []kubermaticv1.ProjectRoleVerb{
+		kubermaticv1.ProjectRoleVerbGet,
+		kubermaticv1.ProjectRoleVerbList,
+		kubermaticv1.ProjectRoleVerbCreate,
+		kubermaticv1.ProjectRoleVerbUpdate,
+		kubermaticv1.ProjectRoleVerbPatch,
+		kubermaticv1.ProjectRoleVerbDelete,
+	} {
+		if projectRole.Allows(resource, verb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func projectRoleLabels(projectName, projectRoleName string) map[string]string {
+	return map[string]string{
+		kubermaticv1.ProjectIDLabelKey:     projectName,
+		kubermaticv1.AuthZProjectRoleLabel: projectRoleName,
+	}
+}
+
+func projectRoleSubjects(projectName, projectRoleName string) []rbacv1.Subject {
+	return []rbacv1.Subject{
+		{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Group",
+			Name:     GenerateProjectRoleGroupNameFor(projectName, projectRoleName),
+		},
+	}
+}
+
+func projectRoleClusterRoleCreator(name, projectName, projectRoleName string, rules []rbacv1.PolicyRule) reconciling.NamedClusterRoleCreatorGetter {
+	return func() (string, reconciling.ClusterRoleCreator) {
+		return name, func(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+			cr.Labels = projectRoleLabels(projectName, projectRoleName)
+			cr.Rules = rules
+
+			return cr, nil
+		}
+	}
+}
+
+func projectRoleClusterRoleBindingCreator(name, projectName, projectRoleName string) reconciling.NamedClusterRoleBindingCreatorGetter {
+	return func() (string, reconciling.ClusterRoleBindingCreator) {
+		return name, func(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+			crb.Labels = projectRoleLabels(projectName, projectRoleName)
+			crb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     name,
+			}
+			crb.Subjects = projectRoleSubjects(projectName, projectRoleName)
+
+			return crb, nil
+		}
+	}
+}
+
+func projectRoleRoleCreator(name, projectName, projectRoleName string, rules []rbacv1.PolicyRule) reconciling.NamedRoleCreatorGetter {
+	return func() (string, reconciling.RoleCreator) {
+		return name, func(r *rbacv1.Role) (*rbacv1.Role, error) {
+			r.Labels = projectRoleLabels(projectName, projectRoleName)
+			r.Rules = rules
+
+			return r, nil
+		}
+	}
+}
+
+func projectRoleRoleBindingCreator(name, projectName, projectRoleName string) reconciling.NamedRoleBindingCreatorGetter {
+	return func() (string, reconciling.RoleBindingCreator) {
+		return name, func(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+			rb.Labels = projectRoleLabels(projectName, projectRoleName)
+			rb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			}
+			rb.Subjects = projectRoleSubjects(projectName, projectRoleName)
+
+			return rb, nil
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateProjectRoleRules(t *testing.T) {
+	ownerRules := []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{kubermaticv1.GroupName},
+			Resources:     []string{"projects"},
+			ResourceNames: []string{"my-project"},
+			Verbs:         []string{"get", "update", "patch", "delete"},
+		},
+		{
+			APIGroups: []string{kubermaticv1.GroupName},
+			Resources: []string{"clusters"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups:     []string{kubermaticv1.GroupName},
+			Resources:     []string{"clusters"},
+			ResourceNames: []string{"my-cluster"},
+			Verbs:         []string{"get", "update", "patch", "delete"},
+		},
+		{
+			APIGroups:     []string{kubermaticv1.GroupName},
+			Resources:     []string{"usersshkeys"},
+			ResourceNames: []string{"my-key"},
+			Verbs:         []string{"get", "update", "patch", "delete"},
+		},
+		{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			ResourceNames: []string{"my-secret"},
+			Verbs:         []string{"get", "update", "patch", "delete"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		rules         []kubermaticv1.ProjectRoleRule
+		expectedRules []rbacv1.PolicyRule
+	}{
+		{
+			name: "scenario 1: a role without rules can only get the project",
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					APIGroups:     []string{kubermaticv1.GroupName},
+					Resources:     []string{"projects"},
+					ResourceNames: []string{"my-project"},
+					Verbs:         []string{"get"},
+				},
+			},
+		},
+		{
+			name: "scenario 2: verbs are limited to the ones allowed by the role",
+			rules: []kubermaticv1.ProjectRoleRule{
+				{
+					Resources: []kubermaticv1.ProjectRoleResource{kubermaticv1.ProjectRoleResourceClusters},
+					Verbs:     []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet, kubermaticv1.ProjectRoleVerbCreate},
+				},
+			},
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					APIGroups:     []string{kubermaticv1.GroupName},
+					Resources:     []string{"projects"},
+					ResourceNames: []string{"my-project"},
+					Verbs:         []string{"get"},
+				},
+				{
+					APIGroups: []string{kubermaticv1.GroupName},
+					Resources: []string{"clusters"},
+					Verbs:     []string{"create"},
+				},
+				{
+					APIGroups:     []string{kubermaticv1.GroupName},
+					Resources:     []string{"clusters"},
+					ResourceNames: []string{"my-cluster"},
+					Verbs:         []string{"get"},
+				},
+			},
+		},
+		{
+			name: "scenario 3: managing machine deployments implies getting clusters",
+			rules: []kubermaticv1.ProjectRoleRule{
+				{
+					Resources: []kubermaticv1.ProjectRoleResource{kubermaticv1.ProjectRoleResourceMachineDeployments},
+					Verbs:     []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbAll},
+				},
+			},
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					APIGroups:     []string{kubermaticv1.GroupName},
+					Resources:     []string{"projects"},
+					ResourceNames: []string{"my-project"},
+					Verbs:         []string{"get"},
+				},
+				{
+					APIGroups:     []string{kubermaticv1.GroupName},
+					Resources:     []string{"clusters"},
+					ResourceNames: []string{"my-cluster"},
+					Verbs:         []string{"get"},
+				},
+			},
+		},
+		{
+			name: "scenario 4: a wildcard role gets all KKP permissions of the owners",
+			rules: []kubermaticv1.ProjectRoleRule{
+				{
+					Resources: []kubermaticv1.ProjectRoleResource{kubermaticv1.ProjectRoleResourceAll},
+					Verbs:     []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbAll},
+				},
+			},
+			expectedRules: ownerRules[:4],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projectRole := &kubermaticv1.ProjectRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-role"},
+				Spec:       kubermaticv1.ProjectRoleSpec{Rules: test.rules},
+			}
+
+			rules := generateProjectRoleRules(projectRole, "my-project", ownerRules)
+			if diff := deep.Equal(rules, test.expectedRules); diff != nil {
+				t.Errorf("rules differ from the expected ones: %v", diff)
+			}
+		})
+	}
+}
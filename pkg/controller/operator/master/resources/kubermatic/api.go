@@ -238,6 +238,10 @@ func APIDeploymentCreator(cfg *kubermaticv1.KubermaticConfiguration, workerName
 				)
 			}
 
+			if cfg.Spec.Auth.SCIMToken != "" {
+				args = append(args, fmt.Sprintf("-scim-token=%s", cfg.Spec.Auth.SCIMToken))
+			}
+
 			if workerName != "" {
 				args = append(args, fmt.Sprintf("-worker-name=%s", workerName))
 			}
@@ -235,6 +235,10 @@ func (r *Reconciler) cleanupDeletedSeed(ctx context.Context, cfg *kubermaticv1.K
 		return fmt.Errorf("failed to clean up Cluster MutatingWebhookConfiguration: %w", err)
 	}
 
+	if err := common.CleanupClusterResource(ctx, client, &admissionregistrationv1.ValidatingWebhookConfiguration{}, kubermaticseed.AddonAdmissionWebhookName); err != nil {
+		return fmt.Errorf("failed to clean up Addon ValidatingWebhookConfiguration: %w", err)
+	}
+
 	if err := common.CleanupClusterResource(ctx, client, &admissionregistrationv1.MutatingWebhookConfiguration{}, kubermaticseed.AddonAdmissionWebhookName); err != nil {
 		return fmt.Errorf("failed to clean up Cluster MutatingWebhookConfiguration: %w", err)
 	}
@@ -255,6 +259,18 @@ func (r *Reconciler) cleanupDeletedSeed(ctx context.Context, cfg *kubermaticv1.K
 		return fmt.Errorf("failed to clean up IPAMPool ValidatingWebhookConfiguration: %w", err)
 	}
 
+	if err := common.CleanupClusterResource(ctx, client, &admissionregistrationv1.ValidatingWebhookConfiguration{}, kubermaticseed.RuleGroupAdmissionWebhookName); err != nil {
+		return fmt.Errorf("failed to clean up RuleGroup ValidatingWebhookConfiguration: %w", err)
+	}
+
+	if err := common.CleanupClusterResource(ctx, client, &admissionregistrationv1.ValidatingWebhookConfiguration{}, kubermaticseed.ProjectRuleGroupAdmissionWebhookName); err != nil {
+		return fmt.Errorf("failed to clean up ProjectRuleGroup ValidatingWebhookConfiguration: %w", err)
+	}
+
+	if err := common.CleanupClusterResource(ctx, client, &admissionregistrationv1.ValidatingWebhookConfiguration{}, kubermaticseed.ProjectAlertmanagerAdmissionWebhookName); err != nil {
+		return fmt.Errorf("failed to clean up ProjectAlertmanager ValidatingWebhookConfiguration: %w", err)
+	}
+
 	// On shared master+seed clusters, the kubermatic-webhook currently has the -seed-name
 	// flag set; now that the seed (maybe the shared seed, maybe another) is gone, we must
 	// trigger a reconciliation once to get rid of the flag. If the deleted Seed is just
@@ -674,8 +690,12 @@ func (r *Reconciler) reconcileAdmissionWebhooks(ctx context.Context, cfg *kuberm
 		common.SeedAdmissionWebhookCreator(ctx, cfg, client),
 		common.KubermaticConfigurationAdmissionWebhookCreator(ctx, cfg, client),
 		kubermaticseed.ClusterValidatingWebhookConfigurationCreator(ctx, cfg, client),
+		kubermaticseed.AddonValidatingWebhookConfigurationCreator(ctx, cfg, client),
 		common.ApplicationDefinitionValidatingWebhookConfigurationCreator(ctx, cfg, client),
 		kubermaticseed.IPAMPoolValidatingWebhookConfigurationCreator(ctx, cfg, client),
+		kubermaticseed.RuleGroupValidatingWebhookConfigurationCreator(ctx, cfg, client),
+		kubermaticseed.ProjectRuleGroupValidatingWebhookConfigurationCreator(ctx, cfg, client),
+		kubermaticseed.ProjectAlertmanagerValidatingWebhookConfigurationCreator(ctx, cfg, client),
 		kubermaticseed.OperatingSystemProfileValidatingWebhookConfigurationCreator(ctx, cfg, client),
 		kubermaticseed.OperatingSystemConfigValidatingWebhookConfigurationCreator(ctx, cfg, client),
 	}
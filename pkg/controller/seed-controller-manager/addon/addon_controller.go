@@ -17,11 +17,8 @@ limitations under the License.
 package addon
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"path"
 	"reflect"
 	"strings"
@@ -35,16 +32,13 @@ import (
 	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
-	"k8c.io/kubermatic/v2/pkg/semver"
-	"k8c.io/kubermatic/v2/pkg/util/kubectl"
+	"k8c.io/kubermatic/v2/pkg/util/apply"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/tools/record"
@@ -56,7 +50,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -168,7 +161,12 @@ func Add(
 		return err
 	}
 
-	return c.Watch(&source.Kind{Type: &kubermaticv1.Addon{}}, &handler.EnqueueRequestForObject{})
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Addon{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Addons waiting for other addons are reconciled when the addons they require change
+	return c.Watch(&source.Kind{Type: &kubermaticv1.Addon{}}, handler.EnqueueRequestsFromMapFunc(enqueueDependentAddons(client, log)))
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -235,7 +233,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 // garbageCollectAddon is called when the cluster that owns the addon is gone
 // or in deletion. The function ensures that the addon is removed without going
-// through the normal cleanup procedure (i.e. no `kubectl delete`).
+// through the normal cleanup procedure (i.e. the objects of the addon are not deleted).
 func (r *Reconciler) garbageCollectAddon(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon) error {
 	if addon.DeletionTimestamp == nil {
 		if err := r.Delete(ctx, addon); err != nil {
@@ -274,11 +272,20 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addo
 		return nil, nil
 	}
 
+	// Addons are only installed once all addons they require are ready
+	ready, err := r.ensureDependenciesReady(ctx, log, addon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if all required addons are ready: %w", err)
+	}
+	if !ready {
+		return nil, nil
+	}
+
 	// This is true when the addon: 1) is fully deployed, 2) doesn't have a `addonEnsureLabelKey` set to true.
 	// we do this to allow users to "edit/delete" resources deployed by unlabeled addons,
 	// while we enfornce the labeled ones
 	if addonResourcesCreated(addon) && !hasEnsureResourcesLabel(addon) {
-		return nil, nil
+		return r.ensureReadyConditionIsSet(ctx, log, addon, cluster)
 	}
 
 	// Reconciling
@@ -288,10 +295,14 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addo
 	if err := r.ensureIsInstalled(ctx, log, addon, cluster); err != nil {
 		return nil, fmt.Errorf("failed to deploy the addon manifests into the cluster: %w", err)
 	}
+	// Nothing has been created by a dry-run.
+	if addon.Spec.DryRun {
+		return nil, nil
+	}
 	if err := r.ensureResourcesCreatedConditionIsSet(ctx, addon); err != nil {
 		return nil, fmt.Errorf("failed to set add ResourcesCreated Condition: %w", err)
 	}
-	return nil, nil
+	return r.ensureReadyConditionIsSet(ctx, log, addon, cluster)
 }
 
 func (r *Reconciler) removeCleanupFinalizer(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon) error {
@@ -363,23 +374,24 @@ func (r *Reconciler) getAddonManifests(ctx context.Context, log *zap.SugaredLogg
 	return allManifests, nil
 }
 
-// combineManifests returns all manifests combined into a multi document yaml.
-func (r *Reconciler) combineManifests(manifests []*bytes.Buffer) *bytes.Buffer {
-	parts := make([]string, len(manifests))
-	for i, m := range manifests {
-		s := m.String()
-		s = strings.TrimSuffix(s, "\n")
-		s = strings.TrimSpace(s)
-		parts[i] = s
+// getAddonObjects returns the objects of the addon manifests with the addonLabelKey label.
+func (r *Reconciler) getAddonObjects(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) ([]*metav1unstructured.Unstructured, error) {
+	manifests, err := r.getAddonManifests(ctx, log, addon, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addon manifests: %w", err)
+	}
+
+	objects, err := r.ensureAddonLabelOnManifests(addon, manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add the addon specific label to all addon resources: %w", err)
 	}
 
-	return bytes.NewBufferString(strings.Join(parts, "\n---\n") + "\n")
+	return objects, nil
 }
 
-// ensureAddonLabelOnManifests adds the addonLabelKey label to all manifests.
-// For this to happen we need to decode all yaml files to json, parse them, add the label and finally encode to yaml again.
-func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, manifests []addonutils.Manifest) ([]*bytes.Buffer, error) {
-	var rawManifests []*bytes.Buffer
+// ensureAddonLabelOnManifests decodes the manifests and adds the addonLabelKey label to all objects.
+func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, manifests []addonutils.Manifest) ([]*metav1unstructured.Unstructured, error) {
+	var objects []*metav1unstructured.Unstructured
 
 	wantLabels := r.getAddonLabel(addon)
 	for _, m := range manifests {
@@ -399,22 +411,10 @@ func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, mani
 		}
 		parsedUnstructuredObj.SetLabels(existingLabels)
 
-		jsonBuffer := &bytes.Buffer{}
-		if err := metav1unstructured.UnstructuredJSONScheme.Encode(parsedUnstructuredObj, jsonBuffer); err != nil {
-			return nil, fmt.Errorf("encoding json failed: %w", err)
-		}
-
-		// Must be encoding back to yaml, otherwise kubectl fails to apply because it tries to parse the whole
-		// thing as json
-		yamlBytes, err := yaml.JSONToYAML(jsonBuffer.Bytes())
-		if err != nil {
-			return nil, err
-		}
-
-		rawManifests = append(rawManifests, bytes.NewBuffer(yamlBytes))
+		objects = append(objects, parsedUnstructuredObj)
 	}
 
-	return rawManifests, nil
+	return objects, nil
 }
 
 func (r *Reconciler) getAddonLabel(addon *kubermaticv1.Addon) map[string]string {
@@ -423,120 +423,51 @@ func (r *Reconciler) getAddonLabel(addon *kubermaticv1.Addon) map[string]string
 	}
 }
 
-type fileHandlingDone func()
-
-func getFileDeleteFinalizer(log *zap.SugaredLogger, filename string) fileHandlingDone {
-	return func() {
-		if err := os.RemoveAll(filename); err != nil {
-			log.Errorw("Failed to delete file", zap.Error(err), "file", filename)
-		}
-	}
-}
-
-func (r *Reconciler) writeCombinedManifest(log *zap.SugaredLogger, manifest *bytes.Buffer, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (string, fileHandlingDone, error) {
-	// Write combined Manifest to disk
-	manifestFilename := path.Join("/tmp", fmt.Sprintf("cluster-%s-%s.yaml", cluster.Name, addon.Name))
-	if err := os.WriteFile(manifestFilename, manifest.Bytes(), 0644); err != nil {
-		return "", nil, fmt.Errorf("failed to write combined manifest to %s: %w", manifestFilename, err)
-	}
-	log.Debugw("Wrote combined manifest", "file", manifestFilename)
-
-	return manifestFilename, getFileDeleteFinalizer(log, manifestFilename), nil
-}
-
-func (r *Reconciler) writeAdminKubeconfig(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (string, fileHandlingDone, error) {
-	// Write kubeconfig to disk
-	kubeconfig, err := r.KubeconfigProvider.GetAdminKubeconfig(ctx, cluster)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to get admin kubeconfig for cluster %s: %w", cluster.Name, err)
-	}
-	kubeconfigFilename := path.Join("/tmp", fmt.Sprintf("cluster-%s-addon-%s-kubeconfig", cluster.Name, addon.Name))
-	if err := os.WriteFile(kubeconfigFilename, kubeconfig, 0644); err != nil {
-		return "", nil, fmt.Errorf("failed to write admin kubeconfig for cluster %s: %w", cluster.Name, err)
-	}
-	log.Debugw("Wrote admin kubeconfig", "file", kubeconfigFilename)
-
-	return kubeconfigFilename, getFileDeleteFinalizer(log, kubeconfigFilename), nil
-}
-
-func (r *Reconciler) setupManifestInteraction(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (string, string, fileHandlingDone, error) {
-	manifests, err := r.getAddonManifests(ctx, log, addon, cluster)
-	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to get addon manifests: %w", err)
-	}
-
-	rawManifests, err := r.ensureAddonLabelOnManifests(addon, manifests)
+func (r *Reconciler) ensureIsInstalled(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
+	objects, err := r.getAddonObjects(ctx, log, addon, cluster)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to add the addon specific label to all addon resources: %w", err)
+		return err
 	}
 
-	rawManifest := r.combineManifests(rawManifests)
-	manifestFilename, manifestDone, err := r.writeCombinedManifest(log, rawManifest, addon, cluster)
-	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to write all addon resources into a combined manifest file: %w", err)
+	// An empty manifest still has to be applied, so that the objects of the previous reconciliation are pruned.
+	if len(objects) == 0 {
+		log.Debug("Manifest is empty after parsing")
 	}
 
-	kubeconfigFilename, kubeconfigDone, err := r.writeAdminKubeconfig(ctx, log, addon, cluster)
+	userClusterClient, err := r.KubeconfigProvider.GetClient(ctx, cluster)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to write the admin kubeconfig to the local filesystem: %w", err)
+		return fmt.Errorf("failed to get client for usercluster: %w", err)
 	}
 
-	done := func() {
-		kubeconfigDone()
-		manifestDone()
+	// Addons installed before the applied objects were recorded in the status have been applied with kubectl,
+	// so the objects to prune have to be found in the cluster once.
+	inventory := addon.Status.Objects
+	if len(inventory) == 0 && addonResourcesCreated(addon) {
+		inventory, err = kubectlInventory(ctx, userClusterClient, addon)
+		if err != nil {
+			return fmt.Errorf("failed to get the objects applied by kubectl: %w", err)
+		}
 	}
-	return kubeconfigFilename, manifestFilename, done, nil
-}
 
-func (r *Reconciler) getApplyCommand(ctx context.Context, kubeconfigFilename, manifestFilename string, selector fmt.Stringer, clusterVersion semver.Semver) (*exec.Cmd, error) {
-	binary, err := kubectl.BinaryForClusterVersion(&clusterVersion)
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine kubectl binary to use: %w", err)
-	}
+	// We delete all resources applied by the previous reconciliation which are not part of the manifests anymore
+	log.Debug("Applying manifests...")
+	statuses, applyErr := applyObjects(ctx, log, userClusterClient, objects, inventory, addon.Spec.DryRun)
 
-	cmd := exec.CommandContext(
-		ctx,
-		binary,
-		"--kubeconfig", kubeconfigFilename,
-		"apply",
-		"--prune",
-		"--filename", manifestFilename,
-		"--selector", selector.String(),
-	)
-	return cmd, nil
-}
-
-func (r *Reconciler) ensureIsInstalled(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
-	kubeconfigFilename, manifestFilename, done, err := r.setupManifestInteraction(ctx, log, addon, cluster)
-	if err != nil {
-		return err
-	}
-	defer done()
-
-	d, err := os.ReadFile(manifestFilename)
-	if err != nil {
-		return err
-	}
-	sd := strings.TrimSpace(string(d))
-	if len(sd) == 0 {
-		log.Debug("Skipping addon installation as the manifest is empty after parsing")
-		return nil
-	}
-
-	// We delete all resources with this label which are not in the combined manifest
-	selector := labels.SelectorFromSet(r.getAddonLabel(addon))
-	cmd, err := r.getApplyCommand(ctx, kubeconfigFilename, manifestFilename, selector, cluster.Status.Versions.ControlPlane)
-	if err != nil {
-		return fmt.Errorf("failed to create command: %w", err)
+	oldAddon := addon.DeepCopy()
+	if addon.Spec.DryRun {
+		addon.Status.DryRunObjects = statuses
+	} else {
+		addon.Status.Objects = statuses
+		addon.Status.DryRunObjects = nil
+	}
+	if !reflect.DeepEqual(oldAddon.Status, addon.Status) {
+		if err := r.Client.Status().Patch(ctx, addon, ctrlruntimeclient.MergeFrom(oldAddon)); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
 	}
 
-	cmdLog := log.With("cmd", strings.Join(cmd.Args, " "))
-
-	cmdLog.Debug("Applying manifest...")
-	out, err := cmd.CombinedOutput()
-	cmdLog.Debugw("Finished executing command", "output", string(out))
-	if err != nil {
-		return fmt.Errorf("failed to execute '%s' for addon %s of cluster %s: %w\n%s", strings.Join(cmd.Args, " "), addon.Name, cluster.Name, err, string(out))
+	if applyErr != nil {
+		return fmt.Errorf("failed to apply manifests of addon %s of cluster %s: %w", addon.Name, cluster.Name, applyErr)
 	}
 
 	return nil
@@ -557,30 +488,48 @@ func (r *Reconciler) ensureResourcesCreatedConditionIsSet(ctx context.Context, a
 }
 
 func (r *Reconciler) cleanupManifests(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
-	kubeconfigFilename, manifestFilename, done, err := r.setupManifestInteraction(ctx, log, addon, cluster)
+	var keys []apply.ObjectKey
+	known := map[apply.ObjectKey]struct{}{}
+	for _, status := range addon.Status.Objects {
+		key := keyForStatus(status)
+		known[key.Identity()] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	// Objects of addons installed before the objects were recorded in the status are found in the manifests.
+	objects, err := r.getAddonObjects(ctx, log, addon, cluster)
 	if err != nil {
 		// FIXME: use a dedicated error type and proper error unwrapping when we have the technology to do it
-		if strings.Contains(err.Error(), "no such file or directory") { // if the manifest is already deleted, that's ok
-			log.Debugf("cleanupManifests failed for addon %s/%s: %v", addon.Namespace, addon.Name, err)
-			return nil
+		if !strings.Contains(err.Error(), "no such file or directory") { // if the manifest is already deleted, that's ok
+			return err
 		}
-		return err
+		log.Debugf("Failed to get manifests of addon %s/%s: %v", addon.Namespace, addon.Name, err)
 	}
-	defer done()
 
-	binary, err := kubectl.BinaryForClusterVersion(&cluster.Status.Versions.ControlPlane)
+	userClusterClient, err := r.KubeconfigProvider.GetClient(ctx, cluster)
 	if err != nil {
-		return fmt.Errorf("failed to determine kubectl binary to use: %w", err)
+		return fmt.Errorf("failed to get client for usercluster: %w", err)
 	}
 
-	cmd := exec.CommandContext(ctx, binary, "--kubeconfig", kubeconfigFilename, "delete", "-f", manifestFilename, "--ignore-not-found")
-	cmdLog := log.With("cmd", strings.Join(cmd.Args, " "))
+	for _, obj := range objects {
+		if err := apply.DefaultNamespace(userClusterClient.RESTMapper(), obj, ""); err != nil {
+			if apply.IsNoMatchError(err) {
+				// objects whose type is not served can not exist.
+				continue
+			}
+			return err
+		}
+
+		key := apply.KeyForObject(obj)
+		if _, ok := known[key.Identity()]; !ok {
+			known[key.Identity()] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
 
-	cmdLog.Debug("Deleting resources...")
-	out, err := cmd.CombinedOutput()
-	cmdLog.Debugw("Finished executing command", "output", string(out))
-	if err != nil {
-		return fmt.Errorf("failed to execute '%s' for addon %s of cluster %s: %w\n%s", strings.Join(cmd.Args, " "), addon.Name, cluster.Name, err, string(out))
+	log.Debug("Deleting resources...")
+	if _, err := apply.Delete(ctx, log, userClusterClient, keys); err != nil {
+		return fmt.Errorf("failed to delete resources of addon %s of cluster %s: %w", addon.Name, cluster.Name, err)
 	}
 	return nil
 }
@@ -604,7 +553,7 @@ func (r *Reconciler) ensureRequiredResourceTypesExist(ctx context.Context, log *
 		// get as little as possible.
 		listOpts := &ctrlruntimeclient.ListOptions{Limit: 1}
 		if err := userClusterClient.List(ctx, unstructuedList, listOpts); err != nil {
-			if meta.IsNoMatchError(err) {
+			if apply.IsNoMatchError(err) {
 				// Try again later
 				log.Infow("Required resource isn't served, trying again in 10 seconds", "resource", formatGVK(requiredResource))
 				return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
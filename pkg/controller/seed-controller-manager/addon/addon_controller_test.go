@@ -29,18 +29,19 @@ import (
 	"k8c.io/kubermatic/v2/pkg/addon"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
-	"k8c.io/kubermatic/v2/pkg/controller/operator/defaults"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/semver"
-	"k8c.io/kubermatic/v2/pkg/util/kubectl"
 	"k8c.io/kubermatic/v2/pkg/version/cni"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	kyaml "k8s.io/apimachinery/pkg/util/yaml"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 )
 
 var testManifests = []string{
@@ -119,34 +120,19 @@ spec:
 `
 )
 
-var (
-	// testManifest1 & testManifest3 have a linebreak at the end, testManifest2 not.
-	combinedTestManifest = fmt.Sprintf("%s---\n%s\n---\n%s", testManifests[0], testManifests[1], testManifests[2])
-)
-
-type fakeKubeconfigProvider struct{}
+type fakeKubeconfigProvider struct {
+	client ctrlruntimeclient.Client
+}
 
 func (f *fakeKubeconfigProvider) GetAdminKubeconfig(_ context.Context, c *kubermaticv1.Cluster) ([]byte, error) {
 	return []byte("foo"), nil
 }
 
 func (f *fakeKubeconfigProvider) GetClient(_ context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
-	return nil, errors.New("not implemented")
-}
-
-func TestController_combineManifests(t *testing.T) {
-	controller := &Reconciler{}
-
-	var manifests []*bytes.Buffer
-	for _, m := range testManifests {
-		manifests = append(manifests, bytes.NewBufferString(m))
-	}
-
-	manifest := controller.combineManifests(manifests)
-
-	if manifest.String() != combinedTestManifest {
-		t.Fatalf("invalid combined manifest returned. Expected \n%s, Got \n%s", combinedTestManifest, manifest.String())
+	if f.client == nil {
+		return nil, errors.New("not implemented")
 	}
+	return f.client, nil
 }
 
 func setupTestCluster(cidrBlock string) *kubermaticv1.Cluster {
@@ -405,39 +391,18 @@ func TestController_ensureAddonLabelOnManifests(t *testing.T) {
 			Name: "test",
 		},
 	}
-	labeledManifests, err := controller.ensureAddonLabelOnManifests(a, []addon.Manifest{{
+	labeledObjects, err := controller.ensureAddonLabelOnManifests(a, []addon.Manifest{{
 		Content: manifest,
 	}})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if labeledManifests[0].String() != testManifest1WithLabel {
-		t.Fatalf("invalid labeled manifest returned. Expected \n%q, Got \n%q", testManifest1WithLabel, labeledManifests[0].String())
-	}
-}
-
-func TestController_getApplyCommand(t *testing.T) {
-	controller := &Reconciler{}
-
-	clusterVersion := defaults.DefaultKubernetesVersioning.Default
-	if clusterVersion == nil {
-		t.Fatal("Should be able to determine default Kubernetes version, but got nil")
-	}
-
-	binary, err := kubectl.BinaryForClusterVersion(clusterVersion)
+	labeledManifest, err := yaml.Marshal(labeledObjects[0].Object)
 	if err != nil {
-		t.Fatalf("Should be able to determine a kubectl binary for %q, but got %v", clusterVersion, err)
-	}
-
-	cmd, err := controller.getApplyCommand(context.Background(), "/opt/kubeconfig", "/opt/manifest.yaml", labels.SelectorFromSet(map[string]string{"foo": "bar"}), *clusterVersion)
-	if err != nil {
-		t.Fatalf("Should be able to determine the command, but got %v", err)
+		t.Fatal(err)
 	}
-
-	expected := fmt.Sprintf("%s --kubeconfig /opt/kubeconfig apply --prune --filename /opt/manifest.yaml --selector foo=bar", binary)
-	got := strings.Join(cmd.Args, " ")
-	if got != expected {
-		t.Fatalf("invalid apply command returned. Expected \n%s, Got \n%s", expected, got)
+	if string(labeledManifest) != testManifest1WithLabel {
+		t.Fatalf("invalid labeled manifest returned. Expected \n%q, Got \n%q", testManifest1WithLabel, string(labeledManifest))
 	}
 }
 
@@ -449,7 +414,54 @@ func TestHugeManifest(t *testing.T) {
 		kubernetesAddonDir: "./testdata",
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
-	if _, _, _, err := r.setupManifestInteraction(context.Background(), log, addon, cluster); err != nil {
-		t.Fatalf("failed to setup manifest interaction: %v", err)
+	if _, err := r.getAddonObjects(context.Background(), log, addon, cluster); err != nil {
+		t.Fatalf("failed to get addon objects: %v", err)
+	}
+}
+
+func TestCleanupManifests(t *testing.T) {
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+	cluster := setupTestCluster("10.240.16.0/20")
+	addon := setupTestAddon("test")
+	addon.Status.Objects = []kubermaticv1.AddonObjectStatus{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "recorded", Phase: kubermaticv1.AddonObjectApplied},
+	}
+
+	addonDir := t.TempDir()
+	if err := os.Mkdir(path.Join(addonDir, addon.Spec.Name), 0777); err != nil {
+		t.Fatal(err)
+	}
+	// the Widget CRD has already been removed from the cluster, so its type is not served anymore.
+	manifest := testManifests[0] + "---\n" + `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`
+	if err := os.WriteFile(path.Join(addonDir, addon.Spec.Name, "manifest.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	userClusterClient := fakectrlruntimeclient.NewClientBuilder().WithRESTMapper(mapper).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "test1"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "recorded"}},
+	).Build()
+
+	r := &Reconciler{
+		kubernetesAddonDir: addonDir,
+		KubeconfigProvider: &fakeKubeconfigProvider{client: userClusterClient},
+	}
+	if err := r.cleanupManifests(context.Background(), log, addon, cluster); err != nil {
+		t.Fatalf("failed to clean up manifests: %v", err)
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := userClusterClient.List(context.Background(), configMaps); err != nil {
+		t.Fatalf("failed to list ConfigMaps: %v", err)
+	}
+	if len(configMaps.Items) > 0 {
+		t.Errorf("expected all ConfigMaps of the addon to be deleted, got %d", len(configMaps.Items))
 	}
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/util/apply"
+
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldManager is the field manager used to server-side apply the addon manifests.
+const fieldManager = "kubermatic-addon-controller"
+
+// lastAppliedConfigAnnotation is set by `kubectl apply` on the objects it applied.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// kubectlPrunedKinds are the kinds that `kubectl apply --prune` prunes by default. Addons were applied with kubectl
+// before their objects were recorded in the status, so these are the kinds of objects that must be taken over into
+// the inventory.
+var kubectlPrunedKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Endpoints"},
+	{Version: "v1", Kind: "Namespace"},
+	{Version: "v1", Kind: "PersistentVolumeClaim"},
+	{Version: "v1", Kind: "PersistentVolume"},
+	{Version: "v1", Kind: "Pod"},
+	{Version: "v1", Kind: "ReplicationController"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "Service"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+}
+
+var objectPhases = map[apply.Phase]kubermaticv1.AddonObjectPhase{
+	apply.PhaseApplied: kubermaticv1.AddonObjectApplied,
+	apply.PhaseFailed:  kubermaticv1.AddonObjectFailed,
+	apply.PhasePruned:  kubermaticv1.AddonObjectPruned,
+}
+
+func keyForStatus(status kubermaticv1.AddonObjectStatus) apply.ObjectKey {
+	return apply.ObjectKey{Group: status.Group, Version: status.Version, Kind: status.Kind, Namespace: status.Namespace, Name: status.Name}
+}
+
+func statusForResult(result apply.Result) kubermaticv1.AddonObjectStatus {
+	status := kubermaticv1.AddonObjectStatus{
+		Group:     result.Group,
+		Version:   result.Version,
+		Kind:      result.Kind,
+		Namespace: result.Namespace,
+		Name:      result.Name,
+		Phase:     objectPhases[result.Phase],
+	}
+	if result.Err != nil {
+		status.Message = result.Err.Error()
+	}
+	return status
+}
+
+// applyObjects server-side applies the objects into the cluster and prunes the objects of the inventory (i.e. the
+// objects applied by the previous reconciliation) that are not part of objects anymore. It returns the status of
+// every object and an aggregated error of all failures.
+func applyObjects(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, objects []*metav1unstructured.Unstructured, inventory []kubermaticv1.AddonObjectStatus, dryRun bool) ([]kubermaticv1.AddonObjectStatus, error) {
+	keys := make([]apply.ObjectKey, 0, len(inventory))
+	for _, status := range inventory {
+		keys = append(keys, keyForStatus(status))
+	}
+
+	results, err := apply.Apply(ctx, log, client, objects, keys, apply.Options{FieldManager: fieldManager, DryRun: dryRun})
+
+	statuses := make([]kubermaticv1.AddonObjectStatus, 0, len(results))
+	for _, result := range results {
+		statuses = append(statuses, statusForResult(result))
+	}
+
+	return statuses, err
+}
+
+// kubectlInventory returns the objects of the addon that have been applied with kubectl, i.e. before the applied
+// objects were recorded in the addon status. Like `kubectl apply --prune`, only objects of the default prune kinds
+// with the addon label and the last-applied-configuration annotation are considered.
+func kubectlInventory(ctx context.Context, client ctrlruntimeclient.Client, addon *kubermaticv1.Addon) ([]kubermaticv1.AddonObjectStatus, error) {
+	var inventory []kubermaticv1.AddonObjectStatus
+
+	for _, gvk := range kubectlPrunedKinds {
+		list := &metav1unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := client.List(ctx, list, ctrlruntimeclient.MatchingLabels{addonLabelKey: addon.Spec.Name}); err != nil {
+			if apply.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s objects: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if _, ok := obj.GetAnnotations()[lastAppliedConfigAnnotation]; !ok {
+				continue
+			}
+			obj.SetGroupVersionKind(gvk)
+			inventory = append(inventory, statusForResult(apply.Result{ObjectKey: apply.KeyForObject(obj), Phase: apply.PhaseApplied}))
+		}
+	}
+
+	return inventory, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKubectlInventory(t *testing.T) {
+	applied := func(name, addon string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:   "kube-system",
+			Name:        name,
+			Labels:      map[string]string{addonLabelKey: addon},
+			Annotations: map[string]string{lastAppliedConfigAnnotation: "{}"},
+		}
+	}
+
+	client := fakectrlruntimeclient.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{ObjectMeta: applied("config", "test")},
+		&appsv1.Deployment{ObjectMeta: applied("deployment", "test")},
+		// objects of other addons
+		&corev1.ConfigMap{ObjectMeta: applied("other", "other")},
+		// objects that have not been applied by kubectl, e.g. Pods created by the Deployment
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "pod", Labels: map[string]string{addonLabelKey: "test"}}},
+		// kubectl does not prune RBAC by default
+		&rbacv1.ClusterRole{ObjectMeta: applied("role", "test")},
+	).Build()
+
+	inventory, err := kubectlInventory(context.Background(), client, setupTestAddon("test"))
+	if err != nil {
+		t.Fatalf("failed to get inventory: %v", err)
+	}
+
+	expected := []kubermaticv1.AddonObjectStatus{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "config", Phase: kubermaticv1.AddonObjectApplied},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "kube-system", Name: "deployment", Phase: kubermaticv1.AddonObjectApplied},
+	}
+	if diff := deep.Equal(expected, inventory); diff != nil {
+		t.Errorf("unexpected inventory: %v", diff)
+	}
+}
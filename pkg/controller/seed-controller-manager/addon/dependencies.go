@@ -0,0 +1,240 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// readinessCheckInterval is the interval in which the readiness checks of addons that are not ready yet are repeated.
+	readinessCheckInterval = 10 * time.Second
+
+	dependenciesReadyReason    = "DependenciesReady"
+	dependenciesNotReadyReason = "DependenciesNotReady"
+	readyReason                = "Ready"
+	notReadyReason             = "NotReady"
+)
+
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// ensureDependenciesReady sets the AddonDependenciesReady condition and returns false if any of the
+// required addons does not exist or is not ready yet. Dependent addons are reconciled when a required
+// addon changes, so there is no need to requeue blocked addons.
+func (r *Reconciler) ensureDependenciesReady(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon) (bool, error) {
+	if len(addon.Spec.RequiredAddons) == 0 {
+		return true, nil
+	}
+
+	var blocking []string
+	for _, name := range addon.Spec.RequiredAddons {
+		required := &kubermaticv1.Addon{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: addon.Namespace, Name: name}, required); err != nil {
+			if apierrors.IsNotFound(err) {
+				blocking = append(blocking, fmt.Sprintf("%s (not installed)", name))
+				continue
+			}
+			return false, fmt.Errorf("failed to get required addon %s: %w", name, err)
+		}
+
+		if required.DeletionTimestamp != nil {
+			blocking = append(blocking, fmt.Sprintf("%s (in deletion)", name))
+			continue
+		}
+
+		if required.Status.Conditions[kubermaticv1.AddonReady].Status != corev1.ConditionTrue {
+			blocking = append(blocking, fmt.Sprintf("%s (not ready)", name))
+		}
+	}
+
+	if len(blocking) > 0 {
+		log.Debugw("Waiting for required addons", "addons", blocking)
+		message := fmt.Sprintf("Waiting for required addons: %s", strings.Join(blocking, ", "))
+		return false, r.ensureAddonCondition(ctx, addon, kubermaticv1.AddonDependenciesReady, corev1.ConditionFalse, dependenciesNotReadyReason, message)
+	}
+
+	return true, r.ensureAddonCondition(ctx, addon, kubermaticv1.AddonDependenciesReady, corev1.ConditionTrue, dependenciesReadyReason, "")
+}
+
+// ensureReadyConditionIsSet runs the readiness checks of the addon and sets the AddonReady condition
+// accordingly. Addons that are not ready are requeued to repeat the checks.
+func (r *Reconciler) ensureReadyConditionIsSet(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	var notReady []string
+
+	if len(addon.Spec.ReadinessChecks) > 0 {
+		userClusterClient, err := r.KubeconfigProvider.GetClient(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client for usercluster: %w", err)
+		}
+
+		for _, check := range addon.Spec.ReadinessChecks {
+			ready, err := isReady(ctx, userClusterClient, check)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check readiness of %s: %w", formatReadinessCheck(check), err)
+			}
+			if !ready {
+				notReady = append(notReady, formatReadinessCheck(check))
+			}
+		}
+	}
+
+	if len(notReady) > 0 {
+		log.Debugw("Addon is not ready yet", "resources", notReady)
+		message := fmt.Sprintf("Waiting for resources to become ready: %s", strings.Join(notReady, ", "))
+		if err := r.ensureAddonCondition(ctx, addon, kubermaticv1.AddonReady, corev1.ConditionFalse, notReadyReason, message); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: readinessCheckInterval}, nil
+	}
+
+	return nil, r.ensureAddonCondition(ctx, addon, kubermaticv1.AddonReady, corev1.ConditionTrue, readyReason, "")
+}
+
+// isReady returns true if the resource of the readiness check is ready. Resources that do not exist are not ready.
+func isReady(ctx context.Context, client ctrlruntimeclient.Client, check kubermaticv1.AddonReadinessCheck) (bool, error) {
+	key := types.NamespacedName{Namespace: check.Namespace, Name: check.Name}
+
+	switch check.Kind {
+	case kubermaticv1.AddonReadinessCheckDeployment:
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(ctx, key, deployment); err != nil {
+			return false, ctrlruntimeclient.IgnoreNotFound(err)
+		}
+		return isDeploymentReady(deployment), nil
+
+	case kubermaticv1.AddonReadinessCheckDaemonSet:
+		daemonSet := &appsv1.DaemonSet{}
+		if err := client.Get(ctx, key, daemonSet); err != nil {
+			return false, ctrlruntimeclient.IgnoreNotFound(err)
+		}
+		return isDaemonSetReady(daemonSet), nil
+
+	case kubermaticv1.AddonReadinessCheckCustomResourceDefinition:
+		// CRDs are not part of the scheme of the user cluster client.
+		crd := &metav1unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+		if err := client.Get(ctx, types.NamespacedName{Name: check.Name}, crd); err != nil {
+			return false, ctrlruntimeclient.IgnoreNotFound(err)
+		}
+		return isCRDEstablished(crd)
+
+	default:
+		return false, fmt.Errorf("unsupported kind %q", check.Kind)
+	}
+}
+
+func isDeploymentReady(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+func isDaemonSetReady(daemonSet *appsv1.DaemonSet) bool {
+	desired := daemonSet.Status.DesiredNumberScheduled
+
+	return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.UpdatedNumberScheduled == desired &&
+		daemonSet.Status.NumberReady == desired
+}
+
+func isCRDEstablished(crd *metav1unstructured.Unstructured) (bool, error) {
+	conditions, _, err := metav1unstructured.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil {
+		return false, fmt.Errorf("failed to read conditions: %w", err)
+	}
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Established" && condition["status"] == string(corev1.ConditionTrue) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func formatReadinessCheck(check kubermaticv1.AddonReadinessCheck) string {
+	if check.Namespace == "" {
+		return fmt.Sprintf("%s %s", check.Kind, check.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", check.Kind, check.Namespace, check.Name)
+}
+
+// ensureAddonCondition sets the condition and patches the addon status if the condition changed.
+func (r *Reconciler) ensureAddonCondition(ctx context.Context, addon *kubermaticv1.Addon, condType kubermaticv1.AddonConditionType, status corev1.ConditionStatus, reason, message string) error {
+	condition, exists := addon.Status.Conditions[condType]
+	if exists && condition.Status == status && condition.Reason == reason && condition.Message == message {
+		return nil
+	}
+
+	oldAddon := addon.DeepCopy()
+	setAddonCodition(addon, condType, status)
+	condition = addon.Status.Conditions[condType]
+	condition.Reason = reason
+	condition.Message = message
+	addon.Status.Conditions[condType] = condition
+
+	return r.Client.Status().Patch(ctx, addon, ctrlruntimeclient.MergeFrom(oldAddon))
+}
+
+// enqueueDependentAddons returns the addons of the same cluster that require the given addon.
+func enqueueDependentAddons(client ctrlruntimeclient.Client, log *zap.SugaredLogger) func(ctrlruntimeclient.Object) []reconcile.Request {
+	return func(a ctrlruntimeclient.Object) []reconcile.Request {
+		addonList := &kubermaticv1.AddonList{}
+		if err := client.List(context.Background(), addonList, ctrlruntimeclient.InNamespace(a.GetNamespace())); err != nil {
+			log.Errorw("Failed to list addons", zap.Error(err), "namespace", a.GetNamespace())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, addon := range addonList.Items {
+			for _, name := range addon.Spec.RequiredAddons {
+				if name == a.GetName() {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{Namespace: addon.Namespace, Name: addon.Name},
+					})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureDependenciesReady(t *testing.T) {
+	newAddon := func(name string, ready bool, requires ...string) *kubermaticv1.Addon {
+		addon := &kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-test", Name: name},
+			Spec:       kubermaticv1.AddonSpec{Name: name, RequiredAddons: requires},
+		}
+		if ready {
+			setAddonCodition(addon, kubermaticv1.AddonReady, corev1.ConditionTrue)
+		}
+		return addon
+	}
+
+	testCases := []struct {
+		name            string
+		addon           *kubermaticv1.Addon
+		existing        []ctrlruntimeclient.Object
+		expectedReady   bool
+		expectedMessage string
+	}{
+		{
+			name:          "addon without requirements",
+			addon:         newAddon("rbac", false),
+			expectedReady: true,
+		},
+		{
+			name:          "all required addons are ready",
+			addon:         newAddon("hubble", false, "cilium"),
+			existing:      []ctrlruntimeclient.Object{newAddon("cilium", true)},
+			expectedReady: true,
+		},
+		{
+			name:            "required addons are missing or not ready",
+			addon:           newAddon("hubble", false, "cilium", "kube-proxy"),
+			existing:        []ctrlruntimeclient.Object{newAddon("cilium", false)},
+			expectedReady:   false,
+			expectedMessage: "Waiting for required addons: cilium (not ready), kube-proxy (not installed)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(append(tc.existing, tc.addon)...).
+				Build()
+			r := &Reconciler{Client: client}
+
+			ready, err := r.ensureDependenciesReady(context.Background(), kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(), tc.addon)
+			if err != nil {
+				t.Fatalf("failed to check dependencies: %v", err)
+			}
+			if ready != tc.expectedReady {
+				t.Fatalf("expected ready to be %v, got %v", tc.expectedReady, ready)
+			}
+
+			if len(tc.addon.Spec.RequiredAddons) == 0 {
+				return
+			}
+
+			condition := tc.addon.Status.Conditions[kubermaticv1.AddonDependenciesReady]
+			expectedStatus := corev1.ConditionFalse
+			if tc.expectedReady {
+				expectedStatus = corev1.ConditionTrue
+			}
+			if condition.Status != expectedStatus {
+				t.Errorf("expected condition status %q, got %q", expectedStatus, condition.Status)
+			}
+			if condition.Message != tc.expectedMessage {
+				t.Errorf("expected condition message %q, got %q", tc.expectedMessage, condition.Message)
+			}
+		})
+	}
+}
+
+func TestIsReady(t *testing.T) {
+	established := &metav1unstructured.Unstructured{}
+	established.SetGroupVersionKind(crdGVK)
+	established.SetName("ciliumnetworkpolicies.cilium.io")
+	if err := metav1unstructured.SetNestedSlice(established.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions"); err != nil {
+		t.Fatalf("failed to set conditions: %v", err)
+	}
+
+	client := fakectrlruntimeclient.NewClientBuilder().WithObjects(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "ready"},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "rolling-out"},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 2},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "ready"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "starting"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 1},
+		},
+		established,
+	).Build()
+
+	testCases := []struct {
+		check    kubermaticv1.AddonReadinessCheck
+		expected bool
+	}{
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckDeployment, Namespace: "kube-system", Name: "ready"}, expected: true},
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckDeployment, Namespace: "kube-system", Name: "rolling-out"}, expected: false},
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckDeployment, Namespace: "kube-system", Name: "missing"}, expected: false},
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckDaemonSet, Namespace: "kube-system", Name: "ready"}, expected: true},
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckDaemonSet, Namespace: "kube-system", Name: "starting"}, expected: false},
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckCustomResourceDefinition, Name: "ciliumnetworkpolicies.cilium.io"}, expected: true},
+		{check: kubermaticv1.AddonReadinessCheck{Kind: kubermaticv1.AddonReadinessCheckCustomResourceDefinition, Name: "missing.cilium.io"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(formatReadinessCheck(tc.check), func(t *testing.T) {
+			ready, err := isReady(context.Background(), client, tc.check)
+			if err != nil {
+				t.Fatalf("failed to check readiness: %v", err)
+			}
+			if ready != tc.expected {
+				t.Errorf("expected ready to be %v, got %v", tc.expected, ready)
+			}
+		})
+	}
+}
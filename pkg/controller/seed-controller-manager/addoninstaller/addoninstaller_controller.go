@@ -25,6 +25,7 @@ import (
 
 	"go.uber.org/zap"
 
+	addonutils "k8c.io/kubermatic/v2/pkg/addon"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/defaults"
@@ -238,15 +239,28 @@ func getDefaultAddonManifests() (*kubermaticv1.AddonList, error) {
 
 func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) error {
 	ensuredAddonsMap := sets.NewString()
-	creators := []reconciling.NamedKubermaticV1AddonCreatorGetter{}
+	skippedAddons := sets.NewString()
+	ensuredAddons := []kubermaticv1.Addon{}
 
 	for i, addon := range addons.Items {
 		if skipAddonInstallation(addon, cluster) {
+			skippedAddons.Insert(addon.Name)
 			continue
 		}
 
 		ensuredAddonsMap.Insert(addon.Name)
-		creators = append(creators, r.addonCreator(ctx, cluster, addons.Items[i]))
+		ensuredAddons = append(ensuredAddons, addons.Items[i])
+	}
+
+	// create the addons in the order of their requirements, so that required addons are installed first
+	ensuredAddons, err := addonutils.SortAddons(ensuredAddons)
+	if err != nil {
+		return fmt.Errorf("failed to determine installation order of addons: %w", err)
+	}
+
+	creators := []reconciling.NamedKubermaticV1AddonCreatorGetter{}
+	for _, addon := range ensuredAddons {
+		creators = append(creators, r.addonCreator(ctx, cluster, addon, skippedAddons))
 	}
 
 	if err := reconciling.ReconcileKubermaticV1Addons(ctx, creators, cluster.Status.NamespaceName, r); err != nil {
@@ -269,7 +283,7 @@ func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, c
 	return nil
 }
 
-func (r *Reconciler) addonCreator(ctx context.Context, cluster *kubermaticv1.Cluster, addon kubermaticv1.Addon) reconciling.NamedKubermaticV1AddonCreatorGetter {
+func (r *Reconciler) addonCreator(ctx context.Context, cluster *kubermaticv1.Cluster, addon kubermaticv1.Addon, skippedAddons sets.String) reconciling.NamedKubermaticV1AddonCreatorGetter {
 	return func() (name string, create reconciling.KubermaticV1AddonCreator) {
 		return addon.Name, func(existing *kubermaticv1.Addon) (*kubermaticv1.Addon, error) {
 			existing.Labels = addon.Labels
@@ -278,6 +292,15 @@ func (r *Reconciler) addonCreator(ctx context.Context, cluster *kubermaticv1.Clu
 			existing.Spec.IsDefault = true
 			existing.Spec.Variables = addon.Spec.Variables
 			existing.Spec.RequiredResourceTypes = addon.Spec.RequiredResourceTypes
+			existing.Spec.ReadinessChecks = addon.Spec.ReadinessChecks
+
+			// addons that are not installed for this cluster (e.g. kube-proxy in eBPF mode) can not be required
+			existing.Spec.RequiredAddons = nil
+			for _, required := range addon.Spec.RequiredAddons {
+				if !skippedAddons.Has(required) {
+					existing.Spec.RequiredAddons = append(existing.Spec.RequiredAddons, required)
+				}
+			}
 			existing.Spec.Name = addon.Name
 			existing.Spec.Cluster = corev1.ObjectReference{
 				APIVersion: cluster.APIVersion,
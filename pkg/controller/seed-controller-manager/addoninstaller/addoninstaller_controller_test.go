@@ -25,6 +25,7 @@ import (
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/test/diff"
 
 	corev1 "k8s.io/api/core/v1"
@@ -380,3 +381,95 @@ func TestUpdateAddon(t *testing.T) {
 		})
 	}
 }
+
+func TestAddonRequirements(t *testing.T) {
+	name := "test-cluster"
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				ProxyMode: resources.EBPFProxyMode,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: kubermaticv1.HealthStatusUp,
+			},
+			NamespaceName: kubernetes.NamespaceName(name),
+		},
+	}
+
+	newAddon := func(name string, requires ...string) kubermaticv1.Addon {
+		return kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubermaticv1.AddonSpec{RequiredAddons: requires},
+		}
+	}
+
+	tests := []struct {
+		name                   string
+		addons                 []kubermaticv1.Addon
+		expectedRequiredAddons map[string][]string
+		expectedError          bool
+	}{
+		{
+			name: "requirements on skipped addons are removed",
+			addons: []kubermaticv1.Addon{
+				newAddon("hubble", "cilium"),
+				newAddon("cilium", "kube-proxy"),
+				newAddon(kubeProxyAddonName),
+			},
+			expectedRequiredAddons: map[string][]string{
+				"hubble": {"cilium"},
+				"cilium": nil,
+			},
+		},
+		{
+			name: "cyclic requirements are rejected",
+			addons: []kubermaticv1.Addon{
+				newAddon("hubble", "cilium"),
+				newAddon("cilium", "hubble"),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := ctrlruntimefakeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(cluster).
+				Build()
+
+			config := createKubermaticConfiguration(kubermaticv1.AddonList{Items: test.addons})
+			configGetter, err := provider.StaticKubermaticConfigurationGetterFactory(config)
+			if err != nil {
+				t.Fatalf("Failed to create Config Getter: %v", err)
+			}
+
+			reconciler := Reconciler{
+				log:          kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:       client,
+				configGetter: configGetter,
+			}
+
+			_, err = reconciler.reconcile(context.Background(), reconciler.log, cluster)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("Expected error: %v, got %v", test.expectedError, err)
+			}
+
+			for addonName, expected := range test.expectedRequiredAddons {
+				addon := &kubermaticv1.Addon{}
+				if err := client.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: addonName}, addon); err != nil {
+					t.Fatalf("Did not find expected addon %q", addonName)
+				}
+				if d := diff.ObjectDiff(expected, addon.Spec.RequiredAddons); d != "" {
+					t.Errorf("Addon %q has unexpected requirements:\n%v", addonName, d)
+				}
+			}
+		})
+	}
+}
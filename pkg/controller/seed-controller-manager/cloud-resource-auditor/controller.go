@@ -0,0 +1,344 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudresourceauditor
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud"
+
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kkp-cloud-resource-auditor"
+
+	// defaultInterval is the time between two audits of a datacenter
+	// if the Seed does not configure an interval.
+	defaultInterval = 6 * time.Hour
+)
+
+// providerGetter returns the cloud provider for a datacenter.
+type providerGetter func(*kubermaticv1.Datacenter, provider.SecretKeySelectorValueFunc, *x509.CertPool) (provider.CloudProvider, error)
+
+// datacenterState is what the controller remembers about the last audit of a datacenter.
+type datacenterState struct {
+	lastAudit time.Time
+	// orphans are the orphaned resources found in the last audit, keyed by kind and ID.
+	orphans sets.String
+	// providerName and kinds are the labels that metrics were reported for.
+	providerName string
+	kinds        sets.String
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log            *zap.SugaredLogger
+	workerName     string
+	seedGetter     provider.SeedGetter
+	caBundle       *x509.CertPool
+	providerGetter providerGetter
+
+	lock  sync.Mutex
+	state map[string]*datacenterState
+}
+
+// Add creates a new cloud resource auditor controller. It reconciles the datacenters of the
+// Seed, the name of each request being the datacenter name.
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	workerName string,
+	namespace string,
+	seedName string,
+	seedGetter provider.SeedGetter,
+	caBundle *x509.CertPool,
+) error {
+	reconciler := &Reconciler{
+		Client:         mgr.GetClient(),
+		log:            log.Named(ControllerName),
+		workerName:     workerName,
+		seedGetter:     seedGetter,
+		caBundle:       caBundle,
+		providerGetter: cloud.Provider,
+		state:          map[string]*datacenterState{},
+	}
+
+	// audits can take a long time and cause lots of API calls, so
+	// datacenters are processed one at a time
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %w", err)
+	}
+
+	enqueueDatacenters := handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
+		seed, ok := o.(*kubermaticv1.Seed)
+		if !ok {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for name := range seed.Spec.Datacenters {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+		}
+
+		return requests
+	})
+
+	if err := c.Watch(
+		&source.Kind{Type: &kubermaticv1.Seed{}},
+		enqueueDatacenters,
+		predicateutil.ByNamespace(namespace),
+		predicateutil.ByName(seedName),
+	); err != nil {
+		return fmt.Errorf("failed to create watch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("datacenter", request.Name)
+	log.Debug("Reconciling")
+
+	// If the seed-ctrl-mgr is itself controlled by a worker, it only sees
+	// a subset of all clusters and would consider all others orphaned.
+	if r.workerName != "" {
+		return reconcile.Result{}, nil
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	datacenter, exists := seed.Spec.Datacenters[request.Name]
+	settings := seed.Spec.CloudResourceAudit
+	if !exists || (settings != nil && settings.Disable) {
+		r.forget(request.Name)
+		return reconcile.Result{}, nil
+	}
+
+	interval := defaultInterval
+	if settings != nil && settings.Interval != nil && settings.Interval.Duration > 0 {
+		interval = settings.Interval.Duration
+	}
+
+	// the Seed was changed, but the last audit is too recent
+	if wait := r.timeUntilNextAudit(request.Name, interval); wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	deleteOrphans := settings != nil && settings.DeleteOrphanedResources
+
+	if err := r.audit(ctx, log, request.Name, &datacenter, deleteOrphans); err != nil {
+		log.Errorw("Audit failed", zap.Error(err))
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// audit lists the resources of all clusters in the given datacenter and reports
+// or deletes those that belong to clusters that do not exist anymore. The Seed only
+// knows its own clusters, so resources that are not marked with the datacenter are
+// never considered, as they might belong to a cluster of another Seed.
+func (r *Reconciler) audit(ctx context.Context, log *zap.SugaredLogger, datacenterName string, datacenter *kubermaticv1.Datacenter, deleteOrphans bool) error {
+	prov, err := r.providerGetter(datacenter.DeepCopy(), r.makeGlobalSecretKeySelectorValue(ctx), r.caBundle)
+	if err != nil {
+		return fmt.Errorf("failed to create cloud provider: %w", err)
+	}
+
+	auditor, ok := prov.(provider.CloudResourceAuditor)
+	if !ok {
+		log.Debug("Cloud provider does not support resource audits, skipping")
+		r.forget(datacenterName)
+		return nil
+	}
+
+	providerName, err := provider.DatacenterCloudProviderName(&datacenter.Spec)
+	if err != nil {
+		return err
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	// Credentials are usually configured per project, so the resources are listed
+	// with the cloud spec of one cluster per project.
+	existingClusters := sets.NewString()
+	cloudSpecs := map[string]kubermaticv1.CloudSpec{}
+	for _, cluster := range clusters.Items {
+		existingClusters.Insert(cluster.Name)
+
+		if cluster.Spec.Cloud.DatacenterName != datacenterName || cluster.DeletionTimestamp != nil {
+			continue
+		}
+
+		project := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+		if _, ok := cloudSpecs[project]; !ok {
+			cloudSpecs[project] = cluster.Spec.Cloud
+		}
+	}
+
+	var (
+		errs       []error
+		orphans    []orphanedResource
+		orphanKeys = sets.NewString()
+	)
+
+	for _, project := range sets.StringKeySet(cloudSpecs).List() {
+		spec := cloudSpecs[project]
+
+		resources, err := auditor.ListClusterResources(ctx, spec)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list resources with credentials of project %s: %w", project, err))
+			continue
+		}
+
+		for _, resource := range resources {
+			key := resourceKey(resource)
+			if resource.Datacenter != datacenterName || existingClusters.Has(resource.ClusterName) || orphanKeys.Has(key) {
+				continue
+			}
+
+			orphanKeys.Insert(key)
+			orphans = append(orphans, orphanedResource{resource: resource, spec: spec})
+		}
+	}
+
+	r.lock.Lock()
+	state, ok := r.state[datacenterName]
+	if !ok {
+		state = &datacenterState{orphans: sets.NewString(), kinds: sets.NewString()}
+		r.state[datacenterName] = state
+	}
+	previousOrphans := state.orphans
+	r.lock.Unlock()
+
+	for _, orphan := range orphans {
+		resource := orphan.resource
+		resourceLog := log.With("kind", resource.Kind, "id", resource.ID, "name", resource.Name, "cluster", resource.ClusterName)
+
+		// A resource is only deleted once it was found to be orphaned in two consecutive
+		// audits, to not race with clusters that are just being created.
+		if !deleteOrphans || !previousOrphans.Has(resourceKey(resource)) {
+			resourceLog.Info("Found orphaned cloud resource")
+			continue
+		}
+
+		if err := auditor.DeleteClusterResource(ctx, orphan.spec, resource); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s %s: %w", resource.Kind, resource.ID, err))
+			continue
+		}
+
+		resourceLog.Info("Deleted orphaned cloud resource")
+		deletedResources.WithLabelValues(datacenterName, providerName, resource.Kind).Inc()
+		orphanKeys.Delete(resourceKey(resource))
+	}
+
+	// report the resources that are left over
+	counts := map[string]int{}
+	for _, orphan := range orphans {
+		if orphanKeys.Has(resourceKey(orphan.resource)) {
+			counts[orphan.resource.Kind]++
+		}
+	}
+
+	for kind, count := range counts {
+		orphanedResources.WithLabelValues(datacenterName, providerName, kind).Set(float64(count))
+	}
+	for _, kind := range state.kinds.List() {
+		if _, ok := counts[kind]; !ok {
+			orphanedResources.WithLabelValues(datacenterName, providerName, kind).Set(0)
+		}
+	}
+
+	r.lock.Lock()
+	state.lastAudit = time.Now()
+	state.orphans = orphanKeys
+	state.providerName = providerName
+	state.kinds = state.kinds.Union(sets.StringKeySet(counts))
+	r.lock.Unlock()
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (r *Reconciler) timeUntilNextAudit(datacenterName string, interval time.Duration) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	state, ok := r.state[datacenterName]
+	if !ok {
+		return 0
+	}
+
+	return time.Until(state.lastAudit.Add(interval))
+}
+
+// forget removes the state and metrics of a datacenter that is not audited anymore.
+func (r *Reconciler) forget(datacenterName string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	state, ok := r.state[datacenterName]
+	if !ok {
+		return
+	}
+
+	for _, kind := range state.kinds.List() {
+		orphanedResources.DeleteLabelValues(datacenterName, state.providerName, kind)
+	}
+	delete(r.state, datacenterName)
+}
+
+func (r *Reconciler) makeGlobalSecretKeySelectorValue(ctx context.Context) provider.SecretKeySelectorValueFunc {
+	return func(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
+		return provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)(configVar, key)
+	}
+}
+
+type orphanedResource struct {
+	resource provider.CloudResource
+	// spec is the cloud spec whose credentials were used to find the resource.
+	spec kubermaticv1.CloudSpec
+}
+
+func resourceKey(resource provider.CloudResource) string {
+	return fmt.Sprintf("%s/%s", resource.Kind, resource.ID)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudresourceauditor
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const datacenterName = "hetzner-fsn1"
+
+type fakeAuditor struct {
+	// the embedded interface is nil, only the auditor methods are implemented
+	provider.CloudProvider
+
+	resources []provider.CloudResource
+	deleted   sets.String
+}
+
+func (f *fakeAuditor) ListClusterResources(_ context.Context, _ kubermaticv1.CloudSpec) ([]provider.CloudResource, error) {
+	var result []provider.CloudResource
+	for _, resource := range f.resources {
+		if !f.deleted.Has(resource.ID) {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAuditor) DeleteClusterResource(_ context.Context, _ kubermaticv1.CloudSpec, resource provider.CloudResource) error {
+	f.deleted.Insert(resource.ID)
+	return nil
+}
+
+func genCluster(name string, project string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: project,
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				DatacenterName: datacenterName,
+				Hetzner:        &kubermaticv1.HetznerCloudSpec{},
+			},
+		},
+	}
+}
+
+func TestAudit(t *testing.T) {
+	testCases := []struct {
+		name            string
+		deleteOrphans   bool
+		expectedDeleted []string
+		expectedOrphans float64
+	}{
+		{
+			name:            "orphaned resources are only reported",
+			deleteOrphans:   false,
+			expectedOrphans: 1,
+		},
+		{
+			name:            "orphaned resources are deleted in the second audit",
+			deleteOrphans:   true,
+			expectedDeleted: []string{"network-2"},
+			expectedOrphans: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			auditor := &fakeAuditor{
+				resources: []provider.CloudResource{
+					{Kind: "network", ID: "network-1", ClusterName: "existing", Datacenter: datacenterName},
+					{Kind: "network", ID: "network-2", ClusterName: "deleted", Datacenter: datacenterName},
+					// clusters of other Seeds that share the cloud account are unknown to this Seed
+					{Kind: "network", ID: "network-3", ClusterName: "foreign", Datacenter: "hetzner-nbg1"},
+					{Kind: "network", ID: "network-4", ClusterName: "unmarked"},
+				},
+				deleted: sets.NewString(),
+			}
+
+			r := &Reconciler{
+				Client: fakectrlruntimeclient.
+					NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(genCluster("existing", "project")).
+					Build(),
+				log: zap.NewNop().Sugar(),
+				providerGetter: func(*kubermaticv1.Datacenter, provider.SecretKeySelectorValueFunc, *x509.CertPool) (provider.CloudProvider, error) {
+					return auditor, nil
+				},
+				state: map[string]*datacenterState{},
+			}
+
+			datacenter := &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{
+					Hetzner: &kubermaticv1.DatacenterSpecHetzner{},
+				},
+			}
+			metric := orphanedResources.WithLabelValues(datacenterName, "hetzner", "network")
+
+			if err := r.audit(ctx, r.log, datacenterName, datacenter, tc.deleteOrphans); err != nil {
+				t.Fatalf("First audit failed: %v", err)
+			}
+
+			if auditor.deleted.Len() > 0 {
+				t.Fatalf("Expected no resources to be deleted in the first audit, but got %v", auditor.deleted.List())
+			}
+			if value := testutil.ToFloat64(metric); value != 1 {
+				t.Fatalf("Expected 1 orphaned resource to be reported, but got %v", value)
+			}
+
+			if err := r.audit(ctx, r.log, datacenterName, datacenter, tc.deleteOrphans); err != nil {
+				t.Fatalf("Second audit failed: %v", err)
+			}
+
+			if expected := sets.NewString(tc.expectedDeleted...); !expected.Equal(auditor.deleted) {
+				t.Fatalf("Expected %v to be deleted, but got %v", expected.List(), auditor.deleted.List())
+			}
+
+			if value := testutil.ToFloat64(metric); value != tc.expectedOrphans {
+				t.Fatalf("Expected %v orphaned resources to be reported, but got %v", tc.expectedOrphans, value)
+			}
+
+			r.forget(datacenterName)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package cloudresourceauditor contains a controller that periodically lists the
+resources that cloud providers created for clusters, like security groups or
+networks, and reports those whose Cluster object no longer exists on this seed.
+This happens when cleanup finalizers are removed by force or the
+seed-controller-manager crashes during a cluster deletion.
+
+Only providers implementing provider.CloudResourceAuditor are audited, and they
+only report resources carrying an explicit KKP ownership tag or marker; resources
+are never recognized by their name alone. Orphaned
+resources are exposed as metrics and, if enabled in the Seed, deleted once they
+were found to be orphaned in two consecutive audits.
+*/
+package cloudresourceauditor
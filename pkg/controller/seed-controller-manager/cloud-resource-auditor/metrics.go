@@ -0,0 +1,40 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudresourceauditor
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	orphanedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "cloud_resource_auditor",
+		Name:      "orphaned_resources",
+		Help:      "The number of cloud resources that belong to clusters which no longer exist",
+	}, []string{"datacenter", "provider", "kind"})
+
+	deletedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "cloud_resource_auditor",
+		Name:      "deleted_resources_total",
+		Help:      "The total number of orphaned cloud resources that were deleted",
+	}, []string{"datacenter", "provider", "kind"})
+)
+
+func MustRegisterMetrics(c prometheus.Registerer) {
+	c.MustRegister(orphanedResources)
+	c.MustRegister(deletedResources)
+}
@@ -599,6 +599,11 @@ func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, backupCon
 		return nil, nil
 	}
 
+	// retained backups are left in the backup destination once their backupConfig is deleted
+	if backupConfig.DeletionTimestamp != nil && backupConfig.RetainsBackups() {
+		return nil, nil
+	}
+
 	var backupsToDelete []*kubermaticv1.BackupStatus
 	keepCount := backupConfig.GetKeptBackupsCount()
 	if backupConfig.DeletionTimestamp != nil {
@@ -838,7 +843,12 @@ func (r *Reconciler) deleteFinishedBackupJobs(ctx context.Context, log *zap.Suga
 }
 
 func (r *Reconciler) handleFinalization(ctx context.Context, backupConfig *kubermaticv1.EtcdBackupConfig) (*reconcile.Result, error) {
-	if backupConfig.DeletionTimestamp == nil || len(backupConfig.Status.CurrentBackups) > 0 {
+	if backupConfig.DeletionTimestamp == nil {
+		return nil, nil
+	}
+
+	// retained backups are never deleted, so there is nothing to wait for
+	if len(backupConfig.Status.CurrentBackups) > 0 && !backupConfig.RetainsBackups() {
 		return nil, nil
 	}
 
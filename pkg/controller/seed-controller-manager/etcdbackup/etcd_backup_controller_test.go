@@ -679,6 +679,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 		name              string
 		currentTime       time.Time
 		keep              int
+		retainBackups     bool
 		existingBackups   []kubermaticv1.BackupStatus
 		existingJobs      []batchv1.Job
 		expectedBackups   []kubermaticv1.BackupStatus
@@ -889,6 +890,38 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 			expectedReconcile: nil,
 			expectedJobs:      nil,
 		},
+		{
+			name:          "retained backups are not deleted when the config is deleted",
+			currentTime:   time.Unix(240, 0).UTC(),
+			keep:          1,
+			retainBackups: true,
+			existingBackups: []kubermaticv1.BackupStatus{
+				{
+					ScheduledTime:      metav1.NewTime(time.Unix(60, 0).UTC()),
+					BackupName:         "testbackup-1970-01-01t00-01-00.db",
+					JobName:            "testcluster-backup-testbackup-create-aaaa",
+					BackupFinishedTime: metav1.NewTime(time.Unix(90, 0).UTC()),
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					BackupMessage:      "job completed",
+					DeleteJobName:      "testcluster-backup-testbackup-delete-aaaa",
+				},
+			},
+			existingJobs: []batchv1.Job{},
+			expectedBackups: []kubermaticv1.BackupStatus{
+				// unchanged
+				{
+					ScheduledTime:      metav1.NewTime(time.Unix(60, 0).UTC()),
+					BackupName:         "testbackup-1970-01-01t00-01-00.db",
+					JobName:            "testcluster-backup-testbackup-create-aaaa",
+					BackupFinishedTime: metav1.NewTime(time.Unix(90, 0).UTC()),
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					BackupMessage:      "job completed",
+					DeleteJobName:      "testcluster-backup-testbackup-delete-aaaa",
+				},
+			},
+			expectedReconcile: nil,
+			expectedJobs:      nil,
+		},
 		{
 			name:        "not more than maxSimultaneousDeleteJobsPerConfig delete jobs are started",
 			currentTime: time.Unix(400, 0).UTC(),
@@ -938,6 +971,11 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 			backupConfig.Spec.Schedule = "xxx" // must be non-empty
 			backupConfig.Spec.Keep = intPtr(tc.keep)
 			backupConfig.Status.CurrentBackups = tc.existingBackups
+			if tc.retainBackups {
+				backupConfig.Annotations = map[string]string{kubermaticv1.EtcdBackupConfigRetainBackupsAnnotation: "true"}
+				backupConfig.Finalizers = []string{DeleteAllBackupsFinalizer}
+				backupConfig.DeletionTimestamp = &metav1.Time{Time: clock.Now()}
+			}
 
 			initObjs := []ctrlruntimeclient.Object{
 				cluster,
@@ -34,9 +34,11 @@ import (
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/validation"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -134,6 +136,22 @@ func newAlertmanagerReconciler(
 	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, enqueueClusterForSecret); err != nil {
 		return fmt.Errorf("failed to watch Secret: %w", err)
 	}
+
+	enqueueClustersForProjectAlertmanager := handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		clusterList := &kubermaticv1.ClusterList{}
+		if err := client.List(context.Background(), clusterList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: a.GetName()}); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list Clusters: %w", err))
+			return []reconcile.Request{}
+		}
+		var requests []reconcile.Request
+		for _, cluster := range clusterList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}})
+		}
+		return requests
+	})
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.ProjectAlertmanager{}}, enqueueClustersForProjectAlertmanager); err != nil {
+		return fmt.Errorf("failed to watch ProjectAlertmanager: %w", err)
+	}
 	return err
 }
 
@@ -246,6 +264,9 @@ func (r *alertmanagerController) handleDeletion(ctx context.Context, cluster *ku
 		if err != nil {
 			return fmt.Errorf("failed to delete alertmanager configuration: %w", err)
 		}
+		if err := r.ensureProjectAlertmanagerStatus(ctx, cluster, false, nil); err != nil {
+			return fmt.Errorf("failed to update projectalertmanager status: %w", err)
+		}
 		if cluster.DeletionTimestamp.IsZero() {
 			// if cluster is still there we need to delete objects manually
 			err := r.cleanUpAlertmanagerObjects(ctx, cluster)
@@ -332,6 +353,11 @@ func (r *alertmanagerController) ensureAlertmanagerConfiguration(ctx context.Con
 		return fmt.Errorf("failed to get alertmanager config: %w", err)
 	}
 
+	// reject invalid configurations right away instead of waiting for Cortex to refuse them
+	if err := validation.ValidateAlertmanagerConfig(config); err != nil {
+		return fmt.Errorf("invalid alertmanager config: %w", err)
+	}
+
 	alertmanagerURL := r.cortexAlertmanagerURL + AlertmanagerConfigEndpoint
 	currentConfig, err := r.getCurrentAlertmanagerConfig(ctx, alertmanagerURL, cluster)
 	if err != nil {
@@ -430,9 +456,103 @@ func (r *alertmanagerController) getAlertmanagerConfigForCluster(ctx context.Con
 			return nil, fmt.Errorf("failed to create or update alertmanager config secret: %w", err)
 		}
 	}
+
+	// the configuration of the project takes precedence over the one of the cluster
+	projectAlertmanager, err := r.getProjectAlertmanagerForCluster(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if projectAlertmanager != nil {
+		if config, enabled := projectAlertmanagerConfigForCluster(projectAlertmanager, cluster.Name); enabled {
+			return config, nil
+		}
+	}
 	return secret.Data[resources.AlertmanagerConfigSecretKey], nil
 }
 
+// getProjectAlertmanagerForCluster returns the ProjectAlertmanager of the cluster's project,
+// or nil if there is none.
+func (r *alertmanagerController) getProjectAlertmanagerForCluster(ctx context.Context, cluster *kubermaticv1.Cluster) (*kubermaticv1.ProjectAlertmanager, error) {
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" {
+		return nil, nil
+	}
+	projectAlertmanager := &kubermaticv1.ProjectAlertmanager{}
+	if err := r.Get(ctx, types.NamespacedName{Name: projectID}, projectAlertmanager); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get projectalertmanager: %w", err)
+	}
+	if !projectAlertmanager.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	return projectAlertmanager, nil
+}
+
+// projectAlertmanagerConfigForCluster returns the Alertmanager configuration for the given cluster,
+// taking the cluster overrides into account. The second return value is false if the cluster
+// opted out of the project configuration.
+func projectAlertmanagerConfigForCluster(projectAlertmanager *kubermaticv1.ProjectAlertmanager, clusterName string) ([]byte, bool) {
+	for _, override := range projectAlertmanager.Spec.ClusterOverrides {
+		if override.ClusterName != clusterName {
+			continue
+		}
+		if override.Disabled {
+			return nil, false
+		}
+		if len(override.Config) > 0 {
+			return override.Config, true
+		}
+	}
+	return projectAlertmanager.Spec.Config, true
+}
+
+// ensureProjectAlertmanagerStatus records the sync status of the cluster in the ProjectAlertmanager
+// of its project. The status is rebuilt from the current clusters of the project, so that entries
+// of deleted clusters, clusters that moved to another project or disabled MLA and clusters that
+// opted out of the project configuration are removed.
+func (r *alertmanagerController) ensureProjectAlertmanagerStatus(ctx context.Context, cluster *kubermaticv1.Cluster, synced bool, configErr error) error {
+	projectAlertmanager, err := r.getProjectAlertmanagerForCluster(ctx, cluster)
+	if err != nil || projectAlertmanager == nil {
+		return err
+	}
+	oldProjectAlertmanager := projectAlertmanager.DeepCopy()
+
+	clusterList := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusterList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: projectAlertmanager.Name}); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterStatus := map[string]kubermaticv1.MLAClusterSyncStatus{}
+	for _, projectCluster := range clusterList.Items {
+		mlaEnabled := projectCluster.Spec.MLA != nil && (projectCluster.Spec.MLA.MonitoringEnabled || projectCluster.Spec.MLA.LoggingEnabled)
+		if _, enabled := projectAlertmanagerConfigForCluster(projectAlertmanager, projectCluster.Name); !enabled || !mlaEnabled || !projectCluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		oldStatus, ok := oldProjectAlertmanager.Status.Clusters[projectCluster.Name]
+		switch {
+		case projectCluster.Name == cluster.Name:
+			if synced {
+				clusterStatus[projectCluster.Name] = newMLAClusterSyncStatus(oldStatus, configErr)
+			}
+		case ok:
+			clusterStatus[projectCluster.Name] = oldStatus
+		}
+	}
+
+	projectAlertmanager.Status.Clusters = clusterStatus
+	if len(clusterStatus) == 0 {
+		projectAlertmanager.Status.Clusters = nil
+	}
+
+	if equality.Semantic.DeepEqual(oldProjectAlertmanager.Status, projectAlertmanager.Status) {
+		return nil
+	}
+	return r.Client.Status().Patch(ctx, projectAlertmanager, ctrlruntimeclient.MergeFrom(oldProjectAlertmanager))
+}
+
 func (r *alertmanagerController) getCurrentAlertmanagerConfig(ctx context.Context, alertmanagerURL string, cluster *kubermaticv1.Cluster) (map[string]interface{}, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, alertmanagerURL, nil)
 	if err != nil {
@@ -499,5 +619,9 @@ func (r *alertmanagerController) ensureAlertManagerConfigStatus(ctx context.Cont
 		return fmt.Errorf("error patching cluster health status: %w", err)
 	}
 
+	if err := r.ensureProjectAlertmanagerStatus(ctx, cluster, true, configErr); err != nil {
+		return fmt.Errorf("error patching projectalertmanager status: %w", err)
+	}
+
 	return nil
 }
@@ -192,6 +192,49 @@ func TestAlertmanagerReconcile(t *testing.T) {
 			expectedErr:                      true,
 			expectedAlertmanagerConfigStatus: getAlertmanagerConfigStatusDown(),
 		},
+		{
+			name:        "reject invalid alertmanager configuration without sending it to cortex",
+			requestName: "test",
+			objects: []ctrlruntimeclient.Object{
+				generateCluster("test", true, false, false),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "config-secret",
+						Namespace: "cluster-test",
+					},
+					Data: map[string][]byte{
+						resources.AlertmanagerConfigSecretKey: []byte(`
+alertmanager_config: |
+  route:
+    receiver: "missing"
+  receivers:
+    - name: "test"
+`),
+					},
+				},
+				&kubermaticv1.Alertmanager{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.AlertmanagerName,
+						Namespace: "cluster-test",
+					},
+					Spec: kubermaticv1.AlertmanagerSpec{
+						ConfigSecret: corev1.LocalObjectReference{
+							Name: "config-secret",
+						},
+					},
+				},
+			},
+			hasFinalizer: true,
+			hasResources: true,
+			expectedErr:  true,
+			expectedAlertmanagerConfigStatus: alertmanagerConfigStatus{
+				clusterStatus: kubermaticv1.HealthStatusDown,
+				alertmanagerStatus: kubermaticv1.AlertmanagerConfigurationStatus{
+					Status:       corev1.ConditionFalse,
+					ErrorMessage: "invalid alertmanager config: invalid alertmanager_config: undefined receiver \"missing\" used in route",
+				},
+			},
+		},
 		{
 			name:        "clean up alertmanager configuration when mla is disabled",
 			requestName: "test",
@@ -344,6 +387,141 @@ func TestAlertmanagerReconcile(t *testing.T) {
 	}
 }
 
+func TestProjectAlertmanagerReconcile(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name                string
+		projectAlertmanager *kubermaticv1.ProjectAlertmanager
+		expectedConfig      string
+		expectedSyncStatus  *corev1.ConditionStatus
+	}{
+		{
+			name: "use project alertmanager configuration",
+			projectAlertmanager: generateProjectAlertmanager("my-project", generateAlertmanagerConfig("project-user"),
+				nil),
+			expectedConfig:     generateAlertmanagerConfig("project-user"),
+			expectedSyncStatus: conditionStatusPtr(corev1.ConditionTrue),
+		},
+		{
+			name: "use cluster override of project alertmanager configuration",
+			projectAlertmanager: generateProjectAlertmanager("my-project", generateAlertmanagerConfig("project-user"),
+				[]kubermaticv1.ProjectAlertmanagerClusterOverride{
+					{
+						ClusterName: "test",
+						Config:      []byte(generateAlertmanagerConfig("override-user")),
+					},
+				}),
+			expectedConfig:     generateAlertmanagerConfig("override-user"),
+			expectedSyncStatus: conditionStatusPtr(corev1.ConditionTrue),
+		},
+		{
+			name: "use cluster configuration if cluster is opted out of project alertmanager",
+			projectAlertmanager: generateProjectAlertmanager("my-project", generateAlertmanagerConfig("project-user"),
+				[]kubermaticv1.ProjectAlertmanagerClusterOverride{
+					{
+						ClusterName: "test",
+						Disabled:    true,
+					},
+				}),
+			expectedConfig: generateAlertmanagerConfig("cluster-user"),
+		},
+		{
+			name:                "use cluster configuration if project alertmanager belongs to other project",
+			projectAlertmanager: generateProjectAlertmanager("other-project", generateAlertmanagerConfig("project-user"), nil),
+			expectedConfig:      generateAlertmanagerConfig("cluster-user"),
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			cluster := generateCluster("test", true, false, false)
+			cluster.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"}
+			// the status of clusters that no longer belong to the project must be removed
+			testcase.projectAlertmanager.Status.Clusters = map[string]kubermaticv1.MLAClusterSyncStatus{
+				"deleted": {Status: corev1.ConditionTrue},
+			}
+			objects := []ctrlruntimeclient.Object{
+				cluster,
+				testcase.projectAlertmanager,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "config-secret",
+						Namespace: "cluster-test",
+					},
+					Data: map[string][]byte{
+						resources.AlertmanagerConfigSecretKey: []byte(generateAlertmanagerConfig("cluster-user")),
+					},
+				},
+				&kubermaticv1.Alertmanager{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.AlertmanagerName,
+						Namespace: "cluster-test",
+					},
+					Spec: kubermaticv1.AlertmanagerSpec{
+						ConfigSecret: corev1.LocalObjectReference{
+							Name: "config-secret",
+						},
+					},
+				},
+			}
+			r, assertExpectation := buildTestServer(t,
+				request{
+					name:     "get",
+					request:  httptest.NewRequest(http.MethodGet, AlertmanagerConfigEndpoint, nil),
+					response: &http.Response{StatusCode: http.StatusNotFound},
+				},
+				request{
+					name: "post",
+					request: httptest.NewRequest(http.MethodPost,
+						AlertmanagerConfigEndpoint,
+						bytes.NewBuffer([]byte(testcase.expectedConfig))),
+					response: &http.Response{StatusCode: http.StatusCreated},
+				},
+			)
+			reconciler, server := newTestAlertmanagerReconciler(objects, r)
+			defer server.Close()
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}}); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+			assertExpectation()
+
+			projectAlertmanager := &kubermaticv1.ProjectAlertmanager{}
+			if err := reconciler.Get(ctx, types.NamespacedName{Name: testcase.projectAlertmanager.Name}, projectAlertmanager); err != nil {
+				t.Fatalf("unable to get projectalertmanager: %v", err)
+			}
+			if projectAlertmanager.Name == "my-project" {
+				assert.NotContains(t, projectAlertmanager.Status.Clusters, "deleted")
+			}
+			syncStatus, ok := projectAlertmanager.Status.Clusters["test"]
+			if testcase.expectedSyncStatus == nil {
+				assert.False(t, ok, "expected no sync status for the cluster")
+				return
+			}
+			assert.True(t, ok, "expected sync status for the cluster")
+			assert.Equal(t, *testcase.expectedSyncStatus, syncStatus.Status)
+			assert.False(t, syncStatus.LastUpdated.IsZero())
+		})
+	}
+}
+
+func generateProjectAlertmanager(projectID string, config string, overrides []kubermaticv1.ProjectAlertmanagerClusterOverride) *kubermaticv1.ProjectAlertmanager {
+	return &kubermaticv1.ProjectAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: projectID,
+		},
+		Spec: kubermaticv1.ProjectAlertmanagerSpec{
+			Config:           []byte(config),
+			ClusterOverrides: overrides,
+		},
+	}
+}
+
+func conditionStatusPtr(status corev1.ConditionStatus) *corev1.ConditionStatus {
+	return &status
+}
+
 func generateCluster(name string, monitoringEnabled, loggingEnabled, deleted bool) *kubermaticv1.Cluster {
 	cluster := &kubermaticv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
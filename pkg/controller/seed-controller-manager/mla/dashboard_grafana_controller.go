@@ -18,9 +18,11 @@ package mla
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -31,18 +33,36 @@ import (
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
 	grafanaDashboardsConfigmapNamePrefix = "grafana-dashboards"
+
+	// GrafanaDashboardProjectLabelKey marks a ConfigMap in the MLA namespace as a set of dashboards
+	// for a single project. The value is the project ID. ConfigMaps with this label are provisioned
+	// only to the Grafana organization of the given project, regardless of their name.
+	GrafanaDashboardProjectLabelKey = "mla.k8c.io/project"
+	// GrafanaDashboardFolderAnnotationKey is the title of the Grafana folder the dashboards of a ConfigMap
+	// are put in. The folder is created if it does not exist yet.
+	GrafanaDashboardFolderAnnotationKey = "mla.k8c.io/dashboard-folder"
+
+	// dashboardResyncInterval is the interval in which dashboards are synced again to revert
+	// any changes made to them in Grafana.
+	dashboardResyncInterval = 10 * time.Minute
 )
 
 // dashboardGrafanaReconciler stores necessary components that are required to manage MLA(Monitoring, Logging, and Alerting) setup.
@@ -88,7 +108,7 @@ func newDashboardGrafanaReconciler(
 	}
 
 	enqueueGrafanaConfigMap := handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
-		if !strings.HasPrefix(a.GetName(), grafanaDashboardsConfigmapNamePrefix) {
+		if !isDashboardConfigMap(a) {
 			return []reconcile.Request{}
 		}
 		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.GetName(), Namespace: a.GetNamespace()}}}
@@ -98,6 +118,26 @@ func newDashboardGrafanaReconciler(
 		return fmt.Errorf("failed to watch ConfigMap: %w", err)
 	}
 
+	// dashboards are synced as soon as the Grafana organization of a project is created
+	enqueueProjectConfigMaps := handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		configMapList := &corev1.ConfigMapList{}
+		if err := client.List(context.Background(), configMapList, ctrlruntimeclient.InNamespace(dashboardGrafanaController.mlaNamespace)); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list configmaps: %w", err))
+			return []reconcile.Request{}
+		}
+		var requests []reconcile.Request
+		for _, configMap := range configMapList.Items {
+			if isDashboardConfigMapForProject(&configMap, a.GetName()) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}})
+			}
+		}
+		return requests
+	})
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Project{}}, enqueueProjectConfigMaps, predicate.AnnotationChangedPredicate{}); err != nil {
+		return fmt.Errorf("failed to watch Project: %w", err)
+	}
+
 	return err
 }
 
@@ -134,7 +174,7 @@ func (r *dashboardGrafanaReconciler) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, fmt.Errorf("failed to ensure Grafana Dashboards: %w", err)
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: dashboardResyncInterval}, nil
 }
 
 type dashboardGrafanaController struct {
@@ -170,7 +210,7 @@ func (r *dashboardGrafanaController) CleanUp(ctx context.Context) error {
 		return fmt.Errorf("failed to create Grafana client: %w", err)
 	}
 	for _, configMap := range configMapList.Items {
-		if !strings.HasPrefix(configMap.GetName(), grafanaDashboardsConfigmapNamePrefix) {
+		if !isDashboardConfigMap(&configMap) {
 			continue
 		}
 		if err := r.handleDeletion(ctx, r.log, &configMap, grafanaClient); err != nil {
@@ -182,24 +222,30 @@ func (r *dashboardGrafanaController) CleanUp(ctx context.Context) error {
 
 func (r *dashboardGrafanaController) handleDeletion(ctx context.Context, log *zap.SugaredLogger, configMap *corev1.ConfigMap, grafanaClient *grafanasdk.Client) error {
 	if grafanaClient != nil {
-		projectList := &kubermaticv1.ProjectList{}
-		if err := r.List(ctx, projectList); err != nil {
-			return fmt.Errorf("failed to list Projects: %w", err)
+		dashboardSync, err := r.getDashboardSync(ctx, configMap)
+		if err != nil {
+			return err
 		}
-		for _, project := range projectList.Items {
-			orgID, ok := project.GetAnnotations()[GrafanaOrgAnnotationKey]
-			if !ok {
-				// looks like corresponding Grafana Org already remove, so we can skip this project
-				log.Debugf("project %+v doesn't have grafana org annotation, skipping", project)
-				continue
-			}
-			id, err := strconv.ParseUint(orgID, 10, 32)
-			if err != nil {
-				return fmt.Errorf("unable to parse grafana org annotation %s: %w", orgID, err)
-			}
-			if err := deleteDashboards(ctx, log, grafanaClient.WithOrgIDHeader(uint(id)), configMap); err != nil {
-				return err
+		projects, err := r.getProjectsForConfigMap(ctx, configMap)
+		if err != nil {
+			return err
+		}
+		states := dashboardSync.Status.Organizations
+		if states == nil {
+			states = map[string]kubermaticv1.GrafanaDashboardsSyncState{}
+		}
+		// dashboards that were synced before their state was recorded are removed based on the ConfigMap data
+		addUnrecordedDashboards(states, projects, configMap.Data)
+
+		states, err = deleteStaleDashboards(ctx, log, r.Client, grafanaClient, states, sets.NewString())
+		if err != nil {
+			if updateErr := r.updateDashboardSync(ctx, configMap, dashboardSync, states); updateErr != nil {
+				return updateErr
 			}
+			return err
+		}
+		if err := r.Delete(ctx, dashboardSync); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete DashboardConfigMapSync: %w", err)
 		}
 	}
 
@@ -207,25 +253,229 @@ func (r *dashboardGrafanaController) handleDeletion(ctx context.Context, log *za
 }
 
 func (r *dashboardGrafanaController) ensureDashboards(ctx context.Context, log *zap.SugaredLogger, configMap *corev1.ConfigMap, grafanaClient *grafanasdk.Client) error {
-	projectList := &kubermaticv1.ProjectList{}
-	if err := r.List(ctx, projectList); err != nil {
-		return fmt.Errorf("failed to list Projects: %w", err)
+	dashboardSync, err := r.getDashboardSync(ctx, configMap)
+	if err != nil {
+		return err
 	}
-	for _, project := range projectList.Items {
+	projects, err := r.getProjectsForConfigMap(ctx, configMap)
+	if err != nil {
+		return err
+	}
+
+	set := dashboardSet{
+		folder:     configMap.GetAnnotations()[GrafanaDashboardFolderAnnotationKey],
+		dashboards: configMap.Data,
+	}
+	states, err := syncDashboardSet(ctx, log, r.Client, grafanaClient, projects, set, dashboardSync.Status.Organizations)
+	// the state is recorded even if the sync failed, so that no dashboard is orphaned
+	if updateErr := r.updateDashboardSync(ctx, configMap, dashboardSync, states); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// getDashboardSync returns the DashboardConfigMapSync of the ConfigMap. If it does not exist yet,
+// an empty one is returned that is created once there is a state to record.
+func (r *dashboardGrafanaController) getDashboardSync(ctx context.Context, configMap *corev1.ConfigMap) (*kubermaticv1.DashboardConfigMapSync, error) {
+	dashboardSync := &kubermaticv1.DashboardConfigMapSync{}
+	if err := r.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, dashboardSync); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get DashboardConfigMapSync: %w", err)
+		}
+		dashboardSync = &kubermaticv1.DashboardConfigMapSync{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            configMap.Name,
+				Namespace:       configMap.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(configMap, corev1.SchemeGroupVersion.WithKind("ConfigMap"))},
+			},
+		}
+	}
+	return dashboardSync, nil
+}
+
+// updateDashboardSync records the synced dashboards in the status of the DashboardConfigMapSync
+// of the ConfigMap, creating it if necessary.
+func (r *dashboardGrafanaController) updateDashboardSync(ctx context.Context, configMap *corev1.ConfigMap, dashboardSync *kubermaticv1.DashboardConfigMapSync, states map[string]kubermaticv1.GrafanaDashboardsSyncState) error {
+	if len(states) == 0 {
+		states = nil
+	}
+	if dashboardSync.ResourceVersion == "" {
+		if states == nil {
+			return nil
+		}
+		if err := r.Create(ctx, dashboardSync); err != nil {
+			return fmt.Errorf("failed to create DashboardConfigMapSync: %w", err)
+		}
+	}
+
+	oldDashboardSync := dashboardSync.DeepCopy()
+	dashboardSync.Status.Organizations = states
+	if equality.Semantic.DeepEqual(oldDashboardSync.Status, dashboardSync.Status) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, dashboardSync, ctrlruntimeclient.MergeFrom(oldDashboardSync)); err != nil {
+		return fmt.Errorf("failed to update DashboardConfigMapSync status: %w", err)
+	}
+	return nil
+}
+
+// addUnrecordedDashboards adds the dashboards of the given data to the state of the Grafana organizations
+// of the projects, if they are not recorded yet.
+func addUnrecordedDashboards(states map[string]kubermaticv1.GrafanaDashboardsSyncState, projects []kubermaticv1.Project, data map[string]string) {
+	for _, project := range projects {
+		orgID, ok := project.GetAnnotations()[GrafanaOrgAnnotationKey]
+		if !ok {
+			continue
+		}
+		state := states[orgID]
+		for _, value := range data {
+			var board grafanasdk.Board
+			if err := json.Unmarshal([]byte(value), &board); err != nil || board.UID == "" {
+				continue
+			}
+			if _, ok := state.Dashboards[board.UID]; ok {
+				continue
+			}
+			if state.Dashboards == nil {
+				state.Dashboards = map[string]kubermaticv1.GrafanaDashboardSyncState{}
+			}
+			state.Dashboards[board.UID] = kubermaticv1.GrafanaDashboardSyncState{}
+		}
+		states[orgID] = state
+	}
+}
+
+// syncDashboardSet syncs the dashboards into the Grafana organizations of the given projects and removes
+// them from the organizations they were synced to before, but no longer belong to. It returns the state
+// of all organizations, which keeps the previous state of organizations that could not be synced.
+func syncDashboardSet(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	client ctrlruntimeclient.Client,
+	grafanaClient *grafanasdk.Client,
+	projects []kubermaticv1.Project,
+	set dashboardSet,
+	states map[string]kubermaticv1.GrafanaDashboardsSyncState,
+) (map[string]kubermaticv1.GrafanaDashboardsSyncState, error) {
+	newStates := make(map[string]kubermaticv1.GrafanaDashboardsSyncState, len(states))
+	for orgID, state := range states {
+		newStates[orgID] = state
+	}
+
+	synced := sets.NewString()
+	for _, project := range projects {
 		orgID, ok := project.GetAnnotations()[GrafanaOrgAnnotationKey]
 		if !ok {
 			// looks like this project doesn't have corresponding Grafana Organization yet,
-			// we can skip it for now and it will be reconciled by org_grafana_controller later
+			// we can skip it for now and it will be reconciled once the organization is created
 			log.Debugf("project %+v doesn't have grafana org annotation, skipping", project)
 			continue
 		}
 		id, err := strconv.ParseUint(orgID, 10, 32)
 		if err != nil {
-			return fmt.Errorf("unable to parse grafana org annotation %s: %w", orgID, err)
+			return newStates, fmt.Errorf("unable to parse grafana org annotation %s: %w", orgID, err)
 		}
-		if err := addDashboards(ctx, log, grafanaClient.WithOrgIDHeader(uint(id)), configMap); err != nil {
-			return err
+		state, err := syncDashboards(ctx, log, grafanaClient.WithOrgIDHeader(uint(id)), set, states[orgID])
+		if err != nil {
+			return newStates, err
 		}
+		newStates[orgID] = state
+		synced.Insert(orgID)
 	}
-	return nil
+
+	return deleteStaleDashboards(ctx, log, client, grafanaClient, newStates, synced)
+}
+
+// deleteStaleDashboards removes the dashboards of all organizations in the state except for the ones to
+// keep, e.g. because the project label of a ConfigMap changed. It returns the remaining state.
+func deleteStaleDashboards(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	client ctrlruntimeclient.Client,
+	grafanaClient *grafanasdk.Client,
+	states map[string]kubermaticv1.GrafanaDashboardsSyncState,
+	keep sets.String,
+) (map[string]kubermaticv1.GrafanaDashboardsSyncState, error) {
+	stale := sets.StringKeySet(states).Difference(keep)
+	if stale.Len() == 0 {
+		return states, nil
+	}
+
+	// organizations of deleted projects are deleted along with their dashboards
+	orgIDs, err := getGrafanaOrgIDs(ctx, client)
+	if err != nil {
+		return states, err
+	}
+
+	newStates := make(map[string]kubermaticv1.GrafanaDashboardsSyncState, len(states))
+	for orgID, state := range states {
+		newStates[orgID] = state
+	}
+	for _, orgID := range stale.List() {
+		if orgIDs.Has(orgID) {
+			id, err := strconv.ParseUint(orgID, 10, 32)
+			if err != nil {
+				return newStates, fmt.Errorf("unable to parse grafana org ID %s: %w", orgID, err)
+			}
+			if err := deleteSyncedDashboards(ctx, log, grafanaClient.WithOrgIDHeader(uint(id)), newStates[orgID]); err != nil {
+				return newStates, err
+			}
+		}
+		delete(newStates, orgID)
+	}
+	return newStates, nil
+}
+
+// getGrafanaOrgIDs returns the IDs of the Grafana organizations of all projects.
+func getGrafanaOrgIDs(ctx context.Context, client ctrlruntimeclient.Client) (sets.String, error) {
+	projectList := &kubermaticv1.ProjectList{}
+	if err := client.List(ctx, projectList); err != nil {
+		return nil, fmt.Errorf("failed to list Projects: %w", err)
+	}
+	orgIDs := sets.NewString()
+	for _, project := range projectList.Items {
+		if orgID, ok := project.GetAnnotations()[GrafanaOrgAnnotationKey]; ok {
+			orgIDs.Insert(orgID)
+		}
+	}
+	return orgIDs, nil
+}
+
+// getProjectsForConfigMap returns the projects the dashboards of the given ConfigMap are provisioned to.
+func (r *dashboardGrafanaController) getProjectsForConfigMap(ctx context.Context, configMap *corev1.ConfigMap) ([]kubermaticv1.Project, error) {
+	projectID, ok := configMap.GetLabels()[GrafanaDashboardProjectLabelKey]
+	if !ok {
+		projectList := &kubermaticv1.ProjectList{}
+		if err := r.List(ctx, projectList); err != nil {
+			return nil, fmt.Errorf("failed to list Projects: %w", err)
+		}
+		return projectList.Items, nil
+	}
+
+	project := &kubermaticv1.Project{}
+	if err := r.Get(ctx, types.NamespacedName{Name: projectID}, project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get Project: %w", err)
+	}
+	return []kubermaticv1.Project{*project}, nil
+}
+
+// isDashboardConfigMap returns true if the object is a ConfigMap with Grafana dashboards, either
+// for all projects (name prefixed with `grafana-dashboards`) or for a single project (labelled).
+func isDashboardConfigMap(obj ctrlruntimeclient.Object) bool {
+	if _, ok := obj.GetLabels()[GrafanaDashboardProjectLabelKey]; ok {
+		return true
+	}
+	return strings.HasPrefix(obj.GetName(), grafanaDashboardsConfigmapNamePrefix)
+}
+
+// isDashboardConfigMapForProject returns true if the dashboards of the ConfigMap should be
+// provisioned to the Grafana organization of the given project.
+func isDashboardConfigMapForProject(obj ctrlruntimeclient.Object, projectID string) bool {
+	if id, ok := obj.GetLabels()[GrafanaDashboardProjectLabelKey]; ok {
+		return id == projectID
+	}
+	return strings.HasPrefix(obj.GetName(), grafanaDashboardsConfigmapNamePrefix)
 }
@@ -34,6 +34,7 @@ import (
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -71,19 +72,49 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 		Overwrite bool             `json:"overwrite"`
 	}
 
+	const dashboardData = `{"title": "dashboard", "uid": "unique"}`
 	board.Overwrite = true
 	board.Dashboard.Title = "dashboard"
 	board.Dashboard.UID = "unique"
 	boardData, err := json.Marshal(board)
 	assert.Nil(t, err)
+	board.FolderID = 5
+	folderBoardData, err := json.Marshal(board)
+	assert.Nil(t, err)
+	folderData, err := json.Marshal(grafanasdk.Folder{Title: "Team"})
+	assert.Nil(t, err)
+
+	hash := dashboardHash(dashboardData)
+	dashboardSync := func(name string, states map[string]kubermaticv1.GrafanaDashboardsSyncState) *kubermaticv1.DashboardConfigMapSync {
+		return &kubermaticv1.DashboardConfigMapSync{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "mla",
+			},
+			Status: kubermaticv1.DashboardConfigMapSyncStatus{
+				Organizations: states,
+			},
+		}
+	}
+	orgRequest := func(method, target, body string, orgID string) *http.Request {
+		var req *http.Request
+		if body == "" {
+			req = httptest.NewRequest(method, target, nil)
+		} else {
+			req = httptest.NewRequest(method, target, strings.NewReader(body))
+		}
+		req.Header.Set("X-Grafana-Org-Id", orgID)
+		return req
+	}
+
 	testCases := []struct {
-		name         string
-		requestName  string
-		objects      []ctrlruntimeclient.Object
-		handlerFunc  http.HandlerFunc
-		requests     []request
-		hasFinalizer bool
-		err          bool
+		name           string
+		requestName    string
+		objects        []ctrlruntimeclient.Object
+		requests       []request
+		hasFinalizer   bool
+		expectedStates map[string]kubermaticv1.GrafanaDashboardsSyncState
+		err            bool
 	}{
 		{
 			name:        "add configmap with dashboards",
@@ -104,7 +135,7 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 						Name:      grafanaDashboardsConfigmapNamePrefix + "-defaults",
 						Namespace: "mla",
 					},
-					Data: map[string]string{"first": `{"title": "dashboard", "uid": "unique"}`},
+					Data: map[string]string{"first": dashboardData},
 				},
 				&corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{
@@ -119,9 +150,12 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 				{
 					name:     "set dashboard",
 					request:  httptest.NewRequest(http.MethodPost, "/api/dashboards/db", strings.NewReader(string(boardData))),
-					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "dashboard set"}`)), StatusCode: http.StatusOK},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"status": "success", "uid": "unique", "version": 1}`)), StatusCode: http.StatusOK},
 				},
 			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+			},
 		},
 		{
 			name:        "add configmap with dashboards, but project not ready yep",
@@ -141,7 +175,7 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 						Name:      grafanaDashboardsConfigmapNamePrefix + "-defaults",
 						Namespace: "mla",
 					},
-					Data: map[string]string{"first": `{"title": "dashboard", "uid": "unique"}`},
+					Data: map[string]string{"first": dashboardData},
 				},
 				&corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{
@@ -154,6 +188,247 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 			hasFinalizer: true,
 			requests:     []request{},
 		},
+		{
+			name:        "add project configmap with dashboards into folder",
+			requestName: "team-dashboards",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "other",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "2"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "otherProjectName",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "team-dashboards",
+						Namespace:   "mla",
+						Labels:      map[string]string{GrafanaDashboardProjectLabelKey: "create"},
+						Annotations: map[string]string{GrafanaDashboardFolderAnnotationKey: "Team"},
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "get folders",
+					request:  orgRequest(http.MethodGet, "/api/folders", "", "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": 3, "uid": "other", "title": "Other"}]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "create folder",
+					request:  orgRequest(http.MethodPost, "/api/folders", string(folderData), "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"id": 5, "uid": "team", "title": "Team"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "set dashboard",
+					request:  orgRequest(http.MethodPost, "/api/dashboards/db", string(folderBoardData), "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"status": "success", "uid": "unique", "version": 1}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {FolderUID: "team", Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+			},
+		},
+		{
+			name:        "skip dashboards that are up to date",
+			requestName: grafanaDashboardsConfigmapNamePrefix + "-defaults",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				dashboardSync(grafanaDashboardsConfigmapNamePrefix+"-defaults", map[string]kubermaticv1.GrafanaDashboardsSyncState{
+					"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 4}}},
+				}),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      grafanaDashboardsConfigmapNamePrefix + "-defaults",
+						Namespace: "mla",
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "get dashboard",
+					request:  httptest.NewRequest(http.MethodGet, "/api/dashboards/uid/unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"dashboard": {"id": 3, "uid": "unique", "title": "Dashboard", "version": 4}, "meta": {"folderId": 0, "version": 4}}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 4}}},
+			},
+		},
+		{
+			name:        "revert dashboards that were changed in Grafana",
+			requestName: grafanaDashboardsConfigmapNamePrefix + "-defaults",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				dashboardSync(grafanaDashboardsConfigmapNamePrefix+"-defaults", map[string]kubermaticv1.GrafanaDashboardsSyncState{
+					"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 4}}},
+				}),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      grafanaDashboardsConfigmapNamePrefix + "-defaults",
+						Namespace: "mla",
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "get dashboard",
+					request:  httptest.NewRequest(http.MethodGet, "/api/dashboards/uid/unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"dashboard": {"id": 3, "uid": "unique", "title": "changed", "version": 5}, "meta": {"folderId": 0, "version": 5}}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "set dashboard",
+					request:  httptest.NewRequest(http.MethodPost, "/api/dashboards/db", strings.NewReader(string(boardData))),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"status": "success", "uid": "unique", "version": 6}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 6}}},
+			},
+		},
+		{
+			name:        "remove dashboards that are no longer part of the configmap",
+			requestName: grafanaDashboardsConfigmapNamePrefix + "-defaults",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				dashboardSync(grafanaDashboardsConfigmapNamePrefix+"-defaults", map[string]kubermaticv1.GrafanaDashboardsSyncState{
+					"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{
+						"unique":  {Hash: hash, Version: 4},
+						"removed": {Hash: "outdated", Version: 1},
+					}},
+				}),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      grafanaDashboardsConfigmapNamePrefix + "-defaults",
+						Namespace: "mla",
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "get dashboard",
+					request:  httptest.NewRequest(http.MethodGet, "/api/dashboards/uid/unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"dashboard": {"id": 3, "uid": "unique", "title": "dashboard", "version": 4}, "meta": {"folderId": 0, "version": 4}}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "delete removed dashboard",
+					request:  httptest.NewRequest(http.MethodDelete, "/api/dashboards/uid/removed", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"title": "removed"}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 4}}},
+			},
+		},
+		{
+			name:        "move dashboards and remove the folder when the project label changes",
+			requestName: "team-dashboards",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "other",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "2"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "otherProjectName",
+					},
+				},
+				dashboardSync("team-dashboards", map[string]kubermaticv1.GrafanaDashboardsSyncState{
+					"1": {FolderUID: "team", Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+				}),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "team-dashboards",
+						Namespace: "mla",
+						Labels:    map[string]string{GrafanaDashboardProjectLabelKey: "other"},
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "set dashboard in new org",
+					request:  orgRequest(http.MethodPost, "/api/dashboards/db", string(boardData), "2"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"status": "success", "uid": "unique", "version": 1}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "delete dashboard in old org",
+					request:  orgRequest(http.MethodDelete, "/api/dashboards/uid/unique", "", "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"title": "dashboard"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "get folders in old org",
+					request:  orgRequest(http.MethodGet, "/api/folders", "", "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": 5, "uid": "team", "title": "Team"}]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "search dashboards in folder",
+					request:  orgRequest(http.MethodGet, "/api/search?folderIds=5&type=dash-db", "", "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "delete folder",
+					request:  orgRequest(http.MethodDelete, "/api/folders/team", "", "1"),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "Folder Team deleted"}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"2": {Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+			},
+		},
 		{
 			name:        "delete configmap with dashboards",
 			requestName: grafanaDashboardsConfigmapNamePrefix + "-defaults",
@@ -183,7 +458,7 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 						DeletionTimestamp: &metav1.Time{Time: time.Now()},
 						Finalizers:        []string{mlaFinalizer, "do-not-remove"},
 					},
-					Data: map[string]string{"first": `{"title": "dashboard", "uid": "unique"}`},
+					Data: map[string]string{"first": dashboardData},
 				},
 				&corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{
@@ -202,6 +477,94 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "delete configmap with synced dashboards and their folder",
+			requestName: "team-dashboards",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				dashboardSync("team-dashboards", map[string]kubermaticv1.GrafanaDashboardsSyncState{
+					"1": {FolderUID: "team", Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+				}),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "team-dashboards",
+						Namespace:         "mla",
+						Labels:            map[string]string{GrafanaDashboardProjectLabelKey: "create"},
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{mlaFinalizer, "do-not-remove"},
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: false,
+			requests: []request{
+				{
+					name:     "delete dashboard",
+					request:  httptest.NewRequest(http.MethodDelete, "/api/dashboards/uid/unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"title": "dashboard"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "get folders",
+					request:  httptest.NewRequest(http.MethodGet, "/api/folders", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": 5, "uid": "team", "title": "Team"}]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "search dashboards in folder",
+					request:  httptest.NewRequest(http.MethodGet, "/api/search?folderIds=5&type=dash-db", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[{"uid": "foreign", "type": "dash-db"}]`)), StatusCode: http.StatusOK},
+				},
+			},
+		},
+		{
+			name:        "delete project configmap with dashboards",
+			requestName: "team-dashboards",
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "create",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "other",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "2"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "otherProjectName",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "team-dashboards",
+						Namespace:         "mla",
+						Labels:            map[string]string{GrafanaDashboardProjectLabelKey: "create"},
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{mlaFinalizer, "do-not-remove"},
+					},
+					Data: map[string]string{"first": dashboardData},
+				},
+			},
+			hasFinalizer: false,
+			requests: []request{
+				{
+					name:     "delete dashboard",
+					request:  httptest.NewRequest(http.MethodDelete, "/api/dashboards/uid/"+"unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "Dashboard dashboard deleted"}`)), StatusCode: http.StatusOK},
+				},
+			},
+		},
 	}
 
 	for idx := range testCases {
@@ -212,16 +575,27 @@ func TestDashboardGrafanaReconcile(t *testing.T) {
 			r, assertExpectation := buildTestServer(t, tc.requests...)
 			controller, server := newTestDashboardGrafanaReconciler(t, tc.objects, r)
 			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.requestName, Namespace: "mla"}}
-			_, err := controller.Reconcile(ctx, request)
+			result, err := controller.Reconcile(ctx, request)
 			if err != nil && !tc.err {
 				assert.Nil(t, err)
 			}
 			assert.Equal(t, tc.err, err != nil)
+			if tc.hasFinalizer {
+				assert.Equal(t, dashboardResyncInterval, result.RequeueAfter)
+			}
 			configMap := &corev1.ConfigMap{}
 			if err := controller.Get(ctx, request.NamespacedName, configMap); err != nil {
 				t.Fatalf("unable to get configMap: %v", err)
 			}
 			assert.Equal(t, tc.hasFinalizer, kubernetes.HasFinalizer(configMap, mlaFinalizer))
+			dashboardSync := &kubermaticv1.DashboardConfigMapSync{}
+			err = controller.Get(ctx, request.NamespacedName, dashboardSync)
+			if tc.expectedStates == nil {
+				assert.True(t, apierrors.IsNotFound(err), "expected no DashboardConfigMapSync, got %v", err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedStates, dashboardSync.Status.Organizations)
+			}
 			assertExpectation()
 			server.Close()
 		})
@@ -18,8 +18,11 @@ package mla
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"go.uber.org/zap"
@@ -28,7 +31,6 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -121,40 +123,174 @@ func addGrafanaOrgUser(ctx context.Context, grafanaClient *grafanasdk.Client, or
 	return nil
 }
 
-func addDashboards(ctx context.Context, log *zap.SugaredLogger, grafanaClient *grafanasdk.Client, configMap *corev1.ConfigMap) error {
-	for _, data := range configMap.Data {
+// dashboardSet is a set of dashboards from a single source, e.g. a ConfigMap, that is synced into
+// Grafana organizations.
+type dashboardSet struct {
+	// folder is the title of the folder the dashboards are put in, empty for the General folder.
+	folder string
+	// dashboards maps a key that is unique within the source, e.g. a file name, to the dashboard JSON.
+	dashboards map[string]string
+}
+
+// syncDashboards ensures that the dashboards of the set exist in the Grafana organization of the client
+// and removes the dashboards and folder recorded in the given state that are no longer part of the set.
+// It returns the state of the organization after the sync.
+func syncDashboards(ctx context.Context, log *zap.SugaredLogger, grafanaClient *grafanasdk.Client, set dashboardSet, state kubermaticv1.GrafanaDashboardsSyncState) (kubermaticv1.GrafanaDashboardsSyncState, error) {
+	folder, err := ensureDashboardFolder(ctx, grafanaClient, set.folder)
+	if err != nil {
+		return state, err
+	}
+
+	newState := kubermaticv1.GrafanaDashboardsSyncState{
+		FolderUID:  folder.UID,
+		Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{},
+	}
+	keys := make([]string, 0, len(set.dashboards))
+	for key := range set.dashboards {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		data := set.dashboards[key]
 		var board grafanasdk.Board
 		if err := json.Unmarshal([]byte(data), &board); err != nil {
-			return fmt.Errorf("unable to unmarshal dashboard: %w", err)
+			return state, fmt.Errorf("unable to unmarshal dashboard %q: %w", key, err)
 		}
-		if status, err := grafanaClient.SetDashboard(ctx, board, grafanasdk.SetDashboardParams{Overwrite: true}); err != nil {
+		hash := dashboardHash(data)
+		if synced, ok := state.Dashboards[board.UID]; ok && isDashboardUpToDate(ctx, grafanaClient, board.UID, synced, hash, folder.ID) {
+			newState.Dashboards[board.UID] = synced
+			continue
+		}
+		status, err := grafanaClient.SetDashboard(ctx, board, grafanasdk.SetDashboardParams{FolderID: folder.ID, Overwrite: true})
+		if err != nil {
 			log.Errorw("unable to set dashboard",
 				zap.Error(err),
 				"status", pointer.StringPtrDerefOr(status.Status, "no status"),
 				"message", pointer.StringPtrDerefOr(status.Message, "no message"))
+			return state, err
+		}
+		// dashboards without UID can't be looked up, so they are set again on every sync and never removed
+		if board.UID == "" {
+			log.Debugw("dashboard doesn't have UID set, it can't be tracked", "title", board.Title)
+			continue
+		}
+		newState.Dashboards[board.UID] = kubermaticv1.GrafanaDashboardSyncState{
+			Hash:    hash,
+			Version: pointer.IntDeref(status.Version, 0),
+		}
+	}
+
+	for uid := range state.Dashboards {
+		if _, ok := newState.Dashboards[uid]; ok {
+			continue
+		}
+		if err := deleteDashboard(ctx, log, grafanaClient, uid); err != nil {
+			return state, err
+		}
+	}
+	if state.FolderUID != "" && state.FolderUID != newState.FolderUID {
+		if err := deleteDashboardFolderIfEmpty(ctx, log, grafanaClient, state.FolderUID); err != nil {
+			return state, err
+		}
+	}
+
+	if len(newState.Dashboards) == 0 {
+		newState.Dashboards = nil
+	}
+	return newState, nil
+}
+
+// deleteSyncedDashboards removes the dashboards recorded in the state from the Grafana organization
+// of the client, together with their folder if it is empty afterwards.
+func deleteSyncedDashboards(ctx context.Context, log *zap.SugaredLogger, grafanaClient *grafanasdk.Client, state kubermaticv1.GrafanaDashboardsSyncState) error {
+	for uid := range state.Dashboards {
+		if err := deleteDashboard(ctx, log, grafanaClient, uid); err != nil {
 			return err
 		}
 	}
+	if state.FolderUID != "" {
+		return deleteDashboardFolderIfEmpty(ctx, log, grafanaClient, state.FolderUID)
+	}
 	return nil
 }
 
-func deleteDashboards(ctx context.Context, log *zap.SugaredLogger, grafanaClient *grafanasdk.Client, configMap *corev1.ConfigMap) error {
-	for _, data := range configMap.Data {
-		var board grafanasdk.Board
-		if err := json.Unmarshal([]byte(data), &board); err != nil {
-			return fmt.Errorf("unable to unmarshal dashboard: %w", err)
+func deleteDashboard(ctx context.Context, log *zap.SugaredLogger, grafanaClient *grafanasdk.Client, uid string) error {
+	if status, err := grafanaClient.DeleteDashboardByUID(ctx, uid); err != nil {
+		log.Errorw("unable to delete dashboard",
+			zap.Error(err),
+			"status", pointer.StringPtrDerefOr(status.Status, "no status"),
+			"message", pointer.StringPtrDerefOr(status.Message, "no message"))
+		return err
+	}
+	return nil
+}
+
+// ensureDashboardFolder returns the folder with the given title and creates it if it's missing.
+// An empty title refers to the "General" folder, which always has the ID 0 and no UID.
+func ensureDashboardFolder(ctx context.Context, grafanaClient *grafanasdk.Client, title string) (grafanasdk.Folder, error) {
+	if title == "" {
+		return grafanasdk.Folder{}, nil
+	}
+	folders, err := grafanaClient.GetAllFolders(ctx)
+	if err != nil {
+		return grafanasdk.Folder{}, fmt.Errorf("unable to get folders: %w", err)
+	}
+	for _, folder := range folders {
+		if folder.Title == title {
+			return folder, nil
 		}
-		if board.UID == "" {
-			log.Debugw("dashboard doesn't have UID set, skipping", "title", board.Title)
+	}
+	folder, err := grafanaClient.CreateFolder(ctx, grafanasdk.Folder{Title: title})
+	if err != nil {
+		return grafanasdk.Folder{}, fmt.Errorf("unable to create folder %q: %w", title, err)
+	}
+	return folder, nil
+}
+
+// deleteDashboardFolderIfEmpty deletes the folder with the given UID, unless it still contains dashboards.
+// Deleting a folder in Grafana deletes its dashboards as well, which may belong to other sources or users.
+func deleteDashboardFolderIfEmpty(ctx context.Context, log *zap.SugaredLogger, grafanaClient *grafanasdk.Client, uid string) error {
+	folders, err := grafanaClient.GetAllFolders(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get folders: %w", err)
+	}
+	for _, folder := range folders {
+		if folder.UID != uid {
 			continue
 		}
-		if status, err := grafanaClient.DeleteDashboardByUID(ctx, board.UID); err != nil {
-			log.Errorw("unable to delete dashboard",
-				zap.Error(err),
-				"status", pointer.StringPtrDerefOr(status.Status, "no status"),
-				"message", pointer.StringPtrDerefOr(status.Message, "no message"))
-			return err
+		boards, err := grafanaClient.Search(ctx, grafanasdk.SearchType(grafanasdk.SearchTypeDashboard), grafanasdk.SearchFolderID(folder.ID))
+		if err != nil {
+			return fmt.Errorf("unable to search dashboards in folder %q: %w", folder.Title, err)
+		}
+		if len(boards) > 0 {
+			log.Debugw("folder still contains dashboards, keeping it", "folder", folder.Title)
+			return nil
+		}
+		if _, err := grafanaClient.DeleteFolderByUID(ctx, uid); err != nil {
+			return fmt.Errorf("unable to delete folder %q: %w", folder.Title, err)
 		}
 	}
 	return nil
 }
+
+// isDashboardUpToDate checks whether the dashboard stored in Grafana is still the one that was synced, so
+// that we do not create a new dashboard version on every resync. Grafana increases the version on every
+// change, so comparing it to the synced version detects changes made in Grafana.
+func isDashboardUpToDate(ctx context.Context, grafanaClient *grafanasdk.Client, uid string, synced kubermaticv1.GrafanaDashboardSyncState, hash string, folderID int) bool {
+	if synced.Hash != hash {
+		return false
+	}
+	// any error, including a missing dashboard, is handled by setting the dashboard again
+	_, properties, err := grafanaClient.GetRawDashboardByUID(ctx, uid)
+	if err != nil {
+		return false
+	}
+	return properties.FolderID == folderID && properties.Version == synced.Version
+}
+
+// dashboardHash returns the hash of a dashboard definition, which is recorded to detect changes in the source.
+func dashboardHash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -97,10 +97,14 @@ func newGrafanaClientProvider(client ctrlruntimeclient.Client, httpClient *http.
 // * org user grafana controller - create/update/delete Grafana Users to organizations based on Kubermatic UserProjectBindings
 // * user grafana controller - create/update/delete Grafana Global Users based on Kubermatic User
 // * datasource grafana controller - create/update/delete Grafana Datasources to organizations based on Kubermatic Clusters
-// * alertmanager configuration controller - manage alertmanager configuration based on Kubermatic Clusters
+// * alertmanager configuration controller - manage alertmanager configuration based on Kubermatic Clusters and ProjectAlertmanagers
 // * rule group controller - manager rule groups that will be used to generate alerts.
-// * dashboard grafana controller - create/delete Grafana dashboards based on configmaps with prefix `grafana-dashboards`
+// * project rule group sync controller - create/update/delete rule groups in all MLA-enabled clusters of a project based on ProjectRuleGroups
+// * dashboard grafana controller - create/update/delete Grafana dashboards based on configmaps with prefix `grafana-dashboards` or a project label, recording the synced dashboards in DashboardConfigMapSyncs
+// * project dashboard source controller - create/update/delete Grafana dashboards of a project based on Git repositories in ProjectDashboardSources
 // * ratelimit cortex controller - updates Cortex runtime configuration with rate limits based on kubermatic MLAAdminSetting
+// * ratelimit loki controller - updates Loki runtime configuration with limits and retention based on kubermatic MLAAdminSetting
+// * usage controller - collects the Cortex and Loki usage of user clusters into the status of kubermatic MLAAdminSetting
 // * cleanup controller - this controller runs when mla disabled and clean objects that left from other MLA controller.
 func Add(
 	ctx context.Context,
@@ -117,6 +121,8 @@ func Add(
 	cortexAlertmanagerURL string,
 	cortexRulerURL string,
 	lokiRulerURL string,
+	cortexDistributorURL string,
+	lokiQuerierURL string,
 	mlaEnabled bool,
 ) error {
 	log = log.Named(ControllerName)
@@ -128,22 +134,36 @@ func Add(
 	}
 
 	orgUserGrafanaController := newOrgUserGrafanaController(mgr.GetClient(), log, clientProvider)
-	orgGrafanaController := newOrgGrafanaController(mgr.GetClient(), log, mlaNamespace, clientProvider)
+	orgGrafanaController := newOrgGrafanaController(mgr.GetClient(), log, clientProvider)
 	alertmanagerController := newAlertmanagerController(mgr.GetClient(), log, httpClient, cortexAlertmanagerURL)
 	datasourceGrafanaController := newDatasourceGrafanaController(mgr.GetClient(), clientProvider, mlaNamespace, log, overwriteRegistry)
 	userGrafanaController := newUserGrafanaController(mgr.GetClient(), log, clientProvider, httpClient, grafanaURL, grafanaHeader)
 	ruleGroupController := newRuleGroupController(mgr.GetClient(), log, httpClient, cortexRulerURL, lokiRulerURL, mlaNamespace)
 	dashboardGrafanaController := newDashboardGrafanaController(mgr.GetClient(), log, mlaNamespace, clientProvider)
+	projectDashboardSourceController := newProjectDashboardSourceController(mgr.GetClient(), log, mlaNamespace, clientProvider)
 	ratelimitCortexController := newRatelimitCortexController(mgr.GetClient(), log, mlaNamespace)
+	ratelimitLokiController := newRatelimitLokiController(mgr.GetClient(), log, mlaNamespace)
+	usageController := newUsageController(mgr.GetClient(), log, httpClient, cortexDistributorURL, lokiQuerierURL)
 	ruleGroupSyncController := newRuleGroupSyncController(mgr.GetClient(), log, mlaNamespace)
+	projectRuleGroupSyncController := newProjectRuleGroupSyncController(mgr.GetClient(), log)
 	if mlaEnabled {
 		// ratelimit cortex controller update 1 configmap, so we better to have only one worker
 		if err := newRatelimitCortexReconciler(mgr, log, 1, workerName, versions, ratelimitCortexController); err != nil {
 			return fmt.Errorf("failed to create mla ratelimit cortex controller: %w", err)
 		}
+		// ratelimit loki controller update 1 configmap, so we better to have only one worker
+		if err := newRatelimitLokiReconciler(mgr, log, 1, workerName, versions, ratelimitLokiController); err != nil {
+			return fmt.Errorf("failed to create mla ratelimit loki controller: %w", err)
+		}
+		if err := newUsageReconciler(mgr, log, numWorkers, workerName, versions, usageController); err != nil {
+			return fmt.Errorf("failed to create mla usage controller: %w", err)
+		}
 		if err := newDashboardGrafanaReconciler(mgr, log, numWorkers, workerName, versions, dashboardGrafanaController); err != nil {
 			return fmt.Errorf("failed to create mla dashboard grafana controller: %w", err)
 		}
+		if err := newProjectDashboardSourceReconciler(mgr, log, numWorkers, workerName, versions, projectDashboardSourceController); err != nil {
+			return fmt.Errorf("failed to create mla project dashboard source controller: %w", err)
+		}
 		if err := newOrgGrafanaReconciler(mgr, log, numWorkers, workerName, versions, orgGrafanaController); err != nil {
 			return fmt.Errorf("failed to create mla org grafana controller: %w", err)
 		}
@@ -165,19 +185,25 @@ func Add(
 		if err := newRuleGroupSyncReconciler(mgr, log, numWorkers, workerName, versions, ruleGroupSyncController); err != nil {
 			return fmt.Errorf("failed to create rule group controller %w", err)
 		}
+		if err := newProjectRuleGroupSyncReconciler(mgr, log, numWorkers, workerName, versions, projectRuleGroupSyncController); err != nil {
+			return fmt.Errorf("failed to create project rule group sync controller: %w", err)
+		}
 	} else {
 		cleanupController := newCleanupController(
 			mgr.GetClient(),
 			log,
 			datasourceGrafanaController,
 			dashboardGrafanaController,
+			projectDashboardSourceController,
 			alertmanagerController,
 			orgUserGrafanaController,
 			orgGrafanaController,
 			userGrafanaController,
 			ruleGroupController,
 			ratelimitCortexController,
+			ratelimitLokiController,
 			ruleGroupSyncController,
+			projectRuleGroupSyncController,
 		)
 		if err := newCleanupReconciler(mgr, log, numWorkers, workerName, versions, cleanupController); err != nil {
 			return fmt.Errorf("failed to create mla cleanup controller: %w", err)
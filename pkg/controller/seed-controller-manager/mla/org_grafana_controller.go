@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"go.uber.org/zap"
 
@@ -30,7 +29,6 @@ import (
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -125,22 +123,17 @@ func (r *orgGrafanaReconciler) Reconcile(ctx context.Context, request reconcile.
 	org := grafanasdk.Org{
 		Name: getOrgNameForProject(project),
 	}
-	orgID, err := r.orgGrafanaController.ensureOrganization(ctx, log, project, org, GrafanaOrgAnnotationKey, grafanaClient)
-	if err != nil {
+	// dashboards are synced by the dashboard grafana controller once the organization annotation is set
+	if _, err := r.orgGrafanaController.ensureOrganization(ctx, log, project, org, GrafanaOrgAnnotationKey, grafanaClient); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure Grafana Organization: %w", err)
 	}
 
-	if err := r.orgGrafanaController.ensureDashboards(ctx, log, orgID, grafanaClient); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed to ensure Grafana Dashboards: %w", err)
-	}
-
 	return reconcile.Result{}, nil
 }
 
 type orgGrafanaController struct {
 	ctrlruntimeclient.Client
 	clientProvider grafanaClientProvider
-	mlaNamespace   string
 
 	log *zap.SugaredLogger
 }
@@ -148,13 +141,11 @@ type orgGrafanaController struct {
 func newOrgGrafanaController(
 	client ctrlruntimeclient.Client,
 	log *zap.SugaredLogger,
-	mlaNamespace string,
 	clientProvider grafanaClientProvider,
 ) *orgGrafanaController {
 	return &orgGrafanaController{
 		Client:         client,
 		clientProvider: clientProvider,
-		mlaNamespace:   mlaNamespace,
 
 		log: log,
 	}
@@ -243,22 +234,6 @@ func (r *orgGrafanaController) createGrafanaOrg(ctx context.Context, expected gr
 	return expected, nil
 }
 
-func (r *orgGrafanaController) ensureDashboards(ctx context.Context, log *zap.SugaredLogger, orgID uint, grafanaClient *grafanasdk.Client) error {
-	configMapList := &corev1.ConfigMapList{}
-	if err := r.List(ctx, configMapList, ctrlruntimeclient.InNamespace(r.mlaNamespace)); err != nil {
-		return fmt.Errorf("Failed to list configmaps: %w", err)
-	}
-	for _, configMap := range configMapList.Items {
-		if !strings.HasPrefix(configMap.GetName(), grafanaDashboardsConfigmapNamePrefix) {
-			continue
-		}
-		if err := addDashboards(ctx, log, grafanaClient.WithOrgIDHeader(orgID), &configMap); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func (r *orgGrafanaController) ensureOrganization(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project, expected grafanasdk.Org, annotationKey string, grafanaClient *grafanasdk.Client) (uint, error) {
 	orgID, ok := project.GetAnnotations()[annotationKey]
 	if !ok {
@@ -0,0 +1,300 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mla
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	grafanasdk "github.com/kubermatic/grafanasdk"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/source"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	ctrlruntimesource "sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// dashboardDownloader returns the dashboards of a ProjectDashboardSource, keyed by their path in the repository.
+type dashboardDownloader func(ctx context.Context, dashboardSource *kubermaticv1.ProjectDashboardSource) (map[string]string, error)
+
+type projectDashboardSourceReconciler struct {
+	ctrlruntimeclient.Client
+	log                              *zap.SugaredLogger
+	workerName                       string
+	recorder                         record.EventRecorder
+	versions                         kubermatic.Versions
+	projectDashboardSourceController *projectDashboardSourceController
+}
+
+func newProjectDashboardSourceReconciler(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	versions kubermatic.Versions,
+	projectDashboardSourceController *projectDashboardSourceController,
+) error {
+	client := mgr.GetClient()
+
+	reconciler := &projectDashboardSourceReconciler{
+		Client:                           client,
+		log:                              log.Named("project-dashboard-source"),
+		workerName:                       workerName,
+		recorder:                         mgr.GetEventRecorderFor(ControllerName),
+		versions:                         versions,
+		projectDashboardSourceController: projectDashboardSourceController,
+	}
+
+	ctrlOptions := controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	}
+	c, err := controller.New(ControllerName, mgr, ctrlOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&ctrlruntimesource.Kind{Type: &kubermaticv1.ProjectDashboardSource{}}, &handler.EnqueueRequestForObject{}, predicate.GenerationChangedPredicate{}); err != nil {
+		return fmt.Errorf("failed to watch ProjectDashboardSource: %w", err)
+	}
+
+	// dashboards are synced as soon as the Grafana organization of a project is created
+	enqueueProjectDashboardSources := handler.EnqueueRequestsFromMapFunc(func(object ctrlruntimeclient.Object) []reconcile.Request {
+		dashboardSourceList := &kubermaticv1.ProjectDashboardSourceList{}
+		if err := client.List(context.Background(), dashboardSourceList); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list projectdashboardsources: %w", err))
+			return []reconcile.Request{}
+		}
+		var requests []reconcile.Request
+		for _, dashboardSource := range dashboardSourceList.Items {
+			if dashboardSource.Spec.Project.Name == object.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: dashboardSource.Name}})
+			}
+		}
+		return requests
+	})
+	if err := c.Watch(&ctrlruntimesource.Kind{Type: &kubermaticv1.Project{}}, enqueueProjectDashboardSources, predicate.AnnotationChangedPredicate{}); err != nil {
+		return fmt.Errorf("failed to watch Project: %w", err)
+	}
+	return nil
+}
+
+func (r *projectDashboardSourceReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	dashboardSource := &kubermaticv1.ProjectDashboardSource{}
+	if err := r.Get(ctx, request.NamespacedName, dashboardSource); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	grafanaClient, err := r.projectDashboardSourceController.clientProvider(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create Grafana client: %w", err)
+	}
+
+	if !dashboardSource.DeletionTimestamp.IsZero() {
+		if err := r.projectDashboardSourceController.handleDeletion(ctx, log, dashboardSource, grafanaClient); err != nil {
+			return reconcile.Result{}, fmt.Errorf("handling deletion: %w", err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if grafanaClient == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if err := kubernetes.TryAddFinalizer(ctx, r, dashboardSource, mlaFinalizer); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+	}
+
+	if err := r.projectDashboardSourceController.ensureDashboards(ctx, log, dashboardSource, grafanaClient); err != nil {
+		r.recorder.Event(dashboardSource, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return reconcile.Result{}, fmt.Errorf("failed to ensure Grafana Dashboards: %w", err)
+	}
+
+	// resync periodically to pick up new commits of branches and to revert changes made in Grafana
+	return reconcile.Result{RequeueAfter: dashboardResyncInterval}, nil
+}
+
+type projectDashboardSourceController struct {
+	ctrlruntimeclient.Client
+	clientProvider grafanaClientProvider
+	download       dashboardDownloader
+
+	log *zap.SugaredLogger
+}
+
+func newProjectDashboardSourceController(
+	client ctrlruntimeclient.Client,
+	log *zap.SugaredLogger,
+	mlaNamespace string,
+	clientProvider grafanaClientProvider,
+) *projectDashboardSourceController {
+	return &projectDashboardSourceController{
+		Client:         client,
+		clientProvider: clientProvider,
+		download:       newGitDashboardDownloader(client, mlaNamespace),
+
+		log: log,
+	}
+}
+
+func (r *projectDashboardSourceController) CleanUp(ctx context.Context) error {
+	dashboardSourceList := &kubermaticv1.ProjectDashboardSourceList{}
+	if err := r.List(ctx, dashboardSourceList); err != nil {
+		return fmt.Errorf("failed to list projectdashboardsources: %w", err)
+	}
+	grafanaClient, err := r.clientProvider(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Grafana client: %w", err)
+	}
+	for _, dashboardSource := range dashboardSourceList.Items {
+		if err := r.handleDeletion(ctx, r.log, &dashboardSource, grafanaClient); err != nil {
+			return fmt.Errorf("handling deletion: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *projectDashboardSourceController) handleDeletion(ctx context.Context, log *zap.SugaredLogger, dashboardSource *kubermaticv1.ProjectDashboardSource, grafanaClient *grafanasdk.Client) error {
+	if grafanaClient != nil {
+		states, err := deleteStaleDashboards(ctx, log, r.Client, grafanaClient, dashboardSource.Status.Organizations, sets.NewString())
+		if updateErr := r.updateStatus(ctx, dashboardSource, states); updateErr != nil {
+			return updateErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return kubernetes.TryRemoveFinalizer(ctx, r, dashboardSource, mlaFinalizer)
+}
+
+func (r *projectDashboardSourceController) ensureDashboards(ctx context.Context, log *zap.SugaredLogger, dashboardSource *kubermaticv1.ProjectDashboardSource, grafanaClient *grafanasdk.Client) error {
+	dashboards, err := r.download(ctx, dashboardSource)
+	if err != nil {
+		return fmt.Errorf("failed to download dashboards: %w", err)
+	}
+
+	var projects []kubermaticv1.Project
+	project := &kubermaticv1.Project{}
+	if err := r.Get(ctx, types.NamespacedName{Name: dashboardSource.Spec.Project.Name}, project); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Project: %w", err)
+		}
+	} else {
+		projects = append(projects, *project)
+	}
+
+	set := dashboardSet{
+		folder:     dashboardSource.Spec.Folder,
+		dashboards: dashboards,
+	}
+	states, err := syncDashboardSet(ctx, log, r.Client, grafanaClient, projects, set, dashboardSource.Status.Organizations)
+	// the state is recorded even if the sync failed, so that no dashboard is orphaned
+	if updateErr := r.updateStatus(ctx, dashboardSource, states); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func (r *projectDashboardSourceController) updateStatus(ctx context.Context, dashboardSource *kubermaticv1.ProjectDashboardSource, states map[string]kubermaticv1.GrafanaDashboardsSyncState) error {
+	oldDashboardSource := dashboardSource.DeepCopy()
+	dashboardSource.Status.Organizations = states
+	if len(states) == 0 {
+		dashboardSource.Status.Organizations = nil
+	}
+	if equality.Semantic.DeepEqual(oldDashboardSource.Status, dashboardSource.Status) {
+		return nil
+	}
+	return r.Status().Patch(ctx, dashboardSource, ctrlruntimeclient.MergeFrom(oldDashboardSource))
+}
+
+// newGitDashboardDownloader returns a dashboardDownloader that clones the repository of a ProjectDashboardSource
+// and reads all `*.json` files below its path. Credentials are read from Secrets in the given namespace.
+func newGitDashboardDownloader(client ctrlruntimeclient.Client, secretNamespace string) dashboardDownloader {
+	return func(ctx context.Context, dashboardSource *kubermaticv1.ProjectDashboardSource) (map[string]string, error) {
+		destination, err := os.MkdirTemp("", "dashboards-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(destination)
+
+		gitSource := source.GitSource{
+			Ctx:             ctx,
+			SeedClient:      client,
+			Source:          &dashboardSource.Spec.Git,
+			SecretNamespace: secretNamespace,
+		}
+		root, err := gitSource.DownloadSource(destination)
+		if err != nil {
+			return nil, err
+		}
+
+		dashboards := map[string]string{}
+		err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				// skip the repository metadata
+				if entry.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(entry.Name(), ".json") {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			key, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			dashboards[key] = string(data)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dashboards: %w", err)
+		}
+		return dashboards, nil
+	}
+}
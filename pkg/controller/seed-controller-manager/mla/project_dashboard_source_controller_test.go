@@ -0,0 +1,311 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mla
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+
+	grafanasdk "github.com/kubermatic/grafanasdk"
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestProjectDashboardSourceReconciler(t *testing.T, objects []ctrlruntimeclient.Object, handler http.Handler, dashboards map[string]string) (*projectDashboardSourceReconciler, *httptest.Server) {
+	dynamicClient := ctrlruntimefakeclient.
+		NewClientBuilder().
+		WithObjects(objects...).
+		Build()
+	ts := httptest.NewServer(handler)
+
+	grafanaClient, err := grafanasdk.NewClient(ts.URL, "admin:admin", ts.Client())
+	assert.Nil(t, err)
+
+	controller := newProjectDashboardSourceController(dynamicClient, kubermaticlog.Logger, "mla", func(ctx context.Context) (*grafanasdk.Client, error) {
+		return grafanaClient, nil
+	})
+	controller.download = func(ctx context.Context, dashboardSource *kubermaticv1.ProjectDashboardSource) (map[string]string, error) {
+		return dashboards, nil
+	}
+	reconciler := projectDashboardSourceReconciler{
+		Client:                           dynamicClient,
+		log:                              kubermaticlog.Logger,
+		recorder:                         record.NewFakeRecorder(10),
+		projectDashboardSourceController: controller,
+	}
+	return &reconciler, ts
+}
+
+func TestProjectDashboardSourceReconcile(t *testing.T) {
+	var board struct {
+		Dashboard grafanasdk.Board `json:"dashboard"`
+		FolderID  int              `json:"folderId"`
+		Overwrite bool             `json:"overwrite"`
+	}
+
+	const dashboardData = `{"title": "dashboard", "uid": "unique"}`
+	board.Overwrite = true
+	board.Dashboard.Title = "dashboard"
+	board.Dashboard.UID = "unique"
+	board.FolderID = 5
+	boardData, err := json.Marshal(board)
+	assert.Nil(t, err)
+	folderData, err := json.Marshal(grafanasdk.Folder{Title: "Team"})
+	assert.Nil(t, err)
+	hash := dashboardHash(dashboardData)
+
+	project := &kubermaticv1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "create",
+			Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+		},
+		Spec: kubermaticv1.ProjectSpec{
+			Name: "projectName",
+		},
+	}
+	dashboardSourceSpec := kubermaticv1.ProjectDashboardSourceSpec{
+		Project: corev1.ObjectReference{Name: "create"},
+		Git: appskubermaticv1.GitSource{
+			Remote: "https://example.com/dashboards.git",
+			Ref:    appskubermaticv1.GitReference{Branch: "main"},
+		},
+		Folder: "Team",
+	}
+
+	testCases := []struct {
+		name           string
+		objects        []ctrlruntimeclient.Object
+		dashboards     map[string]string
+		requests       []request
+		hasFinalizer   bool
+		expectedStates map[string]kubermaticv1.GrafanaDashboardsSyncState
+	}{
+		{
+			name: "sync dashboards of the repository into the project folder",
+			objects: []ctrlruntimeclient.Object{
+				project,
+				&kubermaticv1.ProjectDashboardSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "team",
+					},
+					Spec: dashboardSourceSpec,
+				},
+			},
+			dashboards:   map[string]string{"team/dashboard.json": dashboardData},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "get folders",
+					request:  httptest.NewRequest(http.MethodGet, "/api/folders", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "create folder",
+					request:  httptest.NewRequest(http.MethodPost, "/api/folders", strings.NewReader(string(folderData))),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"id": 5, "uid": "team", "title": "Team"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "set dashboard",
+					request:  httptest.NewRequest(http.MethodPost, "/api/dashboards/db", strings.NewReader(string(boardData))),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"status": "success", "uid": "unique", "version": 1}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {FolderUID: "team", Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+			},
+		},
+		{
+			name: "remove dashboards that were deleted from the repository",
+			objects: []ctrlruntimeclient.Object{
+				project,
+				&kubermaticv1.ProjectDashboardSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "team",
+					},
+					Spec: dashboardSourceSpec,
+					Status: kubermaticv1.ProjectDashboardSourceStatus{
+						Organizations: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+							"1": {FolderUID: "team", Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+						},
+					},
+				},
+			},
+			dashboards:   map[string]string{},
+			hasFinalizer: true,
+			requests: []request{
+				{
+					name:     "get folders",
+					request:  httptest.NewRequest(http.MethodGet, "/api/folders", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": 5, "uid": "team", "title": "Team"}]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "delete dashboard",
+					request:  httptest.NewRequest(http.MethodDelete, "/api/dashboards/uid/unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"title": "dashboard"}`)), StatusCode: http.StatusOK},
+				},
+			},
+			expectedStates: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+				"1": {FolderUID: "team"},
+			},
+		},
+		{
+			name: "delete dashboard source",
+			objects: []ctrlruntimeclient.Object{
+				project,
+				&kubermaticv1.ProjectDashboardSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "team",
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{mlaFinalizer, "do-not-remove"},
+					},
+					Spec: dashboardSourceSpec,
+					Status: kubermaticv1.ProjectDashboardSourceStatus{
+						Organizations: map[string]kubermaticv1.GrafanaDashboardsSyncState{
+							"1": {FolderUID: "team", Dashboards: map[string]kubermaticv1.GrafanaDashboardSyncState{"unique": {Hash: hash, Version: 1}}},
+						},
+					},
+				},
+			},
+			hasFinalizer: false,
+			requests: []request{
+				{
+					name:     "delete dashboard",
+					request:  httptest.NewRequest(http.MethodDelete, "/api/dashboards/uid/unique", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"title": "dashboard"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "get folders",
+					request:  httptest.NewRequest(http.MethodGet, "/api/folders", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": 5, "uid": "team", "title": "Team"}]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "search dashboards in folder",
+					request:  httptest.NewRequest(http.MethodGet, "/api/search?folderIds=5&type=dash-db", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`[]`)), StatusCode: http.StatusOK},
+				},
+				{
+					name:     "delete folder",
+					request:  httptest.NewRequest(http.MethodDelete, "/api/folders/team", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "Folder Team deleted"}`)), StatusCode: http.StatusOK},
+				},
+			},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			r, assertExpectation := buildTestServer(t, tc.requests...)
+			controller, server := newTestProjectDashboardSourceReconciler(t, tc.objects, r, tc.dashboards)
+			defer server.Close()
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "team"}}
+			result, err := controller.Reconcile(ctx, request)
+			assert.Nil(t, err)
+			if tc.hasFinalizer {
+				assert.Equal(t, dashboardResyncInterval, result.RequeueAfter)
+			}
+
+			dashboardSource := &kubermaticv1.ProjectDashboardSource{}
+			if err := controller.Get(ctx, request.NamespacedName, dashboardSource); err != nil {
+				t.Fatalf("unable to get projectDashboardSource: %v", err)
+			}
+			assert.Equal(t, tc.hasFinalizer, kubernetes.HasFinalizer(dashboardSource, mlaFinalizer))
+			assert.Equal(t, tc.expectedStates, dashboardSource.Status.Organizations)
+			assertExpectation()
+		})
+	}
+}
+
+func TestGitDashboardDownloader(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatalf("failed to init git repository: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	files := map[string]string{
+		"dashboards/cluster.json":      `{"uid": "cluster"}`,
+		"dashboards/team/service.json": `{"uid": "service"}`,
+		"dashboards/README.md":         "not a dashboard",
+		"other.json":                   `{"uid": "other"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(repoDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatalf("failed to add file: %v", err)
+		}
+	}
+	if _, err := worktree.Commit("add dashboards", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}}); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+
+	download := newGitDashboardDownloader(ctrlruntimefakeclient.NewClientBuilder().Build(), "mla")
+	dashboards, err := download(context.Background(), &kubermaticv1.ProjectDashboardSource{
+		Spec: kubermaticv1.ProjectDashboardSourceSpec{
+			Git: appskubermaticv1.GitSource{
+				Remote: repoDir,
+				Ref:    appskubermaticv1.GitReference{Branch: head.Name().Short()},
+				Path:   "dashboards",
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"cluster.json":                        `{"uid": "cluster"}`,
+		filepath.Join("team", "service.json"): `{"uid": "service"}`,
+	}, dashboards)
+}
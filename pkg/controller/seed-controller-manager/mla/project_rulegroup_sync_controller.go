@@ -0,0 +1,352 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mla
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	projectRuleGroupFinalizer = "kubermatic.k8c.io/project-rulegroup"
+	// ProjectRuleGroupLabelKey is set on RuleGroups that were created from a ProjectRuleGroup
+	// and contains the name of the ProjectRuleGroup.
+	ProjectRuleGroupLabelKey = "kubermatic.k8c.io/project-rulegroup"
+)
+
+type projectRuleGroupSyncReconciler struct {
+	ctrlruntimeclient.Client
+	log                            *zap.SugaredLogger
+	workerName                     string
+	recorder                       record.EventRecorder
+	versions                       kubermatic.Versions
+	projectRuleGroupSyncController *projectRuleGroupSyncController
+}
+
+func newProjectRuleGroupSyncReconciler(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	versions kubermatic.Versions,
+	projectRuleGroupSyncController *projectRuleGroupSyncController,
+) error {
+	log = log.Named(ControllerName)
+	client := mgr.GetClient()
+
+	reconciler := &projectRuleGroupSyncReconciler{
+		Client:                         client,
+		log:                            log.Named("project-rulegroup-sync"),
+		workerName:                     workerName,
+		recorder:                       mgr.GetEventRecorderFor(ControllerName),
+		versions:                       versions,
+		projectRuleGroupSyncController: projectRuleGroupSyncController,
+	}
+
+	ctrlOptions := controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	}
+	c, err := controller.New(ControllerName, mgr, ctrlOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.ProjectRuleGroup{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch ProjectRuleGroup: %w", err)
+	}
+
+	// re-sync the ProjectRuleGroups of a project whenever a cluster in it changes, e.g. when MLA
+	// gets enabled or the cluster namespace gets created
+	enqueueProjectRuleGroupsForCluster := handler.EnqueueRequestsFromMapFunc(func(object ctrlruntimeclient.Object) []reconcile.Request {
+		projectID := object.GetLabels()[kubermaticv1.ProjectIDLabelKey]
+		if projectID == "" {
+			return []reconcile.Request{}
+		}
+		projectRuleGroupList := &kubermaticv1.ProjectRuleGroupList{}
+		if err := client.List(context.Background(), projectRuleGroupList); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list projectrulegroups: %w", err))
+			return []reconcile.Request{}
+		}
+		var requests []reconcile.Request
+		for _, projectRuleGroup := range projectRuleGroupList.Items {
+			if projectRuleGroup.Spec.Project.Name == projectID {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: projectRuleGroup.Name}})
+			}
+		}
+		return requests
+	})
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, enqueueProjectRuleGroupsForCluster); err != nil {
+		return fmt.Errorf("failed to watch Cluster: %w", err)
+	}
+
+	// revert manual changes to the RuleGroups managed by this controller
+	enqueueProjectRuleGroupForRuleGroup := handler.EnqueueRequestsFromMapFunc(func(object ctrlruntimeclient.Object) []reconcile.Request {
+		name := object.GetLabels()[ProjectRuleGroupLabelKey]
+		if name == "" {
+			return []reconcile.Request{}
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+	})
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.RuleGroup{}}, enqueueProjectRuleGroupForRuleGroup); err != nil {
+		return fmt.Errorf("failed to watch RuleGroup: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRuleGroupSyncReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	projectRuleGroup := &kubermaticv1.ProjectRuleGroup{}
+	if err := r.Get(ctx, request.NamespacedName, projectRuleGroup); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !projectRuleGroup.DeletionTimestamp.IsZero() {
+		if err := r.projectRuleGroupSyncController.handleDeletion(ctx, projectRuleGroup); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete projectRuleGroup: %w", err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := kubernetes.TryAddFinalizer(ctx, r, projectRuleGroup, projectRuleGroupFinalizer); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+	}
+
+	if err := r.projectRuleGroupSyncController.reconcile(ctx, log, projectRuleGroup); err != nil {
+		r.recorder.Event(projectRuleGroup, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile projectrulegroup %s: %w", projectRuleGroup.Name, err)
+	}
+	return reconcile.Result{}, nil
+}
+
+type projectRuleGroupSyncController struct {
+	ctrlruntimeclient.Client
+	log *zap.SugaredLogger
+}
+
+func newProjectRuleGroupSyncController(
+	client ctrlruntimeclient.Client,
+	log *zap.SugaredLogger,
+) *projectRuleGroupSyncController {
+	return &projectRuleGroupSyncController{
+		Client: client,
+		log:    log,
+	}
+}
+
+func (r *projectRuleGroupSyncController) CleanUp(ctx context.Context) error {
+	projectRuleGroupList := &kubermaticv1.ProjectRuleGroupList{}
+	if err := r.List(ctx, projectRuleGroupList); err != nil {
+		return err
+	}
+	for _, projectRuleGroup := range projectRuleGroupList.Items {
+		if err := r.handleDeletion(ctx, &projectRuleGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *projectRuleGroupSyncController) handleDeletion(ctx context.Context, projectRuleGroup *kubermaticv1.ProjectRuleGroup) error {
+	if err := r.deleteStaleRuleGroups(ctx, projectRuleGroup, sets.NewString()); err != nil {
+		return err
+	}
+	return kubernetes.TryRemoveFinalizer(ctx, r, projectRuleGroup, projectRuleGroupFinalizer)
+}
+
+func (r *projectRuleGroupSyncController) reconcile(ctx context.Context, log *zap.SugaredLogger, projectRuleGroup *kubermaticv1.ProjectRuleGroup) error {
+	clusterList := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusterList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: projectRuleGroup.Spec.Project.Name}); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var errs []error
+	// namespaces in which the RuleGroup is expected to exist, all other copies will be removed
+	syncedNamespaces := sets.NewString()
+	clusterStatus := map[string]kubermaticv1.MLAClusterSyncStatus{}
+
+	for _, cluster := range clusterList.Items {
+		if cluster.Status.NamespaceName == "" {
+			log.Debugw("cluster namespace not available, skipping", "cluster", cluster.Name)
+			continue
+		}
+		if !cluster.DeletionTimestamp.IsZero() {
+			log.Debugw("cluster deletion in progress, skipping", "cluster", cluster.Name)
+			continue
+		}
+		if cluster.Spec.Pause {
+			log.Debugw("cluster paused, skipping", "cluster", cluster.Name)
+			syncedNamespaces.Insert(cluster.Status.NamespaceName)
+			if status, ok := projectRuleGroup.Status.Clusters[cluster.Name]; ok {
+				clusterStatus[cluster.Name] = status
+			}
+			continue
+		}
+		if !mlaEnabled(cluster) {
+			log.Debugw("cluster have mla disabled, skipping", "cluster", cluster.Name)
+			continue
+		}
+
+		data, enabled := projectRuleGroupDataForCluster(projectRuleGroup, cluster.Name)
+		if !enabled {
+			log.Debugw("projectrulegroup disabled for cluster, skipping", "cluster", cluster.Name)
+			continue
+		}
+
+		syncedNamespaces.Insert(cluster.Status.NamespaceName)
+		err := r.ensureRuleGroup(ctx, projectRuleGroup, &cluster, data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to sync rulegroup for cluster %s: %w", cluster.Name, err))
+		}
+		clusterStatus[cluster.Name] = newMLAClusterSyncStatus(projectRuleGroup.Status.Clusters[cluster.Name], err)
+	}
+
+	if err := r.deleteStaleRuleGroups(ctx, projectRuleGroup, syncedNamespaces); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := r.updateStatus(ctx, projectRuleGroup, clusterStatus); err != nil {
+		errs = append(errs, fmt.Errorf("failed to update status: %w", err))
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (r *projectRuleGroupSyncController) ensureRuleGroup(ctx context.Context, projectRuleGroup *kubermaticv1.ProjectRuleGroup, cluster *kubermaticv1.Cluster, data []byte) error {
+	// do not take over RuleGroups that were created by users or by the admin RuleGroup sync
+	existing := &kubermaticv1.RuleGroup{}
+	err := r.Get(ctx, types.NamespacedName{Name: projectRuleGroup.Name, Namespace: cluster.Status.NamespaceName}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get rulegroup: %w", err)
+	}
+	if err == nil && existing.Labels[ProjectRuleGroupLabelKey] != projectRuleGroup.Name {
+		return fmt.Errorf("rulegroup %s already exists in the cluster and is not managed by this projectrulegroup", projectRuleGroup.Name)
+	}
+
+	ruleGroupCreatorGetters := []reconciling.NamedKubermaticV1RuleGroupCreatorGetter{
+		projectRuleGroupCreatorGetter(projectRuleGroup, cluster, data),
+	}
+	return reconciling.ReconcileKubermaticV1RuleGroups(ctx, ruleGroupCreatorGetters, cluster.Status.NamespaceName, r)
+}
+
+func (r *projectRuleGroupSyncController) deleteStaleRuleGroups(ctx context.Context, projectRuleGroup *kubermaticv1.ProjectRuleGroup, syncedNamespaces sets.String) error {
+	ruleGroupList := &kubermaticv1.RuleGroupList{}
+	if err := r.List(ctx, ruleGroupList, ctrlruntimeclient.MatchingLabels{ProjectRuleGroupLabelKey: projectRuleGroup.Name}); err != nil {
+		return fmt.Errorf("failed to list rulegroups: %w", err)
+	}
+	for _, ruleGroup := range ruleGroupList.Items {
+		if syncedNamespaces.Has(ruleGroup.Namespace) {
+			continue
+		}
+		if err := r.Delete(ctx, &ruleGroup); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rulegroup in namespace %s: %w", ruleGroup.Namespace, err)
+		}
+	}
+	return nil
+}
+
+func (r *projectRuleGroupSyncController) updateStatus(ctx context.Context, projectRuleGroup *kubermaticv1.ProjectRuleGroup, clusterStatus map[string]kubermaticv1.MLAClusterSyncStatus) error {
+	oldProjectRuleGroup := projectRuleGroup.DeepCopy()
+	projectRuleGroup.Status.Clusters = clusterStatus
+	if len(clusterStatus) == 0 {
+		projectRuleGroup.Status.Clusters = nil
+	}
+	if equality.Semantic.DeepEqual(oldProjectRuleGroup.Status, projectRuleGroup.Status) {
+		return nil
+	}
+	return r.Status().Patch(ctx, projectRuleGroup, ctrlruntimeclient.MergeFrom(oldProjectRuleGroup))
+}
+
+// projectRuleGroupDataForCluster returns the RuleGroup data for the given cluster, taking the
+// cluster overrides into account. The second return value is false if the ruleGroup is disabled
+// for the cluster.
+func projectRuleGroupDataForCluster(projectRuleGroup *kubermaticv1.ProjectRuleGroup, clusterName string) ([]byte, bool) {
+	for _, override := range projectRuleGroup.Spec.ClusterOverrides {
+		if override.ClusterName != clusterName {
+			continue
+		}
+		if override.Disabled {
+			return nil, false
+		}
+		if len(override.Data) > 0 {
+			return override.Data, true
+		}
+	}
+	return projectRuleGroup.Spec.Data, true
+}
+
+func projectRuleGroupCreatorGetter(projectRuleGroup *kubermaticv1.ProjectRuleGroup, cluster *kubermaticv1.Cluster, data []byte) reconciling.NamedKubermaticV1RuleGroupCreatorGetter {
+	return func() (string, reconciling.KubermaticV1RuleGroupCreator) {
+		return projectRuleGroup.Name, func(r *kubermaticv1.RuleGroup) (*kubermaticv1.RuleGroup, error) {
+			r.Name = projectRuleGroup.Name
+			if r.Labels == nil {
+				r.Labels = map[string]string{}
+			}
+			r.Labels[ProjectRuleGroupLabelKey] = projectRuleGroup.Name
+			r.Spec = kubermaticv1.RuleGroupSpec{
+				RuleGroupType: projectRuleGroup.Spec.RuleGroupType,
+				Cluster: corev1.ObjectReference{
+					Name: cluster.Name,
+				},
+				Data: data,
+			}
+			return r, nil
+		}
+	}
+}
+
+// newMLAClusterSyncStatus returns the sync status for a cluster based on the outcome of the
+// last sync. LastUpdated is only changed if the status changes, to not update the object
+// on every reconciliation.
+func newMLAClusterSyncStatus(old kubermaticv1.MLAClusterSyncStatus, syncErr error) kubermaticv1.MLAClusterSyncStatus {
+	status := kubermaticv1.MLAClusterSyncStatus{
+		Status: corev1.ConditionTrue,
+	}
+	if syncErr != nil {
+		status.Status = corev1.ConditionFalse
+		status.ErrorMessage = syncErr.Error()
+	}
+	status.LastUpdated = old.LastUpdated
+	if old.Status != status.Status || old.LastUpdated.IsZero() {
+		status.LastUpdated = metav1.Now()
+	}
+	return status
+}
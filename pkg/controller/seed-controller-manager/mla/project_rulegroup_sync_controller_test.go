@@ -0,0 +1,217 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mla
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestProjectRuleGroupSyncReconciler(objects []ctrlruntimeclient.Object) *projectRuleGroupSyncReconciler {
+	fakeClient := ctrlruntimefakeclient.
+		NewClientBuilder().
+		WithObjects(objects...).
+		WithScheme(testScheme).
+		Build()
+	controller := newProjectRuleGroupSyncController(fakeClient, kubermaticlog.Logger)
+	reconciler := projectRuleGroupSyncReconciler{
+		Client:                         fakeClient,
+		log:                            kubermaticlog.Logger,
+		recorder:                       record.NewFakeRecorder(10),
+		projectRuleGroupSyncController: controller,
+	}
+	return &reconciler
+}
+
+func TestProjectRuleGroupSyncReconcile(t *testing.T) {
+	overrideData := test.GenerateTestRuleGroupData("override")
+
+	testCases := []struct {
+		name           string
+		objects        []ctrlruntimeclient.Object
+		expectedErr    bool
+		expectedData   map[string][]byte
+		expectedStatus map[string]corev1.ConditionStatus
+	}{
+		{
+			name: "sync rulegroup to mla enabled clusters of the project",
+			objects: []ctrlruntimeclient.Object{
+				generateProjectCluster("a", "my-project", true),
+				generateProjectCluster("b", "my-project", true),
+				generateProjectCluster("c", "my-project", false),
+				generateProjectCluster("d", "other-project", true),
+				generateProjectRuleGroup("test-rule", "my-project", nil),
+			},
+			expectedData: map[string][]byte{
+				"cluster-a": test.GenerateTestRuleGroupData("test-rule"),
+				"cluster-b": test.GenerateTestRuleGroupData("test-rule"),
+			},
+			expectedStatus: map[string]corev1.ConditionStatus{
+				"a": corev1.ConditionTrue,
+				"b": corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "apply cluster overrides",
+			objects: []ctrlruntimeclient.Object{
+				generateProjectCluster("a", "my-project", true),
+				generateProjectCluster("b", "my-project", true),
+				generateProjectCluster("c", "my-project", true),
+				generateProjectRuleGroup("test-rule", "my-project", []kubermaticv1.ProjectRuleGroupClusterOverride{
+					{
+						ClusterName: "b",
+						Data:        overrideData,
+					},
+					{
+						ClusterName: "c",
+						Disabled:    true,
+					},
+				}),
+			},
+			expectedData: map[string][]byte{
+				"cluster-a": test.GenerateTestRuleGroupData("test-rule"),
+				"cluster-b": overrideData,
+			},
+			expectedStatus: map[string]corev1.ConditionStatus{
+				"a": corev1.ConditionTrue,
+				"b": corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "remove rulegroup from clusters which are no longer targeted",
+			objects: []ctrlruntimeclient.Object{
+				generateProjectCluster("a", "my-project", true),
+				generateProjectCluster("b", "my-project", false),
+				generateProjectRuleGroup("test-rule", "my-project", nil),
+				generateManagedRuleGroup("test-rule", "cluster-b"),
+				generateManagedRuleGroup("test-rule", "cluster-x"),
+			},
+			expectedData: map[string][]byte{
+				"cluster-a": test.GenerateTestRuleGroupData("test-rule"),
+			},
+			expectedStatus: map[string]corev1.ConditionStatus{
+				"a": corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "do not overwrite rulegroup which is not managed by the projectrulegroup",
+			objects: []ctrlruntimeclient.Object{
+				generateProjectCluster("a", "my-project", true),
+				generateProjectCluster("b", "my-project", true),
+				generateProjectRuleGroup("test-rule", "my-project", nil),
+				generateMLARuleGroup("test-rule", "cluster-b", kubermaticv1.RuleGroupTypeLogs, false),
+			},
+			expectedErr: true,
+			expectedData: map[string][]byte{
+				"cluster-a": test.GenerateTestRuleGroupData("test-rule"),
+			},
+			expectedStatus: map[string]corev1.ConditionStatus{
+				"a": corev1.ConditionTrue,
+				"b": corev1.ConditionFalse,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			reconciler := newTestProjectRuleGroupSyncReconciler(tc.objects)
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-rule"}}
+			_, err := reconciler.Reconcile(ctx, req)
+			assert.Equal(t, tc.expectedErr, err != nil, "unexpected error: %v", err)
+
+			ruleGroupList := &kubermaticv1.RuleGroupList{}
+			assert.NoError(t, reconciler.List(ctx, ruleGroupList, ctrlruntimeclient.MatchingLabels{ProjectRuleGroupLabelKey: "test-rule"}))
+			data := map[string][]byte{}
+			for _, ruleGroup := range ruleGroupList.Items {
+				data[ruleGroup.Namespace] = ruleGroup.Spec.Data
+			}
+			assert.Equal(t, tc.expectedData, data)
+
+			projectRuleGroup := &kubermaticv1.ProjectRuleGroup{}
+			assert.NoError(t, reconciler.Get(ctx, req.NamespacedName, projectRuleGroup))
+			status := map[string]corev1.ConditionStatus{}
+			for clusterName, clusterStatus := range projectRuleGroup.Status.Clusters {
+				status[clusterName] = clusterStatus.Status
+			}
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func TestProjectRuleGroupSyncDeletion(t *testing.T) {
+	ctx := context.Background()
+	projectRuleGroup := generateProjectRuleGroup("test-rule", "my-project", nil)
+	projectRuleGroup.Finalizers = []string{projectRuleGroupFinalizer, "just-a-test-do-not-delete-thanks"}
+	deleteTime := metav1.Now()
+	projectRuleGroup.DeletionTimestamp = &deleteTime
+
+	reconciler := newTestProjectRuleGroupSyncReconciler([]ctrlruntimeclient.Object{
+		generateProjectCluster("a", "my-project", true),
+		projectRuleGroup,
+		generateManagedRuleGroup("test-rule", "cluster-a"),
+	})
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-rule"}})
+	assert.NoError(t, err)
+
+	ruleGroupList := &kubermaticv1.RuleGroupList{}
+	assert.NoError(t, reconciler.List(ctx, ruleGroupList))
+	assert.Empty(t, ruleGroupList.Items)
+
+	assert.NoError(t, reconciler.Get(ctx, types.NamespacedName{Name: "test-rule"}, projectRuleGroup))
+	assert.NotContains(t, projectRuleGroup.Finalizers, projectRuleGroupFinalizer)
+}
+
+func generateProjectCluster(name, projectID string, mlaEnabled bool) *kubermaticv1.Cluster {
+	cluster := generateCluster(name, mlaEnabled, false, false)
+	cluster.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectID}
+	return cluster
+}
+
+func generateProjectRuleGroup(name, projectID string, overrides []kubermaticv1.ProjectRuleGroupClusterOverride) *kubermaticv1.ProjectRuleGroup {
+	return &kubermaticv1.ProjectRuleGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.ProjectRuleGroupSpec{
+			Project: corev1.ObjectReference{
+				Name: projectID,
+			},
+			RuleGroupType:    kubermaticv1.RuleGroupTypeMetrics,
+			Data:             test.GenerateTestRuleGroupData(name),
+			ClusterOverrides: overrides,
+		},
+	}
+}
+
+func generateManagedRuleGroup(name, namespace string) *kubermaticv1.RuleGroup {
+	ruleGroup := generateMLARuleGroup(name, namespace, kubermaticv1.RuleGroupTypeMetrics, false)
+	ruleGroup.Labels = map[string]string{ProjectRuleGroupLabelKey: name}
+	return ruleGroup
+}
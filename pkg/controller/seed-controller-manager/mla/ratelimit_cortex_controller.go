@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/prometheus/common/model"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 
@@ -48,12 +49,13 @@ const (
 )
 
 type TenantOverride struct {
-	IngestionRate      *int32 `yaml:"ingestion_rate,omitempty"`
-	MaxSeriesPerMetric *int32 `yaml:"max_series_per_metric,omitempty"`
-	MaxSeriesPerQuery  *int32 `yaml:"max_series_per_query,omitempty"`
-	MaxSamplesPerQuery *int32 `yaml:"max_samples_per_query,omitempty"`
-	IngestionBurstSize *int32 `yaml:"ingestion_burst_size,omitempty"`
-	MaxSeriesTotal     *int32 `yaml:"max_series_per_user,omitempty"`
+	IngestionRate      *int32  `yaml:"ingestion_rate,omitempty"`
+	MaxSeriesPerMetric *int32  `yaml:"max_series_per_metric,omitempty"`
+	MaxSeriesPerQuery  *int32  `yaml:"max_series_per_query,omitempty"`
+	MaxSamplesPerQuery *int32  `yaml:"max_samples_per_query,omitempty"`
+	IngestionBurstSize *int32  `yaml:"ingestion_burst_size,omitempty"`
+	MaxSeriesTotal     *int32  `yaml:"max_series_per_user,omitempty"`
+	RetentionPeriod    *string `yaml:"compactor_blocks_retention_period,omitempty"`
 }
 
 type Overrides struct {
@@ -196,6 +198,14 @@ func (r *ratelimitCortexController) ensureLimits(ctx context.Context, mlaAdminSe
 			tenantOr.MaxSeriesTotal = &mlaAdminSetting.Spec.MonitoringRateLimits.MaxSeriesTotal
 		}
 	}
+	if mlaAdminSetting.Spec.MonitoringRetention != "" {
+		retention, err := model.ParseDuration(mlaAdminSetting.Spec.MonitoringRetention)
+		if err != nil {
+			return fmt.Errorf("invalid monitoring retention: %w", err)
+		}
+		retentionPeriod := retention.String()
+		tenantOr.RetentionPeriod = &retentionPeriod
+	}
 	if or.Overrides == nil {
 		or.Overrides = make(map[string]TenantOverride)
 	}
@@ -130,6 +130,7 @@ func TestRatelimitCortexReconcile(t *testing.T) {
 							MaxSamplesPerQuery: 5,
 							MaxSeriesPerQuery:  6,
 						},
+						MonitoringRetention: "720h",
 					},
 				},
 				&corev1.ConfigMap{
@@ -149,6 +150,7 @@ func TestRatelimitCortexReconcile(t *testing.T) {
 						MaxSeriesTotal:     utilpointer.Int32(4),
 						MaxSamplesPerQuery: utilpointer.Int32(5),
 						MaxSeriesPerQuery:  utilpointer.Int32(6),
+						RetentionPeriod:    utilpointer.String("30d"),
 					},
 				},
 			},
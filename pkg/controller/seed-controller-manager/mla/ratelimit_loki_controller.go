@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	LokiRuntimeConfigMap   = "loki-runtime-config"
+	lokiRatelimitFinalizer = "kubermatic.k8c.io/mla-loki-ratelimit"
+)
+
+type LokiTenantOverride struct {
+	IngestionRateMB *int32  `yaml:"ingestion_rate_mb,omitempty"`
+	MaxStreamsTotal *int32  `yaml:"max_global_streams_per_user,omitempty"`
+	RetentionPeriod *string `yaml:"retention_period,omitempty"`
+}
+
+type LokiOverrides struct {
+	Overrides map[string]LokiTenantOverride `yaml:"overrides"`
+}
+
+// ratelimitLokiReconciler stores necessary components that are required to manage the Loki limits of user clusters.
+type ratelimitLokiReconciler struct {
+	ctrlruntimeclient.Client
+
+	log                     *zap.SugaredLogger
+	workerName              string
+	recorder                record.EventRecorder
+	versions                kubermatic.Versions
+	ratelimitLokiController *ratelimitLokiController
+}
+
+func newRatelimitLokiReconciler(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	versions kubermatic.Versions,
+	ratelimitLokiController *ratelimitLokiController,
+) error {
+	client := mgr.GetClient()
+
+	reconciler := &ratelimitLokiReconciler{
+		Client: client,
+
+		log:                     log.Named("loki-ratelimit"),
+		workerName:              workerName,
+		recorder:                mgr.GetEventRecorderFor(ControllerName),
+		versions:                versions,
+		ratelimitLokiController: ratelimitLokiController,
+	}
+
+	ctrlOptions := controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	}
+	c, err := controller.New(ControllerName, mgr, ctrlOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.MLAAdminSetting{}}, &handler.EnqueueRequestForObject{}, predicateutil.ByName(resources.MLAAdminSettingsName)); err != nil {
+		return fmt.Errorf("failed to watch MLAAdminSetting: %w", err)
+	}
+
+	return err
+}
+
+func (r *ratelimitLokiReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	mlaAdminSetting := &kubermaticv1.MLAAdminSetting{}
+	if err := r.Get(ctx, request.NamespacedName, mlaAdminSetting); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !mlaAdminSetting.DeletionTimestamp.IsZero() {
+		if err := r.ratelimitLokiController.handleDeletion(ctx, log, mlaAdminSetting); err != nil {
+			return reconcile.Result{}, fmt.Errorf("handling deletion: %w", err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := kubernetes.TryAddFinalizer(ctx, r, mlaAdminSetting, lokiRatelimitFinalizer); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+	}
+
+	if err := r.ratelimitLokiController.ensureLimits(ctx, log, mlaAdminSetting); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure limits: %w", err)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+type ratelimitLokiController struct {
+	ctrlruntimeclient.Client
+	mlaNamespace string
+
+	log *zap.SugaredLogger
+}
+
+func newRatelimitLokiController(
+	client ctrlruntimeclient.Client,
+	log *zap.SugaredLogger,
+	mlaNamespace string,
+) *ratelimitLokiController {
+	return &ratelimitLokiController{
+		Client:       client,
+		mlaNamespace: mlaNamespace,
+
+		log: log,
+	}
+}
+
+// getRuntimeConfig returns the Loki runtime config map and the overrides it contains. The config map
+// is nil if it does not exist yet; it is created once the first limits are configured.
+func (r *ratelimitLokiController) getRuntimeConfig(ctx context.Context) (*corev1.ConfigMap, *LokiOverrides, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.mlaNamespace, Name: LokiRuntimeConfigMap}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("unable to get loki runtime config map: %w", err)
+	}
+	config, ok := configMap.Data[RuntimeConfigFileName]
+	if !ok {
+		return nil, nil, errors.New("unable to find runtime config file in configmap")
+	}
+	or := &LokiOverrides{}
+
+	decoder := yaml.NewDecoder(strings.NewReader(config))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(or); err != nil {
+		return nil, nil, fmt.Errorf("unable to unmarshal runtime config[%s]: %w", config, err)
+	}
+	return configMap, or, nil
+}
+
+func (r *ratelimitLokiController) ensureLimits(ctx context.Context, log *zap.SugaredLogger, mlaAdminSetting *kubermaticv1.MLAAdminSetting) error {
+	tenantOr := LokiTenantOverride{}
+
+	if mlaAdminSetting.Spec.LoggingRateLimits != nil {
+		if mlaAdminSetting.Spec.LoggingRateLimits.IngestionRateMB > 0 {
+			tenantOr.IngestionRateMB = &mlaAdminSetting.Spec.LoggingRateLimits.IngestionRateMB
+		}
+		if mlaAdminSetting.Spec.LoggingRateLimits.MaxStreamsTotal > 0 {
+			tenantOr.MaxStreamsTotal = &mlaAdminSetting.Spec.LoggingRateLimits.MaxStreamsTotal
+		}
+	}
+	if mlaAdminSetting.Spec.LoggingRetention != "" {
+		retention, err := model.ParseDuration(mlaAdminSetting.Spec.LoggingRetention)
+		if err != nil {
+			return fmt.Errorf("invalid logging retention: %w", err)
+		}
+		retentionPeriod := retention.String()
+		tenantOr.RetentionPeriod = &retentionPeriod
+	}
+
+	configMap, or, err := r.getRuntimeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if configMap == nil && tenantOr == (LokiTenantOverride{}) {
+		log.Debug("Loki runtime config map does not exist and no limits are configured, skipping")
+		return nil
+	}
+
+	if configMap == nil {
+		or = &LokiOverrides{}
+	}
+	if or.Overrides == nil {
+		or.Overrides = make(map[string]LokiTenantOverride)
+	}
+	or.Overrides[mlaAdminSetting.Spec.ClusterName] = tenantOr
+	data, err := yaml.Marshal(or)
+	if err != nil {
+		return fmt.Errorf("unable to marshal runtime config[%+v]: %w", or, err)
+	}
+
+	// Loki only picks up the overrides if it is started with the runtime config file of this
+	// config map (`runtime_config.file`). The per-tenant retention period is furthermore only
+	// enforced if the compactor runs with `retention_enabled: true`.
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      LokiRuntimeConfigMap,
+				Namespace: r.mlaNamespace,
+			},
+			Data: map[string]string{RuntimeConfigFileName: string(data)},
+		}
+		if err := r.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("unable to create loki runtime config map: %w", err)
+		}
+		return nil
+	}
+
+	configMap.Data[RuntimeConfigFileName] = string(data)
+	return r.Update(ctx, configMap)
+}
+
+func (r *ratelimitLokiController) CleanUp(ctx context.Context) error {
+	mlaAdminSettingList := &kubermaticv1.MLAAdminSettingList{}
+	if err := r.List(ctx, mlaAdminSettingList); err != nil {
+		return fmt.Errorf("Failed to list mlaAdminSetting: %w", err)
+	}
+	for _, mlaAdminSetting := range mlaAdminSettingList.Items {
+		if err := r.handleDeletion(ctx, r.log, &mlaAdminSetting); err != nil {
+			return fmt.Errorf("handling deletion: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *ratelimitLokiController) handleDeletion(ctx context.Context, log *zap.SugaredLogger, mlaAdminSetting *kubermaticv1.MLAAdminSetting) error {
+	configMap, or, err := r.getRuntimeConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if configMap != nil {
+		if _, ok := or.Overrides[mlaAdminSetting.Spec.ClusterName]; ok {
+			delete(or.Overrides, mlaAdminSetting.Spec.ClusterName)
+			data, err := yaml.Marshal(or)
+			if err != nil {
+				return fmt.Errorf("unable to marshal runtime config[%+v]: %w", or, err)
+			}
+			configMap.Data[RuntimeConfigFileName] = string(data)
+			if err := r.Update(ctx, configMap); err != nil {
+				return fmt.Errorf("unable to update configmap: %w", err)
+			}
+		}
+	}
+
+	return kubernetes.TryRemoveFinalizer(ctx, r, mlaAdminSetting, lokiRatelimitFinalizer)
+}
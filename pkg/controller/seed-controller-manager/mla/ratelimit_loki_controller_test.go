@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mla
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestRatelimitLokiReconciler(objects []ctrlruntimeclient.Object) *ratelimitLokiReconciler {
+	fakeClient := ctrlruntimefakeclient.
+		NewClientBuilder().
+		WithObjects(objects...).
+		WithScheme(testScheme).
+		Build()
+	ratelimitLokiController := newRatelimitLokiController(fakeClient, kubermaticlog.Logger, "mla")
+	reconciler := ratelimitLokiReconciler{
+		Client:                  fakeClient,
+		log:                     kubermaticlog.Logger,
+		recorder:                record.NewFakeRecorder(10),
+		ratelimitLokiController: ratelimitLokiController,
+	}
+	return &reconciler
+}
+
+func TestRatelimitLokiReconcile(t *testing.T) {
+	oldTenantOverride := LokiTenantOverride{
+		IngestionRateMB: utilpointer.Int32(1),
+		MaxStreamsTotal: utilpointer.Int32(1),
+		RetentionPeriod: utilpointer.String("1d"),
+	}
+	data, err := yaml.Marshal(LokiOverrides{Overrides: map[string]LokiTenantOverride{"old": oldTenantOverride}})
+	assert.Nil(t, err)
+	oldRatelimitConfigData := string(data)
+
+	generateRuntimeConfigMap := func(config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      LokiRuntimeConfigMap,
+				Namespace: "mla",
+			},
+			Data: map[string]string{RuntimeConfigFileName: config},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		request           types.NamespacedName
+		objects           []ctrlruntimeclient.Object
+		expectedOverrides *LokiOverrides
+		hasFinalizer      bool
+		err               bool
+	}{
+		{
+			name: "create MLAAdmin settings with values",
+			request: types.NamespacedName{
+				Namespace: "cluster-123",
+				Name:      resources.MLAAdminSettingsName,
+			},
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.MLAAdminSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.MLAAdminSettingsName,
+						Namespace: "cluster-123",
+					},
+					Spec: kubermaticv1.MLAAdminSettingSpec{
+						ClusterName: "123",
+						LoggingRateLimits: &kubermaticv1.LoggingRateLimitSettings{
+							IngestionRate:   10,
+							IngestionRateMB: 2,
+							MaxStreamsTotal: 3,
+						},
+						LoggingRetention: "168h",
+					},
+				},
+				generateRuntimeConfigMap(oldRatelimitConfigData),
+			},
+			expectedOverrides: &LokiOverrides{
+				Overrides: map[string]LokiTenantOverride{
+					"123": {
+						IngestionRateMB: utilpointer.Int32(2),
+						MaxStreamsTotal: utilpointer.Int32(3),
+						RetentionPeriod: utilpointer.String("1w"),
+					},
+					"old": oldTenantOverride,
+				},
+			},
+			hasFinalizer: true,
+		},
+		{
+			name: "skip MLAAdmin settings without loki limits if runtime config does not exist",
+			request: types.NamespacedName{
+				Namespace: "cluster-123",
+				Name:      resources.MLAAdminSettingsName,
+			},
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.MLAAdminSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.MLAAdminSettingsName,
+						Namespace: "cluster-123",
+					},
+					Spec: kubermaticv1.MLAAdminSettingSpec{
+						ClusterName: "123",
+						LoggingRateLimits: &kubermaticv1.LoggingRateLimitSettings{
+							IngestionRate: 10,
+						},
+					},
+				},
+			},
+			hasFinalizer: true,
+		},
+		{
+			name: "create runtime config for MLAAdmin settings with loki limits if it does not exist",
+			request: types.NamespacedName{
+				Namespace: "cluster-123",
+				Name:      resources.MLAAdminSettingsName,
+			},
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.MLAAdminSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.MLAAdminSettingsName,
+						Namespace: "cluster-123",
+					},
+					Spec: kubermaticv1.MLAAdminSettingSpec{
+						ClusterName:      "123",
+						LoggingRetention: "7d",
+					},
+				},
+			},
+			expectedOverrides: &LokiOverrides{
+				Overrides: map[string]LokiTenantOverride{
+					"123": {
+						RetentionPeriod: utilpointer.String("1w"),
+					},
+				},
+			},
+			hasFinalizer: true,
+		},
+		{
+			name: "delete MLAAdmin settings",
+			request: types.NamespacedName{
+				Namespace: "cluster-old",
+				Name:      resources.MLAAdminSettingsName,
+			},
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.MLAAdminSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              resources.MLAAdminSettingsName,
+						Namespace:         "cluster-old",
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{lokiRatelimitFinalizer, "do-not-remove"},
+					},
+					Spec: kubermaticv1.MLAAdminSettingSpec{
+						ClusterName: "old",
+					},
+				},
+				generateRuntimeConfigMap(oldRatelimitConfigData),
+			},
+			expectedOverrides: &LokiOverrides{
+				Overrides: map[string]LokiTenantOverride{},
+			},
+			hasFinalizer: false,
+		},
+	}
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			controller := newTestRatelimitLokiReconciler(tc.objects)
+			request := reconcile.Request{NamespacedName: tc.request}
+			_, err := controller.Reconcile(ctx, request)
+			if err != nil && !tc.err {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.err, err != nil)
+			configMap := &corev1.ConfigMap{}
+			err = controller.Get(ctx, types.NamespacedName{Namespace: "mla", Name: LokiRuntimeConfigMap}, configMap)
+			if tc.expectedOverrides == nil {
+				assert.True(t, apierrors.IsNotFound(err))
+			} else {
+				if err != nil {
+					t.Fatalf("unable to get configMap: %v", err)
+				}
+				actualOverrides := &LokiOverrides{}
+				decoder := yaml.NewDecoder(strings.NewReader(configMap.Data[RuntimeConfigFileName]))
+				decoder.KnownFields(true)
+				assert.Nil(t, decoder.Decode(actualOverrides))
+				assert.Equal(t, *tc.expectedOverrides, *actualOverrides)
+			}
+			mlaAdminSetting := &kubermaticv1.MLAAdminSetting{}
+			if err := controller.Get(ctx, tc.request, mlaAdminSetting); err != nil {
+				t.Fatalf("unable to get mlaAdminSetting: %v", err)
+			}
+			assert.Equal(t, tc.hasFinalizer, kubernetes.HasFinalizer(mlaAdminSetting, lokiRatelimitFinalizer))
+		})
+	}
+}
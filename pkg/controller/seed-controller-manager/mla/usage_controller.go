@@ -0,0 +1,322 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mla
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// usageRefreshInterval is the interval in which the usage of user clusters is collected.
+	usageRefreshInterval = 5 * time.Minute
+
+	CortexUserStatsEndpoint = "/api/v1/user_stats"
+	LokiSeriesEndpoint      = "/loki/api/v1/series"
+	LokiQueryEndpoint       = "/loki/api/v1/query"
+
+	// lokiStreamSelector matches all log streams shipped by the user cluster promtail.
+	lokiStreamSelector = `{namespace=~".+"}`
+)
+
+// usageReconciler collects the resource usage of user clusters in the MLA stack and stores it
+// in the status of their MLAAdminSetting.
+type usageReconciler struct {
+	ctrlruntimeclient.Client
+
+	log             *zap.SugaredLogger
+	workerName      string
+	recorder        record.EventRecorder
+	versions        kubermatic.Versions
+	usageController *usageController
+}
+
+func newUsageReconciler(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	versions kubermatic.Versions,
+	usageController *usageController,
+) error {
+	client := mgr.GetClient()
+
+	reconciler := &usageReconciler{
+		Client: client,
+
+		log:             log.Named("usage"),
+		workerName:      workerName,
+		recorder:        mgr.GetEventRecorderFor(ControllerName),
+		versions:        versions,
+		usageController: usageController,
+	}
+
+	ctrlOptions := controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	}
+	c, err := controller.New(ControllerName, mgr, ctrlOptions)
+	if err != nil {
+		return err
+	}
+
+	// ignore status updates, which are made by this controller itself
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.MLAAdminSetting{}}, &handler.EnqueueRequestForObject{}, predicateutil.ByName(resources.MLAAdminSettingsName), predicate.GenerationChangedPredicate{}); err != nil {
+		return fmt.Errorf("failed to watch MLAAdminSetting: %w", err)
+	}
+
+	return err
+}
+
+func (r *usageReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	mlaAdminSetting := &kubermaticv1.MLAAdminSetting{}
+	if err := r.Get(ctx, request.NamespacedName, mlaAdminSetting); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if !mlaAdminSetting.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: mlaAdminSetting.Spec.ClusterName}, cluster); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		return reconcile.Result{}, nil
+	}
+
+	// keep polling, as unpausing the cluster does not trigger a reconciliation
+	if cluster.Spec.Pause {
+		log.Debug("Cluster is paused, not collecting usage")
+		return reconcile.Result{RequeueAfter: usageRefreshInterval}, nil
+	}
+
+	if err := r.usageController.ensureUsage(ctx, mlaAdminSetting, cluster); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update usage: %w", err)
+	}
+
+	// usage changes without any events we could watch, so it must be polled
+	return reconcile.Result{RequeueAfter: usageRefreshInterval}, nil
+}
+
+type usageController struct {
+	ctrlruntimeclient.Client
+	httpClient *http.Client
+
+	log                  *zap.SugaredLogger
+	cortexDistributorURL string
+	lokiQuerierURL       string
+}
+
+func newUsageController(
+	client ctrlruntimeclient.Client,
+	log *zap.SugaredLogger,
+	httpClient *http.Client,
+	cortexDistributorURL string,
+	lokiQuerierURL string,
+) *usageController {
+	return &usageController{
+		Client:     client,
+		httpClient: httpClient,
+
+		log:                  log,
+		cortexDistributorURL: cortexDistributorURL,
+		lokiQuerierURL:       lokiQuerierURL,
+	}
+}
+
+// ensureUsage collects the Cortex and Loki usage of the cluster independently, so that one of
+// them being unavailable does not prevent the usage of the other from being updated. The last
+// known usage is kept for the one that failed.
+func (r *usageController) ensureUsage(ctx context.Context, mlaAdminSetting *kubermaticv1.MLAAdminSetting, cluster *kubermaticv1.Cluster) error {
+	oldMLAAdminSetting := mlaAdminSetting.DeepCopy()
+
+	oldUsage := &kubermaticv1.MLAUsage{}
+	if oldMLAAdminSetting.Status.Usage != nil {
+		oldUsage = oldMLAAdminSetting.Status.Usage
+	}
+	// the usage is compared without its timestamp, as it would otherwise always differ
+	usage := &kubermaticv1.MLAUsage{LastUpdated: oldUsage.LastUpdated}
+
+	var errs []error
+
+	if cluster.Spec.MLA != nil && cluster.Spec.MLA.MonitoringEnabled {
+		stats, err := r.getCortexUserStats(ctx, cluster)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get cortex usage: %w", err))
+			usage.Series = oldUsage.Series
+			usage.IngestionRate = oldUsage.IngestionRate
+		} else {
+			usage.Series = stats.NumSeries
+			usage.IngestionRate = int64(math.Round(stats.IngestionRate))
+		}
+	}
+
+	if cluster.Spec.MLA != nil && cluster.Spec.MLA.LoggingEnabled {
+		if err := r.collectLokiUsage(ctx, cluster, usage); err != nil {
+			errs = append(errs, err)
+			usage.LogStreams = oldUsage.LogStreams
+			usage.LogIngestionRate = oldUsage.LogIngestionRate
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(oldMLAAdminSetting.Status.Usage, usage) {
+		usage.LastUpdated = metav1.Now()
+		mlaAdminSetting.Status.Usage = usage
+		if err := r.Status().Patch(ctx, mlaAdminSetting, ctrlruntimeclient.MergeFrom(oldMLAAdminSetting)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to patch MLAAdminSetting status: %w", err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (r *usageController) collectLokiUsage(ctx context.Context, cluster *kubermaticv1.Cluster, usage *kubermaticv1.MLAUsage) error {
+	streams, err := r.getLokiStreamCount(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get loki stream count: %w", err)
+	}
+
+	ingestionRate, err := r.getLokiIngestionRate(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get loki ingestion rate: %w", err)
+	}
+
+	usage.LogStreams = streams
+	usage.LogIngestionRate = int64(math.Round(ingestionRate))
+
+	return nil
+}
+
+// cortexUserStats is the response of the Cortex distributor user stats API.
+type cortexUserStats struct {
+	NumSeries     int64   `json:"numSeries"`
+	IngestionRate float64 `json:"ingestionRate"`
+}
+
+func (r *usageController) getCortexUserStats(ctx context.Context, cluster *kubermaticv1.Cluster) (*cortexUserStats, error) {
+	stats := &cortexUserStats{}
+	if err := r.get(ctx, r.cortexDistributorURL+CortexUserStatsEndpoint, nil, cluster, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *usageController) getLokiStreamCount(ctx context.Context, cluster *kubermaticv1.Cluster) (int64, error) {
+	now := time.Now()
+	query := url.Values{}
+	query.Set("match[]", lokiStreamSelector)
+	query.Set("start", strconv.FormatInt(now.Add(-time.Hour).UnixNano(), 10))
+	query.Set("end", strconv.FormatInt(now.UnixNano(), 10))
+
+	response := struct {
+		Data []map[string]string `json:"data"`
+	}{}
+	if err := r.get(ctx, r.lokiQuerierURL+LokiSeriesEndpoint, query, cluster, &response); err != nil {
+		return 0, err
+	}
+	return int64(len(response.Data)), nil
+}
+
+func (r *usageController) getLokiIngestionRate(ctx context.Context, cluster *kubermaticv1.Cluster) (float64, error) {
+	query := url.Values{}
+	query.Set("query", fmt.Sprintf("sum(bytes_rate(%s[5m]))", lokiStreamSelector))
+
+	response := struct {
+		Data struct {
+			Result []struct {
+				Value []interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}{}
+	if err := r.get(ctx, r.lokiQuerierURL+LokiQueryEndpoint, query, cluster, &response); err != nil {
+		return 0, err
+	}
+	// no result means that no logs were received within the range
+	if len(response.Data.Result) == 0 {
+		return 0, nil
+	}
+	value := response.Data.Result[0].Value
+	if len(value) != 2 {
+		return 0, fmt.Errorf("unexpected sample %v", value)
+	}
+	sample, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", value[1])
+	}
+	return strconv.ParseFloat(sample, 64)
+}
+
+func (r *usageController) get(ctx context.Context, endpoint string, query url.Values, cluster *kubermaticv1.Cluster, result interface{}) error {
+	if len(query) > 0 {
+		endpoint = endpoint + "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add(AlertmanagerTenantHeaderName, cluster.Name)
+	// the Cortex user stats endpoint renders HTML unless JSON is explicitly requested
+	req.Header.Add("Accept", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("status code: %d,error: %w", resp.StatusCode, err)
+		}
+		return fmt.Errorf("status code: %d, response body: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("unable to decode response body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mla
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestUsageReconciler(objects []ctrlruntimeclient.Object, handler http.Handler) (*usageReconciler, *httptest.Server) {
+	fakeClient := ctrlruntimefakeclient.
+		NewClientBuilder().
+		WithObjects(objects...).
+		WithScheme(testScheme).
+		Build()
+	ts := httptest.NewServer(handler)
+
+	usageController := newUsageController(fakeClient, kubermaticlog.Logger, ts.Client(), ts.URL, ts.URL)
+	reconciler := usageReconciler{
+		Client:          fakeClient,
+		log:             kubermaticlog.Logger,
+		recorder:        record.NewFakeRecorder(10),
+		usageController: usageController,
+	}
+	return &reconciler, ts
+}
+
+func TestUsageReconcile(t *testing.T) {
+	newHandler := func(lokiAvailable bool) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "test", r.Header.Get(AlertmanagerTenantHeaderName))
+			switch r.URL.Path {
+			case CortexUserStatsEndpoint:
+				assert.Equal(t, "application/json", r.Header.Get("Accept"))
+				_, _ = w.Write([]byte(`{"numSeries":1234,"ingestionRate":56.7,"apiIngestionRate":56.7,"ruleIngestionRate":0}`))
+			case LokiSeriesEndpoint:
+				if !lokiAvailable {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				assert.Equal(t, lokiStreamSelector, r.URL.Query().Get("match[]"))
+				_, _ = w.Write([]byte(`{"status":"success","data":[{"namespace":"kube-system","pod":"a"},{"namespace":"default","pod":"b"}]}`))
+			case LokiQueryEndpoint:
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1660000000.000,"2048.4"]}]}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+	}
+
+	lastUpdated := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	testCases := []struct {
+		name              string
+		monitoringEnabled bool
+		loggingEnabled    bool
+		paused            bool
+		workerName        string
+		lokiUnavailable   bool
+		existingUsage     *kubermaticv1.MLAUsage
+		expectedErr       bool
+		expectedRequeue   time.Duration
+		// expectedUsage is nil if the usage is expected to be left untouched
+		expectedUsage *kubermaticv1.MLAUsage
+	}{
+		{
+			name:              "collect monitoring and logging usage",
+			monitoringEnabled: true,
+			loggingEnabled:    true,
+			expectedRequeue:   usageRefreshInterval,
+			expectedUsage: &kubermaticv1.MLAUsage{
+				Series:           1234,
+				IngestionRate:    57,
+				LogStreams:       2,
+				LogIngestionRate: 2048,
+			},
+		},
+		{
+			name:            "collect only logging usage if monitoring is disabled",
+			loggingEnabled:  true,
+			expectedRequeue: usageRefreshInterval,
+			expectedUsage: &kubermaticv1.MLAUsage{
+				LogStreams:       2,
+				LogIngestionRate: 2048,
+			},
+		},
+		{
+			name:            "keep the timestamp if the usage did not change",
+			loggingEnabled:  true,
+			expectedRequeue: usageRefreshInterval,
+			existingUsage: &kubermaticv1.MLAUsage{
+				LogStreams:       2,
+				LogIngestionRate: 2048,
+				LastUpdated:      lastUpdated,
+			},
+			expectedUsage: &kubermaticv1.MLAUsage{
+				LogStreams:       2,
+				LogIngestionRate: 2048,
+				LastUpdated:      lastUpdated,
+			},
+		},
+		{
+			name:              "collect monitoring usage if loki is unavailable",
+			monitoringEnabled: true,
+			loggingEnabled:    true,
+			lokiUnavailable:   true,
+			existingUsage: &kubermaticv1.MLAUsage{
+				Series:           1,
+				IngestionRate:    1,
+				LogStreams:       3,
+				LogIngestionRate: 1024,
+				LastUpdated:      lastUpdated,
+			},
+			expectedErr: true,
+			expectedUsage: &kubermaticv1.MLAUsage{
+				Series:           1234,
+				IngestionRate:    57,
+				LogStreams:       3,
+				LogIngestionRate: 1024,
+			},
+		},
+		{
+			name:              "skip paused clusters",
+			monitoringEnabled: true,
+			loggingEnabled:    true,
+			paused:            true,
+			expectedRequeue:   usageRefreshInterval,
+		},
+		{
+			name:              "skip clusters of other workers",
+			monitoringEnabled: true,
+			loggingEnabled:    true,
+			workerName:        "other",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cluster := generateCluster("test", tc.monitoringEnabled, tc.loggingEnabled, false)
+			cluster.Spec.Pause = tc.paused
+			if tc.workerName != "" {
+				cluster.Labels = map[string]string{kubermaticv1.WorkerNameLabelKey: tc.workerName}
+			}
+			objects := []ctrlruntimeclient.Object{
+				cluster,
+				&kubermaticv1.MLAAdminSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.MLAAdminSettingsName,
+						Namespace: "cluster-test",
+					},
+					Spec: kubermaticv1.MLAAdminSettingSpec{
+						ClusterName: "test",
+					},
+					Status: kubermaticv1.MLAAdminSettingStatus{
+						Usage: tc.existingUsage,
+					},
+				},
+			}
+			reconciler, server := newTestUsageReconciler(objects, newHandler(!tc.lokiUnavailable))
+			defer server.Close()
+
+			request := types.NamespacedName{Name: resources.MLAAdminSettingsName, Namespace: "cluster-test"}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: request})
+			assert.Equal(t, tc.expectedErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, tc.expectedRequeue, result.RequeueAfter)
+
+			mlaAdminSetting := &kubermaticv1.MLAAdminSetting{}
+			if err := reconciler.Get(ctx, request, mlaAdminSetting); err != nil {
+				t.Fatalf("unable to get mlaAdminSetting: %v", err)
+			}
+			if tc.expectedUsage == nil {
+				assert.Equal(t, tc.existingUsage, mlaAdminSetting.Status.Usage)
+				return
+			}
+			if !assert.NotNil(t, mlaAdminSetting.Status.Usage) {
+				return
+			}
+			usage := *mlaAdminSetting.Status.Usage
+			assert.False(t, usage.LastUpdated.IsZero())
+			if !tc.expectedUsage.LastUpdated.IsZero() {
+				assert.True(t, usage.LastUpdated.Equal(&tc.expectedUsage.LastUpdated))
+			} else if tc.existingUsage != nil {
+				assert.False(t, usage.LastUpdated.Equal(&tc.existingUsage.LastUpdated))
+			}
+			usage.LastUpdated = tc.expectedUsage.LastUpdated
+			assert.Equal(t, *tc.expectedUsage, usage)
+		})
+	}
+}
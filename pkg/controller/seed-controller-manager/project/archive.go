@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// archiveBackupConfigName is the name of the one-shot EtcdBackupConfig
+	// that is created in the cluster namespace to archive the etcd data.
+	archiveBackupConfigName = "project-archive"
+
+	// archiveClusterKey is the ConfigMap key holding the cluster snapshot.
+	archiveClusterKey = "cluster.yaml"
+	// archiveEtcdBackupNameKey is the ConfigMap key holding the name of the etcd snapshot.
+	archiveEtcdBackupNameKey = "etcdBackupName"
+	// archiveEtcdBackupDestinationKey is the ConfigMap key holding the backup destination of the etcd snapshot.
+	archiveEtcdBackupDestinationKey = "etcdBackupDestination"
+)
+
+// archiveConfigMapName returns the name of the ConfigMap in the KKP namespace
+// that holds the archive of the given cluster.
+func archiveConfigMapName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("cluster-archive-%s", cluster.Name)
+}
+
+// archiveCluster stores a snapshot of the cluster's spec in a ConfigMap and,
+// if automatic etcd backups are configured for the seed, takes a one-shot etcd
+// snapshot that is retained in the backup destination after the cluster is gone.
+// It returns true once the archive is complete and the cluster can be deleted.
+func (r *Reconciler) archiveCluster(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster) (bool, error) {
+	log = log.With("cluster", cluster.Name)
+
+	archive, err := r.ensureClusterSnapshot(ctx, project, cluster)
+	if err != nil {
+		return false, fmt.Errorf("failed to snapshot cluster: %w", err)
+	}
+
+	// the etcd snapshot has been taken already
+	if archive.Data[archiveEtcdBackupNameKey] != "" {
+		return true, nil
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return false, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	if !seed.IsDefaultEtcdAutomaticBackupEnabled() || cluster.Status.NamespaceName == "" {
+		log.Info("Automatic etcd backups are not enabled, archiving only the cluster spec")
+		r.recorder.Eventf(project, corev1.EventTypeWarning, "ClusterArchived", "Archived cluster %s without etcd snapshot, automatic etcd backups are not enabled on this seed.", cluster.Name)
+		return true, nil
+	}
+
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: archiveBackupConfigName}
+	if err := r.Get(ctx, key, backupConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get EtcdBackupConfig: %w", err)
+		}
+
+		log.Info("Taking etcd snapshot")
+
+		if err := r.Create(ctx, archiveBackupConfig(project, cluster, seed)); err != nil {
+			return false, fmt.Errorf("failed to create EtcdBackupConfig: %w", err)
+		}
+
+		r.recorder.Eventf(project, corev1.EventTypeNormal, "ArchivingCluster", "Taking etcd snapshot of cluster %s before deleting it.", cluster.Name)
+
+		return false, nil
+	}
+
+	for _, backup := range backupConfig.Status.CurrentBackups {
+		switch backup.BackupPhase {
+		case kubermaticv1.BackupStatusPhaseFailed:
+			return false, fmt.Errorf("etcd snapshot failed: %s; set spec.archive to false to delete the project without archiving it", backup.BackupMessage)
+
+		case kubermaticv1.BackupStatusPhaseCompleted:
+			oldArchive := archive.DeepCopy()
+			archive.Data[archiveEtcdBackupNameKey] = backup.BackupName
+			archive.Data[archiveEtcdBackupDestinationKey] = backupConfig.Spec.Destination
+			if err := r.Patch(ctx, archive, ctrlruntimeclient.MergeFrom(oldArchive)); err != nil {
+				return false, fmt.Errorf("failed to update archive: %w", err)
+			}
+
+			log.Infow("Archived cluster", "backup", backup.BackupName)
+			r.recorder.Eventf(project, corev1.EventTypeNormal, "ClusterArchived", "Archived cluster %s with etcd snapshot %s.", cluster.Name, backup.BackupName)
+
+			return true, nil
+		}
+	}
+
+	log.Debug("etcd snapshot is still in progress")
+
+	return false, nil
+}
+
+func (r *Reconciler) ensureClusterSnapshot(ctx context.Context, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster) (*corev1.ConfigMap, error) {
+	archive := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: r.namespace, Name: archiveConfigMapName(cluster)}
+	if err := r.Get(ctx, key, archive); err == nil {
+		return archive, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// only the spec is of interest, status and most metadata are meaningless
+	// once the cluster is gone
+	snapshot := &kubermaticv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+			Kind:       kubermaticv1.ClusterKindName,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name,
+			Labels:      cluster.Labels,
+			Annotations: cluster.Annotations,
+		},
+		Spec: cluster.Spec,
+	}
+
+	encoded, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cluster: %w", err)
+	}
+
+	archive = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: project.Name,
+			},
+		},
+		Data: map[string]string{
+			archiveClusterKey: string(encoded),
+		},
+	}
+
+	if err := r.Create(ctx, archive); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func archiveBackupConfig(project *kubermaticv1.Project, cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) *kubermaticv1.EtcdBackupConfig {
+	return &kubermaticv1.EtcdBackupConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      archiveBackupConfigName,
+			Namespace: cluster.Status.NamespaceName,
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: project.Name,
+			},
+			Annotations: map[string]string{
+				kubermaticv1.EtcdBackupConfigRetainBackupsAnnotation: "true",
+			},
+		},
+		Spec: kubermaticv1.EtcdBackupConfigSpec{
+			Name: archiveBackupConfigName,
+			Cluster: corev1.ObjectReference{
+				Kind:       kubermaticv1.ClusterKindName,
+				Name:       cluster.Name,
+				UID:        cluster.UID,
+				APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+			},
+			// an empty schedule makes this a one-shot backup
+			Destination: seed.Spec.EtcdBackupRestore.DefaultDestination,
+		},
+	}
+}
+
+// enqueueProjectForArchiveBackupConfig enqueues the owning project whenever
+// an archive EtcdBackupConfig changes.
+func enqueueProjectForArchiveBackupConfig() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a ctrlruntimeclient.Object) []reconcile.Request {
+		if a.GetName() != archiveBackupConfigName {
+			return nil
+		}
+
+		projectID := a.GetLabels()[kubermaticv1.ProjectIDLabelKey]
+		if projectID == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Name: projectID,
+		}}}
+	})
+}
@@ -23,8 +23,10 @@ import (
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	"k8c.io/kubermatic/v2/pkg/controller/util"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,17 +49,35 @@ const (
 	CleanupFinalizer = "kubermatic.k8c.io/cleanup-clusters"
 )
 
+// UserClusterClientProvider provides functionality to get a user cluster client.
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
 type Reconciler struct {
 	ctrlruntimeclient.Client
-	log      *zap.SugaredLogger
-	recorder record.EventRecorder
+	log                           *zap.SugaredLogger
+	recorder                      record.EventRecorder
+	namespace                     string
+	seedGetter                    provider.SeedGetter
+	userClusterConnectionProvider UserClusterClientProvider
 }
 
-func Add(mgr manager.Manager, log *zap.SugaredLogger, workerCount int) error {
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	workerCount int,
+	namespace string,
+	seedGetter provider.SeedGetter,
+	userClusterConnectionProvider UserClusterClientProvider,
+) error {
 	reconciler := &Reconciler{
-		Client:   mgr.GetClient(),
-		log:      log,
-		recorder: mgr.GetEventRecorderFor(ControllerName),
+		Client:                        mgr.GetClient(),
+		log:                           log,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		namespace:                     namespace,
+		seedGetter:                    seedGetter,
+		userClusterConnectionProvider: userClusterConnectionProvider,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: workerCount})
@@ -75,6 +95,11 @@ func Add(mgr manager.Manager, log *zap.SugaredLogger, workerCount int) error {
 		return err
 	}
 
+	// watch the EtcdBackupConfigs created to archive clusters
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.EtcdBackupConfig{}}, enqueueProjectForArchiveBackupConfig()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -108,22 +133,96 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, proj
 		return fmt.Errorf("failed to add finalizer: %w", err)
 	}
 
+	clusters, err := r.getProjectClusters(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	suspended := project.Status.Phase == kubermaticv1.ProjectSuspended
+
+	// only clusters which are not in the desired state yet need to be handled
+	pending := []kubermaticv1.Cluster{}
+	for _, cluster := range clusters.Items {
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+
+		if _, isSuspended := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]; isSuspended != suspended {
+			pending = append(pending, cluster)
+		}
+	}
+
+	// Scale the MachineDeployments of all clusters first. The clusters are only
+	// marked as suspended (or resumed) once this succeeded for every one of them,
+	// otherwise we would lose track of clusters which still have running workers
+	// (or workers that still need to be scaled up again).
+	for i := range pending {
+		if suspended {
+			err = r.scaleDownMachineDeployments(ctx, log, &pending[i])
+		} else {
+			err = r.scaleUpMachineDeployments(ctx, log, &pending[i])
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range pending {
+		if suspended {
+			err = r.suspendCluster(ctx, log, &pending[i])
+		} else {
+			err = r.resumeCluster(ctx, log, &pending[i])
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (r *Reconciler) handleCleanup(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project) error {
-	log.Debug("Handling project deletion")
-
-	// delete all clusters in this project
+func (r *Reconciler) getProjectClusters(ctx context.Context, project *kubermaticv1.Project) (*kubermaticv1.ClusterList, error) {
 	clusters := &kubermaticv1.ClusterList{}
 	selector := labels.SelectorFromSet(map[string]string{kubermaticv1.ProjectIDLabelKey: project.Name})
 	listOpts := &ctrlruntimeclient.ListOptions{LabelSelector: selector}
 	if err := r.List(ctx, clusters, listOpts); err != nil {
-		return fmt.Errorf("failed to list clusters: %w", err)
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	return clusters, nil
+}
+
+func (r *Reconciler) handleCleanup(ctx context.Context, log *zap.SugaredLogger, project *kubermaticv1.Project) error {
+	log.Debug("Handling project deletion")
+
+	// delete all clusters in this project
+	clusters, err := r.getProjectClusters(ctx, project)
+	if err != nil {
+		return err
 	}
 
 	for _, cluster := range clusters.Items {
 		if cluster.DeletionTimestamp == nil {
+			// clusters paused by a project suspension would never be cleaned up
+			if err := r.unpauseCluster(ctx, &cluster); err != nil {
+				return err
+			}
+
+			if project.Spec.Archive {
+				archived, err := r.archiveCluster(ctx, log, project, &cluster)
+				if err != nil {
+					return fmt.Errorf("failed to archive cluster %s: %w", cluster.Name, err)
+				}
+
+				// the archive watches the backup config and will be triggered
+				// again once the etcd snapshot has been taken
+				if !archived {
+					continue
+				}
+			}
+
 			if err := r.Delete(ctx, &cluster); err != nil {
 				return fmt.Errorf("failed to delete cluster %s: %w", cluster.Name, err)
 			}
@@ -0,0 +1,332 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	projectID   = "testproject"
+	clusterName = "testcluster"
+	namespace   = "kubermatic"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add clusterv1alpha1 to scheme: %v", err))
+	}
+	if err := kubermaticv1.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add kubermaticv1 to scheme: %v", err))
+	}
+}
+
+func TestSuspendAndResume(t *testing.T) {
+	ctx := context.Background()
+
+	project := genProject(kubermaticv1.ProjectSuspended)
+	seedClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(project, genCluster()).
+		Build()
+	userClusterClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(genMachineDeployment(3)).
+		Build()
+
+	r := newTestReconciler(seedClient, userClusterClient, &kubermaticv1.Seed{})
+
+	// suspend the project
+	reconcileProject(t, r)
+
+	cluster := getCluster(t, seedClient)
+	if !cluster.Spec.Pause {
+		t.Error("expected cluster to be paused")
+	}
+	if cluster.Spec.PauseReason != suspendedPauseReason {
+		t.Errorf("expected pause reason %q, got %q", suspendedPauseReason, cluster.Spec.PauseReason)
+	}
+	if value := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]; value != "false" {
+		t.Errorf("expected %s annotation to be \"false\", got %q", kubermaticv1.ProjectSuspendedAnnotation, value)
+	}
+
+	md := getMachineDeployment(t, userClusterClient)
+	if *md.Spec.Replicas != 0 {
+		t.Errorf("expected MachineDeployment to be scaled to 0, got %d replicas", *md.Spec.Replicas)
+	}
+	if value := md.Annotations[kubermaticv1.SuspendedReplicasAnnotation]; value != "3" {
+		t.Errorf("expected %s annotation to be \"3\", got %q", kubermaticv1.SuspendedReplicasAnnotation, value)
+	}
+
+	// resume the project
+	oldProject := project.DeepCopy()
+	project.Status.Phase = kubermaticv1.ProjectActive
+	if err := seedClient.Status().Patch(ctx, project, ctrlruntimeclient.MergeFrom(oldProject)); err != nil {
+		t.Fatalf("failed to resume project: %v", err)
+	}
+
+	reconcileProject(t, r)
+
+	cluster = getCluster(t, seedClient)
+	if cluster.Spec.Pause {
+		t.Error("expected cluster to be unpaused")
+	}
+	if cluster.Spec.PauseReason != "" {
+		t.Errorf("expected pause reason to be removed, got %q", cluster.Spec.PauseReason)
+	}
+	if _, ok := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]; ok {
+		t.Errorf("expected %s annotation to be removed", kubermaticv1.ProjectSuspendedAnnotation)
+	}
+
+	md = getMachineDeployment(t, userClusterClient)
+	if *md.Spec.Replicas != 3 {
+		t.Errorf("expected MachineDeployment to be scaled to 3, got %d replicas", *md.Spec.Replicas)
+	}
+	if _, ok := md.Annotations[kubermaticv1.SuspendedReplicasAnnotation]; ok {
+		t.Errorf("expected %s annotation to be removed", kubermaticv1.SuspendedReplicasAnnotation)
+	}
+}
+
+func TestSuspendUnhealthyCluster(t *testing.T) {
+	cluster := genCluster()
+	cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+
+	seedClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(genProject(kubermaticv1.ProjectSuspended), cluster).
+		Build()
+	userClusterClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(genMachineDeployment(3)).
+		Build()
+
+	r := newTestReconciler(seedClient, userClusterClient, &kubermaticv1.Seed{})
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: projectID}}
+	if _, err := r.Reconcile(context.Background(), request); err == nil {
+		t.Fatal("expected reconciling to fail while the control plane is unhealthy")
+	}
+
+	cluster = getCluster(t, seedClient)
+	if cluster.Spec.Pause {
+		t.Error("expected cluster not to be paused")
+	}
+	if _, ok := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]; ok {
+		t.Errorf("expected no %s annotation", kubermaticv1.ProjectSuspendedAnnotation)
+	}
+
+	md := getMachineDeployment(t, userClusterClient)
+	if *md.Spec.Replicas != 3 {
+		t.Errorf("expected MachineDeployment to keep 3 replicas, got %d", *md.Spec.Replicas)
+	}
+}
+
+func TestArchiveBeforeDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	project := genProject(kubermaticv1.ProjectTerminating)
+	project.Spec.Archive = true
+	project.Finalizers = []string{CleanupFinalizer}
+	project.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	seed := &kubermaticv1.Seed{
+		Spec: kubermaticv1.SeedSpec{
+			EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+				Destinations: map[string]*kubermaticv1.BackupDestination{
+					"s3": {Endpoint: "https://s3.example.com", BucketName: "backups"},
+				},
+				DefaultDestination: "s3",
+			},
+		},
+	}
+
+	seedClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(project, genCluster()).
+		Build()
+
+	r := newTestReconciler(seedClient, nil, seed)
+
+	// the first reconciliation snapshots the cluster and starts the etcd backup
+	reconcileProject(t, r)
+
+	archive := &corev1.ConfigMap{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "cluster-archive-" + clusterName}, archive); err != nil {
+		t.Fatalf("failed to get archive: %v", err)
+	}
+	if archive.Data[archiveClusterKey] == "" {
+		t.Error("expected archive to contain the cluster spec")
+	}
+
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: "cluster-" + clusterName, Name: archiveBackupConfigName}, backupConfig); err != nil {
+		t.Fatalf("failed to get EtcdBackupConfig: %v", err)
+	}
+	if !backupConfig.RetainsBackups() {
+		t.Error("expected archive EtcdBackupConfig to retain its backups")
+	}
+	if backupConfig.Spec.Schedule != "" {
+		t.Errorf("expected a one-shot backup, but got schedule %q", backupConfig.Spec.Schedule)
+	}
+
+	// the cluster must not be deleted before the backup has completed
+	getCluster(t, seedClient)
+
+	oldBackupConfig := backupConfig.DeepCopy()
+	backupConfig.Status.CurrentBackups = []kubermaticv1.BackupStatus{
+		{
+			BackupName:  "project-archive-2022-06-01t12-00-00",
+			BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+		},
+	}
+	if err := seedClient.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+		t.Fatalf("failed to update EtcdBackupConfig: %v", err)
+	}
+
+	// the second reconciliation records the backup and deletes the cluster
+	reconcileProject(t, r)
+
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "cluster-archive-" + clusterName}, archive); err != nil {
+		t.Fatalf("failed to get archive: %v", err)
+	}
+	if name := archive.Data[archiveEtcdBackupNameKey]; name != "project-archive-2022-06-01t12-00-00" {
+		t.Errorf("expected archive to reference the etcd backup, got %q", name)
+	}
+	if destination := archive.Data[archiveEtcdBackupDestinationKey]; destination != "s3" {
+		t.Errorf("expected archive to reference the backup destination, got %q", destination)
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); !apierrors.IsNotFound(err) {
+		t.Errorf("expected cluster to be deleted, but got: %v", err)
+	}
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
+
+func newTestReconciler(seedClient, userClusterClient ctrlruntimeclient.Client, seed *kubermaticv1.Seed) *Reconciler {
+	return &Reconciler{
+		Client:    seedClient,
+		log:       kubermaticlog.Logger,
+		recorder:  &record.FakeRecorder{},
+		namespace: namespace,
+		seedGetter: func() (*kubermaticv1.Seed, error) {
+			return seed, nil
+		},
+		userClusterConnectionProvider: &fakeClientProvider{client: userClusterClient},
+	}
+}
+
+func reconcileProject(t *testing.T, r *Reconciler) {
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: projectID}}
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+}
+
+func getCluster(t *testing.T, client ctrlruntimeclient.Client) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	return cluster
+}
+
+func getMachineDeployment(t *testing.T, client ctrlruntimeclient.Client) *clusterv1alpha1.MachineDeployment {
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: resources.KubeSystemNamespaceName, Name: "worker"}, md); err != nil {
+		t.Fatalf("failed to get MachineDeployment: %v", err)
+	}
+	return md
+}
+
+func genProject(phase kubermaticv1.ProjectPhase) *kubermaticv1.Project {
+	return &kubermaticv1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: projectID,
+		},
+		Spec: kubermaticv1.ProjectSpec{
+			Name: "my project",
+		},
+		Status: kubermaticv1.ProjectStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func genCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: projectID,
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			HumanReadableName: "my cluster",
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + clusterName,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+}
+
+func genMachineDeployment(replicas int32) *clusterv1alpha1.MachineDeployment {
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker",
+			Namespace: resources.KubeSystemNamespaceName,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: pointer.Int32Ptr(replicas),
+		},
+	}
+}
@@ -24,5 +24,14 @@ takes care of synchronizing the project deletion to all seeds (i.e.
 you delete a project on the master, the project-synchronizer controller
 then deletes the projects on all seeds, and then this controller cleans
 them up by deleting the clusters).
+
+If a project has spec.archive enabled, every cluster is archived before
+it is deleted: its spec is stored in a ConfigMap in the KKP namespace
+and a one-shot etcd snapshot is taken, which is retained in the backup
+destination after the cluster is gone.
+
+The controller also pauses all clusters of suspended projects and scales
+their MachineDeployments to zero, and restores both once the project is
+resumed.
 */
 package project
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// suspendedPauseReason is the pause reason set on clusters that are paused
+// because their project was suspended.
+const suspendedPauseReason = "project is suspended"
+
+// suspendCluster pauses the cluster, its MachineDeployments must have been
+// scaled down already. The previous pause state is remembered in an annotation,
+// so that clusters which were paused already stay paused when the project is
+// resumed.
+func (r *Reconciler) suspendCluster(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	if _, ok := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]; ok {
+		return nil
+	}
+
+	log = log.With("cluster", cluster.Name)
+	log.Info("Suspending cluster")
+
+	oldCluster := cluster.DeepCopy()
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation] = strconv.FormatBool(cluster.Spec.Pause)
+
+	if !cluster.Spec.Pause {
+		cluster.Spec.Pause = true
+		cluster.Spec.PauseReason = suspendedPauseReason
+	}
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to pause cluster %s: %w", cluster.Name, err)
+	}
+
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "ClusterSuspended", "Cluster has been paused because its project was suspended.")
+
+	return nil
+}
+
+// resumeCluster restores the pause state of a cluster that was suspended
+// together with its project, its MachineDeployments must have been scaled up
+// already.
+func (r *Reconciler) resumeCluster(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	if _, ok := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]; !ok {
+		return nil
+	}
+
+	log = log.With("cluster", cluster.Name)
+	log.Info("Resuming cluster")
+
+	if err := r.unpauseCluster(ctx, cluster); err != nil {
+		return err
+	}
+
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "ClusterResumed", "Cluster has been resumed because its project was resumed.")
+
+	return nil
+}
+
+// unpauseCluster restores the pause state the cluster had before its project
+// was suspended. MachineDeployments are not touched.
+func (r *Reconciler) unpauseCluster(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	previous, ok := cluster.Annotations[kubermaticv1.ProjectSuspendedAnnotation]
+	if !ok {
+		return nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, kubermaticv1.ProjectSuspendedAnnotation)
+
+	if wasPaused, _ := strconv.ParseBool(previous); !wasPaused {
+		cluster.Spec.Pause = false
+		if cluster.Spec.PauseReason == suspendedPauseReason {
+			cluster.Spec.PauseReason = ""
+		}
+	}
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to unpause cluster %s: %w", cluster.Name, err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) scaleDownMachineDeployments(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	// the project is retried until the control plane is healthy again, as
+	// suspending the cluster without scaling it down would leave its workers running
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ClusterSuspended", "The control plane is not healthy, MachineDeployments could not be scaled down.")
+		return fmt.Errorf("cannot scale down MachineDeployments of cluster %s: control plane is not healthy", cluster.Name)
+	}
+
+	userClusterClient, machineDeployments, err := r.getMachineDeployments(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to scale down MachineDeployments of cluster %s: %w", cluster.Name, err)
+	}
+
+	for _, md := range machineDeployments.Items {
+		if _, ok := md.Annotations[kubermaticv1.SuspendedReplicasAnnotation]; ok {
+			continue
+		}
+
+		replicas := int32(1)
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+
+		oldMD := md.DeepCopy()
+		if md.Annotations == nil {
+			md.Annotations = map[string]string{}
+		}
+		md.Annotations[kubermaticv1.SuspendedReplicasAnnotation] = strconv.Itoa(int(replicas))
+		md.Spec.Replicas = pointer.Int32Ptr(0)
+
+		log.Debugw("Scaling down MachineDeployment", "machinedeployment", md.Name, "replicas", replicas)
+
+		if err := userClusterClient.Patch(ctx, &md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+			return fmt.Errorf("failed to scale down MachineDeployment %s: %w", md.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) scaleUpMachineDeployments(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ClusterResumed", "The control plane is not healthy, MachineDeployments could not be scaled up.")
+		return fmt.Errorf("cannot scale up MachineDeployments of cluster %s: control plane is not healthy", cluster.Name)
+	}
+
+	userClusterClient, machineDeployments, err := r.getMachineDeployments(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to scale up MachineDeployments of cluster %s: %w", cluster.Name, err)
+	}
+
+	for _, md := range machineDeployments.Items {
+		value, ok := md.Annotations[kubermaticv1.SuspendedReplicasAnnotation]
+		if !ok {
+			continue
+		}
+
+		replicas, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation on MachineDeployment %s: %w", kubermaticv1.SuspendedReplicasAnnotation, md.Name, err)
+		}
+
+		oldMD := md.DeepCopy()
+		delete(md.Annotations, kubermaticv1.SuspendedReplicasAnnotation)
+		md.Spec.Replicas = pointer.Int32Ptr(int32(replicas))
+
+		log.Debugw("Scaling up MachineDeployment", "machinedeployment", md.Name, "replicas", replicas)
+
+		if err := userClusterClient.Patch(ctx, &md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+			return fmt.Errorf("failed to scale up MachineDeployment %s: %w", md.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) getMachineDeployments(ctx context.Context, cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, *clusterv1alpha1.MachineDeploymentList, error) {
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get usercluster client: %w", err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	// Kubermatic only creates MachineDeployments in the kube-system namespace, everything else is essentially unsupported
+	if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(resources.KubeSystemNamespaceName)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	return userClusterClient, machineDeployments, nil
+}
@@ -18,6 +18,7 @@ package applicationinstallationcontroller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -26,6 +27,7 @@ import (
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/apis/equality"
 	"k8c.io/kubermatic/v2/pkg/applications"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
 	userclustercontrollermanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 
@@ -90,11 +92,17 @@ func Add(ctx context.Context, log *zap.SugaredLogger, seedMgr, userMgr manager.M
 
 	// update of the status with conditions or HelmInfo triggers an update event. To avoid reconciling in loop, we filter
 	// update event on generation. We also allow update events if annotations have changed so that the user can force a
-	// reconciliation without changing the spec.
+	// reconciliation without changing the spec, e.g. to request a rollback or a reinstallation.
 	if err = c.Watch(&source.Kind{Type: &appskubermaticv1.ApplicationInstallation{}}, &handler.EnqueueRequestForObject{}, predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})); err != nil {
 		return fmt.Errorf("failed to create watch for ApplicationInstallation: %w", err)
 	}
 
+	// Applications wait for their dependencies to be ready before being installed and for their dependents to be
+	// uninstalled before being uninstalled. So changes of an ApplicationInstallation are fanned out to related applications.
+	if err = c.Watch(&source.Kind{Type: &appskubermaticv1.ApplicationInstallation{}}, handler.EnqueueRequestsFromMapFunc(enqueueRelatedAppInstallations(ctx, r.userClient)), dependencyChangedPredicate); err != nil {
+		return fmt.Errorf("failed to create watch for ApplicationInstallation dependencies: %w", err)
+	}
+
 	// We also watch ApplicationDefinition because it contains information about how to install the application. Moreover
 	// if KKP admin deletes ApplicationDefinition, the related application must also be deleted.
 	appDefInformer, err := seedMgr.GetCache().GetInformer(ctx, &appskubermaticv1.ApplicationDefinition{})
@@ -139,7 +147,13 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	}
 
 	log.Debug("Processed")
-	return reconcile.Result{}, err
+
+	// the application is periodically reconciled to detect drift of the live objects.
+	result := reconcile.Result{}
+	if err == nil && appInstallation.DeletionTimestamp.IsZero() {
+		result.RequeueAfter = driftDetectionInterval(appInstallation)
+	}
+	return result, err
 }
 
 func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) error {
@@ -156,6 +170,7 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appI
 	}
 
 	appHasBeenInstalled := appInstallation.Status.ApplicationVersion != nil
+	upToDate := appHasBeenInstalled && installationUpToDate(appInstallation)
 
 	// get applicationDefinition. If it can not be found, there are 2 cases:
 	//   1) KKP admin has removed the applicationDefinition, and we have to remove the corresponding ApplicationInstallation(s)
@@ -191,16 +206,37 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appI
 		}
 	}
 
-	if !equality.Semantic.DeepEqual(appVersion, appInstallation.Status.ApplicationVersion) || appInstallation.Status.Method != applicationDef.Spec.Method {
+	if !equality.Semantic.DeepEqual(appVersion, appInstallation.Status.ApplicationVersion) || appInstallation.Status.Method != applicationDef.Spec.Method ||
+		!equality.Semantic.DeepEqual(applicationDef.Spec.Verification, appInstallation.Status.Verification) {
+		upToDate = false
 		oldAppInstallation := appInstallation.DeepCopy()
 		appInstallation.Status.ApplicationVersion = appVersion
 		appInstallation.Status.Method = applicationDef.Spec.Method
+		appInstallation.Status.Verification = applicationDef.Spec.Verification
 
 		if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
 			return fmt.Errorf("failed to update status with applicationVersion: %w", err)
 		}
 	}
 
+	// wait for dependencies to be ready before installing the application.
+	dependenciesReady, err := r.waitForDependencies(ctx, log, appInstallation)
+	if err != nil {
+		return fmt.Errorf("checking dependencies of application installation: %w", err)
+	}
+	if !dependenciesReady {
+		return nil
+	}
+
+	// nothing has changed since the last successful installation, so we only check that the live objects still match
+	// the desired state.
+	if upToDate && driftDetectionInterval(appInstallation) > 0 {
+		if err := r.handleDriftDetection(ctx, log, appInstallation); err != nil {
+			return fmt.Errorf("handling drift detection of application installation: %w", err)
+		}
+		return nil
+	}
+
 	// install application into the user-cluster
 	if err := r.handleInstallation(ctx, log, appInstallation); err != nil {
 		return fmt.Errorf("handling installation of application installation: %w", err)
@@ -237,7 +273,11 @@ func (r *reconciler) handleInstallation(ctx context.Context, log *zap.SugaredLog
 	oldAppInstallation := appInstallation.DeepCopy()
 	appSourcePath, downloadErr := r.appInstaller.DonwloadSource(ctx, log, r.seedClient, appInstallation, downloadDest)
 	if downloadErr != nil {
-		r.setCondition(appInstallation, appskubermaticv1.ManifestsRetrieved, corev1.ConditionFalse, "DownaloadSourceFailed", downloadErr.Error())
+		reason := "DownaloadSourceFailed"
+		if errors.Is(downloadErr, util.ErrUnverifiedSource) {
+			reason = "SourceVerificationFailed"
+		}
+		r.setCondition(appInstallation, appskubermaticv1.ManifestsRetrieved, corev1.ConditionFalse, reason, downloadErr.Error())
 		if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
 			return fmt.Errorf("failed to update status: %w", err)
 		}
@@ -255,6 +295,7 @@ func (r *reconciler) handleInstallation(ctx context.Context, log *zap.SugaredLog
 		r.setCondition(appInstallation, appskubermaticv1.Ready, corev1.ConditionFalse, "InstallationFailed", installErr.Error())
 	} else {
 		r.setCondition(appInstallation, appskubermaticv1.Ready, corev1.ConditionTrue, "InstallationSuccessful", "application successfully installed or upgraded")
+		appInstallation.Status.ObservedGeneration = appInstallation.Generation
 	}
 	statusUpdater(&appInstallation.Status)
 
@@ -263,12 +304,33 @@ func (r *reconciler) handleInstallation(ctx context.Context, log *zap.SugaredLog
 		return fmt.Errorf("failed to update status: %w", err)
 	}
 
+	// the requested rollback or reinstallation has been attempted, so the request is removed.
+	_, rollbackRequested := appInstallation.Annotations[appskubermaticv1.ApplicationInstallationRollbackAnnotation]
+	_, reinstallRequested := appInstallation.Annotations[appskubermaticv1.ApplicationInstallationReinstallAnnotation]
+	if rollbackRequested || reinstallRequested {
+		oldAppInstallation = appInstallation.DeepCopy()
+		delete(appInstallation.Annotations, appskubermaticv1.ApplicationInstallationRollbackAnnotation)
+		delete(appInstallation.Annotations, appskubermaticv1.ApplicationInstallationReinstallAnnotation)
+		if err := r.userClient.Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
+			return fmt.Errorf("failed to remove request annotations: %w", err)
+		}
+	}
+
 	return installErr
 }
 
 // handleDeletion uninstalls the application in the user cluster.
 func (r *reconciler) handleDeletion(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) error {
 	if kuberneteshelper.HasFinalizer(appInstallation, appskubermaticv1.ApplicationInstallationCleanupFinalizer) {
+		// applications are uninstalled in the reverse order of their installation.
+		dependentsUninstalled, err := r.waitForDependents(ctx, log, appInstallation)
+		if err != nil {
+			return fmt.Errorf("checking dependents of application installation: %w", err)
+		}
+		if !dependentsUninstalled {
+			return nil
+		}
+
 		statusUpdater, uninstallErr := r.appInstaller.Delete(ctx, log, r.seedClient, r.userClient, appInstallation)
 		oldAppInstallation := appInstallation.DeepCopy()
 		if uninstallErr != nil {
@@ -0,0 +1,320 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	semverlib "github.com/Masterminds/semver/v3"
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// waitingForDependenciesReason is the reason of the Ready condition when the application waits for its dependencies to be ready.
+	waitingForDependenciesReason = "WaitingForDependencies"
+
+	// waitingForDependentsReason is the reason of the Ready condition when the application waits for the applications
+	// that depend on it to be uninstalled.
+	waitingForDependentsReason = "WaitingForDependents"
+)
+
+// waitForDependencies returns true if the dependencies of appInstallation are ready. Otherwise, it sets the Ready
+// condition to false with the list of dependencies the application is waiting for.
+func (r *reconciler) waitForDependencies(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) (bool, error) {
+	appList := &appskubermaticv1.ApplicationInstallationList{}
+	if err := r.userClient.List(ctx, appList); err != nil {
+		return false, fmt.Errorf("failed to list applicationInstallations: %w", err)
+	}
+
+	pending := pendingDependencies(appInstallation, appList.Items)
+	if len(pending) == 0 {
+		return true, nil
+	}
+
+	message := fmt.Sprintf("waiting for dependencies: %s", strings.Join(pending, ", "))
+	log.Debug(message)
+
+	return false, r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Ready, corev1.ConditionFalse, waitingForDependenciesReason, message)
+}
+
+// waitForDependents returns true if no other application depends on appInstallation anymore, so it can be uninstalled.
+// Otherwise, it sets the Ready condition to false with the list of applications that have to be uninstalled first.
+func (r *reconciler) waitForDependents(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) (bool, error) {
+	appList := &appskubermaticv1.ApplicationInstallationList{}
+	if err := r.userClient.List(ctx, appList); err != nil {
+		return false, fmt.Errorf("failed to list applicationInstallations: %w", err)
+	}
+
+	// applications that are part of a dependency cycle with appInstallation would wait for each other forever.
+	transitiveDeps := transitiveDependencies(appInstallation, appList.Items)
+
+	var pending []string
+	for _, dependent := range dependents(appInstallation, appList.Items) {
+		if _, inCycle := transitiveDeps[keyOf(dependent)]; !inCycle {
+			pending = append(pending, keyOf(dependent).String())
+		}
+	}
+
+	if len(pending) == 0 {
+		return true, nil
+	}
+
+	sort.Strings(pending)
+	message := fmt.Sprintf("waiting for dependent applications to be uninstalled: %s", strings.Join(pending, ", "))
+	log.Debug(message)
+
+	return false, r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Ready, corev1.ConditionFalse, waitingForDependentsReason, message)
+}
+
+// setConditionIfChanged sets the condition and patches the status only if status, reason or message of the condition
+// have changed. This avoids status updates that would trigger the reconciliation of dependent applications in a loop.
+func (r *reconciler) setConditionIfChanged(ctx context.Context, appInstallation *appskubermaticv1.ApplicationInstallation, conditionType appskubermaticv1.ApplicationInstallationConditionType, status corev1.ConditionStatus, reason, message string) error {
+	if condition, exists := appInstallation.Status.Conditions[conditionType]; exists && condition.Status == status && condition.Reason == reason && condition.Message == message {
+		return nil
+	}
+
+	oldAppInstallation := appInstallation.DeepCopy()
+	r.setCondition(appInstallation, conditionType, status, reason, message)
+	if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// pendingDependencies returns a description of the dependencies of appInstallation that are not ready yet.
+func pendingDependencies(appInstallation *appskubermaticv1.ApplicationInstallation, all []appskubermaticv1.ApplicationInstallation) []string {
+	var pending []string
+
+	for _, ref := range appInstallation.Spec.DependsOn {
+		key := refKey(appInstallation, ref)
+		dep := find(all, key)
+
+		switch {
+		case dep == nil:
+			pending = append(pending, fmt.Sprintf("ApplicationInstallation %s not found", key))
+		case !isReady(dep):
+			pending = append(pending, fmt.Sprintf("ApplicationInstallation %s is not ready", key))
+		}
+	}
+
+	if appInstallation.Status.ApplicationVersion != nil {
+		for _, dep := range appInstallation.Status.ApplicationVersion.DependsOn {
+			matches := applicationMatches(appInstallation, dep, all)
+			if len(matches) == 0 {
+				pending = append(pending, fmt.Sprintf("application %s is not installed", describeDependency(dep)))
+				continue
+			}
+
+			ready := false
+			for _, match := range matches {
+				if isReady(match) {
+					ready = true
+					break
+				}
+			}
+			if !ready {
+				pending = append(pending, fmt.Sprintf("application %s is not ready", describeDependency(dep)))
+			}
+		}
+	}
+
+	return pending
+}
+
+// directDependencies returns the ApplicationInstallations appInstallation depends on, either explicitly through
+// spec.dependsOn or through the dependencies of its application version.
+func directDependencies(appInstallation *appskubermaticv1.ApplicationInstallation, all []appskubermaticv1.ApplicationInstallation) []*appskubermaticv1.ApplicationInstallation {
+	var deps []*appskubermaticv1.ApplicationInstallation
+
+	for _, ref := range appInstallation.Spec.DependsOn {
+		if dep := find(all, refKey(appInstallation, ref)); dep != nil {
+			deps = append(deps, dep)
+		}
+	}
+
+	if appInstallation.Status.ApplicationVersion != nil {
+		for _, dep := range appInstallation.Status.ApplicationVersion.DependsOn {
+			deps = append(deps, applicationMatches(appInstallation, dep, all)...)
+		}
+	}
+
+	return deps
+}
+
+// transitiveDependencies returns the keys of all ApplicationInstallations appInstallation depends on directly or indirectly.
+func transitiveDependencies(appInstallation *appskubermaticv1.ApplicationInstallation, all []appskubermaticv1.ApplicationInstallation) map[types.NamespacedName]struct{} {
+	visited := map[types.NamespacedName]struct{}{}
+
+	queue := directDependencies(appInstallation, all)
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[keyOf(dep)]; ok {
+			continue
+		}
+		visited[keyOf(dep)] = struct{}{}
+		queue = append(queue, directDependencies(dep, all)...)
+	}
+
+	return visited
+}
+
+// dependents returns the ApplicationInstallations that directly depend on appInstallation.
+func dependents(appInstallation *appskubermaticv1.ApplicationInstallation, all []appskubermaticv1.ApplicationInstallation) []*appskubermaticv1.ApplicationInstallation {
+	var res []*appskubermaticv1.ApplicationInstallation
+
+	key := keyOf(appInstallation)
+	for i := range all {
+		candidate := &all[i]
+		if keyOf(candidate) == key {
+			continue
+		}
+
+		for _, dep := range directDependencies(candidate, all) {
+			if keyOf(dep) == key {
+				res = append(res, candidate)
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// applicationMatches returns the ApplicationInstallations (except appInstallation) that install the application
+// required by dep.
+func applicationMatches(appInstallation *appskubermaticv1.ApplicationInstallation, dep appskubermaticv1.ApplicationDependency, all []appskubermaticv1.ApplicationInstallation) []*appskubermaticv1.ApplicationInstallation {
+	var constraint *semverlib.Constraints
+	if dep.Version != "" {
+		var err error
+		// the admission webhook ensures constraints are valid.
+		if constraint, err = semverlib.NewConstraint(dep.Version); err != nil {
+			return nil
+		}
+	}
+
+	var matches []*appskubermaticv1.ApplicationInstallation
+	for i := range all {
+		candidate := &all[i]
+		if keyOf(candidate) == keyOf(appInstallation) || candidate.Spec.ApplicationRef.Name != dep.Name {
+			continue
+		}
+		if constraint != nil && !constraint.Check(&candidate.Spec.ApplicationRef.Version.Version) {
+			continue
+		}
+		matches = append(matches, candidate)
+	}
+
+	return matches
+}
+
+// isReady returns true if the application has been successfully installed and is not being deleted.
+func isReady(appInstallation *appskubermaticv1.ApplicationInstallation) bool {
+	return appInstallation.DeletionTimestamp.IsZero() && appInstallation.Status.Conditions[appskubermaticv1.Ready].Status == corev1.ConditionTrue
+}
+
+func describeDependency(dep appskubermaticv1.ApplicationDependency) string {
+	if dep.Version == "" {
+		return dep.Name
+	}
+	return fmt.Sprintf("%s (%s)", dep.Name, dep.Version)
+}
+
+func find(all []appskubermaticv1.ApplicationInstallation, key types.NamespacedName) *appskubermaticv1.ApplicationInstallation {
+	for i := range all {
+		if keyOf(&all[i]) == key {
+			return &all[i]
+		}
+	}
+	return nil
+}
+
+func keyOf(appInstallation *appskubermaticv1.ApplicationInstallation) types.NamespacedName {
+	return types.NamespacedName{Namespace: appInstallation.Namespace, Name: appInstallation.Name}
+}
+
+// refKey returns the key of the ApplicationInstallation referenced by ref. The namespace defaults to the namespace of appInstallation.
+func refKey(appInstallation *appskubermaticv1.ApplicationInstallation, ref appskubermaticv1.ApplicationInstallationReference) types.NamespacedName {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = appInstallation.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// dependencyChangedPredicate filters the events of ApplicationInstallations that can unblock another application:
+// creation, deletion and changes of the readiness or of the dependencies.
+var dependencyChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldApp, okOld := e.ObjectOld.(*appskubermaticv1.ApplicationInstallation)
+		newApp, okNew := e.ObjectNew.(*appskubermaticv1.ApplicationInstallation)
+		if !okOld || !okNew {
+			return false
+		}
+
+		return isReady(oldApp) != isReady(newApp) ||
+			oldApp.DeletionTimestamp.IsZero() != newApp.DeletionTimestamp.IsZero() ||
+			oldApp.Generation != newApp.Generation
+	},
+}
+
+// enqueueRelatedAppInstallations fan-out changes of an ApplicationInstallation to the applications that depend on it
+// (they may wait for it to become ready) and to its dependencies (they may wait for it to be uninstalled).
+func enqueueRelatedAppInstallations(ctx context.Context, userClient ctrlruntimeclient.Client) func(object ctrlruntimeclient.Object) []reconcile.Request {
+	return func(object ctrlruntimeclient.Object) []reconcile.Request {
+		appInstallation, ok := object.(*appskubermaticv1.ApplicationInstallation)
+		if !ok {
+			return []reconcile.Request{}
+		}
+
+		appList := &appskubermaticv1.ApplicationInstallationList{}
+		if err := userClient.List(ctx, appList); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list applicationInstallation: %w", err))
+			return []reconcile.Request{}
+		}
+
+		related := dependents(appInstallation, appList.Items)
+		related = append(related, directDependencies(appInstallation, appList.Items)...)
+
+		seen := map[types.NamespacedName]struct{}{}
+		res := []reconcile.Request{}
+		for _, app := range related {
+			key := keyOf(app)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			res = append(res, reconcile.Request{NamespacedName: key})
+		}
+		return res
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPendingDependencies(t *testing.T) {
+	testCases := []struct {
+		name            string
+		appInstallation *appskubermaticv1.ApplicationInstallation
+		all             []appskubermaticv1.ApplicationInstallation
+		expectedPending []string
+	}{
+		{
+			name:            "scenario 1: application without dependencies can be installed",
+			appInstallation: genApplicationInstallation("consumer", "consumer", "1.0.0"),
+			expectedPending: nil,
+		},
+		{
+			name:            "scenario 2: explicit dependencies must exist and be ready",
+			appInstallation: withDependsOn(genApplicationInstallation("consumer", "consumer", "1.0.0"), "ingress", "cert-manager", "missing"),
+			all: []appskubermaticv1.ApplicationInstallation{
+				*ready(genApplicationInstallation("ingress", "nginx", "1.0.0")),
+				*genApplicationInstallation("cert-manager", "cert-manager", "1.8.0"),
+			},
+			expectedPending: []string{
+				"ApplicationInstallation apps/cert-manager is not ready",
+				"ApplicationInstallation apps/missing not found",
+			},
+		},
+		{
+			name:            "scenario 3: application dependencies must be installed in a matching version and ready",
+			appInstallation: withApplicationDependencies(genApplicationInstallation("consumer", "consumer", "1.0.0"), "cert-manager:>= 1.8", "nginx", "external-dns"),
+			all: []appskubermaticv1.ApplicationInstallation{
+				*ready(genApplicationInstallation("cert-manager", "cert-manager", "1.7.0")),
+				*genApplicationInstallation("nginx", "nginx", "1.0.0"),
+				*ready(genApplicationInstallation("external-dns", "external-dns", "1.0.0")),
+			},
+			expectedPending: []string{
+				"application cert-manager (>= 1.8) is not installed",
+				"application nginx is not ready",
+			},
+		},
+		{
+			name:            "scenario 4: dependencies being deleted are not ready",
+			appInstallation: withDependsOn(genApplicationInstallation("consumer", "consumer", "1.0.0"), "ingress"),
+			all: []appskubermaticv1.ApplicationInstallation{
+				*deleting(ready(genApplicationInstallation("ingress", "nginx", "1.0.0"))),
+			},
+			expectedPending: []string{
+				"ApplicationInstallation apps/ingress is not ready",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			g.Expect(pendingDependencies(tc.appInstallation, tc.all)).Should(gomega.Equal(tc.expectedPending))
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	certManager := genApplicationInstallation("cert-manager", "cert-manager", "1.8.0")
+	ingress := withDependsOn(genApplicationInstallation("ingress", "nginx", "1.0.0"), "cert-manager")
+	consumer := withApplicationDependencies(genApplicationInstallation("consumer", "consumer", "1.0.0"), "nginx")
+	all := []appskubermaticv1.ApplicationInstallation{*certManager, *ingress, *consumer}
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(names(dependents(certManager, all))).Should(gomega.ConsistOf("ingress"))
+	g.Expect(names(dependents(ingress, all))).Should(gomega.ConsistOf("consumer"))
+	g.Expect(dependents(consumer, all)).Should(gomega.BeEmpty())
+
+	g.Expect(transitiveDependencies(consumer, all)).Should(gomega.HaveLen(2))
+	g.Expect(transitiveDependencies(certManager, all)).Should(gomega.BeEmpty())
+
+	// with a cycle, the transitive dependencies include the dependents.
+	cycle := []appskubermaticv1.ApplicationInstallation{*withDependsOn(certManager.DeepCopy(), "consumer"), *ingress, *consumer}
+	g.Expect(transitiveDependencies(&cycle[0], cycle)).Should(gomega.HaveKey(types.NamespacedName{Namespace: applicationNamespace, Name: "ingress"}))
+}
+
+func TestEnqueueRelatedAppInstallations(t *testing.T) {
+	certManager := genApplicationInstallation("cert-manager", "cert-manager", "1.8.0")
+	ingress := withDependsOn(genApplicationInstallation("ingress", "nginx", "1.0.0"), "cert-manager")
+	consumer := withApplicationDependencies(genApplicationInstallation("consumer", "consumer", "1.0.0"), "nginx")
+	unrelated := genApplicationInstallation("unrelated", "unrelated", "1.0.0")
+
+	userClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithObjects(certManager, ingress, consumer, unrelated).
+		Build()
+
+	g := gomega.NewGomegaWithT(t)
+
+	actual := enqueueRelatedAppInstallations(context.Background(), userClient)(ingress)
+	g.Expect(actual).Should(gomega.ConsistOf(
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "cert-manager", Namespace: applicationNamespace}},
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "consumer", Namespace: applicationNamespace}},
+	))
+
+	g.Expect(enqueueRelatedAppInstallations(context.Background(), userClient)(unrelated)).Should(gomega.BeEmpty())
+}
+
+func withDependsOn(appInstallation *appskubermaticv1.ApplicationInstallation, names ...string) *appskubermaticv1.ApplicationInstallation {
+	for _, name := range names {
+		appInstallation.Spec.DependsOn = append(appInstallation.Spec.DependsOn, appskubermaticv1.ApplicationInstallationReference{Name: name})
+	}
+	return appInstallation
+}
+
+// withApplicationDependencies adds dependencies to the application version. A dependency is "<name>[:<version constraint>]".
+func withApplicationDependencies(appInstallation *appskubermaticv1.ApplicationInstallation, deps ...string) *appskubermaticv1.ApplicationInstallation {
+	appInstallation.Status.ApplicationVersion = &appskubermaticv1.ApplicationVersion{Version: appInstallation.Spec.ApplicationRef.Version.String()}
+	for _, dep := range deps {
+		name, version, _ := strings.Cut(dep, ":")
+		appInstallation.Status.ApplicationVersion.DependsOn = append(appInstallation.Status.ApplicationVersion.DependsOn, appskubermaticv1.ApplicationDependency{Name: name, Version: version})
+	}
+	return appInstallation
+}
+
+func ready(appInstallation *appskubermaticv1.ApplicationInstallation) *appskubermaticv1.ApplicationInstallation {
+	appInstallation.Status.Conditions = map[appskubermaticv1.ApplicationInstallationConditionType]appskubermaticv1.ApplicationInstallationCondition{
+		appskubermaticv1.Ready: {Status: corev1.ConditionTrue},
+	}
+	return appInstallation
+}
+
+func deleting(appInstallation *appskubermaticv1.ApplicationInstallation) *appskubermaticv1.ApplicationInstallation {
+	now := metav1.Now()
+	appInstallation.DeletionTimestamp = &now
+	return appInstallation
+}
+
+func names(apps []*appskubermaticv1.ApplicationInstallation) []string {
+	var res []string
+	for _, app := range apps {
+		res = append(res, app.Name)
+	}
+	return res
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultDriftDetectionInterval is the interval between two drift detections if drift detection is enabled without
+	// an interval.
+	defaultDriftDetectionInterval = 10 * time.Minute
+
+	// maxDriftedObjectsInMessage limits the number of drifted objects listed in the message of the Drifted condition.
+	maxDriftedObjectsInMessage = 10
+
+	// Event raised when live objects of an applicationInstallation differ from the desired state.
+	applicationDriftDetectedEvent = "ApplicationDriftDetected"
+)
+
+// driftDetectionInterval returns the interval between two drift detections of appInstallation or 0 if drift detection
+// is not enabled. Drift detection downloads the application source each time, so it is opt-in.
+func driftDetectionInterval(appInstallation *appskubermaticv1.ApplicationInstallation) time.Duration {
+	driftDetection := appInstallation.Spec.DriftDetection
+	if driftDetection == nil || driftDetection.Disabled {
+		return 0
+	}
+	if driftDetection.Interval.Duration > 0 {
+		return driftDetection.Interval.Duration
+	}
+	return defaultDriftDetectionInterval
+}
+
+// installationUpToDate returns true if the current generation of appInstallation has been successfully installed and
+// neither a rollback nor a reinstallation has been requested, i.e. installing the application again is only required
+// to correct drift.
+func installationUpToDate(appInstallation *appskubermaticv1.ApplicationInstallation) bool {
+	if _, rollbackRequested := appInstallation.Annotations[appskubermaticv1.ApplicationInstallationRollbackAnnotation]; rollbackRequested {
+		return false
+	}
+	if _, reinstallRequested := appInstallation.Annotations[appskubermaticv1.ApplicationInstallationReinstallAnnotation]; reinstallRequested {
+		return false
+	}
+
+	return appInstallation.Status.ObservedGeneration == appInstallation.Generation && isReady(appInstallation)
+}
+
+// handleDriftDetection compares the desired state of the application with the live objects of the user cluster and
+// reports the drift with the Drifted condition. If self-healing is enabled, the application is installed again.
+func (r *reconciler) handleDriftDetection(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) error {
+	downloadDest, err := os.MkdirTemp(r.appInstaller.GetAppCache(), appInstallation.Namespace+"-"+appInstallation.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory where application source will be downloaded: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(downloadDest); err != nil {
+			log.Errorw("failed to remove temporary directory where application source has been downloaded", zap.Error(err))
+		}
+	}()
+
+	appSourcePath, err := r.appInstaller.DonwloadSource(ctx, log, r.seedClient, appInstallation, downloadDest)
+	if err != nil {
+		return fmt.Errorf("failed to download application source to detect drift: %w", err)
+	}
+
+	drifted, err := r.appInstaller.DetectDrift(ctx, log, r.seedClient, r.userClient, appInstallation, appSourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to detect drift: %w", err)
+	}
+
+	if len(drifted) == 0 {
+		return r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Drifted, corev1.ConditionFalse, "NoDriftDetected", "live objects match the desired state")
+	}
+
+	message := driftMessage(drifted)
+	r.traceWarning(appInstallation, log, applicationDriftDetectedEvent, message)
+
+	if appInstallation.Spec.DriftDetection == nil || !appInstallation.Spec.DriftDetection.SelfHeal {
+		return r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Drifted, corev1.ConditionTrue, "DriftDetected", message)
+	}
+
+	// a rolled back release is kept until the spec changes, so installing the application again would not correct anything.
+	if rolledBack(appInstallation) {
+		return r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Drifted, corev1.ConditionTrue, "RolledBack", "release has been rolled back, drift is not corrected until the spec changes: "+message)
+	}
+
+	log.Info("drift detected, re-applying the desired state")
+	if err := r.handleInstallation(ctx, log, appInstallation); err != nil {
+		if condErr := r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Drifted, corev1.ConditionTrue, "SelfHealFailed", message); condErr != nil {
+			log.Errorw("failed to update Drifted condition", zap.Error(condErr))
+		}
+		return err
+	}
+
+	return r.setConditionIfChanged(ctx, appInstallation, appskubermaticv1.Drifted, corev1.ConditionFalse, "DriftCorrected", "desired state has been re-applied: "+message)
+}
+
+// rolledBack returns true if the release of the current generation of appInstallation has been rolled back on request.
+func rolledBack(appInstallation *appskubermaticv1.ApplicationInstallation) bool {
+	return appInstallation.Status.RolledBackGeneration != 0 && appInstallation.Status.RolledBackGeneration == appInstallation.Generation
+}
+
+// driftMessage lists the drifted objects.
+func driftMessage(drifted []util.DriftedObject) string {
+	objects := make([]string, 0, len(drifted))
+	for i, obj := range drifted {
+		if i == maxDriftedObjectsInMessage {
+			objects = append(objects, fmt.Sprintf("and %d more", len(drifted)-maxDriftedObjectsInMessage))
+			break
+		}
+		objects = append(objects, obj.String())
+	}
+
+	return fmt.Sprintf("%d object(s) differ from the desired state: %s", len(drifted), strings.Join(objects, "; "))
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/fake"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDriftDetectionInterval(t *testing.T) {
+	testCases := []struct {
+		name           string
+		driftDetection *appskubermaticv1.DriftDetection
+		expected       time.Duration
+	}{
+		{
+			name:     "not enabled",
+			expected: 0,
+		},
+		{
+			name:           "defaults to 10 minutes",
+			driftDetection: &appskubermaticv1.DriftDetection{},
+			expected:       defaultDriftDetectionInterval,
+		},
+		{
+			name:           "custom interval",
+			driftDetection: &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: 2 * time.Minute}},
+			expected:       2 * time.Minute,
+		},
+		{
+			name:           "disabled",
+			driftDetection: &appskubermaticv1.DriftDetection{Disabled: true, Interval: metav1.Duration{Duration: 2 * time.Minute}},
+			expected:       0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := genApplicationInstallation("app", "app-def", "1.0.0")
+			app.Spec.DriftDetection = tc.driftDetection
+
+			if interval := driftDetectionInterval(app); interval != tc.expected {
+				t.Errorf("expected interval %s, got %s", tc.expected, interval)
+			}
+		})
+	}
+}
+
+func TestInstallationUpToDate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:     "installed generation is up to date",
+			expected: true,
+		},
+		{
+			name:        "rollback has been requested",
+			annotations: map[string]string{appskubermaticv1.ApplicationInstallationRollbackAnnotation: "1"},
+			expected:    false,
+		},
+		{
+			name:        "reinstallation has been requested",
+			annotations: map[string]string{appskubermaticv1.ApplicationInstallationReinstallAnnotation: ""},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := ready(genApplicationInstallation("app", "app-def", "1.0.0"))
+			app.Annotations = tc.annotations
+
+			if upToDate := installationUpToDate(app); upToDate != tc.expected {
+				t.Errorf("expected up to date: %t, got %t", tc.expected, upToDate)
+			}
+		})
+	}
+}
+
+func TestHandleDriftDetection(t *testing.T) {
+	drifted := []util.DriftedObject{
+		{Object: appskubermaticv1.InventoryObject{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm"}, Fields: []string{"data.key"}},
+	}
+
+	testCases := []struct {
+		name            string
+		selfHeal        bool
+		rolledBack      bool
+		driftedObjects  []util.DriftedObject
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedApplied bool
+	}{
+		{
+			name:           "no drift",
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "NoDriftDetected",
+		},
+		{
+			name:           "drift is reported",
+			driftedObjects: drifted,
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "DriftDetected",
+		},
+		{
+			name:            "drift is corrected with self-healing",
+			selfHeal:        true,
+			driftedObjects:  drifted,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "DriftCorrected",
+			expectedApplied: true,
+		},
+		{
+			name:           "drift of a rolled back release is not corrected",
+			selfHeal:       true,
+			rolledBack:     true,
+			driftedObjects: drifted,
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "RolledBack",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			app := ready(genApplicationInstallation("app", "app-def", "1.0.0"))
+			app.Spec.DriftDetection = &appskubermaticv1.DriftDetection{SelfHeal: tc.selfHeal}
+			if tc.rolledBack {
+				app.Generation = 1
+				app.Status.RolledBackGeneration = 1
+			}
+
+			userClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(app).Build()
+			installer := &fake.ApplicationInstallerRecorder{DriftedObjects: tc.driftedObjects}
+			r := &reconciler{
+				log:          kubermaticlog.Logger,
+				seedClient:   fakectrlruntimeclient.NewClientBuilder().Build(),
+				userClient:   userClient,
+				userRecorder: record.NewFakeRecorder(10),
+				appInstaller: installer,
+			}
+
+			if err := r.handleDriftDetection(ctx, r.log, app); err != nil {
+				t.Fatalf("failed to handle drift detection: %v", err)
+			}
+
+			if _, called := installer.DetectDriftEvents.Load(app.Name); !called {
+				t.Error("expected drift to be detected")
+			}
+			if _, applied := installer.ApplyEvents.Load(app.Name); applied != tc.expectedApplied {
+				t.Errorf("expected application to be applied: %t, got %t", tc.expectedApplied, applied)
+			}
+
+			current := &appskubermaticv1.ApplicationInstallation{}
+			if err := userClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(app), current); err != nil {
+				t.Fatalf("failed to get application installation: %v", err)
+			}
+
+			condition := current.Status.Conditions[appskubermaticv1.Drifted]
+			if condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason {
+				t.Errorf("expected Drifted condition %s/%s, got %s/%s", tc.expectedStatus, tc.expectedReason, condition.Status, condition.Reason)
+			}
+			if len(tc.driftedObjects) > 0 && !strings.Contains(condition.Message, "ConfigMap default/cm (data.key)") {
+				t.Errorf("expected drifted object to be listed in condition message, got %q", condition.Message)
+			}
+		})
+	}
+}
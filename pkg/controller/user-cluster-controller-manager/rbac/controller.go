@@ -24,6 +24,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	userclustercontrollermanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager"
 
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -44,8 +45,19 @@ const (
 	ResourceViewerName = "system:kubermatic:viewers"
 )
 
+// MachineDeploymentsResourceNames returns the names of the Cluster Roles and Cluster Role Bindings
+// for the groups that are allowed to perform a single verb on MachineDeployments.
+func MachineDeploymentsResourceNames() []string {
+	names := []string{}
+	for _, verb := range rbac.MachineDeploymentsGroupVerbs {
+		names = append(names, fmt.Sprintf("system:%s:%s", rbac.RBACResourcesNamePrefix, rbac.GenerateMachineDeploymentsGroupNameFor(verb)))
+	}
+
+	return names
+}
+
 var mapFn = handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object) []reconcile.Request {
-	return []reconcile.Request{
+	requests := []reconcile.Request{
 		{NamespacedName: types.NamespacedName{
 			Name:      ResourceOwnerName,
 			Namespace: "",
@@ -59,10 +71,19 @@ var mapFn = handler.EnqueueRequestsFromMapFunc(func(o ctrlruntimeclient.Object)
 			Namespace: "",
 		}},
 	}
+
+	for _, name := range MachineDeploymentsResourceNames() {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: "",
+		}})
+	}
+
+	return requests
 })
 
 // Add creates a new RBAC generator controller that is responsible for creating Cluster Roles and Cluster Role Bindings
-// for groups: `owners`, `editors` and `viewers``.
+// for groups: `owners`, `editors`, `viewers` and the per-verb `machinedeployments` groups.
 func Add(mgr manager.Manager, logger *zap.SugaredLogger, registerReconciledCheck func(name string, check healthz.Checker) error, clusterIsPaused userclustercontrollermanager.IsPausedChecker) error {
 	reconcile := &reconciler{
 		Client:          mgr.GetClient(),
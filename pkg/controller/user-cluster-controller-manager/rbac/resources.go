@@ -19,6 +19,7 @@ package rbacusercluster
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	constrainttemplatesv1 "github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1"
 	configv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/config/v1alpha1"
@@ -32,6 +33,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -49,10 +51,58 @@ func generateVerbsForGroup(groupName string) ([]string, error) {
 		return []string{"list", "get", "watch"}, nil
 	}
 
+	// the MachineDeployments groups are only allowed to perform the verb they are named after
+	for _, verb := range rbac.MachineDeploymentsGroupVerbs {
+		if groupName == rbac.GenerateMachineDeploymentsGroupNameFor(verb) {
+			return []string{verb}, nil
+		}
+	}
+
 	// unknown group passed
 	return []string{}, fmt.Errorf("unable to generate verbs, unknown group name %q given", groupName)
 }
 
+// machineDeploymentsGroupRules returns the rules for a MachineDeployments group. Besides
+// the verb on MachineDeployments, "get" allows to read the MachineSets and Machines of a
+// MachineDeployment to list its nodes, events and metrics, while "delete" allows to
+// delete single Machines and Nodes.
+func machineDeploymentsGroupRules(verbs []string) []rbacv1.PolicyRule {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{clusterPolicyAPIGroup},
+			Resources: []string{"machinedeployments"},
+			Verbs:     verbs,
+		},
+	}
+
+	verbSet := sets.NewString(verbs...)
+
+	if verbSet.Has("get") {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{clusterPolicyAPIGroup},
+			Resources: []string{"machinesets", "machines"},
+			Verbs:     []string{"get", "list"},
+		})
+	}
+
+	if verbSet.Has("delete") {
+		rules = append(rules,
+			rbacv1.PolicyRule{
+				APIGroups: []string{clusterPolicyAPIGroup},
+				Resources: []string{"machines"},
+				Verbs:     []string{"get", "delete"},
+			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get", "delete"},
+			},
+		)
+	}
+
+	return rules
+}
+
 func newClusterRoleCreator(resourceName string) (reconciling.NamedClusterRoleCreatorGetter, error) {
 	return func() (string, reconciling.ClusterRoleCreator) {
 		return resourceName, func(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
@@ -73,6 +123,16 @@ func CreateClusterRole(resourceName string, cr *rbacv1.ClusterRole) (*rbacv1.Clu
 	}
 
 	cr.Name = resourceName // this is useful for our conformance tests, the reconciling framework would otherwise set it later
+
+	// users bound to a ProjectRole that grants access to MachineDeployments
+	// get exactly the granted verbs on MachineDeployments and what the API
+	// needs to serve the MachineDeployment endpoints for that verb
+	if strings.HasPrefix(groupName, rbac.MachineDeploymentsGroupNamePrefix+"-") {
+		cr.Rules = machineDeploymentsGroupRules(verbs)
+
+		return cr, nil
+	}
+
 	cr.Rules = []rbacv1.PolicyRule{
 		{
 			APIGroups: []string{clusterPolicyAPIGroup},
@@ -201,7 +261,7 @@ func CreateClusterRoleBinding(resourceName string, crb *rbacv1.ClusterRoleBindin
 	return crb, nil
 }
 
-var groupNameRegex = regexp.MustCompile(fmt.Sprintf("system:%s:(%s|%s|%s)", rbac.RBACResourcesNamePrefix, rbac.OwnerGroupNamePrefix, rbac.EditorGroupNamePrefix, rbac.ViewerGroupNamePrefix))
+var groupNameRegex = regexp.MustCompile(fmt.Sprintf("system:%s:(%s|%s|%s|%s-(?:%s))$", rbac.RBACResourcesNamePrefix, rbac.OwnerGroupNamePrefix, rbac.EditorGroupNamePrefix, rbac.ViewerGroupNamePrefix, rbac.MachineDeploymentsGroupNamePrefix, strings.Join(rbac.MachineDeploymentsGroupVerbs, "|")))
 
 func getGroupName(resourceName string) (string, error) {
 	match := groupNameRegex.FindStringSubmatch(resourceName)
@@ -56,6 +56,12 @@ func TestGeneratedResourcesForGroups(t *testing.T) {
 			resourceName: rbac.ViewerGroupNamePrefix,
 			expectError:  true,
 		},
+		{
+			name:              "scenario 5: check resources for machine deployments getters",
+			resourceName:      genResourceName(rbac.GenerateMachineDeploymentsGroupNameFor("get")),
+			expectedResources: []string{"machinedeployments"},
+			expectError:       false,
+		},
 	}
 
 	for _, test := range tests {
@@ -79,6 +85,43 @@ func TestGeneratedResourcesForGroups(t *testing.T) {
 	}
 }
 
+func TestMachineDeploymentsGroupRules(t *testing.T) {
+	tests := []struct {
+		verb              string
+		expectedResources []string
+	}{
+		{
+			verb:              "get",
+			expectedResources: []string{"machinedeployments", "machinesets", "machines"},
+		},
+		{
+			verb:              "list",
+			expectedResources: []string{"machinedeployments"},
+		},
+		{
+			verb:              "delete",
+			expectedResources: []string{"machinedeployments", "machines", "nodes"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.verb, func(t *testing.T) {
+			cr, err := CreateClusterRole(genResourceName(rbac.GenerateMachineDeploymentsGroupNameFor(test.verb)), &rbacv1.ClusterRole{})
+			if err != nil {
+				t.Fatalf("expected no error, but got: %v", err)
+			}
+
+			actualResources := []string{}
+			for _, rule := range cr.Rules {
+				actualResources = append(actualResources, rule.Resources...)
+			}
+			if !equality.Semantic.DeepEqual(actualResources, test.expectedResources) {
+				t.Fatalf("incorrect resources were returned, got: %v, want: %v", actualResources, test.expectedResources)
+			}
+		})
+	}
+}
+
 func TestGenerateVerbsForGroup(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -110,6 +153,17 @@ func TestGenerateVerbsForGroup(t *testing.T) {
 			resourceName: rbac.ViewerGroupNamePrefix,
 			expectError:  true,
 		},
+		{
+			name:          "scenario 5: generate verbs for machine deployments getters",
+			resourceName:  genResourceName(rbac.GenerateMachineDeploymentsGroupNameFor("get")),
+			expectedVerbs: []string{"get"},
+			expectError:   false,
+		},
+		{
+			name:         "scenario 6: unknown machine deployments verb",
+			resourceName: genResourceName(rbac.GenerateMachineDeploymentsGroupNameFor("escalate")),
+			expectError:  true,
+		},
 	}
 
 	for _, test := range tests {
@@ -660,6 +660,10 @@ func (r *reconciler) reconcileMutatingWebhookConfigurations(ctx context.Context,
 	if r.opaIntegration && r.opaEnableMutation {
 		creators = append(creators, gatekeeper.MutatingWebhookConfigurationCreator(r.opaWebhookTimeout))
 	}
+	// MachineDeployments are only defaulted with the cloud resources of DigitalOcean clusters
+	if r.cloudProvider == kubermaticv1.DigitaloceanCloudProvider {
+		creators = append(creators, machine.MutatingWebhookConfigurationCreator(data.caCert.Cert, r.namespace))
+	}
 	if err := reconciling.ReconcileMutatingWebhookConfigurations(ctx, creators, "", r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile MutatingWebhookConfigurations: %w", err)
 	}
@@ -32,7 +32,8 @@ import (
 )
 
 const (
-	machineValidatingWebhookConfigurationName = "kubermatic-machine-validation"
+	machineValidatingWebhookConfigurationName         = "kubermatic-machine-validation"
+	machineDeploymentMutatingWebhookConfigurationName = "kubermatic-machinedeployment-mutation"
 )
 
 // ValidatingWebhookConfigurationCreator returns the ValidatingWebhookConfiguration for the machine CRD.
@@ -84,3 +85,55 @@ func ValidatingWebhookConfigurationCreator(caCert *x509.Certificate, namespace s
 		}
 	}
 }
+
+// MutatingWebhookConfigurationCreator returns the MutatingWebhookConfiguration for the machinedeployment CRD.
+func MutatingWebhookConfigurationCreator(caCert *x509.Certificate, namespace string) reconciling.NamedMutatingWebhookConfigurationCreatorGetter {
+	return func() (string, reconciling.MutatingWebhookConfigurationCreator) {
+		return machineDeploymentMutatingWebhookConfigurationName, func(hook *admissionregistrationv1.MutatingWebhookConfiguration) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
+			matchPolicy := admissionregistrationv1.Exact
+			failurePolicy := admissionregistrationv1.Fail
+			reinvocationPolicy := admissionregistrationv1.NeverReinvocationPolicy
+			sideEffects := admissionregistrationv1.SideEffectClassNone
+			scope := admissionregistrationv1.NamespacedScope
+
+			url := fmt.Sprintf("https://%s.%s.svc.cluster.local.:%d/mutate-cluster-k8s-io-v1alpha1-machinedeployment",
+				resources.UserClusterWebhookServiceName,
+				namespace,
+				resources.UserClusterWebhookUserListenPort,
+			)
+
+			hook.Webhooks = []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:                    "machinedeployments.cluster.k8c.io", // this should be a FQDN
+					AdmissionReviewVersions: []string{admissionregistrationv1.SchemeGroupVersion.Version, admissionregistrationv1beta1.SchemeGroupVersion.Version},
+					MatchPolicy:             &matchPolicy,
+					FailurePolicy:           &failurePolicy,
+					ReinvocationPolicy:      &reinvocationPolicy,
+					SideEffects:             &sideEffects,
+					TimeoutSeconds:          pointer.Int32Ptr(3),
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						CABundle: triple.EncodeCertPEM(caCert),
+						URL:      &url,
+					},
+					ObjectSelector:    &metav1.LabelSelector{},
+					NamespaceSelector: &metav1.LabelSelector{},
+					Rules: []admissionregistrationv1.RuleWithOperations{
+						{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{clusterv1alpha1.SchemeGroupVersion.Group},
+								APIVersions: []string{clusterv1alpha1.SchemeGroupVersion.Version},
+								Resources:   []string{"machinedeployments"},
+								Scope:       &scope,
+							},
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+						},
+					},
+				},
+			}
+			return hook, nil
+		}
+	}
+}
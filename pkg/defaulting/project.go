@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// DefaultClusterSpecFromProjectPolicy applies the project's default cluster
+// settings to all settings the cluster does not configure itself. It should be
+// called before DefaultClusterSpec, so that the project defaults take precedence
+// over the seed-wide defaults.
+func DefaultClusterSpecFromProjectPolicy(spec *kubermaticv1.ClusterSpec, policy *kubermaticv1.ProjectClusterPolicy) {
+	if policy == nil || policy.Defaults == nil {
+		return
+	}
+
+	defaults := policy.Defaults
+
+	if spec.AuditLogging == nil && defaults.AuditLogging != nil {
+		spec.AuditLogging = defaults.AuditLogging.DeepCopy()
+	}
+
+	if spec.OPAIntegration == nil && defaults.OPAIntegration != nil {
+		spec.OPAIntegration = &kubermaticv1.OPAIntegrationSettings{
+			Enabled: *defaults.OPAIntegration,
+		}
+	}
+
+	if spec.MLA == nil && (defaults.MonitoringEnabled != nil || defaults.LoggingEnabled != nil) {
+		spec.MLA = &kubermaticv1.MLASettings{}
+
+		if defaults.MonitoringEnabled != nil {
+			spec.MLA.MonitoringEnabled = *defaults.MonitoringEnabled
+		}
+		if defaults.LoggingEnabled != nil {
+			spec.MLA.LoggingEnabled = *defaults.LoggingEnabled
+		}
+	}
+
+	if len(spec.AdmissionPlugins) == 0 && len(defaults.AdmissionPlugins) > 0 {
+		spec.AdmissionPlugins = append([]string{}, defaults.AdmissionPlugins...)
+	}
+}
+
+// EnforceProjectClusterPolicy applies the annotations and locked settings from
+// the project's cluster policy to the cluster, overwriting whatever the cluster
+// configures itself. The project's labels are not copied here, this is left to
+// the project-label-synchronizer.
+func EnforceProjectClusterPolicy(cluster *kubermaticv1.Cluster, project *kubermaticv1.Project) {
+	policy := project.Spec.ClusterPolicy
+	if policy == nil {
+		return
+	}
+
+	for key, value := range policy.Annotations {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[key] = value
+	}
+
+	locked := policy.Locked
+	if locked == nil {
+		return
+	}
+
+	spec := &cluster.Spec
+
+	if locked.AuditLogging != nil {
+		spec.AuditLogging = locked.AuditLogging.DeepCopy()
+	}
+
+	if locked.OPAIntegration != nil {
+		if spec.OPAIntegration == nil {
+			spec.OPAIntegration = &kubermaticv1.OPAIntegrationSettings{}
+		}
+		spec.OPAIntegration.Enabled = *locked.OPAIntegration
+	}
+
+	if locked.MonitoringEnabled != nil || locked.LoggingEnabled != nil {
+		if spec.MLA == nil {
+			spec.MLA = &kubermaticv1.MLASettings{}
+		}
+
+		if locked.MonitoringEnabled != nil {
+			spec.MLA.MonitoringEnabled = *locked.MonitoringEnabled
+		}
+		if locked.LoggingEnabled != nil {
+			spec.MLA.LoggingEnabled = *locked.LoggingEnabled
+		}
+	}
+
+	enabledPlugins := sets.NewString(spec.AdmissionPlugins...)
+	for _, plugin := range locked.AdmissionPlugins {
+		if !enabledPlugins.Has(plugin) {
+			spec.AdmissionPlugins = append(spec.AdmissionPlugins, plugin)
+			enabledPlugins.Insert(plugin)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestDefaultClusterSpecFromProjectPolicy(t *testing.T) {
+	policy := &kubermaticv1.ProjectClusterPolicy{
+		Defaults: &kubermaticv1.ProjectClusterSettings{
+			OPAIntegration:    pointer.BoolPtr(true),
+			MonitoringEnabled: pointer.BoolPtr(true),
+			AdmissionPlugins:  []string{"PodNodeSelector"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     kubermaticv1.ClusterSpec
+		expected kubermaticv1.ClusterSpec
+	}{
+		{
+			name: "apply defaults to empty spec",
+			spec: kubermaticv1.ClusterSpec{},
+			expected: kubermaticv1.ClusterSpec{
+				OPAIntegration:   &kubermaticv1.OPAIntegrationSettings{Enabled: true},
+				MLA:              &kubermaticv1.MLASettings{MonitoringEnabled: true},
+				AdmissionPlugins: []string{"PodNodeSelector"},
+			},
+		},
+		{
+			name: "keep settings configured by the cluster",
+			spec: kubermaticv1.ClusterSpec{
+				OPAIntegration:   &kubermaticv1.OPAIntegrationSettings{Enabled: false},
+				MLA:              &kubermaticv1.MLASettings{LoggingEnabled: true},
+				AdmissionPlugins: []string{"EventRateLimit"},
+			},
+			expected: kubermaticv1.ClusterSpec{
+				OPAIntegration:   &kubermaticv1.OPAIntegrationSettings{Enabled: false},
+				MLA:              &kubermaticv1.MLASettings{LoggingEnabled: true},
+				AdmissionPlugins: []string{"EventRateLimit"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec.DeepCopy()
+			DefaultClusterSpecFromProjectPolicy(spec, policy)
+
+			if !diff.SemanticallyEqual(tc.expected, *spec) {
+				t.Fatalf("Diff found between expected and actual spec:\n%v", diff.ObjectDiff(tc.expected, *spec))
+			}
+		})
+	}
+}
+
+func TestEnforceProjectClusterPolicy(t *testing.T) {
+	project := &kubermaticv1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "abcd1234",
+			Labels: map[string]string{
+				"team":                         "platform",
+				kubermaticv1.ProjectIDLabelKey: "abcd1234",
+			},
+		},
+		Spec: kubermaticv1.ProjectSpec{
+			ClusterPolicy: &kubermaticv1.ProjectClusterPolicy{
+				Annotations: map[string]string{
+					"cost-center": "1234",
+				},
+				Locked: &kubermaticv1.ProjectClusterSettings{
+					OPAIntegration:   pointer.BoolPtr(true),
+					LoggingEnabled:   pointer.BoolPtr(false),
+					AdmissionPlugins: []string{"PodNodeSelector"},
+				},
+			},
+		},
+	}
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: "abcd1234",
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			MLA:              &kubermaticv1.MLASettings{MonitoringEnabled: true, LoggingEnabled: true},
+			AdmissionPlugins: []string{"EventRateLimit"},
+		},
+	}
+
+	EnforceProjectClusterPolicy(cluster, project)
+
+	expected := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey: "abcd1234",
+			},
+			Annotations: map[string]string{
+				"cost-center": "1234",
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			OPAIntegration:   &kubermaticv1.OPAIntegrationSettings{Enabled: true},
+			MLA:              &kubermaticv1.MLASettings{MonitoringEnabled: true, LoggingEnabled: false},
+			AdmissionPlugins: []string{"EventRateLimit", "PodNodeSelector"},
+		},
+	}
+
+	if !diff.SemanticallyEqual(expected, cluster) {
+		t.Fatalf("Diff found between expected and actual cluster:\n%v", diff.ObjectDiff(expected, cluster))
+	}
+}
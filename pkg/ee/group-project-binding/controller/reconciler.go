@@ -159,6 +159,11 @@ func getTargetClusterRoles(ctx context.Context, client ctrlruntimeclient.Client,
 		clusterRoles []rbacv1.ClusterRole
 	)
 
+	// bindings that only reference a ProjectRole are handled by the ProjectRole RBAC generator
+	if binding.Spec.Role == "" {
+		return nil, nil
+	}
+
 	clusterRoleList := &rbacv1.ClusterRoleList{}
 
 	// find those ClusterRoles created for a specific role in a specific project.
@@ -186,6 +191,10 @@ func getTargetRoles(ctx context.Context, client ctrlruntimeclient.Client, bindin
 	roleMap := make(map[string][]rbacv1.Role)
 	roleList := &rbacv1.RoleList{}
 
+	if binding.Spec.Role == "" {
+		return roleMap, nil
+	}
+
 	// find those Roles created for a specific role in a specific project.
 	if err := client.List(ctx, roleList, ctrlruntimeclient.MatchingLabels{
 		kubermaticv1.AuthZRoleLabel: fmt.Sprintf("%s-%s", binding.Spec.Role, binding.Spec.ProjectID),
@@ -26,6 +26,7 @@ package metering
 
 import (
 	"fmt"
+	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
@@ -47,6 +48,16 @@ func cronJobCreator(reportName string, mrc *kubermaticv1.MeteringReportConfigura
 			args = append(args, fmt.Sprintf("--prometheus-api=http://%s.%s.svc", prometheus.Name, namespace))
 			args = append(args, fmt.Sprintf("--last-number-of-days=%d", mrc.Interval))
 			args = append(args, fmt.Sprintf("--output-dir=%s", reportName))
+			if len(mrc.Formats) > 0 {
+				formats := make([]string, 0, len(mrc.Formats))
+				for _, f := range mrc.Formats {
+					formats = append(formats, string(f))
+				}
+				args = append(args, fmt.Sprintf("--output-format=%s", strings.Join(formats, ",")))
+			}
+			if len(mrc.Metrics) > 0 {
+				args = append(args, fmt.Sprintf("--metrics=%s", strings.Join(mrc.Metrics, ",")))
+			}
 			args = append(args, mrc.Types...)
 
 			if job.Labels == nil {
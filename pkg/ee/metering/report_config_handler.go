@@ -66,9 +66,11 @@ type createReportConfigurationReq struct {
 
 	// in: body
 	Body struct {
-		Schedule  string `json:"schedule"`
-		Interval  int32  `json:"interval"`
-		Retention *int32 `json:"retention,omitempty"`
+		Schedule  string   `json:"schedule"`
+		Interval  int32    `json:"interval"`
+		Retention *int32   `json:"retention,omitempty"`
+		Formats   []string `json:"formats,omitempty"`
+		Metrics   []string `json:"metrics,omitempty"`
 	}
 }
 
@@ -92,6 +94,14 @@ func (m createReportConfigurationReq) Validate() error {
 		}
 	}
 
+	if err := validation.ValidateMeteringReportFormats(m.Body.Formats); err != nil {
+		return utilerrors.NewBadRequest(err.Error())
+	}
+
+	if err := validation.ValidateMeteringReportMetrics(m.Body.Metrics); err != nil {
+		return utilerrors.NewBadRequest(err.Error())
+	}
+
 	return nil
 }
 
@@ -103,9 +113,11 @@ type updateReportConfigurationReq struct {
 
 	// in: body
 	Body struct {
-		Schedule  string `json:"schedule,omitempty"`
-		Interval  *int32 `json:"interval,omitempty"`
-		Retention *int32 `json:"retention,omitempty"`
+		Schedule  string   `json:"schedule,omitempty"`
+		Interval  *int32   `json:"interval,omitempty"`
+		Retention *int32   `json:"retention,omitempty"`
+		Formats   []string `json:"formats,omitempty"`
+		Metrics   []string `json:"metrics,omitempty"`
 	}
 }
 
@@ -133,6 +145,14 @@ func (m updateReportConfigurationReq) Validate() error {
 		}
 	}
 
+	if err := validation.ValidateMeteringReportFormats(m.Body.Formats); err != nil {
+		return utilerrors.NewBadRequest(err.Error())
+	}
+
+	if err := validation.ValidateMeteringReportMetrics(m.Body.Metrics); err != nil {
+		return utilerrors.NewBadRequest(err.Error())
+	}
+
 	return nil
 }
 
@@ -216,12 +236,7 @@ func GetMeteringReportConfiguration(seedsGetter provider.SeedsGetter, request in
 		if report, ok := seed.Spec.Metering.ReportConfigurations[req.Name]; ok {
 			// Metering configuration is replicated across all seeds.
 			// We can return after finding configuration in the first seed.
-			return &apiv1.MeteringReportConfiguration{
-				Name:      req.Name,
-				Schedule:  report.Schedule,
-				Interval:  report.Interval,
-				Retention: report.Retention,
-			}, nil
+			return convertReportConfigurationToAPI(req.Name, report), nil
 		}
 	}
 
@@ -246,12 +261,7 @@ func ListMeteringReportConfigurations(seedsGetter provider.SeedsGetter) ([]apiv1
 			continue
 		}
 		for reportConfigName, reportConfig := range seed.Spec.Metering.ReportConfigurations {
-			resp = append(resp, apiv1.MeteringReportConfiguration{
-				Name:      reportConfigName,
-				Schedule:  reportConfig.Schedule,
-				Interval:  reportConfig.Interval,
-				Retention: reportConfig.Retention,
-			})
+			resp = append(resp, *convertReportConfigurationToAPI(reportConfigName, reportConfig))
 		}
 		// Metering configuration is replicated across all seeds.
 		// We can break after finding configuration in the first seed.
@@ -353,12 +363,16 @@ func createMeteringReportConfiguration(ctx context.Context, reportCfgReq createR
 			Interval:  uint32(reportCfgReq.Body.Interval),
 			Schedule:  reportCfgReq.Body.Schedule,
 			Retention: &retention,
+			Formats:   convertReportFormats(reportCfgReq.Body.Formats),
+			Metrics:   reportCfgReq.Body.Metrics,
 		}
 	} else {
 		seed.Spec.Metering.ReportConfigurations[reportCfgReq.Name] = &kubermaticv1.MeteringReportConfiguration{
 			Interval:  uint32(reportCfgReq.Body.Interval),
 			Schedule:  reportCfgReq.Body.Schedule,
 			Retention: nil,
+			Formats:   convertReportFormats(reportCfgReq.Body.Formats),
+			Metrics:   reportCfgReq.Body.Metrics,
 		}
 	}
 
@@ -397,6 +411,14 @@ func updateMeteringReportConfiguration(ctx context.Context, reportCfgReq updateR
 		reportConfiguration.Retention = &retention
 	}
 
+	if reportCfgReq.Body.Formats != nil {
+		reportConfiguration.Formats = convertReportFormats(reportCfgReq.Body.Formats)
+	}
+
+	if reportCfgReq.Body.Metrics != nil {
+		reportConfiguration.Metrics = reportCfgReq.Body.Metrics
+	}
+
 	if err := masterClient.Update(ctx, seed); err != nil {
 		return fmt.Errorf("failed to update report configuration %q in seed %q: %w", reportCfgReq.Name, seed.Name, err)
 	}
@@ -421,3 +443,27 @@ func deleteMeteringReportConfiguration(ctx context.Context, reportConfigName str
 
 	return nil
 }
+
+func convertReportConfigurationToAPI(name string, reportConfig *kubermaticv1.MeteringReportConfiguration) *apiv1.MeteringReportConfiguration {
+	var formats []string
+	for _, f := range reportConfig.Formats {
+		formats = append(formats, string(f))
+	}
+
+	return &apiv1.MeteringReportConfiguration{
+		Name:      name,
+		Schedule:  reportConfig.Schedule,
+		Interval:  reportConfig.Interval,
+		Retention: reportConfig.Retention,
+		Formats:   formats,
+		Metrics:   reportConfig.Metrics,
+	}
+}
+
+func convertReportFormats(formats []string) []kubermaticv1.MeteringReportFormat {
+	var result []kubermaticv1.MeteringReportFormat
+	for _, f := range formats {
+		result = append(result, kubermaticv1.MeteringReportFormat(f))
+	}
+	return result
+}
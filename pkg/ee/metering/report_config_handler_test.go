@@ -254,6 +254,49 @@ func TestCreateMeteringReportConfigEndpoint(t *testing.T) {
 			httpStatus:             http.StatusBadRequest,
 			expectedResponse:       `{"error":{"code":400,"message":"retention value cannot be smaller than 1."}}`,
 		},
+		// scenario 8
+		{
+			name:       "Create new metering report configuration with formats and metrics.",
+			reportName: "monthly",
+			body: `{
+				"interval": 30,
+				"schedule": "1 1 1 * *",
+				"formats": ["csv", "json", "parquet"],
+				"metrics": ["total-used-cpu-seconds", "average-cluster-machines"]
+			}`,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{testSeed},
+			existingAPIUser:        test.GenDefaultAdminAPIUser(),
+			httpStatus:             http.StatusCreated,
+			expectedResponse:       `{}`,
+		},
+		// scenario 9
+		{
+			name:       "Create new metering report configuration. Invalid format.",
+			reportName: "monthly",
+			body: `{
+				"interval": 30,
+				"schedule": "1 1 1 * *",
+				"formats": ["xml"]
+			}`,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{testSeed},
+			existingAPIUser:        test.GenDefaultAdminAPIUser(),
+			httpStatus:             http.StatusBadRequest,
+			expectedResponse:       `{"error":{"code":400,"message":"invalid report format: xml"}}`,
+		},
+		// scenario 10
+		{
+			name:       "Create new metering report configuration. Invalid metric.",
+			reportName: "monthly",
+			body: `{
+				"interval": 30,
+				"schedule": "1 1 1 * *",
+				"metrics": ["total-used-gpu-seconds"]
+			}`,
+			existingKubermaticObjs: []ctrlruntimeclient.Object{testSeed},
+			existingAPIUser:        test.GenDefaultAdminAPIUser(),
+			httpStatus:             http.StatusBadRequest,
+			expectedResponse:       `{"error":{"code":400,"message":"invalid report metric: total-used-gpu-seconds"}}`,
+		},
 	}
 
 	for _, tc := range testcases {
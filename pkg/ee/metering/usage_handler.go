@@ -0,0 +1,413 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2022 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package metering
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+const (
+	jsonReportSuffix = ".json"
+	csvReportSuffix  = ".csv"
+
+	averageMetricPrefix = "average-"
+	maxMetricPrefix     = "max-"
+)
+
+// usageRecord is a single line of a JSON Lines or CSV report written by the metering tool.
+type usageRecord struct {
+	ProjectID   string             `json:"project-id"`
+	ProjectName string             `json:"project-name"`
+	ClusterID   string             `json:"cluster-id"`
+	ClusterName string             `json:"cluster-name"`
+	Namespace   string             `json:"namespace,omitempty"`
+	From        time.Time          `json:"from"`
+	To          time.Time          `json:"to"`
+	Metrics     map[string]float64 `json:"metrics"`
+}
+
+// swagger:parameters getMeteringUsage
+type usageReq struct {
+	// The start of the time range in RFC 3339 format.
+	// in: query
+	// required: true
+	From string `json:"from"`
+	// The end of the time range in RFC 3339 format.
+	// in: query
+	// required: true
+	To string `json:"to"`
+	// The report configuration whose JSON and CSV reports are aggregated. Defaults to all configurations.
+	// in: query
+	ConfigurationName string `json:"configuration_name"`
+	// Restricts the result to a single project.
+	// in: query
+	ProjectID string `json:"project_id"`
+
+	from time.Time
+	to   time.Time
+}
+
+func DecodeGetMeteringUsageReq(r *http.Request) (interface{}, error) {
+	var req usageReq
+	var err error
+
+	req.From = r.URL.Query().Get("from")
+	req.from, err = time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		return nil, utilerrors.NewBadRequest("invalid value for `from`, expected RFC 3339 format: %v", err)
+	}
+
+	req.To = r.URL.Query().Get("to")
+	req.to, err = time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		return nil, utilerrors.NewBadRequest("invalid value for `to`, expected RFC 3339 format: %v", err)
+	}
+
+	if !req.to.After(req.from) {
+		return nil, utilerrors.NewBadRequest("`to` must be after `from`")
+	}
+
+	req.ConfigurationName = r.URL.Query().Get("configuration_name")
+	req.ProjectID = r.URL.Query().Get("project_id")
+
+	return req, nil
+}
+
+// GetUsage aggregates the usage per project and cluster from the JSON and CSV reports generated by metering.
+// Parquet reports are ignored, as the same data is always available in one of the other formats.
+// Only records which lie completely within the requested time range are taken into account.
+// Assumes all Seeds uses the same secrets.
+func GetUsage(ctx context.Context, req interface{}, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) (*apiv2.MeteringUsage, error) {
+	if seedsGetter == nil || seedClientGetter == nil {
+		return nil, errors.New("parameter seedsGetter nor seedClientGetter cannot be nil")
+	}
+
+	request, ok := req.(usageReq)
+	if !ok {
+		return nil, utilerrors.NewBadRequest("invalid request")
+	}
+
+	seedsMap, err := seedsGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix string
+	if request.ConfigurationName != "" {
+		prefix = request.ConfigurationName + "/"
+	}
+
+	for _, seed := range seedsMap {
+		seedClient, err := seedClientGetter(seed)
+		if err != nil {
+			return nil, err
+		}
+
+		mc, bucket, err := getS3DataFromSeed(ctx, seed, seedClient)
+		if err != nil {
+			return nil, err
+		}
+
+		aggregator := newUsageAggregator(request.from, request.to, request.ProjectID)
+		reports := 0
+
+		mcCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		for object := range mc.ListObjects(mcCtx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if object.Err != nil {
+				return nil, errors.New(object.Err.Error())
+			}
+
+			// reports written before the time range started cannot contain any matching record
+			if object.LastModified.Before(request.from) {
+				continue
+			}
+
+			var addReport func(io.Reader) error
+			switch {
+			case strings.HasSuffix(object.Key, jsonReportSuffix):
+				addReport = aggregator.addJSONReport
+			case strings.HasSuffix(object.Key, csvReportSuffix):
+				addReport = aggregator.addCSVReport
+			default:
+				continue
+			}
+
+			if err := readUsageReport(ctx, mc, bucket, object.Key, addReport); err != nil {
+				return nil, fmt.Errorf("failed to read report %s: %w", object.Key, err)
+			}
+			reports++
+		}
+
+		if reports == 0 {
+			return nil, utilerrors.New(http.StatusNotFound, "no JSON or CSV metering reports found, make sure the report configurations generate one of these formats")
+		}
+
+		return aggregator.result(), nil
+	}
+
+	return nil, utilerrors.New(http.StatusNotFound, "no seed with metering reports found")
+}
+
+func readUsageReport(ctx context.Context, mc *minio.Client, bucket, key string, addReport func(io.Reader) error) error {
+	report, err := mc.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return err
+	}
+	defer report.Close()
+
+	return addReport(report)
+}
+
+type usageAggregator struct {
+	from      time.Time
+	to        time.Time
+	projectID string
+
+	projects map[string]*projectUsage
+}
+
+type projectUsage struct {
+	name     string
+	clusters map[string]*clusterUsage
+}
+
+type clusterUsage struct {
+	name string
+	// sums holds the plain sums for totals and the duration weighted sums for averages.
+	sums     map[string]float64
+	maxima   map[string]float64
+	duration float64
+}
+
+func newUsageAggregator(from, to time.Time, projectID string) *usageAggregator {
+	return &usageAggregator{
+		from:      from,
+		to:        to,
+		projectID: projectID,
+		projects:  map[string]*projectUsage{},
+	}
+}
+
+// addJSONReport reads all records of a JSON Lines report.
+func (a *usageAggregator) addJSONReport(report io.Reader) error {
+	decoder := json.NewDecoder(report)
+	for {
+		record := usageRecord{}
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode report: %w", err)
+		}
+		a.add(record)
+	}
+}
+
+// addCSVReport reads all records of a CSV report. The first line is the header,
+// columns not describing the project, cluster or time range are metrics.
+// Metric columns without a numeric value are ignored.
+func (a *usageAggregator) addCSVReport(report io.Reader) error {
+	reader := csv.NewReader(report)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return fmt.Errorf("failed to read report header: %w", err)
+	}
+
+	for {
+		row, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read report: %w", err)
+		}
+
+		record, err := parseCSVRecord(header, row)
+		if err != nil {
+			return err
+		}
+		a.add(record)
+	}
+}
+
+func parseCSVRecord(header, row []string) (usageRecord, error) {
+	record := usageRecord{Metrics: map[string]float64{}}
+
+	for i, column := range header {
+		value := strings.TrimSpace(row[i])
+		if value == "" {
+			continue
+		}
+
+		var err error
+		switch column {
+		case "project-id":
+			record.ProjectID = value
+		case "project-name":
+			record.ProjectName = value
+		case "cluster-id":
+			record.ClusterID = value
+		case "cluster-name":
+			record.ClusterName = value
+		case "namespace":
+			record.Namespace = value
+		case "from":
+			record.From, err = time.Parse(time.RFC3339, value)
+		case "to":
+			record.To, err = time.Parse(time.RFC3339, value)
+		default:
+			if metric, parseErr := strconv.ParseFloat(value, 64); parseErr == nil {
+				record.Metrics[column] = metric
+			}
+		}
+		if err != nil {
+			return record, fmt.Errorf("invalid value for column %q: %w", column, err)
+		}
+	}
+
+	return record, nil
+}
+
+func (a *usageAggregator) add(record usageRecord) {
+	// namespace records are a breakdown of cluster records and would be counted twice
+	if record.Namespace != "" || record.ClusterID == "" {
+		return
+	}
+	if a.projectID != "" && record.ProjectID != a.projectID {
+		return
+	}
+	if record.From.Before(a.from) || record.To.After(a.to) || !record.To.After(record.From) {
+		return
+	}
+
+	project, ok := a.projects[record.ProjectID]
+	if !ok {
+		project = &projectUsage{clusters: map[string]*clusterUsage{}}
+		a.projects[record.ProjectID] = project
+	}
+	if record.ProjectName != "" {
+		project.name = record.ProjectName
+	}
+
+	cluster, ok := project.clusters[record.ClusterID]
+	if !ok {
+		cluster = &clusterUsage{sums: map[string]float64{}, maxima: map[string]float64{}}
+		project.clusters[record.ClusterID] = cluster
+	}
+	if record.ClusterName != "" {
+		cluster.name = record.ClusterName
+	}
+
+	duration := record.To.Sub(record.From).Seconds()
+	cluster.duration += duration
+
+	for metric, value := range record.Metrics {
+		switch {
+		case strings.HasPrefix(metric, maxMetricPrefix):
+			if current, ok := cluster.maxima[metric]; !ok || value > current {
+				cluster.maxima[metric] = value
+			}
+		case strings.HasPrefix(metric, averageMetricPrefix):
+			cluster.sums[metric] += value * duration
+		default:
+			cluster.sums[metric] += value
+		}
+	}
+}
+
+// result returns the aggregated usage. Averages of a cluster are weighted by the duration of
+// the records, while the metrics of a project are the sum of the metrics of its clusters.
+func (a *usageAggregator) result() *apiv2.MeteringUsage {
+	usage := &apiv2.MeteringUsage{
+		From:     a.from,
+		To:       a.to,
+		Projects: []apiv2.MeteringProjectUsage{},
+	}
+
+	for projectID, project := range a.projects {
+		projectResult := apiv2.MeteringProjectUsage{
+			ProjectID:   projectID,
+			ProjectName: project.name,
+			Metrics:     map[string]float64{},
+			Clusters:    []apiv2.MeteringClusterUsage{},
+		}
+
+		for clusterID, cluster := range project.clusters {
+			clusterResult := apiv2.MeteringClusterUsage{
+				ClusterID:   clusterID,
+				ClusterName: cluster.name,
+				Metrics:     map[string]float64{},
+			}
+
+			for metric, value := range cluster.sums {
+				if strings.HasPrefix(metric, averageMetricPrefix) {
+					value /= cluster.duration
+				}
+				clusterResult.Metrics[metric] = value
+			}
+			for metric, value := range cluster.maxima {
+				clusterResult.Metrics[metric] = value
+			}
+
+			for metric, value := range clusterResult.Metrics {
+				projectResult.Metrics[metric] += value
+			}
+			projectResult.Clusters = append(projectResult.Clusters, clusterResult)
+		}
+
+		sort.Slice(projectResult.Clusters, func(i, j int) bool {
+			return projectResult.Clusters[i].ClusterID < projectResult.Clusters[j].ClusterID
+		})
+		usage.Projects = append(usage.Projects, projectResult)
+	}
+
+	sort.Slice(usage.Projects, func(i, j int) bool {
+		return usage.Projects[i].ProjectID < usage.Projects[j].ProjectID
+	})
+
+	return usage
+}
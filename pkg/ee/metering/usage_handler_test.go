@@ -0,0 +1,181 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2022 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package metering
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+)
+
+func TestUsageAggregator(t *testing.T) {
+	from := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 8, 8, 0, 0, 0, 0, time.UTC)
+
+	report := `
+{"project-id":"p1","project-name":"Project 1","cluster-id":"c1","cluster-name":"Cluster 1","from":"2022-08-01T00:00:00Z","to":"2022-08-02T00:00:00Z","metrics":{"total-used-cpu-seconds":100,"average-cluster-machines":2,"max-cluster-machines":2}}
+{"project-id":"p1","project-name":"Project 1","cluster-id":"c1","cluster-name":"Cluster 1","from":"2022-08-02T00:00:00Z","to":"2022-08-05T00:00:00Z","metrics":{"total-used-cpu-seconds":300,"average-cluster-machines":4,"max-cluster-machines":5}}
+{"project-id":"p1","project-name":"Project 1","cluster-id":"c1","cluster-name":"Cluster 1","namespace":"kube-system","from":"2022-08-02T00:00:00Z","to":"2022-08-05T00:00:00Z","metrics":{"total-used-cpu-seconds":50}}
+{"project-id":"p1","project-name":"Project 1","cluster-id":"c2","cluster-name":"Cluster 2","from":"2022-08-01T00:00:00Z","to":"2022-08-08T00:00:00Z","metrics":{"total-used-cpu-seconds":10,"average-cluster-machines":1,"max-cluster-machines":1}}
+{"project-id":"p2","project-name":"Project 2","cluster-id":"c3","cluster-name":"Cluster 3","from":"2022-07-25T00:00:00Z","to":"2022-08-01T00:00:00Z","metrics":{"total-used-cpu-seconds":1000}}
+{"project-id":"p2","project-name":"Project 2","cluster-id":"c3","cluster-name":"Cluster 3","from":"2022-08-07T00:00:00Z","to":"2022-08-09T00:00:00Z","metrics":{"total-used-cpu-seconds":1000}}
+`
+
+	csvReport := `project-id,project-name,cluster-id,cluster-name,namespace,from,to,total-used-cpu-seconds,average-cluster-machines,max-cluster-machines
+p1,Project 1,c1,Cluster 1,,2022-08-01T00:00:00Z,2022-08-02T00:00:00Z,100,2,2
+p1,Project 1,c1,Cluster 1,,2022-08-02T00:00:00Z,2022-08-05T00:00:00Z,300,4,5
+p1,Project 1,c1,Cluster 1,kube-system,2022-08-02T00:00:00Z,2022-08-05T00:00:00Z,50,,
+p1,Project 1,c2,Cluster 2,,2022-08-01T00:00:00Z,2022-08-08T00:00:00Z,10,1,1
+p2,Project 2,c3,Cluster 3,,2022-07-25T00:00:00Z,2022-08-01T00:00:00Z,1000,,
+p2,Project 2,c3,Cluster 3,,2022-08-07T00:00:00Z,2022-08-09T00:00:00Z,1000,,
+`
+
+	testcases := []struct {
+		name           string
+		projectID      string
+		csv            bool
+		expectedResult *apiv2.MeteringUsage
+	}{
+		{
+			name: "aggregate all projects",
+			expectedResult: &apiv2.MeteringUsage{
+				From: from,
+				To:   to,
+				Projects: []apiv2.MeteringProjectUsage{
+					{
+						ProjectID:   "p1",
+						ProjectName: "Project 1",
+						Metrics: map[string]float64{
+							"total-used-cpu-seconds":   410,
+							"average-cluster-machines": 4.5,
+							"max-cluster-machines":     6,
+						},
+						Clusters: []apiv2.MeteringClusterUsage{
+							{
+								ClusterID:   "c1",
+								ClusterName: "Cluster 1",
+								Metrics: map[string]float64{
+									"total-used-cpu-seconds":   400,
+									"average-cluster-machines": 3.5,
+									"max-cluster-machines":     5,
+								},
+							},
+							{
+								ClusterID:   "c2",
+								ClusterName: "Cluster 2",
+								Metrics: map[string]float64{
+									"total-used-cpu-seconds":   10,
+									"average-cluster-machines": 1,
+									"max-cluster-machines":     1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "aggregate all projects from a CSV report",
+			csv:  true,
+			expectedResult: &apiv2.MeteringUsage{
+				From: from,
+				To:   to,
+				Projects: []apiv2.MeteringProjectUsage{
+					{
+						ProjectID:   "p1",
+						ProjectName: "Project 1",
+						Metrics: map[string]float64{
+							"total-used-cpu-seconds":   410,
+							"average-cluster-machines": 4.5,
+							"max-cluster-machines":     6,
+						},
+						Clusters: []apiv2.MeteringClusterUsage{
+							{
+								ClusterID:   "c1",
+								ClusterName: "Cluster 1",
+								Metrics: map[string]float64{
+									"total-used-cpu-seconds":   400,
+									"average-cluster-machines": 3.5,
+									"max-cluster-machines":     5,
+								},
+							},
+							{
+								ClusterID:   "c2",
+								ClusterName: "Cluster 2",
+								Metrics: map[string]float64{
+									"total-used-cpu-seconds":   10,
+									"average-cluster-machines": 1,
+									"max-cluster-machines":     1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "filter by project",
+			projectID: "p2",
+			expectedResult: &apiv2.MeteringUsage{
+				From:     from,
+				To:       to,
+				Projects: []apiv2.MeteringProjectUsage{},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := newUsageAggregator(from, to, tc.projectID)
+
+			addReport, content := aggregator.addJSONReport, report
+			if tc.csv {
+				addReport, content = aggregator.addCSVReport, csvReport
+			}
+
+			if err := addReport(strings.NewReader(content)); err != nil {
+				t.Fatalf("failed to read report: %v", err)
+			}
+
+			if diff := deep.Equal(aggregator.result(), tc.expectedResult); diff != nil {
+				t.Fatalf("Got unexpected result, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestUsageAggregatorInvalidReport(t *testing.T) {
+	aggregator := newUsageAggregator(time.Now().Add(-time.Hour), time.Now(), "")
+	if err := aggregator.addJSONReport(strings.NewReader("project-id,cluster-id\np1,c1\n")); err == nil {
+		t.Fatal("expected an error for a non-JSON report")
+	}
+	if err := aggregator.addCSVReport(strings.NewReader("project-id,from\np1,yesterday\n")); err == nil {
+		t.Fatal("expected an error for a CSV report with an invalid time")
+	}
+}
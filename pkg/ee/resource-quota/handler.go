@@ -30,6 +30,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -311,6 +312,8 @@ func convertToAPIStruct(resourceQuota *kubermaticv1.ResourceQuota, humanReadable
 		Status: apiv2.ResourceQuotaStatus{
 			GlobalUsage: convertToAPIQuota(resourceQuota.Status.GlobalUsage),
 			LocalUsage:  convertToAPIQuota(resourceQuota.Status.LocalUsage),
+			GlobalCost:  convertToAPICost(resourceQuota.Status.GlobalCost),
+			LocalCost:   convertToAPICost(resourceQuota.Status.LocalCost),
 		},
 		SubjectHumanReadableName: humanReadableSubjectName,
 	}
@@ -377,3 +380,20 @@ func convertToCRDQuota(quota apiv2.Quota) (kubermaticv1.ResourceDetails, error)
 
 	return *kubermaticv1.NewResourceDetails(cpu, mem, storage), nil
 }
+
+func convertToAPICost(cost map[string]string) map[string]float64 {
+	if len(cost) == 0 {
+		return nil
+	}
+
+	apiCost := make(map[string]float64, len(cost))
+	for currency, amount := range cost {
+		value, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			continue
+		}
+		apiCost[currency] = value
+	}
+
+	return apiCost
+}
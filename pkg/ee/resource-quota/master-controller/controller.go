@@ -28,6 +28,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"go.uber.org/zap"
 
@@ -118,8 +119,9 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 		return nil
 	}
 
-	// for all related resource quotas on seeds, calculate global usage
+	// for all related resource quotas on seeds, calculate global usage and cost
 	globalUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
+	globalCost := map[string]float64{}
 	for seed, seedClient := range r.seedClients {
 		seedResourceQuota := &kubermaticv1.ResourceQuota{}
 		err := seedClient.Get(ctx, types.NamespacedName{Namespace: resourceQuota.Namespace, Name: resourceQuota.Name},
@@ -140,13 +142,42 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 		if localUsage.Storage != nil {
 			globalUsage.Storage.Add(*localUsage.Storage)
 		}
+		for currency, amount := range seedResourceQuota.Status.LocalCost {
+			value, err := strconv.ParseFloat(amount, 64)
+			if err != nil {
+				log.Debugw("invalid local cost of seed resource quota, skipping", "seed", seed, "currency", currency, zap.Error(err))
+				continue
+			}
+			globalCost[currency] += value
+		}
 	}
 
 	if err := r.ensureGlobalUsage(ctx, log, resourceQuota, globalUsage); err != nil {
 		return err
 	}
 
-	return nil
+	return r.ensureGlobalCost(ctx, log, resourceQuota, globalCost)
+}
+
+func (r *reconciler) ensureGlobalCost(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota,
+	costs map[string]float64) error {
+	globalCost := map[string]string{}
+	for currency, amount := range costs {
+		globalCost[currency] = strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+
+	if len(globalCost) == 0 && len(resourceQuota.Status.GlobalCost) == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(globalCost, resourceQuota.Status.GlobalCost) {
+		log.Debugw("global cost for resource quota is the same, not updating", "cost", globalCost)
+		return nil
+	}
+	log.Debugw("global cost for resource quota needs update", "cost", globalCost)
+
+	return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.masterClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
+		rq.Status.GlobalCost = globalCost
+	})
 }
 
 func (r *reconciler) ensureGlobalUsage(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota,
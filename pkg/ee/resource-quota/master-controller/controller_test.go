@@ -52,6 +52,7 @@ func TestReconcile(t *testing.T) {
 		name          string
 		requestName   string
 		expectedUsage kubermaticv1.ResourceDetails
+		expectedCost  map[string]string
 		masterClient  ctrlruntimeclient.Client
 		seedClients   map[string]ctrlruntimeclient.Client
 	}{
@@ -59,6 +60,7 @@ func TestReconcile(t *testing.T) {
 			name:          "scenario 1: calculate rq global usage",
 			requestName:   rqName,
 			expectedUsage: *genResourceDetails("7", "7G", "18G"),
+			expectedCost:  map[string]string{"EUR": "30.50", "USD": "12.00"},
 			masterClient: fakectrlruntimeclient.
 				NewClientBuilder().
 				WithScheme(scheme).
@@ -68,12 +70,12 @@ func TestReconcile(t *testing.T) {
 				"first": fakectrlruntimeclient.
 					NewClientBuilder().
 					WithScheme(scheme).
-					WithObjects(genResourceQuota(rqName, *genResourceDetails("2", "5G", "10G"))).
+					WithObjects(withLocalCost(genResourceQuota(rqName, *genResourceDetails("2", "5G", "10G")), map[string]string{"EUR": "20.25", "USD": "12"})).
 					Build(),
 				"second": fakectrlruntimeclient.
 					NewClientBuilder().
 					WithScheme(scheme).
-					WithObjects(genResourceQuota(rqName, *genResourceDetails("5", "2G", "8G"))).
+					WithObjects(withLocalCost(genResourceQuota(rqName, *genResourceDetails("5", "2G", "8G")), map[string]string{"EUR": "10.25"})).
 					Build(),
 			},
 		},
@@ -104,6 +106,10 @@ func TestReconcile(t *testing.T) {
 			if !diff.SemanticallyEqual(tc.expectedUsage, rq.Status.GlobalUsage) {
 				t.Fatalf("Objects differ:\n%v", diff.ObjectDiff(tc.expectedUsage, rq.Status.GlobalUsage))
 			}
+
+			if !diff.SemanticallyEqual(tc.expectedCost, rq.Status.GlobalCost) {
+				t.Fatalf("Costs differ:\n%v", diff.ObjectDiff(tc.expectedCost, rq.Status.GlobalCost))
+			}
 		})
 	}
 }
@@ -122,6 +128,11 @@ func genResourceQuota(name string, localUsage kubermaticv1.ResourceDetails) *kub
 	return rq
 }
 
+func withLocalCost(rq *kubermaticv1.ResourceQuota, localCost map[string]string) *kubermaticv1.ResourceQuota {
+	rq.Status.LocalCost = localCost
+	return rq
+}
+
 func genResourceDetails(cpu, mem, storage string) *kubermaticv1.ResourceDetails {
 	return kubermaticv1.NewResourceDetails(resource.MustParse(cpu), resource.MustParse(mem), resource.MustParse(storage))
 }
@@ -28,18 +28,25 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"go.uber.org/zap"
 
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/pricing"
+	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -54,17 +61,26 @@ import (
 
 var ControllerName = "kkp-resource-quota-seed-controller"
 
+// UserClusterClientProvider provides functionality to get a user cluster client.
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
 type reconciler struct {
-	log                     *zap.SugaredLogger
-	workerNameLabelSelector labels.Selector
-	recorder                record.EventRecorder
-	seedClient              ctrlruntimeclient.Client
+	log                           *zap.SugaredLogger
+	workerNameLabelSelector       labels.Selector
+	recorder                      record.EventRecorder
+	seedClient                    ctrlruntimeclient.Client
+	seedGetter                    provider.SeedGetter
+	userClusterConnectionProvider UserClusterClientProvider
 }
 
 func Add(mgr manager.Manager,
 	log *zap.SugaredLogger,
 	workerName string,
 	numWorkers int,
+	seedGetter provider.SeedGetter,
+	userClusterConnectionProvider UserClusterClientProvider,
 ) error {
 	workerSelector, err := workerlabel.LabelSelector(workerName)
 	if err != nil {
@@ -72,10 +88,12 @@ func Add(mgr manager.Manager,
 	}
 
 	reconciler := &reconciler{
-		log:                     log.Named(ControllerName),
-		workerNameLabelSelector: workerSelector,
-		recorder:                mgr.GetEventRecorderFor(ControllerName),
-		seedClient:              mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		workerNameLabelSelector:       workerSelector,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		seedClient:                    mgr.GetClient(),
+		seedGetter:                    seedGetter,
+		userClusterConnectionProvider: userClusterConnectionProvider,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
@@ -102,7 +120,7 @@ func Add(mgr manager.Manager,
 	return nil
 }
 
-// Reconcile calculates the resource usage for a resource quota and sets the local usage.
+// Reconcile calculates the resource usage and cost for a resource quota and sets the local usage and cost.
 func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := r.log.With("request", request)
 	log.Debug("Reconciling")
@@ -159,7 +177,78 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 		return err
 	}
 
-	return nil
+	// If the cost of any cluster could not be determined, the last known local cost
+	// is kept instead of being replaced by an incomplete sum.
+	localCost, err := r.calculateLocalCost(ctx, log, clusterList.Items)
+	if err != nil {
+		return fmt.Errorf("failed to calculate local cost: %w", err)
+	}
+
+	return r.ensureLocalCost(ctx, log, resourceQuota, localCost)
+}
+
+// calculateLocalCost sums up the estimated monthly cost of the given clusters per currency.
+// Clusters that are not running yet or cannot be priced are skipped. If the MachineDeployments
+// of a running cluster cannot be listed, an aggregate error is returned.
+func (r *reconciler) calculateLocalCost(ctx context.Context, log *zap.SugaredLogger, clusters []kubermaticv1.Cluster) (map[string]string, error) {
+	seed, err := r.seedGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, r.seedClient)
+
+	costs := map[string]float64{}
+	var errs []error
+	for i := range clusters {
+		cluster := &clusters[i]
+		clusterLog := log.With("cluster", cluster.Name)
+
+		datacenter, ok := seed.Spec.Datacenters[cluster.Spec.Cloud.DatacenterName]
+		if !ok {
+			clusterLog.Debugw("datacenter of cluster not found, skipping cost estimation", "datacenter", cluster.Spec.Cloud.DatacenterName)
+			continue
+		}
+
+		if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+			clusterLog.Debug("cluster API server is not running, skipping cost estimation")
+			continue
+		}
+
+		userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get user cluster client for cluster %s: %w", cluster.Name, err))
+			continue
+		}
+
+		machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+		if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list machine deployments of cluster %s: %w", cluster.Name, err))
+			continue
+		}
+
+		catalog := pricing.NewCatalog(&datacenter, cluster, secretKeyGetter)
+		estimate, err := pricing.EstimateCluster(ctx, catalog, &datacenter, machineDeployments.Items)
+		if err != nil {
+			clusterLog.Infow("failed to estimate cluster cost", zap.Error(err))
+			continue
+		}
+
+		if estimate.Currency != "" {
+			costs[estimate.Currency] += estimate.Monthly
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+
+	localCost := map[string]string{}
+	for currency, amount := range costs {
+		localCost[currency] = strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+
+	return localCost, nil
 }
 
 func (r *reconciler) ensureLocalUsage(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota,
@@ -181,6 +270,21 @@ func (r *reconciler) ensureLocalUsage(ctx context.Context, log *zap.SugaredLogge
 	})
 }
 
+func (r *reconciler) ensureLocalCost(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota, localCost map[string]string) error {
+	if len(localCost) == 0 && len(resourceQuota.Status.LocalCost) == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(localCost, resourceQuota.Status.LocalCost) {
+		log.Debugw("local cost for resource quota is the same, not updating", "cost", localCost)
+		return nil
+	}
+	log.Debugw("local cost for resource quota needs update", "cost", localCost)
+
+	return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.seedClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
+		rq.Status.LocalCost = localCost
+	})
+}
+
 func withClusterEventFilter() predicate.Predicate {
 	return predicate.Funcs{
 		// when cluster is created, no point to calculate yet as the machines are not created
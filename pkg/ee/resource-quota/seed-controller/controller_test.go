@@ -26,17 +26,23 @@ package seedcontroller
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test"
 	"k8c.io/kubermatic/v2/pkg/test/diff"
 	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -44,11 +50,15 @@ import (
 
 const rqName = "resourceQuota"
 const projectId = "project1"
+const datacenterName = "dc1"
 
 func TestReconcile(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = kubermaticv1.AddToScheme(scheme)
 
+	userClusterScheme := runtime.NewScheme()
+	_ = clusterv1alpha1.AddToScheme(userClusterScheme)
+
 	workerSelector, err := workerlabel.LabelSelector("")
 	if err != nil {
 		t.Fatalf("failed to build worker-name selector: %v", err)
@@ -59,7 +69,10 @@ func TestReconcile(t *testing.T) {
 		requestName   string
 		resourceQuota *kubermaticv1.ResourceQuota
 		seedClient    ctrlruntimeclient.Client
+		clientErr     error
+		expectedErr   bool
 		expectedUsage kubermaticv1.ResourceDetails
+		expectedCost  map[string]string
 	}{
 		{
 			name:          "scenario 1: calculate rq local usage",
@@ -74,6 +87,22 @@ func TestReconcile(t *testing.T) {
 					genCluster("notSameProjectCluster", "impostor", "3", "3G", "3G")).
 				Build(),
 			expectedUsage: *genResourceDetails("7", "7G", "18G"),
+			expectedCost:  map[string]string{"EUR": "40.00"},
+		},
+		{
+			name:          "scenario 2: keep rq local cost when a user cluster is unreachable",
+			requestName:   rqName,
+			resourceQuota: genResourceQuota(rqName),
+			seedClient: fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(genResourceQuotaWithCost(rqName, map[string]string{"EUR": "15.00"}),
+					genCluster("c1", projectId, "2", "5G", "10G")).
+				Build(),
+			clientErr:     errors.New("connection refused"),
+			expectedErr:   true,
+			expectedUsage: *genResourceDetails("2", "5G", "10G"),
+			expectedCost:  map[string]string{"EUR": "15.00"},
 		},
 	}
 
@@ -85,23 +114,35 @@ func TestReconcile(t *testing.T) {
 				recorder:                &record.FakeRecorder{},
 				workerNameLabelSelector: workerSelector,
 				seedClient:              tc.seedClient,
+				seedGetter:              test.NewSeedGetter(genSeed()),
+				userClusterConnectionProvider: &fakeClientProvider{
+					client: fakectrlruntimeclient.
+						NewClientBuilder().
+						WithScheme(userClusterScheme).
+						WithObjects(genMachineDeployment("workers", "cx21", 2)).
+						Build(),
+					err: tc.clientErr,
+				},
 			}
 
 			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.requestName}}
-			if _, err := r.Reconcile(ctx, request); err != nil {
-				t.Fatalf("reconciling failed: %v", err)
+			_, err := r.Reconcile(ctx, request)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedErr, err)
 			}
 
 			rq := &kubermaticv1.ResourceQuota{}
-			err := tc.seedClient.Get(ctx, request.NamespacedName, rq)
-
-			if err != nil {
+			if err := tc.seedClient.Get(ctx, request.NamespacedName, rq); err != nil {
 				t.Fatalf("failed to get resource quota: %v", err)
 			}
 
 			if !diff.SemanticallyEqual(tc.expectedUsage, rq.Status.LocalUsage) {
 				t.Fatalf("Objects differ:\n%v", diff.ObjectDiff(tc.expectedUsage, rq.Status.LocalUsage))
 			}
+
+			if !diff.SemanticallyEqual(tc.expectedCost, rq.Status.LocalCost) {
+				t.Fatalf("Costs differ:\n%v", diff.ObjectDiff(tc.expectedCost, rq.Status.LocalCost))
+			}
 		})
 	}
 }
@@ -119,6 +160,13 @@ func genResourceQuota(name string) *kubermaticv1.ResourceQuota {
 	return rq
 }
 
+func genResourceQuotaWithCost(name string, cost map[string]string) *kubermaticv1.ResourceQuota {
+	rq := genResourceQuota(name)
+	rq.Status.LocalCost = cost
+
+	return rq
+}
+
 func genResourceDetails(cpu, mem, storage string) *kubermaticv1.ResourceDetails {
 	return kubermaticv1.NewResourceDetails(resource.MustParse(cpu), resource.MustParse(mem), resource.MustParse(storage))
 }
@@ -127,7 +175,51 @@ func genCluster(name, projectId, cpu, mem, storage string) *kubermaticv1.Cluster
 	cluster := &kubermaticv1.Cluster{}
 	cluster.Name = name
 	cluster.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectId}
+	cluster.Spec.Cloud.DatacenterName = datacenterName
 	cluster.Status.ResourceUsage = genResourceDetails(cpu, mem, storage)
+	cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
 
 	return cluster
 }
+
+func genSeed() *kubermaticv1.Seed {
+	return &kubermaticv1.Seed{
+		Spec: kubermaticv1.SeedSpec{
+			Datacenters: map[string]kubermaticv1.Datacenter{
+				datacenterName: {
+					Spec: kubermaticv1.DatacenterSpec{
+						Pricing: &kubermaticv1.DatacenterPricing{
+							Currency:     "EUR",
+							ControlPlane: "10",
+							MachineTypes: map[string]string{"cx21": "5"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func genMachineDeployment(name, serverType string, replicas int32) *clusterv1alpha1.MachineDeployment {
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Name = name
+	md.Namespace = metav1.NamespaceSystem
+	md.Spec.Replicas = pointer.Int32(replicas)
+	md.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{
+		Raw: []byte(`{"cloudProvider":"hetzner","cloudProviderSpec":{"serverType":"` + serverType + `"},"operatingSystem":"ubuntu","operatingSystemSpec":{}}`),
+	}
+
+	return md
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+	err    error
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.client, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/pricing"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EstimateMachineDeploymentCost estimates the monthly cost of the given machine deployment
+// before it is created in the cluster.
+func EstimateMachineDeploymentCost(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, machineDeployment apiv1.NodeDeployment, projectID, clusterID string) (*apiv2.CostEstimate, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbGet); err != nil {
+		return nil, err
+	}
+
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, _, err := getPricingCatalog(ctx, userInfoGetter, seedsGetter, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := pricing.EstimateMachineDeployment(ctx, catalog, machineDeployment.Name, machineDeployment.Spec.Template.Cloud, machineDeployment.Spec.Replicas)
+	if err != nil {
+		if errors.Is(err, pricing.ErrUnknownMachineType) || errors.Is(err, pricing.ErrNoPrice) {
+			return nil, utilerrors.NewBadRequest(err.Error())
+		}
+		return nil, fmt.Errorf("failed to estimate machine deployment cost: %w", err)
+	}
+
+	return &apiv2.CostEstimate{
+		Currency:           estimate.PricePerMachine.Currency,
+		Monthly:            estimate.Monthly,
+		MachineDeployments: []apiv2.MachineDeploymentCostEstimate{convertMachineDeploymentCostEstimate(*estimate)},
+	}, nil
+}
+
+// EstimateClusterCost estimates the monthly cost of the given cluster, including its control plane
+// and all of its machine deployments.
+func EstimateClusterCost(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, projectID, clusterID string) (*apiv2.CostEstimate, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbList); err != nil {
+		return nil, err
+	}
+
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, dc, err := getPricingCatalog(ctx, userInfoGetter, seedsGetter, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	estimate, err := pricing.EstimateCluster(ctx, catalog, dc, machineDeployments.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate cluster cost: %w", err)
+	}
+
+	result := &apiv2.CostEstimate{
+		Currency:           estimate.Currency,
+		Monthly:            estimate.Monthly,
+		ControlPlane:       estimate.ControlPlane,
+		MachineDeployments: make([]apiv2.MachineDeploymentCostEstimate, 0, len(estimate.MachineDeployments)),
+	}
+	for _, md := range estimate.MachineDeployments {
+		result.MachineDeployments = append(result.MachineDeployments, convertMachineDeploymentCostEstimate(md))
+	}
+
+	return result, nil
+}
+
+func getPricingCatalog(ctx context.Context, userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, cluster *kubermaticv1.Cluster) (pricing.Catalog, *kubermaticv1.Datacenter, error) {
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting dc: %w", err)
+	}
+
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
+
+	return pricing.NewCatalog(dc, cluster, secretKeyGetter), dc, nil
+}
+
+func convertMachineDeploymentCostEstimate(estimate pricing.MachineDeploymentEstimate) apiv2.MachineDeploymentCostEstimate {
+	result := apiv2.MachineDeploymentCostEstimate{
+		Name:        estimate.Name,
+		MachineType: estimate.MachineType,
+		Replicas:    estimate.Replicas,
+		Monthly:     estimate.Monthly,
+	}
+	if estimate.PricePerMachine != nil {
+		price := estimate.PricePerMachine.Monthly
+		result.PricePerMachine = &price
+	}
+
+	return result
+}
@@ -90,10 +90,18 @@ func GetAdminKubeconfigEndpoint(ctx context.Context, userInfoGetter provider.Use
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
-	if userInfo.Roles.Has("viewers") && userInfo.Roles.Len() == 1 {
+	switch {
+	case userInfo.Roles.Has("viewers") && userInfo.Roles.Len() == 1:
 		filePrefix = "viewer"
 		adminClientCfg, err = clusterProvider.GetViewerKubeconfigForUserCluster(ctx, cluster)
-	} else {
+	case userInfo.Roles.Len() == 0:
+		// users that are only bound to ProjectRoles can at most get the viewer kubeconfig
+		if !userInfo.IsAllowedByProjectRoles(kubermaticv1.ProjectRoleResourceKubeconfig, kubermaticv1.ProjectRoleVerbGet) {
+			return nil, utilerrors.New(http.StatusForbidden, "forbidden to get the kubeconfig of the cluster")
+		}
+		filePrefix = "viewer"
+		adminClientCfg, err = clusterProvider.GetViewerKubeconfigForUserCluster(ctx, cluster)
+	default:
 		adminClientCfg, err = clusterProvider.GetAdminKubeconfigForUserCluster(ctx, cluster)
 	}
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/label"
@@ -61,6 +62,10 @@ const (
 )
 
 func CreateMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, machineDeployment apiv1.NodeDeployment, projectID, clusterID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbCreate); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
@@ -128,6 +133,36 @@ func CreateMachineDeployment(ctx context.Context, userInfoGetter provider.UserIn
 	return outputMachineDeployment(md)
 }
 
+// verifyMachineDeploymentAccess checks if the user is allowed to perform the verb on the
+// MachineDeployments of the project, either through one of the builtin roles or through
+// a ProjectRole that allows the verb. Viewers are only allowed to read MachineDeployments.
+func verifyMachineDeploymentAccess(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID string, verb kubermaticv1.ProjectRoleVerb) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if adminUserInfo.IsAdmin {
+		return nil
+	}
+
+	userInfo, err := userInfoGetter(ctx, projectID)
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if userInfo.Roles.HasAny(rbac.OwnerGroupNamePrefix, rbac.EditorGroupNamePrefix) {
+		return nil
+	}
+	if userInfo.Roles.Has(rbac.ViewerGroupNamePrefix) && (verb == kubermaticv1.ProjectRoleVerbGet || verb == kubermaticv1.ProjectRoleVerbList) {
+		return nil
+	}
+
+	if !userInfo.IsAllowedByProjectRoles(kubermaticv1.ProjectRoleResourceMachineDeployments, verb) {
+		return utilerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden to %s machine deployments", verb))
+	}
+
+	return nil
+}
+
 func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.NodeDeployment, error) {
 	nodeStatus := apiv1.NodeStatus{}
 	nodeStatus.MachineName = md.Name
@@ -186,6 +221,10 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 }
 
 func DeleteMachineNode(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbDelete); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
 	if err != nil {
@@ -214,6 +253,10 @@ func DeleteMachineNode(ctx context.Context, userInfoGetter provider.UserInfoGett
 }
 
 func ListMachineDeployments(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbList); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
@@ -245,6 +288,10 @@ func ListMachineDeployments(ctx context.Context, userInfoGetter provider.UserInf
 }
 
 func GetMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbGet); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
 	if err != nil {
@@ -265,6 +312,10 @@ func GetMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoG
 }
 
 func ListMachineDeploymentNodes(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID string, hideInitialConditions bool) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbGet); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
@@ -378,6 +429,10 @@ func outputNode(node *corev1.Node, hideInitialNodeConditions bool) *apiv1.Node {
 }
 
 func ListMachineDeploymentMetrics(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbGet); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
@@ -429,6 +484,10 @@ func ListMachineDeploymentMetrics(ctx context.Context, userInfoGetter provider.U
 }
 
 func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, sshKeyProvider provider.SSHKeyProvider, seedsGetter provider.SeedsGetter, projectID, clusterID, machineDeploymentID string, patch json.RawMessage) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbPatch); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	userInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
@@ -523,6 +582,10 @@ func PatchMachineDeployment(ctx context.Context, userInfoGetter provider.UserInf
 }
 
 func RestartMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbPatch); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
 	if err != nil {
@@ -552,6 +615,10 @@ func RestartMachineDeployment(ctx context.Context, userInfoGetter provider.UserI
 }
 
 func ListMachineDeploymentNodesEvents(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID, eventType string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbGet); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
@@ -622,6 +689,10 @@ func ListMachineDeploymentNodesEvents(ctx context.Context, userInfoGetter provid
 }
 
 func DeleteMachineDeployment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID, clusterID, machineDeploymentID string) (interface{}, error) {
+	if err := verifyMachineDeploymentAccess(ctx, userInfoGetter, projectID, kubermaticv1.ProjectRoleVerbDelete); err != nil {
+		return nil, err
+	}
+
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	kubermaticcontext "k8c.io/kubermatic/v2/pkg/util/context"
 	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 
@@ -197,6 +198,10 @@ func UserSaver(userProvider provider.UserProvider) endpoint.Middleware {
 				}
 			}
 
+			if user.Spec.Deactivated {
+				return nil, utilerrors.New(http.StatusForbidden, "user is deactivated")
+			}
+
 			now := Now().UTC()
 
 			// Throttle the last seen update to once a minute not to pressure the K8S API too much.
@@ -206,7 +211,10 @@ func UserSaver(userProvider provider.UserProvider) endpoint.Middleware {
 
 			updatedUser := user.DeepCopy()
 			updatedUser.Status.LastSeen = metav1.NewTime(now)
-			updatedUser.Spec.Groups = authenticatedUser.Groups
+			// groups of users provisioned through SCIM are managed by the provisioning client
+			if user.Labels[kubermaticv1.UserSCIMManagedLabel] != "true" {
+				updatedUser.Spec.Groups = authenticatedUser.Groups
+			}
 			updatedUser, err = userProvider.UpdateUser(ctx, updatedUser)
 
 			// Ignore conflict error during update of the lastSeen field as it is not super important.
@@ -377,6 +385,7 @@ func TokenExtractor(o auth.TokenExtractor) transporthttp.RequestFunc {
 func createUserInfo(ctx context.Context, user *kubermaticv1.User, projectID string, userProjectMapper provider.ProjectMemberMapper) (*provider.UserInfo, error) {
 	groups := sets.NewString()
 	roles := sets.NewString()
+	var projectRoles []kubermaticv1.ProjectRole
 
 	if projectID != "" {
 		var err error
@@ -389,11 +398,16 @@ func createUserInfo(ctx context.Context, user *kubermaticv1.User, projectID stri
 		if err != nil {
 			return nil, err
 		}
+
+		projectRoles, err = userProjectMapper.MapUserToProjectRoles(ctx, user, projectID)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		groups.Insert(user.Spec.Groups...)
 	}
 
-	return &provider.UserInfo{Email: user.Spec.Email, Groups: groups.List(), Roles: roles}, nil
+	return &provider.UserInfo{Email: user.Spec.Email, Groups: groups.List(), Roles: roles, ProjectRoles: projectRoles}, nil
 }
 
 func GetClusterProvider(ctx context.Context, request interface{}, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter) (provider.ClusterProvider, context.Context, error) {
@@ -449,7 +463,7 @@ func checkBlockedTokens(ctx context.Context, email, token string, userProvider p
 		return common.KubernetesErrorToHTTPError(err)
 	}
 	tokenSet := sets.NewString(blockedTokens...)
-	if tokenSet.Has(token) {
+	if tokenSet.HasAny(token, resources.TokenBlacklistAllTokens) {
 		return utilerrors.NewNotAuthorized()
 	}
 
@@ -220,6 +220,7 @@ type RoutingParams struct {
 	ClusterTemplateInstanceProviderGetter          provider.ClusterTemplateInstanceProviderGetter
 	RuleGroupProviderGetter                        provider.RuleGroupProviderGetter
 	PrivilegedAllowedRegistryProvider              provider.PrivilegedAllowedRegistryProvider
+	PrivilegedAlertmanagerReceiverTemplateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider
 	EtcdBackupConfigProviderGetter                 provider.EtcdBackupConfigProviderGetter
 	EtcdRestoreProviderGetter                      provider.EtcdRestoreProviderGetter
 	EtcdBackupConfigProjectProviderGetter          provider.EtcdBackupConfigProjectProviderGetter
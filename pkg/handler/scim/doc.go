@@ -0,0 +1,23 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package scim implements a SCIM 2.0 (RFC 7643 and RFC 7644) service provider that allows identity
+providers to provision KKP users and to manage the groups they are members of. The groups of the
+users are what GroupProjectBindings bind to projects, so maintaining the group members through
+SCIM maintains the project memberships granted by GroupProjectBindings.
+*/
+package scim
@@ -0,0 +1,590 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KKP has no dedicated group resource: groups are the names in the groups of the SCIM provisioned
+// users, the groups bound to projects by GroupProjectBindings and the groups created through SCIM,
+// which are recorded in a ConfigMap so that they exist before members are added to them. Users that
+// were not provisioned through SCIM get their groups from the token claims on login and are not
+// considered. To have stable and URL safe identifiers, the ID of a group is its base64 encoded name.
+
+// groupsConfigMapName is the name of the ConfigMap that records the groups created through SCIM.
+// Its keys are the IDs of the groups, its values their names.
+const groupsConfigMapName = "scim-groups"
+
+func groupID(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+func groupNameFromID(id string) (string, error) {
+	name, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil || len(name) == 0 {
+		return "", newError(http.StatusNotFound, "", "group %q not found", id)
+	}
+
+	return string(name), nil
+}
+
+// groupState is the current state of all groups.
+type groupState struct {
+	// users are the SCIM provisioned users
+	users []kubermaticv1.User
+	// groups maps the group names to the names of their members
+	groups map[string]sets.String
+}
+
+func (s *Server) getGroupState(ctx context.Context) (*groupState, error) {
+	users, err := s.listKKPUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &groupState{
+		users:  []kubermaticv1.User{},
+		groups: map[string]sets.String{},
+	}
+
+	for _, user := range users {
+		if !isSCIMManaged(&user) {
+			continue
+		}
+
+		state.users = append(state.users, user)
+		for _, group := range user.Spec.Groups {
+			if state.groups[group] == nil {
+				state.groups[group] = sets.NewString()
+			}
+			state.groups[group].Insert(user.Name)
+		}
+	}
+
+	bindings := &kubermaticv1.GroupProjectBindingList{}
+	if err := s.client.List(ctx, bindings); err != nil {
+		return nil, err
+	}
+	for _, binding := range bindings.Items {
+		if binding.Spec.Group != "" && state.groups[binding.Spec.Group] == nil {
+			state.groups[binding.Spec.Group] = sets.NewString()
+		}
+	}
+
+	recorded, err := s.getGroupsConfigMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range recorded.Data {
+		if state.groups[group] == nil {
+			state.groups[group] = sets.NewString()
+		}
+	}
+
+	return state, nil
+}
+
+// getGroupsConfigMap returns the ConfigMap recording the groups created through SCIM.
+// If it does not exist yet, an empty ConfigMap is returned.
+func (s *Server) getGroupsConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: groupsConfigMapName}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", groupsConfigMapName, err)
+		}
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      groupsConfigMapName,
+			},
+		}
+	}
+
+	return cm, nil
+}
+
+// recordGroups adds and removes the given groups to and from the groups created through SCIM.
+func (s *Server) recordGroups(ctx context.Context, add []string, remove []string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.getGroupsConfigMap(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		for _, group := range remove {
+			delete(cm.Data, groupID(group))
+		}
+		for _, group := range add {
+			cm.Data[groupID(group)] = group
+		}
+
+		if cm.ResourceVersion == "" {
+			return s.client.Create(ctx, cm)
+		}
+
+		return s.client.Update(ctx, cm)
+	})
+}
+
+func (state *groupState) toSCIMGroup(name string) Group {
+	members := []MultiValuedAttribute{}
+	for _, user := range state.users {
+		if state.groups[name].Has(user.Name) {
+			members = append(members, MultiValuedAttribute{
+				Value:   user.Name,
+				Display: user.Spec.Email,
+				Type:    resourceTypeUser,
+			})
+		}
+	}
+
+	return Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          groupID(name),
+		DisplayName: name,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+		},
+	}
+}
+
+// memberNames validates the given members and returns the names of the users.
+// Only SCIM provisioned users can be members.
+func (state *groupState) memberNames(members []MultiValuedAttribute) (sets.String, error) {
+	existing := sets.NewString()
+	for _, user := range state.users {
+		existing.Insert(user.Name)
+	}
+
+	names := sets.NewString()
+	for _, member := range members {
+		if !existing.Has(member.Value) {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "member %q is not a user provisioned through SCIM", member.Value)
+		}
+		names.Insert(member.Value)
+	}
+
+	return names, nil
+}
+
+func (s *Server) listGroups(r *http.Request) (*response, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"), "id", "displayName")
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getGroupState(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(state.groups))
+	for name := range state.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resources := []interface{}{}
+	for _, name := range names {
+		if f != nil {
+			if f.attribute == "id" && f.value != groupID(name) {
+				continue
+			}
+			if f.attribute == "displayname" && f.value != name {
+				continue
+			}
+		}
+		resources = append(resources, state.toSCIMGroup(name))
+	}
+
+	list, err := paginate(r, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: list}, nil
+}
+
+func (s *Server) getGroup(r *http.Request) (*response, error) {
+	name, err := groupNameFromID(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getGroupState(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := state.groups[name]; !ok {
+		return nil, newError(http.StatusNotFound, "", "group %q not found", name)
+	}
+
+	return &response{status: http.StatusOK, body: state.toSCIMGroup(name)}, nil
+}
+
+func (s *Server) createGroup(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	group := &Group{}
+	if err := decodeBody(r, group); err != nil {
+		return nil, err
+	}
+	if group.DisplayName == "" {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "displayName must not be empty")
+	}
+
+	state, err := s.getGroupState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := state.groups[group.DisplayName]; ok {
+		return nil, newError(http.StatusConflict, scimTypeUniqueness, "group %q already exists", group.DisplayName)
+	}
+
+	members, err := state.memberNames(group.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordGroups(ctx, []string{group.DisplayName}, nil); err != nil {
+		return nil, fmt.Errorf("failed to record group: %w", err)
+	}
+
+	if err := s.setGroupMembers(ctx, state, group.DisplayName, members); err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusCreated, body: state.toSCIMGroup(group.DisplayName)}, nil
+}
+
+func (s *Server) replaceGroup(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	name, err := groupNameFromID(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	group := &Group{}
+	if err := decodeBody(r, group); err != nil {
+		return nil, err
+	}
+	if group.DisplayName == "" {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "displayName must not be empty")
+	}
+
+	state, err := s.getGroupState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := state.memberNames(group.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.updateGroup(ctx, state, name, group.DisplayName, members); err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: state.toSCIMGroup(group.DisplayName)}, nil
+}
+
+func (s *Server) patchGroup(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	name, err := groupNameFromID(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	req := &PatchRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	if err := validatePatchRequest(req); err != nil {
+		return nil, err
+	}
+
+	state, err := s.getGroupState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newName := name
+	members := sets.NewString()
+	if state.groups[name] != nil {
+		members = sets.NewString(state.groups[name].List()...)
+	}
+
+	for _, op := range req.Operations {
+		if err := applyGroupPatchOperation(state, op, &newName, members); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.updateGroup(ctx, state, name, newName, members); err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: state.toSCIMGroup(newName)}, nil
+}
+
+func (s *Server) deleteGroup(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	name, err := groupNameFromID(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getGroupState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := state.groups[name]; !ok {
+		return nil, newError(http.StatusNotFound, "", "group %q not found", name)
+	}
+
+	if err := s.setGroupMembers(ctx, state, name, sets.NewString()); err != nil {
+		return nil, err
+	}
+
+	// the group does not exist anymore, so neither do the project memberships of the group
+	bindings := &kubermaticv1.GroupProjectBindingList{}
+	if err := s.client.List(ctx, bindings); err != nil {
+		return nil, err
+	}
+	for i := range bindings.Items {
+		if bindings.Items[i].Spec.Group == name {
+			if err := s.client.Delete(ctx, &bindings.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to delete GroupProjectBinding %s: %w", bindings.Items[i].Name, err)
+			}
+		}
+	}
+
+	if err := s.recordGroups(ctx, nil, []string{name}); err != nil {
+		return nil, fmt.Errorf("failed to remove group record: %w", err)
+	}
+
+	return &response{status: http.StatusNoContent}, nil
+}
+
+// applyGroupPatchOperation applies a single patch operation to the given name and members of a group.
+func applyGroupPatchOperation(state *groupState, op PatchOperation, name *string, members sets.String) error {
+	// operations without a path contain a map of attributes to set
+	if op.Path == "" {
+		if op.Op == patchOperationRemove {
+			return newError(http.StatusBadRequest, scimTypeNoTarget, "remove operations require a path")
+		}
+
+		attributes, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "operations without a path require an object as value")
+		}
+
+		for path, value := range attributes {
+			// some clients send the ID along with the other attributes
+			if path == "id" {
+				continue
+			}
+			if err := applyGroupPatchOperation(state, PatchOperation{Op: op.Op, Path: path, Value: value}, name, members); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	attribute, valueFilter, _ := parsePath(op.Path)
+	switch attribute {
+	case "displayname":
+		if op.Op == patchOperationRemove {
+			return newError(http.StatusBadRequest, scimTypeMutability, "displayName cannot be removed")
+		}
+
+		value, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "displayName must not be empty")
+		}
+		*name = value
+
+	case "members":
+		if valueFilter != "" {
+			if op.Op != patchOperationRemove {
+				return newError(http.StatusBadRequest, scimTypeInvalidPath, "value filters are only supported for remove operations")
+			}
+			members.Delete(valueFilter)
+			return nil
+		}
+
+		values, err := parseMultiValuedAttribute(op.Value)
+		if err != nil {
+			return err
+		}
+
+		switch op.Op {
+		case patchOperationRemove:
+			// removing without values removes all members
+			if len(values) == 0 {
+				members.Delete(members.List()...)
+			}
+			for _, value := range values {
+				members.Delete(value.Value)
+			}
+
+		case patchOperationReplace, patchOperationAdd:
+			names, err := state.memberNames(values)
+			if err != nil {
+				return err
+			}
+			if op.Op == patchOperationReplace {
+				members.Delete(members.List()...)
+			}
+			members.Insert(names.List()...)
+		}
+
+	default:
+		return newError(http.StatusBadRequest, scimTypeInvalidPath, "attribute %q is not supported", op.Path)
+	}
+
+	return nil
+}
+
+// updateGroup renames the group if necessary and sets its members.
+func (s *Server) updateGroup(ctx context.Context, state *groupState, name, newName string, members sets.String) error {
+	if newName != name {
+		if _, ok := state.groups[newName]; ok {
+			return newError(http.StatusConflict, scimTypeUniqueness, "group %q already exists", newName)
+		}
+
+		if err := s.renameGroup(ctx, state, name, newName); err != nil {
+			return err
+		}
+	}
+
+	return s.setGroupMembers(ctx, state, newName, members)
+}
+
+// renameGroup renames the group for all of its members and its GroupProjectBindings.
+func (s *Server) renameGroup(ctx context.Context, state *groupState, name, newName string) error {
+	for i := range state.users {
+		user := &state.users[i]
+		if !state.groups[name].Has(user.Name) {
+			continue
+		}
+
+		if err := s.updateUser(ctx, user, func(u *kubermaticv1.User) error {
+			groups := []string{}
+			for _, group := range u.Spec.Groups {
+				if group == name {
+					group = newName
+				}
+				groups = append(groups, group)
+			}
+			u.Spec.Groups = groups
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to update user %s: %w", user.Name, err)
+		}
+	}
+
+	bindings := &kubermaticv1.GroupProjectBindingList{}
+	if err := s.client.List(ctx, bindings); err != nil {
+		return err
+	}
+	for i := range bindings.Items {
+		binding := &bindings.Items[i]
+		if binding.Spec.Group != name {
+			continue
+		}
+
+		oldBinding := binding.DeepCopy()
+		binding.Spec.Group = newName
+		if err := s.client.Patch(ctx, binding, ctrlruntimeclient.MergeFrom(oldBinding)); err != nil {
+			return fmt.Errorf("failed to update GroupProjectBinding %s: %w", binding.Name, err)
+		}
+	}
+
+	if err := s.recordGroups(ctx, []string{newName}, []string{name}); err != nil {
+		return fmt.Errorf("failed to record renamed group: %w", err)
+	}
+
+	state.groups[newName] = state.groups[name]
+	delete(state.groups, name)
+
+	return nil
+}
+
+// setGroupMembers makes sure that exactly the given users are members of the group.
+func (s *Server) setGroupMembers(ctx context.Context, state *groupState, name string, members sets.String) error {
+	for i := range state.users {
+		user := &state.users[i]
+		isMember := sets.NewString(user.Spec.Groups...).Has(name)
+		if isMember == members.Has(user.Name) {
+			continue
+		}
+
+		if err := s.updateUser(ctx, user, func(u *kubermaticv1.User) error {
+			groups := []string{}
+			for _, group := range u.Spec.Groups {
+				if group != name {
+					groups = append(groups, group)
+				}
+			}
+			if !isMember {
+				groups = append(groups, name)
+			}
+			u.Spec.Groups = groups
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to update user %s: %w", user.Name, err)
+		}
+	}
+
+	state.groups[name] = sets.NewString(members.List()...)
+
+	return nil
+}
@@ -0,0 +1,416 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tokenRevocationPeriod is the period for which all tokens of a deactivated user are invalidated.
+// Tokens issued by the identity provider are expected to expire within this period, afterwards
+// the deactivated user is still refused by the API, but the token blacklist does not grow forever.
+const tokenRevocationPeriod = 24 * time.Hour
+
+// Server is the SCIM service provider of the KKP API.
+type Server struct {
+	log          *zap.SugaredLogger
+	client       ctrlruntimeclient.Client
+	userProvider provider.UserProvider
+	namespace    string
+	token        string
+	now          func() time.Time
+}
+
+// New returns a new SCIM server. SCIM clients need to authenticate using the given bearer token.
+// The groups created through SCIM are recorded in a ConfigMap in the given namespace.
+func New(log *zap.SugaredLogger, client ctrlruntimeclient.Client, userProvider provider.UserProvider, namespace, token string) *Server {
+	return &Server{
+		log:          log.Named("scim"),
+		client:       client,
+		userProvider: userProvider,
+		namespace:    namespace,
+		token:        token,
+		now:          time.Now,
+	}
+}
+
+// Register registers the SCIM endpoints on the given router, which is usually the
+// subrouter for the "/scim/v2" path prefix.
+func (s *Server) Register(r *mux.Router) {
+	r.Use(s.authenticate)
+
+	r.Methods(http.MethodGet).Path("/ServiceProviderConfig").Handler(s.handle(s.getServiceProviderConfig))
+	r.Methods(http.MethodGet).Path("/ResourceTypes").Handler(s.handle(s.listResourceTypes))
+
+	r.Methods(http.MethodGet).Path("/Users").Handler(s.handle(s.listUsers))
+	r.Methods(http.MethodPost).Path("/Users").Handler(s.handle(s.createUser))
+	r.Methods(http.MethodGet).Path("/Users/{id}").Handler(s.handle(s.getUser))
+	r.Methods(http.MethodPut).Path("/Users/{id}").Handler(s.handle(s.replaceUser))
+	r.Methods(http.MethodPatch).Path("/Users/{id}").Handler(s.handle(s.patchUser))
+	r.Methods(http.MethodDelete).Path("/Users/{id}").Handler(s.handle(s.deleteUser))
+
+	r.Methods(http.MethodGet).Path("/Groups").Handler(s.handle(s.listGroups))
+	r.Methods(http.MethodPost).Path("/Groups").Handler(s.handle(s.createGroup))
+	r.Methods(http.MethodGet).Path("/Groups/{id}").Handler(s.handle(s.getGroup))
+	r.Methods(http.MethodPut).Path("/Groups/{id}").Handler(s.handle(s.replaceGroup))
+	r.Methods(http.MethodPatch).Path("/Groups/{id}").Handler(s.handle(s.patchGroup))
+	r.Methods(http.MethodDelete).Path("/Groups/{id}").Handler(s.handle(s.deleteGroup))
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, newError(http.StatusUnauthorized, "", "invalid or missing bearer token"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// response is returned by the handlers of the server.
+type response struct {
+	status int
+	body   interface{}
+}
+
+type handlerFunc func(r *http.Request) (*response, error)
+
+func (s *Server) handle(f handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := f(r)
+		if err != nil {
+			var scimErr *scimError
+			if !errors.As(err, &scimErr) {
+				scimErr = kubernetesErrorToSCIMError(err)
+			}
+			if scimErr.status >= http.StatusInternalServerError {
+				s.log.Errorw("SCIM request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+			}
+
+			writeError(w, scimErr)
+			return
+		}
+
+		if resp.body == nil {
+			w.WriteHeader(resp.status)
+			return
+		}
+
+		writeJSON(w, resp.status, resp.body)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err *scimError) {
+	writeJSON(w, err.status, Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(err.status),
+		ScimType: err.scimType,
+		Detail:   err.detail,
+	})
+}
+
+// scimError is an error that is returned to the SCIM client.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func newError(status int, scimType string, format string, args ...interface{}) *scimError {
+	return &scimError{
+		status:   status,
+		scimType: scimType,
+		detail:   fmt.Sprintf(format, args...),
+	}
+}
+
+func kubernetesErrorToSCIMError(err error) *scimError {
+	switch {
+	case apierrors.IsNotFound(err):
+		return newError(http.StatusNotFound, "", "resource not found")
+	case apierrors.IsAlreadyExists(err):
+		return newError(http.StatusConflict, scimTypeUniqueness, "resource already exists")
+	case apierrors.IsConflict(err):
+		return newError(http.StatusPreconditionFailed, "", "resource was modified concurrently, please retry")
+	case apierrors.IsBadRequest(err), apierrors.IsInvalid(err):
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "%v", err)
+	default:
+		return newError(http.StatusInternalServerError, "", "internal server error")
+	}
+}
+
+func decodeBody(r *http.Request, obj interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "failed to decode request body: %v", err)
+	}
+
+	return nil
+}
+
+// filter is a parsed SCIM filter. Only the equality operator is supported, which is
+// what identity providers use to look up resources before provisioning them.
+type filter struct {
+	attribute string
+	value     string
+}
+
+var filterRegex = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseFilter parses the given filter. The attribute name is returned in lower case,
+// as attribute names are case insensitive in SCIM.
+func parseFilter(raw string, supportedAttributes ...string) (*filter, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	match := filterRegex.FindStringSubmatch(raw)
+	if match == nil {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidFilter, "unsupported filter %q, only the \"eq\" operator is supported", raw)
+	}
+
+	attribute := strings.ToLower(match[1])
+	for _, supported := range supportedAttributes {
+		if attribute == strings.ToLower(supported) {
+			value, err := strconv.Unquote(`"` + match[2] + `"`)
+			if err != nil {
+				return nil, newError(http.StatusBadRequest, scimTypeInvalidFilter, "invalid filter value in %q", raw)
+			}
+
+			return &filter{attribute: attribute, value: value}, nil
+		}
+	}
+
+	return nil, newError(http.StatusBadRequest, scimTypeInvalidFilter, "filtering by %q is not supported, supported attributes are %v", match[1], supportedAttributes)
+}
+
+// paginate returns the requested page of the given resources.
+func paginate(r *http.Request, resources []interface{}) (*ListResponse, error) {
+	startIndex := 1
+	if raw := r.URL.Query().Get("startIndex"); raw != "" {
+		index, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid startIndex %q", raw)
+		}
+		// values lower than 1 are interpreted as 1
+		if index > 1 {
+			startIndex = index
+		}
+	}
+
+	count := defaultPageSize
+	if raw := r.URL.Query().Get("count"); raw != "" {
+		c, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid count %q", raw)
+		}
+		switch {
+		case c < 0:
+			count = 0
+		case c > maxPageSize:
+			count = maxPageSize
+		default:
+			count = c
+		}
+	}
+
+	page := []interface{}{}
+	if start := startIndex - 1; start < len(resources) {
+		end := start + count
+		if end > len(resources) {
+			end = len(resources)
+		}
+		page = resources[start:end]
+	}
+
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}, nil
+}
+
+func (s *Server) getServiceProviderConfig(_ *http.Request) (*response, error) {
+	return &response{
+		status: http.StatusOK,
+		body: ServiceProviderConfig{
+			Schemas:          []string{SchemaServiceProviderConfig},
+			DocumentationURI: "https://docs.kubermatic.com",
+			Patch:            supported{Supported: true},
+			Bulk:             bulkSupported{Supported: false},
+			Filter:           filterSupported{Supported: true, MaxResults: maxPageSize},
+			ChangePassword:   supported{Supported: false},
+			Sort:             supported{Supported: false},
+			ETag:             supported{Supported: false},
+			AuthenticationSchemes: []authenticationScheme{
+				{
+					Type:        "oauthbearertoken",
+					Name:        "OAuth Bearer Token",
+					Description: "Authentication using the SCIM token configured in the KubermaticConfiguration",
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *Server) listResourceTypes(r *http.Request) (*response, error) {
+	resourceTypes := []interface{}{
+		ResourceType{
+			Schemas:  []string{SchemaResourceType},
+			ID:       resourceTypeUser,
+			Name:     resourceTypeUser,
+			Endpoint: "/Users",
+			Schema:   SchemaUser,
+		},
+		ResourceType{
+			Schemas:  []string{SchemaResourceType},
+			ID:       resourceTypeGroup,
+			Name:     resourceTypeGroup,
+			Endpoint: "/Groups",
+			Schema:   SchemaGroup,
+		},
+	}
+
+	list, err := paginate(r, resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: list}, nil
+}
+
+// parseBool parses boolean attribute values. Some identity providers send booleans
+// as strings like "False", which are accepted as well.
+func parseBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return false, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid boolean value %q", v)
+		}
+		return b, nil
+	default:
+		return false, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid boolean value %v", value)
+	}
+}
+
+func parseString(value interface{}) (string, error) {
+	v, ok := value.(string)
+	if !ok {
+		return "", newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid string value %v", value)
+	}
+
+	return v, nil
+}
+
+// parseMultiValuedAttribute converts the given patch value to a list of values. Both single
+// values and lists are accepted.
+func parseMultiValuedAttribute(value interface{}) ([]MultiValuedAttribute, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	// the value was decoded into generic types, so encode it again to decode it properly
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid value: %v", err)
+	}
+
+	var values []MultiValuedAttribute
+	if err := json.Unmarshal(raw, &values); err != nil {
+		var single MultiValuedAttribute
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid multi-valued attribute %s", string(raw))
+		}
+		values = []MultiValuedAttribute{single}
+	}
+
+	return values, nil
+}
+
+// valueFilterRegex matches paths like `members[value eq "id"]`.
+var valueFilterRegex = regexp.MustCompile(`^(\w+)\[\s*value\s+(?i:eq)\s+"([^"]*)"\s*\](?:\.(\w+))?$`)
+
+// parsePath splits the given patch path into the attribute name (in lower case), an optional
+// value filter and an optional sub attribute.
+func parsePath(path string) (attribute, valueFilter, subAttribute string) {
+	if match := valueFilterRegex.FindStringSubmatch(path); match != nil {
+		return strings.ToLower(match[1]), match[2], strings.ToLower(match[3])
+	}
+
+	// strip the schema URN, e.g. "urn:ietf:params:scim:schemas:core:2.0:User:name.formatted"
+	if strings.HasPrefix(path, "urn:") {
+		if idx := strings.LastIndex(path, ":"); idx >= 0 {
+			path = path[idx+1:]
+		}
+	}
+
+	attribute = strings.ToLower(path)
+	if idx := strings.Index(attribute, "."); idx >= 0 {
+		return attribute[:idx], "", attribute[idx+1:]
+	}
+
+	return attribute, "", ""
+}
+
+func validatePatchRequest(req *PatchRequest) error {
+	if len(req.Operations) == 0 {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "no patch operations given")
+	}
+
+	for i, op := range req.Operations {
+		req.Operations[i].Op = strings.ToLower(op.Op)
+		switch req.Operations[i].Op {
+		case patchOperationAdd, patchOperationReplace, patchOperationRemove:
+		default:
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "unsupported patch operation %q", op.Op)
+		}
+	}
+
+	return nil
+}
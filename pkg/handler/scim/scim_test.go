@@ -0,0 +1,444 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/scim"
+	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testToken = "secret-scim-token"
+
+func newTestRouter(objects ...ctrlruntimeclient.Object) (http.Handler, ctrlruntimeclient.Client) {
+	client := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objects...).
+		Build()
+
+	router := mux.NewRouter()
+	scim.New(zap.NewNop().Sugar(), client, kubernetes.NewUserProvider(client), "kubermatic", testToken).Register(router.PathPrefix("/scim/v2").Subrouter())
+
+	return router, client
+}
+
+func doRequest(t *testing.T, router http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &reqBody)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Content-Type", "application/scim+json")
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func decodeResponse(t *testing.T, res *httptest.ResponseRecorder, obj interface{}) {
+	t.Helper()
+
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+}
+
+func genUser(name, email string, groups ...string) *kubermaticv1.User {
+	return &kubermaticv1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.UserSpec{
+			Name:   email,
+			Email:  email,
+			Groups: groups,
+		},
+	}
+}
+
+func genSCIMUser(name, email string, groups ...string) *kubermaticv1.User {
+	user := genUser(name, email, groups...)
+	user.Labels = map[string]string{
+		kubermaticv1.UserSCIMManagedLabel: "true",
+	}
+
+	return user
+}
+
+func getUser(t *testing.T, client ctrlruntimeclient.Client, name string) *kubermaticv1.User {
+	t.Helper()
+
+	user := &kubermaticv1.User{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: name}, user); err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+
+	return user
+}
+
+func TestAuthentication(t *testing.T) {
+	router, _ := newTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	req.Header.Set("Authorization", "Bearer wrong-token")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, res.Code)
+	}
+
+	scimErr := scim.Error{}
+	decodeResponse(t, res, &scimErr)
+	if scimErr.Status != "401" || len(scimErr.Schemas) != 1 || scimErr.Schemas[0] != scim.SchemaError {
+		t.Fatalf("expected a SCIM error response, got %+v", scimErr)
+	}
+}
+
+func TestUserLifecycle(t *testing.T) {
+	// the existing user logged in before being provisioned
+	router, client := newTestRouter(genUser("existing", "jane@acme.com", "from-token"))
+
+	// provision a new user
+	res := doRequest(t, router, http.MethodPost, "/scim/v2/Users", map[string]interface{}{
+		"schemas":    []string{scim.SchemaUser},
+		"userName":   "john@acme.com",
+		"externalId": "00u1",
+		"name":       map[string]string{"givenName": "John", "familyName": "Doe"},
+		"active":     true,
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, res.Code, res.Body.String())
+	}
+
+	created := scim.User{}
+	decodeResponse(t, res, &created)
+	if created.UserName != "john@acme.com" || created.DisplayName != "John Doe" || created.ExternalID != "00u1" {
+		t.Fatalf("unexpected user was created: %+v", created)
+	}
+
+	user := getUser(t, client, created.ID)
+	if user.Labels[kubermaticv1.UserSCIMManagedLabel] != "true" {
+		t.Fatal("expected the created user to be marked as SCIM managed")
+	}
+
+	// provisioning the same user again is refused
+	res = doRequest(t, router, http.MethodPost, "/scim/v2/Users", map[string]interface{}{
+		"schemas":  []string{scim.SchemaUser},
+		"userName": "john@acme.com",
+	})
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, res.Code, res.Body.String())
+	}
+
+	// users that logged in before are taken over
+	res = doRequest(t, router, http.MethodPost, "/scim/v2/Users", map[string]interface{}{
+		"schemas":  []string{scim.SchemaUser},
+		"userName": "jane@acme.com",
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, res.Code, res.Body.String())
+	}
+	if user := getUser(t, client, "existing"); user.Labels[kubermaticv1.UserSCIMManagedLabel] != "true" {
+		t.Fatal("expected the existing user to be marked as SCIM managed")
+	}
+
+	// look up the user by its user name
+	res = doRequest(t, router, http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22john@acme.com%22`, nil)
+	list := scim.ListResponse{}
+	decodeResponse(t, res, &list)
+	if list.TotalResults != 1 {
+		t.Fatalf("expected to find exactly one user, got %d", list.TotalResults)
+	}
+
+	// deactivate the user, some identity providers send booleans as strings
+	res = doRequest(t, router, http.MethodPatch, "/scim/v2/Users/"+created.ID, map[string]interface{}{
+		"schemas": []string{scim.SchemaPatchOp},
+		"Operations": []map[string]interface{}{
+			{"op": "Replace", "path": "active", "value": "False"},
+		},
+	})
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	user = getUser(t, client, created.ID)
+	if !user.Spec.Deactivated {
+		t.Fatal("expected the user to be deactivated")
+	}
+	if blacklist := getTokenBlacklist(t, client, user); !strings.Contains(blacklist, `"token":"*"`) {
+		t.Fatalf("expected all tokens of the user to be invalidated, got blacklist %s", blacklist)
+	}
+
+	// reactivate the user
+	res = doRequest(t, router, http.MethodPatch, "/scim/v2/Users/"+created.ID, map[string]interface{}{
+		"schemas": []string{scim.SchemaPatchOp},
+		"Operations": []map[string]interface{}{
+			{"op": "replace", "value": map[string]interface{}{"active": true}},
+		},
+	})
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	user = getUser(t, client, created.ID)
+	if user.Spec.Deactivated {
+		t.Fatal("expected the user to be active")
+	}
+	if blacklist := getTokenBlacklist(t, client, user); strings.Contains(blacklist, `"token":"*"`) {
+		t.Fatalf("expected the invalidation of all tokens to be reverted, got blacklist %s", blacklist)
+	}
+
+	// deprovision the user
+	res = doRequest(t, router, http.MethodDelete, "/scim/v2/Users/"+created.ID, nil)
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, res.Code, res.Body.String())
+	}
+
+	res = doRequest(t, router, http.MethodGet, "/scim/v2/Users/"+created.ID, nil)
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNotFound, res.Code, res.Body.String())
+	}
+}
+
+func getTokenBlacklist(t *testing.T, client ctrlruntimeclient.Client, user *kubermaticv1.User) string {
+	t.Helper()
+
+	if user.Spec.InvalidTokensReference == nil {
+		t.Fatal("expected the user to reference a token blacklist")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: user.Spec.InvalidTokensReference.Namespace, Name: user.Spec.InvalidTokensReference.Name}
+	if err := client.Get(context.Background(), key, secret); err != nil {
+		t.Fatalf("failed to get token blacklist: %v", err)
+	}
+
+	return string(secret.Data[resources.TokenBlacklist])
+}
+
+func TestGroupMembership(t *testing.T) {
+	binding := &kubermaticv1.GroupProjectBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "developers-binding",
+		},
+		Spec: kubermaticv1.GroupProjectBindingSpec{
+			Group:     "developers",
+			ProjectID: "my-project",
+			Role:      "editors",
+		},
+	}
+
+	router, client := newTestRouter(
+		genSCIMUser("alice", "alice@acme.com"),
+		genSCIMUser("bob", "bob@acme.com", "developers"),
+		genUser("carol", "carol@acme.com", "from-token"),
+		binding,
+	)
+
+	// groups are created without members first
+	res := doRequest(t, router, http.MethodPost, "/scim/v2/Groups", map[string]interface{}{
+		"schemas":     []string{scim.SchemaGroup},
+		"displayName": "auditors",
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, res.Code, res.Body.String())
+	}
+	auditors := scim.Group{}
+	decodeResponse(t, res, &auditors)
+
+	res = doRequest(t, router, http.MethodGet, "/scim/v2/Groups/"+auditors.ID, nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	// users that were not provisioned through SCIM cannot become members
+	res = doRequest(t, router, http.MethodPatch, "/scim/v2/Groups/"+auditors.ID, map[string]interface{}{
+		"schemas": []string{scim.SchemaPatchOp},
+		"Operations": []map[string]interface{}{
+			{"op": "add", "path": "members", "value": []map[string]string{{"value": "carol"}}},
+		},
+	})
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, res.Code, res.Body.String())
+	}
+	if carol := getUser(t, client, "carol"); carol.Labels[kubermaticv1.UserSCIMManagedLabel] == "true" {
+		t.Fatal("expected carol not to be marked as SCIM managed")
+	}
+
+	// the groups of users that were not provisioned through SCIM are not known
+	res = doRequest(t, router, http.MethodGet, `/scim/v2/Groups?filter=displayName+eq+%22from-token%22`, nil)
+	list := scim.ListResponse{}
+	decodeResponse(t, res, &list)
+	if list.TotalResults != 0 {
+		t.Fatalf("expected to find no group, got %d", list.TotalResults)
+	}
+
+	// the group of the GroupProjectBinding is known
+	res = doRequest(t, router, http.MethodGet, `/scim/v2/Groups?filter=displayName+eq+%22developers%22`, nil)
+	list = scim.ListResponse{}
+	decodeResponse(t, res, &list)
+	if list.TotalResults != 1 {
+		t.Fatalf("expected to find exactly one group, got %d", list.TotalResults)
+	}
+
+	res = doRequest(t, router, http.MethodPost, "/scim/v2/Groups", map[string]interface{}{
+		"schemas":     []string{scim.SchemaGroup},
+		"displayName": "developers",
+	})
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, res.Code, res.Body.String())
+	}
+
+	res = doRequest(t, router, http.MethodPost, "/scim/v2/Groups", map[string]interface{}{
+		"schemas":     []string{scim.SchemaGroup},
+		"displayName": "operators",
+		"members":     []map[string]string{{"value": "alice"}},
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, res.Code, res.Body.String())
+	}
+	operators := scim.Group{}
+	decodeResponse(t, res, &operators)
+
+	if groups := getUser(t, client, "alice").Spec.Groups; len(groups) != 1 || groups[0] != "operators" {
+		t.Fatalf("expected alice to be member of the operators, got groups %v", groups)
+	}
+
+	// add alice to the developers and remove bob
+	res = doRequest(t, router, http.MethodGet, `/scim/v2/Groups?filter=displayName+eq+%22developers%22`, nil)
+	decodeResponse(t, res, &list)
+	developersID := list.Resources[0].(map[string]interface{})["id"].(string)
+
+	res = doRequest(t, router, http.MethodPatch, "/scim/v2/Groups/"+developersID, map[string]interface{}{
+		"schemas": []string{scim.SchemaPatchOp},
+		"Operations": []map[string]interface{}{
+			{"op": "add", "path": "members", "value": []map[string]string{{"value": "alice"}}},
+			{"op": "remove", "path": `members[value eq "bob"]`},
+		},
+	})
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	if groups := getUser(t, client, "alice").Spec.Groups; len(groups) != 2 {
+		t.Fatalf("expected alice to be member of two groups, got groups %v", groups)
+	}
+	bob := getUser(t, client, "bob")
+	if len(bob.Spec.Groups) != 0 {
+		t.Fatalf("expected bob to be member of no group, got groups %v", bob.Spec.Groups)
+	}
+
+	// renaming the group keeps its project memberships
+	res = doRequest(t, router, http.MethodPut, "/scim/v2/Groups/"+developersID, map[string]interface{}{
+		"schemas":     []string{scim.SchemaGroup},
+		"displayName": "engineers",
+		"members":     []map[string]string{{"value": "alice"}},
+	})
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	renamedBinding := &kubermaticv1.GroupProjectBinding{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: binding.Name}, renamedBinding); err != nil {
+		t.Fatalf("failed to get GroupProjectBinding: %v", err)
+	}
+	if renamedBinding.Spec.Group != "engineers" {
+		t.Fatalf("expected the GroupProjectBinding to bind the renamed group, got %q", renamedBinding.Spec.Group)
+	}
+
+	// deleting the group removes it from its members and its GroupProjectBindings
+	engineers := scim.Group{}
+	decodeResponse(t, res, &engineers)
+	res = doRequest(t, router, http.MethodDelete, "/scim/v2/Groups/"+engineers.ID, nil)
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, res.Code, res.Body.String())
+	}
+
+	if groups := getUser(t, client, "alice").Spec.Groups; len(groups) != 1 || groups[0] != "operators" {
+		t.Fatalf("expected alice to be member of the operators only, got groups %v", groups)
+	}
+	bindings := &kubermaticv1.GroupProjectBindingList{}
+	if err := client.List(context.Background(), bindings); err != nil {
+		t.Fatalf("failed to list GroupProjectBindings: %v", err)
+	}
+	if len(bindings.Items) != 0 {
+		t.Fatalf("expected the GroupProjectBinding to be deleted, got %d bindings", len(bindings.Items))
+	}
+
+	res = doRequest(t, router, http.MethodGet, "/scim/v2/Groups/"+operators.ID, nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	// deleted groups are gone, even without members
+	res = doRequest(t, router, http.MethodDelete, "/scim/v2/Groups/"+auditors.ID, nil)
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, res.Code, res.Body.String())
+	}
+	res = doRequest(t, router, http.MethodGet, "/scim/v2/Groups/"+auditors.ID, nil)
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNotFound, res.Code, res.Body.String())
+	}
+}
+
+func TestUnsupportedFilter(t *testing.T) {
+	router, _ := newTestRouter()
+
+	for _, filter := range []string{
+		`userName+sw+%22john%22`,
+		`nickName+eq+%22john%22`,
+	} {
+		res := doRequest(t, router, http.MethodGet, "/scim/v2/Users?filter="+filter, nil)
+		if res.Code != http.StatusBadRequest {
+			t.Fatalf("expected filter %q to be refused with status %d, got %d", filter, http.StatusBadRequest, res.Code)
+		}
+
+		scimErr := scim.Error{}
+		decodeResponse(t, res, &scimErr)
+		if scimErr.ScimType != "invalidFilter" {
+			t.Fatalf("expected scimType invalidFilter, got %q", scimErr.ScimType)
+		}
+	}
+}
@@ -0,0 +1,536 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// toSCIMUser converts a KKP user into its SCIM representation.
+func toSCIMUser(user *kubermaticv1.User) User {
+	created := user.CreationTimestamp.Time.UTC()
+
+	groups := make([]MultiValuedAttribute, 0, len(user.Spec.Groups))
+	for _, group := range user.Spec.Groups {
+		groups = append(groups, MultiValuedAttribute{
+			Value:   groupID(group),
+			Display: group,
+		})
+	}
+
+	return User{
+		Schemas:     []string{SchemaUser},
+		ID:          user.Name,
+		ExternalID:  user.Annotations[kubermaticv1.UserSCIMExternalIDAnnotation],
+		UserName:    user.Spec.Email,
+		Name:        &Name{Formatted: user.Spec.Name},
+		DisplayName: user.Spec.Name,
+		Emails: []MultiValuedAttribute{
+			{
+				Value:   user.Spec.Email,
+				Type:    "work",
+				Primary: true,
+			},
+		},
+		Active: pointer.Bool(!user.Spec.Deactivated),
+		Groups: groups,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      &created,
+		},
+	}
+}
+
+// email returns the email address of the given SCIM user, which is the primary email
+// address or, if no email address is given, the user name.
+func (u *User) email() string {
+	for _, email := range u.Emails {
+		if email.Primary && email.Value != "" {
+			return email.Value
+		}
+	}
+
+	if strings.Contains(u.UserName, "@") || len(u.Emails) == 0 {
+		return u.UserName
+	}
+
+	return u.Emails[0].Value
+}
+
+// name returns the full name of the given SCIM user.
+func (u *User) name() string {
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if full := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); full != "" {
+			return full
+		}
+	}
+
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+
+	return u.email()
+}
+
+// listKKPUsers returns all KKP users, service accounts excluded, sorted by name.
+func (s *Server) listKKPUsers(ctx context.Context) ([]kubermaticv1.User, error) {
+	userList := &kubermaticv1.UserList{}
+	if err := s.client.List(ctx, userList); err != nil {
+		return nil, err
+	}
+
+	users := make([]kubermaticv1.User, 0, len(userList.Items))
+	for _, user := range userList.Items {
+		if kubermaticv1helper.IsProjectServiceAccount(user.Spec.Email) || kubermaticv1helper.IsProjectServiceAccount(user.Name) {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+
+	return users, nil
+}
+
+// isSCIMManaged returns true if the user was provisioned through SCIM.
+func isSCIMManaged(user *kubermaticv1.User) bool {
+	return user.Labels[kubermaticv1.UserSCIMManagedLabel] == "true"
+}
+
+func (s *Server) getKKPUser(ctx context.Context, id string) (*kubermaticv1.User, error) {
+	user := &kubermaticv1.User{}
+	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: id}, user); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newError(http.StatusNotFound, "", "user %q not found", id)
+		}
+		return nil, err
+	}
+
+	if kubermaticv1helper.IsProjectServiceAccount(user.Spec.Email) || kubermaticv1helper.IsProjectServiceAccount(user.Name) {
+		return nil, newError(http.StatusNotFound, "", "user %q not found", id)
+	}
+
+	return user, nil
+}
+
+func (s *Server) getKKPUserByEmail(ctx context.Context, email string) (*kubermaticv1.User, error) {
+	users, err := s.listKKPUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if strings.EqualFold(users[i].Spec.Email, email) {
+			return &users[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Server) listUsers(r *http.Request) (*response, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"), "id", "userName", "externalId", "emails", "emails.value")
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.listKKPUsers(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []interface{}{}
+	for i := range users {
+		if f != nil && !userMatchesFilter(&users[i], f) {
+			continue
+		}
+		resources = append(resources, toSCIMUser(&users[i]))
+	}
+
+	list, err := paginate(r, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: list}, nil
+}
+
+func userMatchesFilter(user *kubermaticv1.User, f *filter) bool {
+	switch f.attribute {
+	case "id":
+		return user.Name == f.value
+	case "username", "emails", "emails.value":
+		return strings.EqualFold(user.Spec.Email, f.value)
+	case "externalid":
+		return user.Annotations[kubermaticv1.UserSCIMExternalIDAnnotation] == f.value
+	default:
+		return false
+	}
+}
+
+func (s *Server) getUser(r *http.Request) (*response, error) {
+	user, err := s.getKKPUser(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: toSCIMUser(user)}, nil
+}
+
+func (s *Server) createUser(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	scimUser := &User{}
+	if err := decodeBody(r, scimUser); err != nil {
+		return nil, err
+	}
+
+	email := scimUser.email()
+	if email == "" {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "userName must not be empty")
+	}
+
+	user, err := s.getKKPUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// users that logged in before they were provisioned are taken over, users
+	// that were already provisioned cannot be created again
+	if user != nil {
+		if isSCIMManaged(user) {
+			return nil, newError(http.StatusConflict, scimTypeUniqueness, "user %q already exists", email)
+		}
+	} else {
+		user, err = s.userProvider.CreateUser(ctx, scimUser.name(), email, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.updateUser(ctx, user, func(u *kubermaticv1.User) error {
+		applySCIMUser(u, scimUser, email)
+
+		// the groups of provisioned users are managed through SCIM from now on
+		if u.Labels == nil {
+			u.Labels = map[string]string{}
+		}
+		u.Labels[kubermaticv1.UserSCIMManagedLabel] = "true"
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.setUserActive(ctx, user, scimUser.Active == nil || *scimUser.Active); err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusCreated, body: toSCIMUser(user)}, nil
+}
+
+func (s *Server) replaceUser(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	user, err := s.getKKPUser(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	scimUser := &User{}
+	if err := decodeBody(r, scimUser); err != nil {
+		return nil, err
+	}
+
+	email := scimUser.email()
+	if email == "" {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "userName must not be empty")
+	}
+	if err := s.ensureEmailIsUnique(ctx, user, email); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateUser(ctx, user, func(u *kubermaticv1.User) error {
+		applySCIMUser(u, scimUser, email)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.setUserActive(ctx, user, scimUser.Active == nil || *scimUser.Active); err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: toSCIMUser(user)}, nil
+}
+
+func (s *Server) patchUser(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	user, err := s.getKKPUser(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	req := &PatchRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	if err := validatePatchRequest(req); err != nil {
+		return nil, err
+	}
+
+	active := !user.Spec.Deactivated
+	if err := s.updateUser(ctx, user, func(u *kubermaticv1.User) error {
+		for _, op := range req.Operations {
+			if err := applyUserPatchOperation(u, op, &active); err != nil {
+				return err
+			}
+		}
+		return s.ensureEmailIsUnique(ctx, u, u.Spec.Email)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.setUserActive(ctx, user, active); err != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusOK, body: toSCIMUser(user)}, nil
+}
+
+func (s *Server) deleteUser(r *http.Request) (*response, error) {
+	ctx := r.Context()
+
+	user, err := s.getKKPUser(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	// remove the user from all projects, the bindings are not removed along with the user
+	bindings := &kubermaticv1.UserProjectBindingList{}
+	if err := s.client.List(ctx, bindings); err != nil {
+		return nil, err
+	}
+	for i := range bindings.Items {
+		if strings.EqualFold(bindings.Items[i].Spec.UserEmail, user.Spec.Email) {
+			if err := s.client.Delete(ctx, &bindings.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to delete UserProjectBinding %s: %w", bindings.Items[i].Name, err)
+			}
+		}
+	}
+
+	if err := s.client.Delete(ctx, user); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+
+	return &response{status: http.StatusNoContent}, nil
+}
+
+func (s *Server) ensureEmailIsUnique(ctx context.Context, user *kubermaticv1.User, email string) error {
+	existing, err := s.getKKPUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.Name != user.Name {
+		return newError(http.StatusConflict, scimTypeUniqueness, "another user with the userName %q exists already", email)
+	}
+
+	return nil
+}
+
+// updateUser applies the given modification to the user and patches it.
+func (s *Server) updateUser(ctx context.Context, user *kubermaticv1.User, modify func(*kubermaticv1.User) error) error {
+	oldUser := user.DeepCopy()
+
+	if err := modify(user); err != nil {
+		return err
+	}
+
+	return s.client.Patch(ctx, user, ctrlruntimeclient.MergeFrom(oldUser))
+}
+
+// setUserActive activates or deactivates the user. All tokens of deactivated users are invalidated.
+func (s *Server) setUserActive(ctx context.Context, user *kubermaticv1.User, active bool) error {
+	if user.Spec.Deactivated == !active {
+		return nil
+	}
+
+	if err := s.updateUser(ctx, user, func(u *kubermaticv1.User) error {
+		u.Spec.Deactivated = !active
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if active {
+		if err := s.userProvider.ClearAllTokensInvalidation(ctx, user); err != nil {
+			return fmt.Errorf("failed to revert the invalidation of the user's tokens: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.userProvider.InvalidateAllTokens(ctx, user, apiv1.NewTime(s.now().Add(tokenRevocationPeriod))); err != nil {
+		return fmt.Errorf("failed to invalidate the user's tokens: %w", err)
+	}
+
+	return nil
+}
+
+// applySCIMUser applies all attributes of the given SCIM user to the KKP user.
+func applySCIMUser(user *kubermaticv1.User, scimUser *User, email string) {
+	user.Spec.Email = email
+	user.Spec.Name = scimUser.name()
+
+	if user.Annotations == nil {
+		user.Annotations = map[string]string{}
+	}
+	if scimUser.ExternalID != "" {
+		user.Annotations[kubermaticv1.UserSCIMExternalIDAnnotation] = scimUser.ExternalID
+	} else {
+		delete(user.Annotations, kubermaticv1.UserSCIMExternalIDAnnotation)
+	}
+}
+
+// applyUserPatchOperation applies a single patch operation to the user. The active state is
+// not applied directly, but returned in the given pointer, as its change requires further actions.
+func applyUserPatchOperation(user *kubermaticv1.User, op PatchOperation, active *bool) error {
+	// operations without a path contain a map of attributes to set
+	if op.Path == "" {
+		if op.Op == patchOperationRemove {
+			return newError(http.StatusBadRequest, scimTypeNoTarget, "remove operations require a path")
+		}
+
+		attributes, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "operations without a path require an object as value")
+		}
+
+		for path, value := range attributes {
+			if err := applyUserPatchOperation(user, PatchOperation{Op: op.Op, Path: path, Value: value}, active); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	attribute, _, subAttribute := parsePath(op.Path)
+	if op.Op == patchOperationRemove {
+		if attribute == "externalid" {
+			delete(user.Annotations, kubermaticv1.UserSCIMExternalIDAnnotation)
+			return nil
+		}
+		return newError(http.StatusBadRequest, scimTypeMutability, "attribute %q cannot be removed", op.Path)
+	}
+
+	switch attribute {
+	case "active":
+		value, err := parseBool(op.Value)
+		if err != nil {
+			return err
+		}
+		*active = value
+
+	case "username":
+		value, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "userName must not be empty")
+		}
+		user.Spec.Email = value
+
+	case "displayname":
+		value, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		user.Spec.Name = value
+
+	case "name":
+		// name.formatted is used as the full name, other components are ignored
+		if subAttribute == "" {
+			values, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid value for attribute name")
+			}
+			if formatted, ok := values["formatted"].(string); ok && formatted != "" {
+				user.Spec.Name = formatted
+			}
+		} else if subAttribute == "formatted" {
+			value, err := parseString(op.Value)
+			if err != nil {
+				return err
+			}
+			user.Spec.Name = value
+		}
+
+	case "emails":
+		// the primary (or only) email address is used as the email of the user
+		if subAttribute == "value" {
+			value, err := parseString(op.Value)
+			if err != nil {
+				return err
+			}
+			user.Spec.Email = value
+			return nil
+		}
+
+		emails, err := parseMultiValuedAttribute(op.Value)
+		if err != nil {
+			return err
+		}
+		scimUser := User{UserName: user.Spec.Email, Emails: emails}
+		if email := scimUser.email(); email != "" {
+			user.Spec.Email = email
+		}
+
+	case "externalid":
+		value, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		if user.Annotations == nil {
+			user.Annotations = map[string]string{}
+		}
+		user.Annotations[kubermaticv1.UserSCIMExternalIDAnnotation] = value
+
+	default:
+		return newError(http.StatusBadRequest, scimTypeInvalidPath, "attribute %q is not supported", op.Path)
+	}
+
+	return nil
+}
@@ -86,6 +86,7 @@ func NewTestRouting(
 	kubermaticVersions kubermatic.Versions,
 	defaultConstraintProvider provider.DefaultConstraintProvider,
 	privilegedAllowedRegistryProvider provider.PrivilegedAllowedRegistryProvider,
+	privilegedAlertmanagerReceiverTemplateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider,
 	etcdBackupConfigProviderGetter provider.EtcdBackupConfigProviderGetter,
 	etcdRestoreProviderGetter provider.EtcdRestoreProviderGetter,
 	etcdBackupConfigProjectProviderGetter provider.EtcdBackupConfigProjectProviderGetter,
@@ -147,6 +148,7 @@ func NewTestRouting(
 		ClusterTemplateInstanceProviderGetter:          clusterTemplateInstanceProviderGetter,
 		RuleGroupProviderGetter:                        ruleGroupProviderGetter,
 		PrivilegedAllowedRegistryProvider:              privilegedAllowedRegistryProvider,
+		PrivilegedAlertmanagerReceiverTemplateProvider: privilegedAlertmanagerReceiverTemplateProvider,
 		EtcdBackupConfigProviderGetter:                 etcdBackupConfigProviderGetter,
 		EtcdRestoreProviderGetter:                      etcdRestoreProviderGetter,
 		EtcdBackupConfigProjectProviderGetter:          etcdBackupConfigProjectProviderGetter,
@@ -211,6 +211,7 @@ type newRoutingFunc func(
 	kubermaticVersions kubermatic.Versions,
 	defaultConstraintProvider provider.DefaultConstraintProvider,
 	privilegedAllowedRegistryProvider provider.PrivilegedAllowedRegistryProvider,
+	privilegedAlertmanagerReceiverTemplateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider,
 	etcdBackupConfigProviderGetter provider.EtcdBackupConfigProviderGetter,
 	etcdRestoreProviderGetter provider.EtcdRestoreProviderGetter,
 	etcdBackupConfigProjectProviderGetter provider.EtcdBackupConfigProjectProviderGetter,
@@ -425,6 +426,8 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		FakeClient: fakeClient,
 	}
 
+	privilegedAlertmanagerReceiverTemplateProvider := kubernetes.NewPrivilegedAlertmanagerReceiverTemplateProvider(fakeClient)
+
 	defaultConstraintProvider, err := kubernetes.NewDefaultConstraintProvider(fakeImpersonationClient, fakeClient, resources.KubermaticNamespace)
 	if err != nil {
 		return nil, nil, err
@@ -619,6 +622,7 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		kubermaticVersions,
 		fakeDefaultConstraintProvider,
 		fakePrivilegedAllowedRegistryProvider,
+		privilegedAlertmanagerReceiverTemplateProvider,
 		etcdBackupConfigProviderGetter,
 		etcdRestoreProviderGetter,
 		etcdBackupConfigProjectProviderGetter,
@@ -1882,6 +1886,18 @@ func GenAlertmanagerConfigSecret(name, namespace string, config []byte) *corev1.
 	}
 }
 
+func GenAlertmanagerReceiverTemplate(name string, receiverType kubermaticv1.AlertmanagerReceiverType, receiver string) *kubermaticv1.AlertmanagerReceiverTemplate {
+	return &kubermaticv1.AlertmanagerReceiverTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+			Type:     receiverType,
+			Receiver: receiver,
+		},
+	}
+}
+
 func GenClusterTemplate(name, id, projectID, scope, userEmail string) *kubermaticv1.ClusterTemplate {
 	return &kubermaticv1.ClusterTemplate{
 		ObjectMeta: metav1.ObjectMeta{
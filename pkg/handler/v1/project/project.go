@@ -191,7 +191,7 @@ func validateUserProjectsLimit(ctx context.Context, user *kubermaticv1.User, set
 	var errorList []string
 	var projectsCounter int64
 	for _, mapping := range userMappings {
-		userInfo := &provider.UserInfo{Email: mapping.Spec.UserEmail, Groups: []string{mapping.Spec.Group}}
+		userInfo := &provider.UserInfo{Email: mapping.Spec.UserEmail, Groups: bindingGroups(mapping)}
 		projectInternal, err := projectProvider.Get(ctx, userInfo, mapping.Spec.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: true})
 		if err != nil {
 			// Request came from the specified user. Instead `Not found` error status the `Forbidden` is returned.
@@ -228,6 +228,21 @@ func validateUserProjectsLimit(ctx context.Context, user *kubermaticv1.User, set
 	return nil
 }
 
+// bindingGroups returns the groups a user is impersonated with to access the project
+// of the binding. Bindings that only reference a ProjectRole have no group, but grant
+// access through the group the ProjectRole is bound to.
+func bindingGroups(binding *kubermaticv1.UserProjectBinding) []string {
+	groups := []string{}
+	if binding.Spec.Group != "" {
+		groups = append(groups, binding.Spec.Group)
+	}
+	if binding.Spec.ProjectRole != "" {
+		groups = append(groups, rbac.GenerateProjectRoleGroupNameFor(binding.Spec.ProjectID, binding.Spec.ProjectRole))
+	}
+
+	return groups
+}
+
 // ListEndpoint defines an HTTP endpoint for listing projects.
 func ListEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, memberMapper provider.ProjectMemberMapper, memberProvider provider.ProjectMemberProvider, userProvider provider.UserProvider, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -253,7 +268,7 @@ func ListEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provid
 		}
 		var errorList []string
 		for _, mapping := range userMappings {
-			userInfo := &provider.UserInfo{Email: mapping.Spec.UserEmail, Groups: append(userInfo.Groups, mapping.Spec.Group)}
+			userInfo := &provider.UserInfo{Email: mapping.Spec.UserEmail, Groups: append(bindingGroups(mapping), userInfo.Groups...)}
 			projectInternal, err := projectProvider.Get(ctx, userInfo, mapping.Spec.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: true})
 			if err != nil {
 				if isStatus(err, http.StatusNotFound) {
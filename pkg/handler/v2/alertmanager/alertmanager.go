@@ -17,9 +17,9 @@ limitations under the License.
 package alertmanager
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -35,6 +35,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -94,6 +95,52 @@ func ResetEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provi
 	}
 }
 
+func AttachReceiverEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider,
+	privilegedProjectProvider provider.PrivilegedProjectProvider, templateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(attachAlertmanagerReceiverReq)
+
+		if req.Body.Template == "" {
+			return nil, utilerrors.NewBadRequest("receiver template must be specified")
+		}
+
+		c, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := templateProvider.GetUnsecured(ctx, req.Body.Template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		receiverName := req.Body.Name
+		if receiverName == "" {
+			receiverName = template.Name
+		}
+
+		alertmanager, configSecret, err := getAlertmanagerConfig(ctx, userInfoGetter, c, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		config, err := attachReceiver(configSecret.Data[resources.AlertmanagerConfigSecretKey], receiverName, &template.Spec)
+		if err != nil {
+			return nil, utilerrors.NewBadRequest(fmt.Sprintf("failed to attach receiver: %v", err))
+		}
+		if err := validation.ValidateAlertmanagerConfig(config); err != nil {
+			return nil, utilerrors.NewBadRequest(fmt.Errorf("invalid alertmanager configuration: %w", err).Error())
+		}
+		configSecret.Data[resources.AlertmanagerConfigSecretKey] = config
+
+		al, updatedConfig, err := updateAlertmanagerConfig(ctx, userInfoGetter, req.ProjectID, alertmanager, configSecret)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertInternalToAPIAlertmanager(al, updatedConfig), nil
+	}
+}
+
 // getAlertmanagerReq defines HTTP request for getting alertmanager
 // swagger:parameters getAlertmanager
 type getAlertmanagerReq struct {
@@ -110,14 +157,16 @@ type updateAlertmanagerReq struct {
 }
 
 func (req *updateAlertmanagerReq) validateUpdateAlertmanagerReq() error {
-	bodyMap := map[string]interface{}{}
-
-	decoder := yaml.NewDecoder(bytes.NewReader(req.Body.Spec.Config))
-	if err := decoder.Decode(&bodyMap); err != nil {
-		return fmt.Errorf("can not unmarshal yaml configuration: %w", err)
-	}
+	return validation.ValidateAlertmanagerConfig(req.Body.Spec.Config)
+}
 
-	return nil
+// attachAlertmanagerReceiverReq defines HTTP request for attaching a receiver template to the alertmanager
+// swagger:parameters attachAlertmanagerReceiver
+type attachAlertmanagerReceiverReq struct {
+	cluster.GetClusterReq
+	// in: body
+	// required: true
+	Body apiv2.AlertmanagerReceiverAttachment
 }
 
 // resetAlertmanagerReq defines HTTP request for deleting alertmanager
@@ -166,6 +215,70 @@ func DecodeResetAlertmanagerReq(c context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
+func DecodeAttachAlertmanagerReceiverReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req attachAlertmanagerReceiverReq
+
+	cr, err := cluster.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.GetClusterReq = cr.(cluster.GetClusterReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// attachReceiver adds the receiver of the given template to a Cortex Alertmanager configuration.
+// All alerts are routed to the new receiver in addition to the existing routes.
+func attachReceiver(config []byte, name string, template *kubermaticv1.AlertmanagerReceiverTemplateSpec) ([]byte, error) {
+	receiver, err := validation.AlertmanagerReceiverFromTemplate(name, template)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := map[string]interface{}{}
+	if err := yaml.Unmarshal(config, &wrapper); err != nil {
+		return nil, fmt.Errorf("can not unmarshal yaml configuration: %w", err)
+	}
+
+	alertmanagerConfigString, _ := wrapper["alertmanager_config"].(string)
+	alertmanagerConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(alertmanagerConfigString), &alertmanagerConfig); err != nil {
+		return nil, fmt.Errorf("can not unmarshal alertmanager_config: %w", err)
+	}
+
+	receivers, _ := alertmanagerConfig["receivers"].([]interface{})
+	for _, r := range receivers {
+		if existing, ok := r.(map[string]interface{}); ok && existing["name"] == name {
+			return nil, fmt.Errorf("receiver %q already exists", name)
+		}
+	}
+	alertmanagerConfig["receivers"] = append(receivers, receiver)
+
+	route, ok := alertmanagerConfig["route"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("alertmanager_config has no route")
+	}
+	routes, _ := route["routes"].([]interface{})
+	route["routes"] = append([]interface{}{
+		map[string]interface{}{
+			"receiver": name,
+			"continue": true,
+		},
+	}, routes...)
+
+	alertmanagerConfigYAML, err := yaml.Marshal(alertmanagerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alertmanager_config: %w", err)
+	}
+	wrapper["alertmanager_config"] = string(alertmanagerConfigYAML)
+
+	return yaml.Marshal(wrapper)
+}
+
 func convertAPIToInternalAlertmanager(cluster *kubermaticv1.Cluster, alertmanager *apiv2.Alertmanager) (*kubermaticv1.Alertmanager, *corev1.Secret) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -24,10 +24,16 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	amconfig "github.com/prometheus/alertmanager/config"
+	"gopkg.in/yaml.v3"
+
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/validation"
 
 	corev1 "k8s.io/api/core/v1"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,6 +53,7 @@ alertmanager_config: |
       email_configs:
       - to: 'test@example.org'
 `
+	testWebhookReceiver = "webhook_configs:\n- url: https://example.com/hook\n"
 )
 
 func TestGetEndpoint(t *testing.T) {
@@ -437,6 +444,146 @@ func TestDeleteEndpoint(t *testing.T) {
 	}
 }
 
+func TestAttachReceiverEndpoint(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name                      string
+		Attachment                apiv2.AlertmanagerReceiverAttachment
+		ExistingKubermaticObjects []ctrlruntimeclient.Object
+		ExistingAPIUser           *apiv1.User
+		ExpectedReceivers         []string
+		ExpectedHTTPStatus        int
+	}{
+		{
+			Name:       "scenario 1: attach receiver template to alertmanager",
+			Attachment: apiv2.AlertmanagerReceiverAttachment{Template: "team-hook"},
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAlertmanager(test.GenDefaultCluster().Status.NamespaceName,
+					testAlertmanagerConfigSecretName),
+				test.GenAlertmanagerReceiverTemplate("team-hook", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			),
+			ExistingAPIUser:    test.GenDefaultAPIUser(),
+			ExpectedReceivers:  []string{"test", "team-hook"},
+			ExpectedHTTPStatus: http.StatusOK,
+		},
+		{
+			Name:       "scenario 2: attach receiver template with a custom receiver name",
+			Attachment: apiv2.AlertmanagerReceiverAttachment{Template: "team-hook", Name: "ops"},
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAlertmanager(test.GenDefaultCluster().Status.NamespaceName,
+					testAlertmanagerConfigSecretName),
+				test.GenAlertmanagerReceiverTemplate("team-hook", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			),
+			ExistingAPIUser:    test.GenDefaultAPIUser(),
+			ExpectedReceivers:  []string{"test", "ops"},
+			ExpectedHTTPStatus: http.StatusOK,
+		},
+		{
+			Name:       "scenario 3: receiver with the same name already exists",
+			Attachment: apiv2.AlertmanagerReceiverAttachment{Template: "team-hook", Name: "test"},
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAlertmanager(test.GenDefaultCluster().Status.NamespaceName,
+					testAlertmanagerConfigSecretName),
+				test.GenAlertmanagerReceiverTemplate("team-hook", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			),
+			ExistingAPIUser:    test.GenDefaultAPIUser(),
+			ExpectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			Name:       "scenario 4: receiver template is not found",
+			Attachment: apiv2.AlertmanagerReceiverAttachment{Template: "missing"},
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAlertmanager(test.GenDefaultCluster().Status.NamespaceName,
+					testAlertmanagerConfigSecretName),
+			),
+			ExistingAPIUser:    test.GenDefaultAPIUser(),
+			ExpectedHTTPStatus: http.StatusNotFound,
+		},
+		{
+			Name:       "scenario 5: user john can not attach receiver to bob's cluster",
+			Attachment: apiv2.AlertmanagerReceiverAttachment{Template: "team-hook"},
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAdminUser("John", "john@acme.com", false),
+				test.GenAlertmanager(test.GenDefaultCluster().Status.NamespaceName,
+					testAlertmanagerConfigSecretName),
+				test.GenAlertmanagerReceiverTemplate("team-hook", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			),
+			ExistingAPIUser:    test.GenAPIUser("John", "john@acme.com"),
+			ExpectedHTTPStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			body, err := json.Marshal(tc.Attachment)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			url := fmt.Sprintf("/api/v2/projects/%s/clusters/%s/alertmanager/receivers", test.GenDefaultProject().Name, test.GenDefaultCluster().Name)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+			resp := httptest.NewRecorder()
+			kubernetesObjs := []ctrlruntimeclient.Object{
+				test.GenAlertmanagerConfigSecret(testAlertmanagerConfigSecretName,
+					test.GenDefaultCluster().Status.NamespaceName,
+					[]byte(testAlertmanagerConfig)),
+			}
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, kubernetesObjs, tc.ExistingKubermaticObjects, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+			ep.ServeHTTP(resp, req)
+
+			if resp.Code != tc.ExpectedHTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.ExpectedHTTPStatus, resp.Code, resp.Body.String())
+			}
+			if resp.Code != http.StatusOK {
+				return
+			}
+
+			var alertmanager apiv2.Alertmanager
+			if err := json.Unmarshal(resp.Body.Bytes(), &alertmanager); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if err := validation.ValidateAlertmanagerConfig(alertmanager.Spec.Config); err != nil {
+				t.Fatalf("resulting configuration is invalid: %v", err)
+			}
+
+			var wrapper struct {
+				AlertmanagerConfig string `yaml:"alertmanager_config"`
+			}
+			if err := yaml.Unmarshal(alertmanager.Spec.Config, &wrapper); err != nil {
+				t.Fatalf("failed to unmarshal configuration: %v", err)
+			}
+			config, err := amconfig.Load(wrapper.AlertmanagerConfig)
+			if err != nil {
+				t.Fatalf("failed to load alertmanager configuration: %v", err)
+			}
+
+			receivers := []string{}
+			for _, r := range config.Receivers {
+				receivers = append(receivers, r.Name)
+			}
+			if !diff.DeepEqual(tc.ExpectedReceivers, receivers) {
+				t.Fatalf("Unexpected receivers:\n%v", diff.ObjectDiff(tc.ExpectedReceivers, receivers))
+			}
+			if len(config.Route.Routes) == 0 || config.Route.Routes[0].Receiver != tc.ExpectedReceivers[len(tc.ExpectedReceivers)-1] {
+				t.Fatalf("expected the first route to point to the attached receiver, got %v", config.Route.Routes)
+			}
+		})
+	}
+}
+
 func requestURL(projectID, clusterID string) string {
 	return fmt.Sprintf("/api/v2/projects/%s/clusters/%s/alertmanager/config", projectID, clusterID)
 }
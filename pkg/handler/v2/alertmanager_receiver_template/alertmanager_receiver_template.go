@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanagerreceivertemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func CreateEndpoint(userInfoGetter provider.UserInfoGetter, templateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createAlertmanagerReceiverTemplateReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		if err := validation.ValidateAlertmanagerReceiverTemplate(req.Body.Name, &req.Body.Spec); err != nil {
+			return nil, utilerrors.NewBadRequest(fmt.Sprintf("invalid receiver template: %v", err))
+		}
+
+		template := &kubermaticv1.AlertmanagerReceiverTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: req.Body.Name,
+			},
+			Spec: req.Body.Spec,
+		}
+
+		template, err := templateProvider.CreateUnsecured(ctx, template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalToAPIAlertmanagerReceiverTemplate(template), nil
+	}
+}
+
+func GetEndpoint(templateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getAlertmanagerReceiverTemplateReq)
+
+		template, err := templateProvider.GetUnsecured(ctx, req.TemplateName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalToAPIAlertmanagerReceiverTemplate(template), nil
+	}
+}
+
+func ListEndpoint(templateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		templateList, err := templateProvider.ListUnsecured(ctx)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		apiTemplates := make([]*apiv2.AlertmanagerReceiverTemplate, 0)
+		for i := range templateList.Items {
+			apiTemplates = append(apiTemplates, convertInternalToAPIAlertmanagerReceiverTemplate(&templateList.Items[i]))
+		}
+
+		return apiTemplates, nil
+	}
+}
+
+func UpdateEndpoint(userInfoGetter provider.UserInfoGetter, templateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateAlertmanagerReceiverTemplateReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		if err := validation.ValidateAlertmanagerReceiverTemplate(req.TemplateName, &req.Body.Spec); err != nil {
+			return nil, utilerrors.NewBadRequest(fmt.Sprintf("invalid receiver template: %v", err))
+		}
+
+		template, err := templateProvider.GetUnsecured(ctx, req.TemplateName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template.Spec = req.Body.Spec
+
+		template, err = templateProvider.UpdateUnsecured(ctx, template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalToAPIAlertmanagerReceiverTemplate(template), nil
+	}
+}
+
+func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, templateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getAlertmanagerReceiverTemplateReq)
+
+		if err := verifyAdmin(ctx, userInfoGetter); err != nil {
+			return nil, err
+		}
+
+		if err := templateProvider.DeleteUnsecured(ctx, req.TemplateName); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// createAlertmanagerReceiverTemplateReq represents a request for creating an Alertmanager receiver template
+// swagger:parameters createAlertmanagerReceiverTemplate
+type createAlertmanagerReceiverTemplateReq struct {
+	// in: body
+	// required: true
+	Body apiv2.AlertmanagerReceiverTemplate
+}
+
+func DecodeCreateAlertmanagerReceiverTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createAlertmanagerReceiverTemplateReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, utilerrors.NewBadRequest(err.Error())
+	}
+
+	return req, nil
+}
+
+// getAlertmanagerReceiverTemplateReq represents a request for getting an Alertmanager receiver template
+// swagger:parameters getAlertmanagerReceiverTemplate deleteAlertmanagerReceiverTemplate
+type getAlertmanagerReceiverTemplateReq struct {
+	// in: path
+	// required: true
+	TemplateName string `json:"template_name"`
+}
+
+func DecodeGetAlertmanagerReceiverTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req getAlertmanagerReceiverTemplateReq
+
+	templateName := mux.Vars(r)["template_name"]
+	if templateName == "" {
+		return nil, utilerrors.NewBadRequest("'template_name' parameter is required but was not provided")
+	}
+	req.TemplateName = templateName
+
+	return req, nil
+}
+
+// updateAlertmanagerReceiverTemplateReq represents a request for updating an Alertmanager receiver template
+// swagger:parameters updateAlertmanagerReceiverTemplate
+type updateAlertmanagerReceiverTemplateReq struct {
+	getAlertmanagerReceiverTemplateReq
+	// in: body
+	// required: true
+	Body apiv2.AlertmanagerReceiverTemplate
+}
+
+func DecodeUpdateAlertmanagerReceiverTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updateAlertmanagerReceiverTemplateReq
+
+	getReq, err := DecodeGetAlertmanagerReceiverTemplateReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.getAlertmanagerReceiverTemplateReq = getReq.(getAlertmanagerReceiverTemplateReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, utilerrors.NewBadRequest(err.Error())
+	}
+
+	return req, nil
+}
+
+func verifyAdmin(ctx context.Context, userInfoGetter provider.UserInfoGetter) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return err
+	}
+	if !adminUserInfo.IsAdmin {
+		return utilerrors.New(http.StatusForbidden,
+			fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+	}
+
+	return nil
+}
+
+func convertInternalToAPIAlertmanagerReceiverTemplate(template *kubermaticv1.AlertmanagerReceiverTemplate) *apiv2.AlertmanagerReceiverTemplate {
+	return &apiv2.AlertmanagerReceiverTemplate{
+		Name: template.Name,
+		Spec: template.Spec,
+	}
+}
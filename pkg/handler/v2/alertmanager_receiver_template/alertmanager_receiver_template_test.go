@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanagerreceivertemplate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testSlackReceiver   = "slack_configs:\n- api_url: https://hooks.slack.com/services/xyz\n  channel: '#alerts'\n"
+	testWebhookReceiver = "webhook_configs:\n- url: https://example.com/hook\n"
+)
+
+func TestCreateAlertmanagerReceiverTemplate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Template         apiv2.AlertmanagerReceiverTemplate
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can create receiver template",
+			Template:         genAPITemplate("slack", kubermaticv1.AlertmanagerReceiverTypeSlack, testSlackReceiver),
+			ExpectedResponse: `{"name":"slack","spec":{"type":"slack","receiver":"slack_configs:\n- api_url: https://hooks.slack.com/services/xyz\n  channel: '#alerts'\n"}}`,
+			HTTPStatus:       http.StatusCreated,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: non-admin can not create receiver template",
+			Template:         genAPITemplate("slack", kubermaticv1.AlertmanagerReceiverTypeSlack, testSlackReceiver),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+		{
+			Name:            "scenario 3: receiver template must match its type",
+			Template:        genAPITemplate("slack", kubermaticv1.AlertmanagerReceiverTypeSlack, testWebhookReceiver),
+			HTTPStatus:      http.StatusBadRequest,
+			ExistingAPIUser: test.GenDefaultAdminAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			body, err := json.Marshal(tc.Template)
+			if err != nil {
+				t.Fatalf("error marshalling body into json: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v2/alertmanagerreceivertemplates", bytes.NewBuffer(body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, []ctrlruntimeclient.Object{test.APIUserToKubermaticUser(*tc.ExistingAPIUser)}, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+			}
+		})
+	}
+}
+
+func TestListAlertmanagerReceiverTemplates(t *testing.T) {
+	t.Parallel()
+	existingObjects := []ctrlruntimeclient.Object{
+		test.GenAlertmanagerReceiverTemplate("slack", kubermaticv1.AlertmanagerReceiverTypeSlack, testSlackReceiver),
+		test.GenAlertmanagerReceiverTemplate("webhook", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+		test.APIUserToKubermaticUser(*test.GenDefaultAPIUser()),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/alertmanagerreceivertemplates", nil)
+	res := httptest.NewRecorder()
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, existingObjects, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint: %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	var templates []apiv2.AlertmanagerReceiverTemplate
+	if err := json.Unmarshal(res.Body.Bytes(), &templates); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 receiver templates, got %d", len(templates))
+	}
+}
+
+func TestUpdateAlertmanagerReceiverTemplate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		TemplateName     string
+		Template         apiv2.AlertmanagerReceiverTemplate
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+	}{
+		{
+			Name:             "scenario 1: admin can update receiver template",
+			TemplateName:     "hook",
+			Template:         genAPITemplate("ignored", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			ExpectedResponse: `{"name":"hook","spec":{"type":"webhook","receiver":"webhook_configs:\n- url: https://example.com/hook\n"}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:             "scenario 2: non-admin can not update receiver template",
+			TemplateName:     "hook",
+			Template:         genAPITemplate("hook", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+		{
+			Name:             "scenario 3: admin can not update non-existing receiver template",
+			TemplateName:     "missing",
+			Template:         genAPITemplate("missing", kubermaticv1.AlertmanagerReceiverTypeWebhook, testWebhookReceiver),
+			ExpectedResponse: `{"error":{"code":404,"message":"alertmanagerreceivertemplates.kubermatic.k8c.io \"missing\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			existingObjects := []ctrlruntimeclient.Object{
+				test.GenAlertmanagerReceiverTemplate("hook", kubermaticv1.AlertmanagerReceiverTypeSlack, testSlackReceiver),
+				test.APIUserToKubermaticUser(*tc.ExistingAPIUser),
+			}
+
+			body, err := json.Marshal(tc.Template)
+			if err != nil {
+				t.Fatalf("error marshalling body into json: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v2/alertmanagerreceivertemplates/%s", tc.TemplateName), bytes.NewBuffer(body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, existingObjects, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestDeleteAlertmanagerReceiverTemplate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name            string
+		TemplateName    string
+		HTTPStatus      int
+		ExistingAPIUser *apiv1.User
+	}{
+		{
+			Name:            "scenario 1: admin can delete receiver template",
+			TemplateName:    "slack",
+			HTTPStatus:      http.StatusOK,
+			ExistingAPIUser: test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:            "scenario 2: non-admin can not delete receiver template",
+			TemplateName:    "slack",
+			HTTPStatus:      http.StatusForbidden,
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			existingObjects := []ctrlruntimeclient.Object{
+				test.GenAlertmanagerReceiverTemplate("slack", kubermaticv1.AlertmanagerReceiverTypeSlack, testSlackReceiver),
+				test.APIUserToKubermaticUser(*tc.ExistingAPIUser),
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v2/alertmanagerreceivertemplates/%s", tc.TemplateName), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, existingObjects, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func genAPITemplate(name string, receiverType kubermaticv1.AlertmanagerReceiverType, receiver string) apiv2.AlertmanagerReceiverTemplate {
+	return apiv2.AlertmanagerReceiverTemplate{
+		Name: name,
+		Spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+			Type:     receiverType,
+			Receiver: receiver,
+		},
+	}
+}
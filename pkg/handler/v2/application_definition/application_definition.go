@@ -18,14 +18,21 @@ package applicationdefinition
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/valuesschema"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func ListApplicationDefinitions(applicationDefinitionProvider provider.ApplicationDefinitionProvider) endpoint.Endpoint {
@@ -44,6 +51,55 @@ func ListApplicationDefinitions(applicationDefinitionProvider provider.Applicati
 	}
 }
 
+// GetApplicationValuesSchema returns the values schema of an application version together with the default values.
+func GetApplicationValuesSchema(applicationDefinitionProvider provider.ApplicationDefinitionProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(getApplicationValuesSchemaReq)
+
+		appDef, err := applicationDefinitionProvider.GetUnsecured(ctx, req.AppDefName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		found := false
+		for _, v := range appDef.Spec.Versions {
+			if v.Version == req.Version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, utilerrors.NewNotFound("ApplicationVersion", req.Version)
+		}
+
+		defaultValues := map[string]interface{}{}
+		if appDef.Spec.DefaultValues != nil {
+			if defaultValues, err = valuesschema.ValuesFromRawExtension(*appDef.Spec.DefaultValues); err != nil {
+				return nil, utilerrors.New(http.StatusInternalServerError, fmt.Sprintf("invalid default values: %v", err))
+			}
+		}
+
+		result := &apiv2.ApplicationValuesSchema{}
+		if schema := valuesschema.ForVersion(appDef, req.Version); schema != nil {
+			result.Schema = &runtime.RawExtension{Raw: schema}
+
+			if defaultValues, err = valuesschema.ApplyDefaults(schema, defaultValues); err != nil {
+				return nil, utilerrors.New(http.StatusInternalServerError, err.Error())
+			}
+		}
+
+		if len(defaultValues) > 0 {
+			raw, err := json.Marshal(defaultValues)
+			if err != nil {
+				return nil, err
+			}
+			result.DefaultValues = &runtime.RawExtension{Raw: raw}
+		}
+
+		return result, nil
+	}
+}
+
 func convertInternalToExternal(appDef *appskubermaticv1.ApplicationDefinition) *apiv2.ApplicationDefinition {
 	return &apiv2.ApplicationDefinition{
 		ObjectMeta: apiv1.ObjectMeta{
@@ -23,10 +23,12 @@ import (
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -95,6 +97,66 @@ func TestListApplicationDefinitions(t *testing.T) {
 	}
 }
 
+func TestGetApplicationValuesSchema(t *testing.T) {
+	t.Parallel()
+
+	appDefWithSchema := test.GenApplicationDefinition("appdef1")
+	appDefWithSchema.Spec.DefaultValues = &runtime.RawExtension{Raw: []byte(`{"image":{"repository":"nginx"}}`)}
+	appDefWithSchema.Spec.Versions[0].ValuesSchema = &appskubermaticv1.ValuesSchema{
+		Inline: &runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"replicas":{"type":"integer","default":2}}}`)},
+	}
+
+	testcases := []struct {
+		Name               string
+		Version            string
+		ExistingObjects    []ctrlruntimeclient.Object
+		ExpectedHTTPStatus int
+		ExpectedResponse   string
+	}{
+		{
+			Name:               "schema and defaults of a version with inline schema",
+			Version:            "v1.0.0",
+			ExistingObjects:    test.GenDefaultKubermaticObjects(appDefWithSchema),
+			ExpectedHTTPStatus: http.StatusOK,
+			ExpectedResponse:   `{"schema":{"type":"object","properties":{"replicas":{"type":"integer","default":2}}},"defaultValues":{"image":{"repository":"nginx"},"replicas":2}}`,
+		},
+		{
+			Name:               "defaults of a version without schema",
+			Version:            "v1.1.0",
+			ExistingObjects:    test.GenDefaultKubermaticObjects(appDefWithSchema),
+			ExpectedHTTPStatus: http.StatusOK,
+			ExpectedResponse:   `{"defaultValues":{"image":{"repository":"nginx"}}}`,
+		},
+		{
+			Name:               "unknown version",
+			Version:            "v2.0.0",
+			ExistingObjects:    test.GenDefaultKubermaticObjects(appDefWithSchema),
+			ExpectedHTTPStatus: http.StatusNotFound,
+			ExpectedResponse:   `{"error":{"code":404,"message":"ApplicationVersion \"v2.0.0\" not found"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/applicationdefinitions/appdef1/versions/"+tc.Version+"/schema", nil)
+			res := httptest.NewRecorder()
+
+			ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, nil, nil, tc.ExistingObjects, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.ExpectedHTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.ExpectedHTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
 func genKubermaticUser(name, email string, isAdmin bool) *kubermaticv1.User {
 	user := test.GenUser("", name, email)
 	user.Spec.IsAdmin = isAdmin
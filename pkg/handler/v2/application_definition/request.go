@@ -15,3 +15,38 @@ limitations under the License.
 */
 
 package applicationdefinition
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// getApplicationValuesSchemaReq defines HTTP request for getApplicationValuesSchema
+// swagger:parameters getApplicationValuesSchema
+type getApplicationValuesSchemaReq struct {
+	// in: path
+	// required: true
+	AppDefName string `json:"appdef_name"`
+
+	// in: path
+	// required: true
+	Version string `json:"version"`
+}
+
+func DecodeGetApplicationValuesSchema(c context.Context, r *http.Request) (interface{}, error) {
+	req := getApplicationValuesSchemaReq{
+		AppDefName: mux.Vars(r)["appdef_name"],
+		Version:    mux.Vars(r)["version"],
+	}
+	if req.AppDefName == "" {
+		return nil, fmt.Errorf("'appdef_name' parameter is required but was not provided")
+	}
+	if req.Version == "" {
+		return nil, fmt.Errorf("'version' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
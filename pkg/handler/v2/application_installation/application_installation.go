@@ -18,6 +18,7 @@ package applicationinstallation
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-kit/kit/endpoint"
 
@@ -27,6 +28,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,6 +158,41 @@ func UpdateApplicationInstallation(userInfoGetter provider.UserInfoGetter) endpo
 	}
 }
 
+func RollbackApplicationInstallation(userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(rollbackApplicationInstallationReq)
+		if err := req.Validate(); err != nil {
+			return nil, utilerrors.NewBadRequest(err.Error())
+		}
+
+		client, err := userClusterClientFromContext(ctx, userInfoGetter, req.ProjectID, req.ClusterID)
+		if err != nil {
+			return nil, err
+		}
+
+		appInstall := &appskubermaticv1.ApplicationInstallation{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.ApplicationInstallationName}, appInstall); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if appInstall.Status.Method != appskubermaticv1.HelmTemplateMethod {
+			return nil, utilerrors.NewBadRequest("only applications installed with the helm template method can be rolled back")
+		}
+
+		// the rollback is performed by the application-installation-controller in the user cluster.
+		if appInstall.Annotations == nil {
+			appInstall.Annotations = map[string]string{}
+		}
+		appInstall.Annotations[appskubermaticv1.ApplicationInstallationRollbackAnnotation] = strconv.Itoa(req.Body.Revision)
+
+		if err := client.Update(ctx, appInstall); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalToAPIApplicationInstallation(appInstall), nil
+	}
+}
+
 func userClusterClientFromContext(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string) (ctrlruntimeclient.Client, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	userInfo, err := userInfoGetter(ctx, projectID)
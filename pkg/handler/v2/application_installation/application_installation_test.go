@@ -491,3 +491,103 @@ func TestUpdateApplicationInstallation(t *testing.T) {
 		})
 	}
 }
+
+func TestRollbackApplicationInstallation(t *testing.T) {
+	t.Parallel()
+
+	genHelmApplicationInstallation := func() *appskubermaticv1.ApplicationInstallation {
+		appInstall := test.GenApplicationInstallation("app1", test.GenDefaultCluster().Name, app1TargetNamespace)
+		appInstall.Status.Method = appskubermaticv1.HelmTemplateMethod
+		return appInstall
+	}
+
+	testcases := []struct {
+		Name                        string
+		ApplicationInstallationName string
+		ExistingKubermaticObjects   []ctrlruntimeclient.Object
+		Body                        apiv2.ApplicationInstallationRollbackBody
+		ExpectedHTTPStatusCode      int
+		ExpectedAnnotation          string
+	}{
+		{
+			Name:                        "rollback an existing ApplicationInstallation",
+			ApplicationInstallationName: "app1",
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				genHelmApplicationInstallation(),
+			),
+			Body:                   apiv2.ApplicationInstallationRollbackBody{Revision: 2},
+			ExpectedHTTPStatusCode: http.StatusOK,
+			ExpectedAnnotation:     "2",
+		},
+		{
+			Name:                        "revision must be positive",
+			ApplicationInstallationName: "app1",
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				genHelmApplicationInstallation(),
+			),
+			Body:                   apiv2.ApplicationInstallationRollbackBody{Revision: 0},
+			ExpectedHTTPStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:                        "ApplicationInstallation not installed with helm can not be rolled back",
+			ApplicationInstallationName: "app1",
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenApplicationInstallation("app1", test.GenDefaultCluster().Name, app1TargetNamespace),
+			),
+			Body:                   apiv2.ApplicationInstallationRollbackBody{Revision: 1},
+			ExpectedHTTPStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:                        "try to rollback an ApplicationInstallation that does not exist",
+			ApplicationInstallationName: "app1",
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+			),
+			Body:                   apiv2.ApplicationInstallationRollbackBody{Revision: 1},
+			ExpectedHTTPStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			requestURL := fmt.Sprintf("/api/v2/projects/%s/clusters/%s/applicationinstallations/%s/%s/rollback", test.GenDefaultProject().Name, test.GenDefaultCluster().Name, app1TargetNamespace, tc.ApplicationInstallationName)
+			body, err := json.Marshal(tc.Body)
+			if err != nil {
+				t.Fatalf("failed to marshal body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBuffer(body))
+			res := httptest.NewRecorder()
+
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, nil, nil, tc.ExistingKubermaticObjects, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to: %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.ExpectedHTTPStatusCode {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.ExpectedHTTPStatusCode, res.Code, res.Body.String())
+			}
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			appInstall := &appskubermaticv1.ApplicationInstallation{}
+			if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: app1TargetNamespace, Name: tc.ApplicationInstallationName}, appInstall); err != nil {
+				t.Fatalf("failed to get ApplicationInstallation: %v", err)
+			}
+
+			if annotation := appInstall.Annotations[appskubermaticv1.ApplicationInstallationRollbackAnnotation]; annotation != tc.ExpectedAnnotation {
+				t.Errorf("Expected rollback annotation %q, got %q", tc.ExpectedAnnotation, annotation)
+			}
+		})
+	}
+}
@@ -57,6 +57,18 @@ func convertInternalToAPIApplicationInstallation(in *appskubermaticv1.Applicatio
 	})
 	out.Status.Conditions = apiCondition
 
+	for _, rel := range in.Status.HelmReleaseHistory {
+		revision := apiv2.HelmReleaseRevision{
+			Revision: rel.Version,
+		}
+		if rel.Info != nil {
+			revision.Status = rel.Info.Status.String()
+			revision.Description = rel.Info.Description
+			revision.LastDeployed = apiv1.NewTime(rel.Info.LastDeployed.Time)
+		}
+		out.Status.HelmReleaseHistory = append(out.Status.HelmReleaseHistory, revision)
+	}
+
 	if in.DeletionTimestamp != nil {
 		ts := apiv1.NewTime(in.DeletionTimestamp.Time)
 		out.DeletionTimestamp = &ts
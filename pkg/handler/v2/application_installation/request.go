@@ -96,6 +96,24 @@ type updateApplicationInstallationReq struct {
 	Body apiv2.ApplicationInstallationBody
 }
 
+// rollbackApplicationInstallationReq defines HTTP request for rollbackApplicationInstallation
+// swagger:parameters rollbackApplicationInstallation
+type rollbackApplicationInstallationReq struct {
+	common.ProjectReq
+	// in: path
+	ClusterID string `json:"cluster_id"`
+
+	// in: path
+	Namespace string `json:"namespace"`
+
+	// in: path
+	ApplicationInstallationName string `json:"appinstall_name"`
+
+	// in: body
+	// required: true
+	Body apiv2.ApplicationInstallationRollbackBody
+}
+
 func DecodeListApplicationInstallations(c context.Context, r *http.Request) (interface{}, error) {
 	var req listApplicationInstallationsReq
 
@@ -259,6 +277,54 @@ func (req updateApplicationInstallationReq) GetSeedCluster() apiv1.SeedCluster {
 	}
 }
 
+func DecodeRollbackApplicationInstallation(c context.Context, r *http.Request) (interface{}, error) {
+	var req rollbackApplicationInstallationReq
+
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	projectReq, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = projectReq.(common.ProjectReq)
+
+	namespace, err := common.DecodeNamespace(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Namespace = namespace
+
+	appInstallName, err := DecodeApplicationInstallationName(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ApplicationInstallationName = appInstallName
+
+	if err = json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (req rollbackApplicationInstallationReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+// Validate validates rollbackApplicationInstallationReq request.
+func (req rollbackApplicationInstallationReq) Validate() error {
+	if req.Body.Revision <= 0 {
+		return fmt.Errorf("revision must be a positive integer")
+	}
+	return nil
+}
+
 func DecodeApplicationInstallationName(c context.Context, r *http.Request) (string, error) {
 	appInstallName := mux.Vars(r)["appinstall_name"]
 	if appInstallName == "" {
@@ -486,3 +486,67 @@ func DeleteMachineDeployment(projectProvider provider.ProjectProvider, privilege
 		return handlercommon.DeleteMachineDeployment(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID, req.MachineDeploymentID)
 	}
 }
+
+func EstimateMachineDeploymentCost(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(estimateMachineDeploymentCostReq)
+		return handlercommon.EstimateMachineDeploymentCost(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, req.Body, req.ProjectID, req.ClusterID)
+	}
+}
+
+// estimateMachineDeploymentCostReq defines HTTP request for estimateMachineDeploymentCost
+// swagger:parameters estimateMachineDeploymentCost
+type estimateMachineDeploymentCostReq struct {
+	common.ProjectReq
+	// in: path
+	ClusterID string `json:"cluster_id"`
+	// in: body
+	Body apiv1.NodeDeployment
+}
+
+func DecodeEstimateMachineDeploymentCost(c context.Context, r *http.Request) (interface{}, error) {
+	createReq, err := DecodeCreateMachineDeployment(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimateMachineDeploymentCostReq(createReq.(createMachineDeploymentReq)), nil
+}
+
+// GetSeedCluster returns the SeedCluster object.
+func (req estimateMachineDeploymentCostReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func EstimateClusterCost(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(estimateClusterCostReq)
+		return handlercommon.EstimateClusterCost(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, req.ProjectID, req.ClusterID)
+	}
+}
+
+// estimateClusterCostReq defines HTTP request for estimateClusterCost
+// swagger:parameters estimateClusterCost
+type estimateClusterCostReq struct {
+	common.ProjectReq
+	// in: path
+	ClusterID string `json:"cluster_id"`
+}
+
+func DecodeEstimateClusterCost(c context.Context, r *http.Request) (interface{}, error) {
+	listReq, err := DecodeListMachineDeployments(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimateClusterCostReq(listReq.(listMachineDeploymentsReq)), nil
+}
+
+// GetSeedCluster returns the SeedCluster object.
+func (req estimateClusterCostReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !ee
+
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metering
+
+import (
+	"context"
+	"net/http"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func getUsage(_ context.Context, _ interface{}, _ provider.SeedsGetter, _ provider.SeedClientGetter) (*apiv2.MeteringUsage, error) {
+	return nil, nil
+}
+
+func DecodeGetUsageReq(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
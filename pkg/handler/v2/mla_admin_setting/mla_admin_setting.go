@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/prometheus/common/model"
 
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -142,6 +143,16 @@ func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider prov
 }
 
 func convertAPIToInternalMLAAdminSetting(cluster *kubermaticv1.Cluster, mlaAdminSetting *apiv2.MLAAdminSetting) (*kubermaticv1.MLAAdminSetting, error) {
+	if mlaAdminSetting.MonitoringRetention != "" {
+		if _, err := model.ParseDuration(mlaAdminSetting.MonitoringRetention); err != nil {
+			return nil, utilerrors.NewBadRequest("invalid monitoring retention: %v", err)
+		}
+	}
+	if mlaAdminSetting.LoggingRetention != "" {
+		if _, err := model.ParseDuration(mlaAdminSetting.LoggingRetention); err != nil {
+			return nil, utilerrors.NewBadRequest("invalid logging retention: %v", err)
+		}
+	}
 	internalMLAAdminSetting := &kubermaticv1.MLAAdminSetting{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      resources.MLAAdminSettingsName,
@@ -151,6 +162,8 @@ func convertAPIToInternalMLAAdminSetting(cluster *kubermaticv1.Cluster, mlaAdmin
 			ClusterName:          cluster.Name,
 			MonitoringRateLimits: mlaAdminSetting.MonitoringRateLimits,
 			LoggingRateLimits:    mlaAdminSetting.LoggingRateLimits,
+			MonitoringRetention:  mlaAdminSetting.MonitoringRetention,
+			LoggingRetention:     mlaAdminSetting.LoggingRetention,
 		},
 	}
 	return internalMLAAdminSetting, nil
@@ -160,5 +173,8 @@ func convertInternalToAPIMLAAdminSetting(mlaAdminSetting *kubermaticv1.MLAAdminS
 	return &apiv2.MLAAdminSetting{
 		MonitoringRateLimits: mlaAdminSetting.Spec.MonitoringRateLimits,
 		LoggingRateLimits:    mlaAdminSetting.Spec.LoggingRateLimits,
+		MonitoringRetention:  mlaAdminSetting.Spec.MonitoringRetention,
+		LoggingRetention:     mlaAdminSetting.Spec.LoggingRetention,
+		Usage:                mlaAdminSetting.Status.Usage,
 	}
 }
@@ -26,6 +26,7 @@ import (
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
 	"k8c.io/kubermatic/v2/pkg/resources"
@@ -96,6 +97,42 @@ func TestGetEndpoint(t *testing.T) {
 			ExpectedHTTPStatusCode: http.StatusOK,
 			ExpectedResponse:       test.GenAPIMLAAdminSetting(1),
 		},
+		{
+			Name:      "admin can get retention and usage of the given cluster",
+			ProjectID: test.GenDefaultProject().Name,
+			ClusterID: test.GenDefaultCluster().Name,
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAdminUser("John", "john@acme.com", true),
+				func() *kubermaticv1.MLAAdminSetting {
+					mlaAdminSetting := test.GenMLAAdminSetting(mlaAdminSettingName, test.GenDefaultCluster().Name, 1)
+					mlaAdminSetting.Spec.MonitoringRetention = "30d"
+					mlaAdminSetting.Spec.LoggingRetention = "7d"
+					mlaAdminSetting.Status.Usage = &kubermaticv1.MLAUsage{
+						Series:           100,
+						IngestionRate:    10,
+						LogStreams:       5,
+						LogIngestionRate: 1024,
+					}
+					return mlaAdminSetting
+				}(),
+			),
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+			ExpectedHTTPStatusCode: http.StatusOK,
+			ExpectedResponse: func() *apiv2.MLAAdminSetting {
+				mlaAdminSetting := test.GenAPIMLAAdminSetting(1)
+				mlaAdminSetting.MonitoringRetention = "30d"
+				mlaAdminSetting.LoggingRetention = "7d"
+				mlaAdminSetting.Usage = &kubermaticv1.MLAUsage{
+					Series:           100,
+					IngestionRate:    10,
+					LogStreams:       5,
+					LogIngestionRate: 1024,
+				}
+				return mlaAdminSetting
+			}(),
+		},
 		{
 			Name:      "admin gets mla admin setting which doesn't exist",
 			ProjectID: test.GenDefaultProject().Name,
@@ -215,6 +252,23 @@ func TestCreateEndpoint(t *testing.T) {
 			MLAAdminSetting:        test.GenAPIMLAAdminSetting(1),
 			ExpectedHTTPStatusCode: http.StatusConflict,
 		},
+		{
+			Name:      "admin user john cannot create mla admin setting with invalid retention",
+			ProjectID: test.GenDefaultProject().Name,
+			ClusterID: test.GenDefaultCluster().Name,
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAdminUser("John", "john@acme.com", true),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			MLAAdminSetting: func() *apiv2.MLAAdminSetting {
+				mlaAdminSetting := test.GenAPIMLAAdminSetting(1)
+				mlaAdminSetting.LoggingRetention = "one week"
+				return mlaAdminSetting
+			}(),
+			ExpectedHTTPStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -28,6 +28,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/addon"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/alertmanager"
+	alertmanagerreceivertemplate "k8c.io/kubermatic/v2/pkg/handler/v2/alertmanager_receiver_template"
 	allowedregistry "k8c.io/kubermatic/v2/pkg/handler/v2/allowed_registry"
 	applicationdefinition "k8c.io/kubermatic/v2/pkg/handler/v2/application_definition"
 	applicationinstallation "k8c.io/kubermatic/v2/pkg/handler/v2/application_installation"
@@ -47,6 +48,7 @@ import (
 	ipampool "k8c.io/kubermatic/v2/pkg/handler/v2/ipampool"
 	kubernetesdashboard "k8c.io/kubermatic/v2/pkg/handler/v2/kubernetes-dashboard"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/machine"
+	"k8c.io/kubermatic/v2/pkg/handler/v2/metering"
 	mlaadminsetting "k8c.io/kubermatic/v2/pkg/handler/v2/mla_admin_setting"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/networkdefaults"
 	operatingsystemprofile "k8c.io/kubermatic/v2/pkg/handler/v2/operatingsystemprofile"
@@ -287,6 +289,10 @@ func (r Routing) RegisterV2(mux *mux.Router, oidcKubeConfEndpoint bool, oidcCfg
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments").
 		Handler(r.createMachineDeployment())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/costestimate").
+		Handler(r.estimateMachineDeploymentCost())
+
 	mux.Methods(http.MethodDelete).
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/nodes/{node_id}").
 		Handler(r.deleteMachineDeploymentNode())
@@ -327,6 +333,10 @@ func (r Routing) RegisterV2(mux *mux.Router, oidcKubeConfEndpoint bool, oidcCfg
 		Path("/projects/{project_id}/clusters/{cluster_id}/machinedeployments/{machinedeployment_id}").
 		Handler(r.deleteMachineDeployment())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clusters/{cluster_id}/costestimate").
+		Handler(r.estimateClusterCost())
+
 	// Defines set of HTTP endpoints for SSH Keys that belong to a cluster
 	mux.Methods(http.MethodPut).
 		Path("/projects/{project_id}/clusters/{cluster_id}/sshkeys/{key_id}").
@@ -450,11 +460,19 @@ func (r Routing) RegisterV2(mux *mux.Router, oidcKubeConfEndpoint bool, oidcCfg
 		Path("/projects/{project_id}/clusters/{cluster_id}/applicationinstallations/{namespace}/{appinstall_name}").
 		Handler(r.updateApplicationInstallation())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/applicationinstallations/{namespace}/{appinstall_name}/rollback").
+		Handler(r.rollbackApplicationInstallation())
+
 	// Defines a set of HTTP endpoint for ApplicationDefinitions which are available in the KKP installation
 	mux.Methods(http.MethodGet).
 		Path("/applicationdefinitions").
 		Handler(r.listApplicationDefinitions())
 
+	mux.Methods(http.MethodGet).
+		Path("/applicationdefinitions/{appdef_name}/versions/{version}/schema").
+		Handler(r.getApplicationValuesSchema())
+
 	// Define a set of endpoints for gatekeeper constraint templates
 	mux.Methods(http.MethodGet).
 		Path("/constrainttemplates").
@@ -573,6 +591,31 @@ func (r Routing) RegisterV2(mux *mux.Router, oidcKubeConfEndpoint bool, oidcCfg
 		Path("/projects/{project_id}/clusters/{cluster_id}/alertmanager/config").
 		Handler(r.resetAlertmanager())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/alertmanager/receivers").
+		Handler(r.attachAlertmanagerReceiver())
+
+	// Defines a set of HTTP endpoints for managing alertmanager receiver templates
+	mux.Methods(http.MethodPost).
+		Path("/alertmanagerreceivertemplates").
+		Handler(r.createAlertmanagerReceiverTemplate())
+
+	mux.Methods(http.MethodGet).
+		Path("/alertmanagerreceivertemplates").
+		Handler(r.listAlertmanagerReceiverTemplates())
+
+	mux.Methods(http.MethodGet).
+		Path("/alertmanagerreceivertemplates/{template_name}").
+		Handler(r.getAlertmanagerReceiverTemplate())
+
+	mux.Methods(http.MethodPut).
+		Path("/alertmanagerreceivertemplates/{template_name}").
+		Handler(r.updateAlertmanagerReceiverTemplate())
+
+	mux.Methods(http.MethodDelete).
+		Path("/alertmanagerreceivertemplates/{template_name}").
+		Handler(r.deleteAlertmanagerReceiverTemplate())
+
 	// Defines a set of HTTP endpoints for various cloud providers
 	// Note that these endpoints don't require credentials as opposed to the ones defined under /providers/*
 	mux.Methods(http.MethodGet).
@@ -890,6 +933,10 @@ func (r Routing) RegisterV2(mux *mux.Router, oidcKubeConfEndpoint bool, oidcCfg
 		Path("/projects/{project_id}/clusters/{cluster_id}/rulegroups/{rulegroup_id}").
 		Handler(r.deleteRuleGroup())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clusters/{cluster_id}/rulegroups/test").
+		Handler(r.testRuleGroup())
+
 	// Defines a set of HTTP endpoints for managing allowed registries
 	mux.Methods(http.MethodPost).
 		Path("/allowedregistries").
@@ -1139,6 +1186,11 @@ func (r Routing) RegisterV2(mux *mux.Router, oidcKubeConfEndpoint bool, oidcCfg
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/clusters/{cluster_id}/operatingsystemprofiles").
 		Handler(r.listOperatingSystemProfilesForCluster())
+
+	// Defines an endpoint to query the usage aggregated from metering reports
+	mux.Methods(http.MethodGet).
+		Path("/metering/usage").
+		Handler(r.getMeteringUsage())
 }
 
 // swagger:route POST /api/v2/projects/{project_id}/clusters project createClusterV2
@@ -2511,6 +2563,61 @@ func (r Routing) createMachineDeployment() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/costestimate project estimateMachineDeploymentCost
+//
+//     Estimates the monthly cost of a machine deployment before it is created in the given cluster.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: CostEstimate
+//       401: empty
+//       403: empty
+func (r Routing) estimateMachineDeploymentCost() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(machine.EstimateMachineDeploymentCost(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		machine.DecodeEstimateMachineDeploymentCost,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/clusters/{cluster_id}/costestimate project estimateClusterCost
+//
+//     Estimates the monthly cost of the given cluster, including the control plane and all machine deployments.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: CostEstimate
+//       401: empty
+//       403: empty
+func (r Routing) estimateClusterCost() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(machine.EstimateClusterCost(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		machine.DecodeEstimateClusterCost,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route DELETE /api/v2/projects/{project_id}/clusters/{cluster_id}/machinedeployments/nodes/{node_id} project deleteMachineDeploymentNode
 //
 //    Deletes the given node that belongs to the machine deployment.
@@ -4811,6 +4918,163 @@ func (r Routing) resetAlertmanager() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/alertmanager/receivers project attachAlertmanagerReceiver
+//
+//     Adds a receiver based on the given receiver template to the alertmanager configuration of the cluster.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Alertmanager
+//       401: empty
+//       403: empty
+func (r Routing) attachAlertmanagerReceiver() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Alertmanagers(r.clusterProviderGetter, r.alertmanagerProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAlertmanagers(r.clusterProviderGetter, r.alertmanagerProviderGetter, r.seedsGetter),
+		)(alertmanager.AttachReceiverEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.privilegedAlertmanagerReceiverTemplateProvider)),
+		alertmanager.DecodeAttachAlertmanagerReceiverReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v2/alertmanagerreceivertemplates alertmanagerreceivertemplate createAlertmanagerReceiverTemplate
+//
+//     Creates an alertmanager receiver template. Only available for admins.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: AlertmanagerReceiverTemplate
+//       401: empty
+//       403: empty
+func (r Routing) createAlertmanagerReceiverTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(alertmanagerreceivertemplate.CreateEndpoint(r.userInfoGetter, r.privilegedAlertmanagerReceiverTemplateProvider)),
+		alertmanagerreceivertemplate.DecodeCreateAlertmanagerReceiverTemplateReq,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/alertmanagerreceivertemplates alertmanagerreceivertemplate listAlertmanagerReceiverTemplates
+//
+//     Lists alertmanager receiver templates.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []AlertmanagerReceiverTemplate
+//       401: empty
+//       403: empty
+func (r Routing) listAlertmanagerReceiverTemplates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(alertmanagerreceivertemplate.ListEndpoint(r.privilegedAlertmanagerReceiverTemplateProvider)),
+		common.DecodeEmptyReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/alertmanagerreceivertemplates/{template_name} alertmanagerreceivertemplate getAlertmanagerReceiverTemplate
+//
+//     Gets the alertmanager receiver template with the given name.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AlertmanagerReceiverTemplate
+//       401: empty
+//       403: empty
+func (r Routing) getAlertmanagerReceiverTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(alertmanagerreceivertemplate.GetEndpoint(r.privilegedAlertmanagerReceiverTemplateProvider)),
+		alertmanagerreceivertemplate.DecodeGetAlertmanagerReceiverTemplateReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PUT /api/v2/alertmanagerreceivertemplates/{template_name} alertmanagerreceivertemplate updateAlertmanagerReceiverTemplate
+//
+//     Updates the alertmanager receiver template with the given name. Only available for admins.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AlertmanagerReceiverTemplate
+//       401: empty
+//       403: empty
+func (r Routing) updateAlertmanagerReceiverTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(alertmanagerreceivertemplate.UpdateEndpoint(r.userInfoGetter, r.privilegedAlertmanagerReceiverTemplateProvider)),
+		alertmanagerreceivertemplate.DecodeUpdateAlertmanagerReceiverTemplateReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v2/alertmanagerreceivertemplates/{template_name} alertmanagerreceivertemplate deleteAlertmanagerReceiverTemplate
+//
+//     Deletes the alertmanager receiver template with the given name. Only available for admins.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteAlertmanagerReceiverTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(alertmanagerreceivertemplate.DeleteEndpoint(r.userInfoGetter, r.privilegedAlertmanagerReceiverTemplateProvider)),
+		alertmanagerreceivertemplate.DecodeGetAlertmanagerReceiverTemplateReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/seeds/{seed_name}/settings seed getSeedSettings
 //
 //     Gets the seed settings.
@@ -5170,6 +5434,35 @@ func (r Routing) deleteRuleGroup() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/rulegroups/test rulegroup testRuleGroup
+//
+//     Runs unit tests against a metrics rule group and reports the failed tests and the firing alerts.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: RuleGroupTestResult
+//       401: empty
+//       403: empty
+func (r Routing) testRuleGroup() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(rulegroup.TestEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider)),
+		rulegroup.DecodeTestReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/externalccmmigration migrateClusterToExternalCCM
 //
 //    Enable the migration to the external CCM for the given cluster
@@ -7444,6 +7737,35 @@ func (r Routing) updateApplicationInstallation() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v2/projects/{project_id}/clusters/{cluster_id}/applicationinstallations/{namespace}/{appinstall_name}/rollback applications rollbackApplicationInstallation
+//
+//    Rolls back the helm release of the given ApplicationInstallation to a previous revision
+//
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ApplicationInstallation
+//       401: empty
+//       403: empty
+func (r Routing) rollbackApplicationInstallation() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(applicationinstallation.RollbackApplicationInstallation(r.userInfoGetter)),
+		applicationinstallation.DecodeRollbackApplicationInstallation,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/applicationdefinitions applications listApplicationDefinitions
 //
 //     List ApplicationDefinitions which are available in the KKP installation
@@ -7468,6 +7790,30 @@ func (r Routing) listApplicationDefinitions() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v2/applicationdefinitions/{appdef_name}/versions/{version}/schema applications getApplicationValuesSchema
+//
+//     Gets the values schema and the default values of an application version
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ApplicationValuesSchema
+//       401: empty
+//       403: empty
+func (r Routing) getApplicationValuesSchema() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(applicationdefinition.GetApplicationValuesSchema(r.applicationDefinitionProvider)),
+		applicationdefinition.DecodeGetApplicationValuesSchema,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/seeds/{seed_name}/ipampools ipampool listIPAMPools
 //
 //    Lists IPAM pools.
@@ -7640,3 +7986,27 @@ func (r Routing) listOperatingSystemProfilesForCluster() http.Handler {
 		r.defaultServerOptions()...,
 	)
 }
+
+// swagger:route GET /api/v2/metering/usage metering getMeteringUsage
+//
+//     Returns the usage per project and cluster aggregated from the JSON and CSV metering reports for the given time range. Only available in Kubermatic Enterprise Edition
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: MeteringUsage
+//       401: empty
+//       403: empty
+func (r Routing) getMeteringUsage() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(metering.GetUsageEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
+		metering.DecodeGetUsageReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
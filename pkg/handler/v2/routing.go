@@ -86,6 +86,7 @@ type Routing struct {
 	clusterTemplateInstanceProviderGetter          provider.ClusterTemplateInstanceProviderGetter
 	ruleGroupProviderGetter                        provider.RuleGroupProviderGetter
 	privilegedAllowedRegistryProvider              provider.PrivilegedAllowedRegistryProvider
+	privilegedAlertmanagerReceiverTemplateProvider provider.PrivilegedAlertmanagerReceiverTemplateProvider
 	etcdBackupConfigProviderGetter                 provider.EtcdBackupConfigProviderGetter
 	etcdRestoreProviderGetter                      provider.EtcdRestoreProviderGetter
 	etcdBackupConfigProjectProviderGetter          provider.EtcdBackupConfigProjectProviderGetter
@@ -152,6 +153,7 @@ func NewV2Routing(routingParams handler.RoutingParams) Routing {
 		clusterTemplateInstanceProviderGetter:          routingParams.ClusterTemplateInstanceProviderGetter,
 		ruleGroupProviderGetter:                        routingParams.RuleGroupProviderGetter,
 		privilegedAllowedRegistryProvider:              routingParams.PrivilegedAllowedRegistryProvider,
+		privilegedAlertmanagerReceiverTemplateProvider: routingParams.PrivilegedAlertmanagerReceiverTemplateProvider,
 		etcdBackupConfigProviderGetter:                 routingParams.EtcdBackupConfigProviderGetter,
 		etcdRestoreProviderGetter:                      routingParams.EtcdRestoreProviderGetter,
 		etcdBackupConfigProjectProviderGetter:          routingParams.EtcdBackupConfigProjectProviderGetter,
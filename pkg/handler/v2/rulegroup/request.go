@@ -29,6 +29,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/cluster"
 	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
 )
 
 // getReq defines HTTP request for getting ruleGroup
@@ -61,6 +62,9 @@ func (req *createReq) validate() (ruleGroupName string, err error) {
 	if req.Body.IsDefault {
 		return "", fmt.Errorf("only Admin can create default rule group")
 	}
+	if err := validation.ValidateRuleGroup(req.Body.Type, req.Body.Data); err != nil {
+		return "", err
+	}
 	return GetRuleGroupNameInData(req.Body.Data)
 }
 
@@ -80,6 +84,9 @@ func (req *updateReq) validate() error {
 	if req.Body.IsDefault {
 		return fmt.Errorf("only Admin can update default rule group")
 	}
+	if err := validation.ValidateRuleGroup(req.Body.Type, req.Body.Data); err != nil {
+		return err
+	}
 	ruleGroupNameInData, err := GetRuleGroupNameInData(req.Body.Data)
 	if err != nil {
 		return err
@@ -90,6 +97,22 @@ func (req *updateReq) validate() error {
 	return nil
 }
 
+// testReq defines HTTP request for running the unit tests of a ruleGroup
+// swagger:parameters testRuleGroup
+type testReq struct {
+	cluster.GetClusterReq
+	// in: body
+	// required: true
+	Body apiv2.RuleGroupTest
+}
+
+func (req *testReq) validate() error {
+	if len(req.Body.Tests) == 0 {
+		return fmt.Errorf("tests must be provided")
+	}
+	return validation.ValidateRuleGroup(kubermaticv1.RuleGroupTypeMetrics, req.Body.Data)
+}
+
 // deleteReq defines HTTP request for deleting ruleGroup
 // swagger:parameters deleteRuleGroup
 type deleteReq struct {
@@ -166,6 +189,20 @@ func DecodeUpdateReq(c context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func DecodeTestReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req testReq
+	cr, err := cluster.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = cr.(cluster.GetClusterReq)
+
+	if err = json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 func DecodeDeleteReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req deleteReq
 	cr, err := cluster.DecodeGetClusterReq(c, r)
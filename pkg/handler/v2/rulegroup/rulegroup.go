@@ -126,6 +126,25 @@ func UpdateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider prov
 	}
 }
 
+func TestEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider,
+	privilegedProjectProvider provider.PrivilegedProjectProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(testReq)
+		if err := req.validate(); err != nil {
+			return nil, utilerrors.NewBadRequest(fmt.Errorf("invalid rule group: %w", err).Error())
+		}
+		// the tests do not depend on the cluster, but are only available to users with access to it
+		if _, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil); err != nil {
+			return nil, err
+		}
+		result, err := runRuleGroupTests(ctx, req.Body.Data, req.Body.Tests)
+		if err != nil {
+			return nil, utilerrors.NewBadRequest(fmt.Errorf("invalid tests: %w", err).Error())
+		}
+		return result, nil
+	}
+}
+
 func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider,
 	privilegedProjectProvider provider.PrivilegedProjectProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
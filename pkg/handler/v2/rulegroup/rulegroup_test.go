@@ -363,6 +363,22 @@ func TestCreateEndpoint(t *testing.T) {
 			ExpectedHTTPStatusCode: http.StatusCreated,
 			ExpectedResponse:       test.GenAPIRuleGroup("test-rule-group", kubermaticv1.RuleGroupTypeMetrics, false),
 		},
+		{
+			Name:      "cannot create rule group with an invalid expression",
+			ProjectID: test.GenDefaultProject().Name,
+			ClusterID: test.GenDefaultCluster().Name,
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			RuleGroup: &apiv2.RuleGroup{
+				Name: "test-rule-group",
+				Data: []byte("name: test-rule-group\nrules:\n- alert: InstanceDown\n  expr: up = = 0\n"),
+				Type: kubermaticv1.RuleGroupTypeMetrics,
+			},
+			ExpectedHTTPStatusCode: http.StatusBadRequest,
+		},
 		{
 			Name:      "cannot create rule group in the given cluster because it already exists",
 			ProjectID: test.GenDefaultProject().Name,
@@ -736,3 +752,137 @@ func TestDeleteEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestTestEndpoint(t *testing.T) {
+	t.Parallel()
+	const tests = `
+tests:
+- name: instance down
+  interval: 1m
+  input_series:
+  - series: 'up{job="node", instance="a"}'
+    values: '1 0 0 0 0 0 0 0'
+  alert_rule_test:
+  - eval_time: 2m
+    alertname: InstanceDown
+  - eval_time: 7m
+    alertname: InstanceDown
+    exp_alerts:
+    - exp_labels:
+        job: node
+        instance: a
+        severity: page
+      exp_annotations:
+        summary: "Instance  down"
+  promql_expr_test:
+  - expr: up
+    eval_time: 0m
+    exp_samples:
+    - labels: 'up{job="node", instance="a"}'
+      value: 1
+`
+	testCases := []struct {
+		Name                   string
+		ExistingAPIUser        *apiv1.User
+		RuleGroupTest          apiv2.RuleGroupTest
+		ExpectedHTTPStatusCode int
+		ExpectedResponse       *apiv2.RuleGroupTestResult
+	}{
+		{
+			Name:            "run passing tests against rule group",
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			RuleGroupTest: apiv2.RuleGroupTest{
+				Data:  test.GenerateTestRuleGroupData("test-rule-group"),
+				Tests: []byte(tests),
+			},
+			ExpectedHTTPStatusCode: http.StatusOK,
+			ExpectedResponse: &apiv2.RuleGroupTestResult{
+				Passed: true,
+				FiringAlerts: []apiv2.RuleGroupTestAlert{
+					{
+						TestGroup: "instance down",
+						EvalTime:  "7m",
+						Alertname: "InstanceDown",
+						Labels:    map[string]string{"alertname": "InstanceDown", "instance": "a", "job": "node", "severity": "page"},
+						Annotations: map[string]string{
+							"summary": "Instance  down",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:            "run failing tests against rule group",
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			RuleGroupTest: apiv2.RuleGroupTest{
+				Data:  []byte("name: test-rule-group\nrules:\n- alert: InstanceDown\n  expr: up == 0\n  for: 10m\n"),
+				Tests: []byte("tests:\n- interval: 1m\n  input_series:\n  - series: up\n    values: 0x10\n  alert_rule_test:\n  - eval_time: 5m\n    alertname: InstanceDown\n    exp_alerts:\n    - exp_labels: {}\n"),
+			},
+			ExpectedHTTPStatusCode: http.StatusOK,
+			ExpectedResponse: &apiv2.RuleGroupTestResult{
+				Failures: []string{`alertname: InstanceDown, time: 5m, exp: [{labels: {alertname="InstanceDown"}, annotations: {}}], got: []`},
+			},
+		},
+		{
+			Name:            "reject invalid tests",
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			RuleGroupTest: apiv2.RuleGroupTest{
+				Data:  test.GenerateTestRuleGroupData("test-rule-group"),
+				Tests: []byte("tests:\n- interval: 1m\n  input_serie: []\n"),
+			},
+			ExpectedHTTPStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:            "reject input series expanding to too many samples",
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			RuleGroupTest: apiv2.RuleGroupTest{
+				Data:  test.GenerateTestRuleGroupData("test-rule-group"),
+				Tests: []byte("tests:\n- interval: 1m\n  input_series:\n  - series: up\n    values: 0+1x1000000000\n"),
+			},
+			ExpectedHTTPStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:            "user john cannot run tests in bob's cluster",
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			RuleGroupTest: apiv2.RuleGroupTest{
+				Data:  test.GenerateTestRuleGroupData("test-rule-group"),
+				Tests: []byte(tests),
+			},
+			ExpectedHTTPStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			requestURL := fmt.Sprintf("/api/v2/projects/%s/clusters/%s/rulegroups/test", test.GenDefaultProject().Name, test.GenDefaultCluster().Name)
+			body, err := json.Marshal(tc.RuleGroupTest)
+			if err != nil {
+				t.Fatalf("failed to marshal rule group test: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBuffer(body))
+			resp := httptest.NewRecorder()
+
+			existingObjects := test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				test.GenAdminUser("John", "john@acme.com", false),
+			)
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, existingObjects, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+			ep.ServeHTTP(resp, req)
+
+			if resp.Code != tc.ExpectedHTTPStatusCode {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.ExpectedHTTPStatusCode, resp.Code, resp.Body.String())
+			}
+			if resp.Code == http.StatusOK {
+				b, err := json.Marshal(tc.ExpectedResponse)
+				if err != nil {
+					t.Fatalf("failed to marshal expected response %v", err)
+				}
+				test.CompareWithResult(t, resp, string(b))
+			}
+		})
+	}
+}
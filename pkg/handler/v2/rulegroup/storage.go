@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroup
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/tsdbutil"
+)
+
+// memoryStorage is a minimal in-memory storage for the rule unit tests. Unlike the
+// Prometheus test storage, it does not write a TSDB to disk.
+type memoryStorage struct {
+	lock   sync.RWMutex
+	series map[string]*memorySeries
+}
+
+type memorySeries struct {
+	labels  labels.Labels
+	samples []tsdbutil.Sample
+}
+
+type memorySample struct {
+	t int64
+	v float64
+}
+
+func (s memorySample) T() int64 {
+	return s.t
+}
+
+func (s memorySample) V() float64 {
+	return s.v
+}
+
+var (
+	_ storage.Queryable  = &memoryStorage{}
+	_ storage.Appendable = &memoryStorage{}
+)
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{
+		series: map[string]*memorySeries{},
+	}
+}
+
+// append adds a sample to the series with the given labels. Like the TSDB, it rejects
+// samples that are older than the latest sample of the series.
+func (m *memoryStorage) append(lset labels.Labels, t int64, v float64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key := lset.String()
+	series, ok := m.series[key]
+	if !ok {
+		series = &memorySeries{labels: lset.Copy()}
+		m.series[key] = series
+	}
+
+	if n := len(series.samples); n > 0 {
+		last := series.samples[n-1]
+		switch {
+		case t < last.T():
+			return storage.ErrOutOfOrderSample
+		case t == last.T():
+			if math.Float64bits(v) != math.Float64bits(last.V()) {
+				return storage.ErrDuplicateSampleForTimestamp
+			}
+			return nil
+		}
+	}
+
+	series.samples = append(series.samples, memorySample{t: t, v: v})
+	return nil
+}
+
+func (m *memoryStorage) Appender(_ context.Context) storage.Appender {
+	return &memoryAppender{storage: m}
+}
+
+func (m *memoryStorage) Querier(_ context.Context, mint, maxt int64) (storage.Querier, error) {
+	return &memoryQuerier{storage: m, mint: mint, maxt: maxt}, nil
+}
+
+// memoryAppender buffers samples until they are committed.
+type memoryAppender struct {
+	storage *memoryStorage
+	pending []memoryPendingSample
+}
+
+type memoryPendingSample struct {
+	labels labels.Labels
+	memorySample
+}
+
+func (a *memoryAppender) Append(ref storage.SeriesRef, l labels.Labels, t int64, v float64) (storage.SeriesRef, error) {
+	a.pending = append(a.pending, memoryPendingSample{labels: l.Copy(), memorySample: memorySample{t: t, v: v}})
+	return ref, nil
+}
+
+func (a *memoryAppender) AppendExemplar(ref storage.SeriesRef, _ labels.Labels, _ exemplar.Exemplar) (storage.SeriesRef, error) {
+	return ref, nil
+}
+
+func (a *memoryAppender) Commit() error {
+	pending := a.pending
+	a.pending = nil
+
+	for _, s := range pending {
+		if err := a.storage.append(s.labels, s.t, s.v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *memoryAppender) Rollback() error {
+	a.pending = nil
+	return nil
+}
+
+type memoryQuerier struct {
+	storage    *memoryStorage
+	mint, maxt int64
+}
+
+func (q *memoryQuerier) Select(_ bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	mint, maxt := q.mint, q.maxt
+	if hints != nil {
+		mint, maxt = hints.Start, hints.End
+	}
+
+	var result []storage.Series
+	for _, series := range q.matchingSeries(matchers, mint, maxt) {
+		result = append(result, storage.NewListSeries(series.labels, series.samples))
+	}
+
+	// series are always returned sorted, as the PromQL engine requires it for most selects
+	sort.Slice(result, func(i, j int) bool {
+		return labels.Compare(result[i].Labels(), result[j].Labels()) < 0
+	})
+
+	return &memorySeriesSet{series: result, index: -1}
+}
+
+func (q *memoryQuerier) LabelValues(name string, matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	values := map[string]struct{}{}
+	for _, series := range q.matchingSeries(matchers, q.mint, q.maxt) {
+		if value := series.labels.Get(name); value != "" {
+			values[value] = struct{}{}
+		}
+	}
+
+	return sortedKeys(values), nil, nil
+}
+
+func (q *memoryQuerier) LabelNames(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	names := map[string]struct{}{}
+	for _, series := range q.matchingSeries(matchers, q.mint, q.maxt) {
+		for _, l := range series.labels {
+			names[l.Name] = struct{}{}
+		}
+	}
+
+	return sortedKeys(names), nil, nil
+}
+
+func (q *memoryQuerier) Close() error {
+	return nil
+}
+
+// matchingSeries returns all series matching the given matchers, with a copy of their samples
+// between mint and maxt.
+func (q *memoryQuerier) matchingSeries(matchers []*labels.Matcher, mint, maxt int64) []memorySeries {
+	q.storage.lock.RLock()
+	defer q.storage.lock.RUnlock()
+
+	var result []memorySeries
+
+Series:
+	for _, series := range q.storage.series {
+		for _, m := range matchers {
+			if !m.Matches(series.labels.Get(m.Name)) {
+				continue Series
+			}
+		}
+		start := sort.Search(len(series.samples), func(i int) bool {
+			return series.samples[i].T() >= mint
+		})
+		end := sort.Search(len(series.samples), func(i int) bool {
+			return series.samples[i].T() > maxt
+		})
+		if start >= end {
+			continue
+		}
+
+		result = append(result, memorySeries{
+			labels:  series.labels,
+			samples: append([]tsdbutil.Sample{}, series.samples[start:end]...),
+		})
+	}
+
+	return result
+}
+
+type memorySeriesSet struct {
+	series []storage.Series
+	index  int
+}
+
+func (s *memorySeriesSet) Next() bool {
+	s.index++
+	return s.index < len(s.series)
+}
+
+func (s *memorySeriesSet) At() storage.Series {
+	return s.series[s.index]
+}
+
+func (s *memorySeriesSet) Err() error {
+	return nil
+}
+
+func (s *memorySeriesSet) Warnings() storage.Warnings {
+	return nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,526 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+	"gopkg.in/yaml.v3"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+)
+
+const (
+	// maxTestEvaluations limits the number of rule evaluations per test group,
+	// so that a single request cannot keep the API busy for too long.
+	maxTestEvaluations = 10000
+	// maxTestGroups limits the number of test groups per request.
+	maxTestGroups = 20
+	// maxTestSamples limits the number of samples all input series of a request expand to,
+	// as the expanding notation allows to describe huge series with a few characters.
+	maxTestSamples = 100000
+	// maxQuerySamples and queryTimeout limit every single query, like `promtool test rules` does.
+	maxQuerySamples = 10000
+	queryTimeout    = 100 * time.Second
+)
+
+// unitTestFile holds the unit tests of a rule group. The format follows the one used by
+// `promtool test rules`, except that the rules are not loaded from files.
+type unitTestFile struct {
+	EvaluationInterval model.Duration `yaml:"evaluation_interval,omitempty"`
+	Tests              []testGroup    `yaml:"tests"`
+}
+
+// testGroup is a group of input series and tests associated with it.
+type testGroup struct {
+	Interval        model.Duration    `yaml:"interval"`
+	InputSeries     []series          `yaml:"input_series"`
+	AlertRuleTests  []alertTestCase   `yaml:"alert_rule_test,omitempty"`
+	PromqlExprTests []promqlTestCase  `yaml:"promql_expr_test,omitempty"`
+	ExternalLabels  map[string]string `yaml:"external_labels,omitempty"`
+	ExternalURL     string            `yaml:"external_url,omitempty"`
+	TestGroupName   string            `yaml:"name,omitempty"`
+}
+
+type series struct {
+	Series string `yaml:"series"`
+	Values string `yaml:"values"`
+}
+
+type alertTestCase struct {
+	EvalTime  model.Duration `yaml:"eval_time"`
+	Alertname string         `yaml:"alertname"`
+	ExpAlerts []alert        `yaml:"exp_alerts"`
+}
+
+type alert struct {
+	ExpLabels      map[string]string `yaml:"exp_labels"`
+	ExpAnnotations map[string]string `yaml:"exp_annotations"`
+}
+
+type promqlTestCase struct {
+	Expr       string         `yaml:"expr"`
+	EvalTime   model.Duration `yaml:"eval_time"`
+	ExpSamples []sample       `yaml:"exp_samples"`
+}
+
+type sample struct {
+	Labels string  `yaml:"labels"`
+	Value  float64 `yaml:"value"`
+}
+
+type labelsAndAnnotations []labelAndAnnotation
+
+type labelAndAnnotation struct {
+	Labels      labels.Labels
+	Annotations labels.Labels
+}
+
+func (la labelsAndAnnotations) sort() {
+	sort.Slice(la, func(i, j int) bool {
+		if diff := labels.Compare(la[i].Labels, la[j].Labels); diff != 0 {
+			return diff < 0
+		}
+		return labels.Compare(la[i].Annotations, la[j].Annotations) < 0
+	})
+}
+
+func (la labelsAndAnnotations) String() string {
+	alerts := make([]string, 0, len(la))
+	for _, a := range la {
+		alerts = append(alerts, fmt.Sprintf("{labels: %s, annotations: %s}", a.Labels, a.Annotations))
+	}
+	return "[" + strings.Join(alerts, ", ") + "]"
+}
+
+// runRuleGroupTests evaluates the metrics rule group against the input series of every test
+// group and compares the firing alerts and query results with the expected ones.
+func runRuleGroupTests(ctx context.Context, ruleGroupData, testData []byte) (*apiv2.RuleGroupTestResult, error) {
+	group := rulefmt.RuleGroup{}
+	if err := yaml.Unmarshal(ruleGroupData, &group); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal rule group data in yaml: %w", err)
+	}
+
+	testFile := unitTestFile{}
+	decoder := yaml.NewDecoder(bytes.NewReader(testData))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&testFile); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal tests in yaml: %w", err)
+	}
+	if len(testFile.Tests) == 0 {
+		return nil, errors.New("no tests defined")
+	}
+	if len(testFile.Tests) > maxTestGroups {
+		return nil, fmt.Errorf("at most %d test groups are allowed", maxTestGroups)
+	}
+	if testFile.EvaluationInterval == 0 {
+		testFile.EvaluationInterval = model.Duration(time.Minute)
+	}
+
+	var samples uint64
+	for _, tg := range testFile.Tests {
+		count, err := tg.inputSampleCount()
+		if err != nil {
+			return nil, err
+		}
+		samples += count
+		if samples > maxTestSamples {
+			return nil, fmt.Errorf("the input series expand to more than %d samples", maxTestSamples)
+		}
+	}
+
+	result := &apiv2.RuleGroupTestResult{}
+	for _, tg := range testFile.Tests {
+		failures, firing, err := tg.test(ctx, time.Duration(testFile.EvaluationInterval), group)
+		if err != nil {
+			return nil, err
+		}
+		result.Failures = append(result.Failures, failures...)
+		result.FiringAlerts = append(result.FiringAlerts, firing...)
+	}
+	result.Passed = len(result.Failures) == 0
+
+	return result, nil
+}
+
+// test runs the tests of a single test group. Errors in the test definition are returned as
+// error, failed expectations and rule evaluation errors are returned as failures.
+func (tg *testGroup) test(ctx context.Context, evalInterval time.Duration, ruleGroup rulefmt.RuleGroup) ([]string, []apiv2.RuleGroupTestAlert, error) {
+	for _, alert := range tg.AlertRuleTests {
+		if alert.Alertname == "" {
+			return nil, nil, fmt.Errorf("%san item under alert_rule_test misses required attribute alertname at eval_time %v", tg.prefix(), alert.EvalTime)
+		}
+	}
+
+	maxEvalTime := tg.maxEvalTime()
+	if int64(maxEvalTime/evalInterval) > maxTestEvaluations {
+		return nil, nil, fmt.Errorf("%sthe tests require more than %d rule evaluations, reduce the eval_time or increase the evaluation_interval", tg.prefix(), maxTestEvaluations)
+	}
+
+	inputSamples, err := tg.inputSamples()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store := newMemoryStorage()
+	engine := promql.NewEngine(promql.EngineOpts{
+		MaxSamples: maxQuerySamples,
+		Timeout:    queryTimeout,
+		NoStepSubqueryIntervalFn: func(int64) int64 {
+			return evalInterval.Milliseconds()
+		},
+		EnableAtModifier:     true,
+		EnableNegativeOffset: true,
+	})
+
+	group, err := tg.ruleGroup(ctx, engine, store, ruleGroup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alertEvalTimes, alertTests := tg.alertTestsByEvalTime()
+
+	var (
+		failures []string
+		firing   []apiv2.RuleGroupTestAlert
+		curr     int
+	)
+
+	mint := time.Unix(0, 0).UTC()
+	maxt := mint.Add(maxEvalTime)
+	for ts := mint; !ts.After(maxt); ts = ts.Add(evalInterval) {
+		// Input samples are loaded up to the evaluation time only, so that rules
+		// recording into input series do not append out-of-order samples.
+		var evalFailures []string
+		for len(inputSamples) > 0 && inputSamples[0].t <= ts.UnixMilli() {
+			if err := store.append(inputSamples[0].labels, inputSamples[0].t, inputSamples[0].v); err != nil {
+				evalFailures = append(evalFailures, fmt.Sprintf("%sfailed to load samples: %v", tg.prefix(), err))
+				break
+			}
+			inputSamples = inputSamples[1:]
+		}
+		if len(evalFailures) == 0 {
+			group.Eval(ctx, ts)
+			for _, r := range group.Rules() {
+				if r.LastError() != nil {
+					evalFailures = append(evalFailures, fmt.Sprintf("%srule: %s, time: %s, err: %v", tg.prefix(), r.Name(), ts.Sub(mint), r.LastError()))
+				}
+			}
+		}
+		// Stop testing if the rules could not be evaluated, as all following
+		// results would be unreliable.
+		if len(evalFailures) > 0 {
+			return append(failures, evalFailures...), firing, nil
+		}
+
+		// Alerts are compared with the evaluation at ts if ts <= eval_time < ts+evalInterval.
+		for curr < len(alertEvalTimes) && ts.Sub(mint) <= time.Duration(alertEvalTimes[curr]) &&
+			time.Duration(alertEvalTimes[curr]) < ts.Add(evalInterval).Sub(mint) {
+			evalTime := alertEvalTimes[curr]
+
+			got := map[string]labelsAndAnnotations{}
+			for _, r := range group.Rules() {
+				ar, ok := r.(*rules.AlertingRule)
+				if !ok {
+					continue
+				}
+
+				for _, a := range ar.ActiveAlerts() {
+					if a.State != rules.StateFiring {
+						continue
+					}
+					got[ar.Name()] = append(got[ar.Name()], labelAndAnnotation{
+						Labels:      append(labels.Labels{}, a.Labels...),
+						Annotations: append(labels.Labels{}, a.Annotations...),
+					})
+					firing = append(firing, apiv2.RuleGroupTestAlert{
+						TestGroup:   tg.TestGroupName,
+						EvalTime:    evalTime.String(),
+						Alertname:   ar.Name(),
+						Labels:      a.Labels.Map(),
+						Annotations: a.Annotations.Map(),
+					})
+				}
+			}
+
+			for _, testCase := range alertTests[evalTime] {
+				gotAlerts := got[testCase.Alertname]
+
+				var expAlerts labelsAndAnnotations
+				for _, a := range testCase.ExpAlerts {
+					// The alertname label is added by Prometheus during the evaluation,
+					// so it is not part of the expected labels.
+					expLabels := map[string]string{}
+					for k, v := range a.ExpLabels {
+						expLabels[k] = v
+					}
+					expLabels[labels.AlertName] = testCase.Alertname
+
+					expAlerts = append(expAlerts, labelAndAnnotation{
+						Labels:      labels.FromMap(expLabels),
+						Annotations: labels.FromMap(a.ExpAnnotations),
+					})
+				}
+
+				gotAlerts.sort()
+				expAlerts.sort()
+
+				if !reflect.DeepEqual(expAlerts, gotAlerts) {
+					failures = append(failures, fmt.Sprintf("%salertname: %s, time: %s, exp: %s, got: %s",
+						tg.prefix(), testCase.Alertname, evalTime, expAlerts, gotAlerts))
+				}
+			}
+
+			curr++
+		}
+	}
+
+	failures = append(failures, tg.testExpressions(ctx, engine, store, mint)...)
+
+	return failures, firing, nil
+}
+
+// testExpressions checks the results of the PromQL expression tests.
+func (tg *testGroup) testExpressions(ctx context.Context, engine *promql.Engine, store storage.Queryable, mint time.Time) []string {
+	var failures []string
+
+Outer:
+	for _, testCase := range tg.PromqlExprTests {
+		got, err := query(ctx, engine, store, testCase.Expr, mint.Add(time.Duration(testCase.EvalTime)))
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%sexpr: %q, time: %s, err: %v", tg.prefix(), testCase.Expr, testCase.EvalTime, err))
+			continue
+		}
+
+		var gotSamples []string
+		for _, s := range got {
+			gotSamples = append(gotSamples, fmt.Sprintf("%s %v", s.Metric, s.V))
+		}
+
+		var expSamples []string
+		for _, s := range testCase.ExpSamples {
+			lb, err := parser.ParseMetric(s.Labels)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%sexpr: %q, time: %s, err: labels %q: %v", tg.prefix(), testCase.Expr, testCase.EvalTime, s.Labels, err))
+				continue Outer
+			}
+			expSamples = append(expSamples, fmt.Sprintf("%s %v", lb, s.Value))
+		}
+
+		sort.Strings(gotSamples)
+		sort.Strings(expSamples)
+		if !reflect.DeepEqual(expSamples, gotSamples) {
+			failures = append(failures, fmt.Sprintf("%sexpr: %q, time: %s, exp: %v, got: %v", tg.prefix(), testCase.Expr, testCase.EvalTime, expSamples, gotSamples))
+		}
+	}
+
+	return failures
+}
+
+// ruleGroup creates the Prometheus rule group which is evaluated against the test storage.
+func (tg *testGroup) ruleGroup(ctx context.Context, engine *promql.Engine, store *memoryStorage, ruleGroup rulefmt.RuleGroup) (*rules.Group, error) {
+	externalLabels := labels.FromMap(tg.ExternalLabels)
+	logger := log.NewNopLogger()
+
+	groupRules := make([]rules.Rule, 0, len(ruleGroup.Rules))
+	for _, r := range ruleGroup.Rules {
+		expr, err := parser.ParseExpr(r.Expr.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse expression %q: %w", r.Expr.Value, err)
+		}
+
+		if r.Alert.Value != "" {
+			// Alerts are marked as restored, so that the ALERTS series are created during evaluation.
+			groupRules = append(groupRules, rules.NewAlertingRule(r.Alert.Value, expr, time.Duration(r.For),
+				labels.FromMap(r.Labels), labels.FromMap(r.Annotations), externalLabels, tg.ExternalURL, true, logger))
+			continue
+		}
+		groupRules = append(groupRules, rules.NewRecordingRule(r.Record.Value, expr, labels.FromMap(r.Labels)))
+	}
+
+	interval := time.Duration(ruleGroup.Interval)
+	if interval == 0 {
+		interval = time.Duration(tg.Interval)
+	}
+
+	return rules.NewGroup(rules.GroupOptions{
+		Name:     ruleGroup.Name,
+		Interval: interval,
+		Limit:    ruleGroup.Limit,
+		Rules:    groupRules,
+		Opts: &rules.ManagerOptions{
+			QueryFunc:  rules.EngineQueryFunc(engine, store),
+			Appendable: store,
+			Context:    ctx,
+			NotifyFunc: func(ctx context.Context, expr string, alerts ...*rules.Alert) {},
+			Logger:     logger,
+		},
+	}), nil
+}
+
+// alertTestsByEvalTime returns the sorted evaluation times of the alert tests and the tests per evaluation time.
+func (tg *testGroup) alertTestsByEvalTime() ([]model.Duration, map[model.Duration][]alertTestCase) {
+	alertTests := map[model.Duration][]alertTestCase{}
+	for _, alert := range tg.AlertRuleTests {
+		alertTests[alert.EvalTime] = append(alertTests[alert.EvalTime], alert)
+	}
+
+	evalTimes := make([]model.Duration, 0, len(alertTests))
+	for evalTime := range alertTests {
+		evalTimes = append(evalTimes, evalTime)
+	}
+	sort.Slice(evalTimes, func(i, j int) bool {
+		return evalTimes[i] < evalTimes[j]
+	})
+
+	return evalTimes, alertTests
+}
+
+// inputSamples parses the input series and returns their samples ordered by time.
+func (tg *testGroup) inputSamples() ([]memoryPendingSample, error) {
+	interval := time.Duration(tg.Interval)
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	var samples []memoryPendingSample
+	for _, is := range tg.InputSeries {
+		lset, values, err := parser.ParseSeriesDesc(is.Series + " " + is.Values)
+		if err != nil {
+			return nil, fmt.Errorf("%sinvalid input series %q: %w", tg.prefix(), is.Series, err)
+		}
+
+		for i, value := range values {
+			if value.Omitted {
+				continue
+			}
+			samples = append(samples, memoryPendingSample{
+				labels:       lset,
+				memorySample: memorySample{t: int64(i) * interval.Milliseconds(), v: value.Value},
+			})
+		}
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].t < samples[j].t
+	})
+
+	return samples, nil
+}
+
+// inputSampleCount returns the number of samples the input series expand to, without
+// expanding them.
+func (tg *testGroup) inputSampleCount() (uint64, error) {
+	var count uint64
+	for _, is := range tg.InputSeries {
+		for _, value := range strings.Fields(is.Values) {
+			n, err := expandedValueCount(value)
+			if err != nil {
+				return 0, fmt.Errorf("%sinvalid input series %q: %w", tg.prefix(), is.Series, err)
+			}
+			if n > maxTestSamples || count+n > maxTestSamples {
+				return maxTestSamples + 1, nil
+			}
+			count += n
+		}
+	}
+	return count, nil
+}
+
+// expandedValueCount returns the number of samples a single value of the expanding notation,
+// like "1+2x10" or "_x5", results in.
+func expandedValueCount(value string) (uint64, error) {
+	i := strings.LastIndex(value, "x")
+	if i < 0 {
+		return 1, nil
+	}
+	// hexadecimal values are not expanded
+	if _, err := strconv.ParseInt(strings.TrimLeft(value, "+-"), 0, 64); err == nil {
+		return 1, nil
+	}
+
+	times, err := strconv.ParseUint(value[i+1:], 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if times == math.MaxUint64 {
+		return times, nil
+	}
+	return times + 1, nil
+}
+
+// maxEvalTime returns the max eval time among all alert and PromQL unit tests.
+func (tg *testGroup) maxEvalTime() time.Duration {
+	var maxd model.Duration
+	for _, alert := range tg.AlertRuleTests {
+		if alert.EvalTime > maxd {
+			maxd = alert.EvalTime
+		}
+	}
+	for _, pet := range tg.PromqlExprTests {
+		if pet.EvalTime > maxd {
+			maxd = pet.EvalTime
+		}
+	}
+	return time.Duration(maxd)
+}
+
+func (tg *testGroup) prefix() string {
+	if tg.TestGroupName == "" {
+		return ""
+	}
+	return fmt.Sprintf("test group %s: ", tg.TestGroupName)
+}
+
+func query(ctx context.Context, engine *promql.Engine, store storage.Queryable, qs string, t time.Time) (promql.Vector, error) {
+	q, err := engine.NewInstantQuery(store, nil, qs, t)
+	if err != nil {
+		return nil, err
+	}
+	res := q.Exec(ctx)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	switch v := res.Value.(type) {
+	case promql.Vector:
+		return v, nil
+	case promql.Scalar:
+		return promql.Vector{promql.Sample{
+			Point:  promql.Point(v),
+			Metric: labels.Labels{},
+		}}, nil
+	default:
+		return nil, errors.New("rule result is not a vector or scalar")
+	}
+}
@@ -29,6 +29,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/rulegroup"
 	utilerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
 )
 
 // getReq defines HTTP request for getting ruleGroup
@@ -82,6 +83,9 @@ func (req createReq) GetSeedCluster() apiv1.SeedCluster {
 }
 
 func (req createReq) validate() (ruleGroupName string, err error) {
+	if err := validation.ValidateRuleGroup(req.Body.Type, req.Body.Data); err != nil {
+		return "", err
+	}
 	return rulegroup.GetRuleGroupNameInData(req.Body.Data)
 }
 
@@ -106,6 +110,9 @@ func (req updateReq) GetSeedCluster() apiv1.SeedCluster {
 }
 
 func (req updateReq) validate() error {
+	if err := validation.ValidateRuleGroup(req.Body.Type, req.Body.Data); err != nil {
+		return err
+	}
 	ruleGroupNameInData, err := rulegroup.GetRuleGroupNameInData(req.Body.Data)
 	if err != nil {
 		return err
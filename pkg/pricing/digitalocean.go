@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+	"golang.org/x/oauth2"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/digitalocean"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// digitaloceanCurrency is the currency of all DigitalOcean prices.
+const digitaloceanCurrency = "USD"
+
+// digitaloceanCatalog fetches the prices of droplet sizes that are available in the region of the datacenter.
+type digitaloceanCatalog struct {
+	dc        *kubermaticv1.DatacenterSpecDigitalocean
+	cache     *cache.Expiring
+	newClient func(ctx context.Context) (*godo.Client, error)
+}
+
+func newDigitaloceanCatalog(dc *kubermaticv1.DatacenterSpecDigitalocean, cloud kubermaticv1.CloudSpec, secretKeyGetter provider.SecretKeySelectorValueFunc) *digitaloceanCatalog {
+	return &digitaloceanCatalog{
+		dc:    dc,
+		cache: priceListCache,
+		newClient: func(ctx context.Context) (*godo.Client, error) {
+			token, err := digitalocean.GetCredentialsForCluster(cloud, secretKeyGetter)
+			if err != nil {
+				return nil, err
+			}
+			static := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+			return godo.NewClient(oauth2.NewClient(ctx, static)), nil
+		},
+	}
+}
+
+func (c *digitaloceanCatalog) MachinePrice(ctx context.Context, machineType string) (*Price, error) {
+	prices, err := cachedPriceList(c.cache, "digitalocean/"+c.dc.Region, func() (priceList, error) {
+		return c.fetchPriceList(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	monthly, ok := prices[machineType]
+	if !ok {
+		return nil, nil
+	}
+
+	return &Price{Currency: digitaloceanCurrency, Monthly: monthly}, nil
+}
+
+// fetchPriceList fetches the prices of all sizes that are available in the region of the datacenter.
+func (c *digitaloceanCatalog) fetchPriceList(ctx context.Context) (priceList, error) {
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := priceList{}
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		sizes, resp, err := client.Sizes.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sizes: %w", err)
+		}
+
+		for _, size := range sizes {
+			for _, region := range size.Regions {
+				if region == c.dc.Region {
+					prices[size.Slug] = size.PriceMonthly
+					break
+				}
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			return prices, nil
+		}
+		opts.Page++
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	machineconversions "k8c.io/kubermatic/v2/pkg/machine"
+)
+
+var (
+	// ErrUnknownMachineType is returned when no machine type can be determined for a node spec.
+	ErrUnknownMachineType = errors.New("the machine type cannot be determined for this provider")
+	// ErrNoPrice is returned when the catalog does not know the price of a machine type.
+	ErrNoPrice = errors.New("no price is known for the machine type")
+)
+
+// Estimate is the estimated monthly cost of a cluster.
+type Estimate struct {
+	// Currency of all amounts; empty if nothing could be priced.
+	Currency string
+	// ControlPlane is the monthly cost of the control plane.
+	ControlPlane float64
+	// MachineDeployments are the estimates of the individual MachineDeployments.
+	MachineDeployments []MachineDeploymentEstimate
+	// Monthly is the total monthly cost.
+	Monthly float64
+}
+
+// MachineDeploymentEstimate is the estimated monthly cost of a MachineDeployment.
+type MachineDeploymentEstimate struct {
+	Name        string
+	MachineType string
+	Replicas    int32
+	// PricePerMachine is nil if the price of the machine type is unknown, in
+	// which case the MachineDeployment is not part of the total.
+	PricePerMachine *Price
+	// Monthly is the monthly cost of all replicas.
+	Monthly float64
+}
+
+// MachineType returns the machine type (e.g. the instance type, size or flavor)
+// that prices are looked up by, or an empty string if the provider does not
+// have machine types.
+func MachineType(spec apiv1.NodeCloudSpec) string {
+	switch {
+	case spec.AWS != nil:
+		return spec.AWS.InstanceType
+	case spec.Azure != nil:
+		return spec.Azure.Size
+	case spec.Digitalocean != nil:
+		return spec.Digitalocean.Size
+	case spec.GCP != nil:
+		return spec.GCP.MachineType
+	case spec.Hetzner != nil:
+		return spec.Hetzner.Type
+	case spec.Openstack != nil:
+		return spec.Openstack.Flavor
+	case spec.Packet != nil:
+		return spec.Packet.InstanceType
+	case spec.Alibaba != nil:
+		return spec.Alibaba.InstanceType
+	}
+
+	return ""
+}
+
+// EstimateMachineDeployment estimates the monthly cost of a MachineDeployment
+// with the given node spec and number of replicas. An error wrapping
+// ErrUnknownMachineType or ErrNoPrice is returned if it cannot be priced.
+func EstimateMachineDeployment(ctx context.Context, catalog Catalog, name string, spec apiv1.NodeCloudSpec, replicas int32) (*MachineDeploymentEstimate, error) {
+	machineType := MachineType(spec)
+	if machineType == "" {
+		return nil, ErrUnknownMachineType
+	}
+
+	price, err := catalog.MachinePrice(ctx, machineType)
+	if err != nil {
+		return nil, err
+	}
+	if price == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNoPrice, machineType)
+	}
+
+	return &MachineDeploymentEstimate{
+		Name:            name,
+		MachineType:     machineType,
+		Replicas:        replicas,
+		PricePerMachine: price,
+		Monthly:         roundCents(price.Monthly * float64(replicas)),
+	}, nil
+}
+
+// EstimateCluster estimates the monthly cost of a cluster in the given datacenter
+// with the given MachineDeployments, including the control plane overhead.
+// MachineDeployments that cannot be priced are listed without price and are
+// not part of the total.
+func EstimateCluster(ctx context.Context, catalog Catalog, dc *kubermaticv1.Datacenter, machineDeployments []clusterv1alpha1.MachineDeployment) (*Estimate, error) {
+	estimate := &Estimate{
+		MachineDeployments: []MachineDeploymentEstimate{},
+	}
+
+	add := func(price Price, amount float64) error {
+		if estimate.Currency == "" {
+			estimate.Currency = price.Currency
+		}
+		if estimate.Currency != price.Currency {
+			return fmt.Errorf("prices use different currencies (%s and %s)", estimate.Currency, price.Currency)
+		}
+		estimate.Monthly = roundCents(estimate.Monthly + amount)
+		return nil
+	}
+
+	controlPlane, err := ControlPlanePrice(dc)
+	if err != nil {
+		return nil, err
+	}
+	if controlPlane != nil {
+		estimate.ControlPlane = controlPlane.Monthly
+		if err := add(*controlPlane, controlPlane.Monthly); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, md := range machineDeployments {
+		replicas := int32(0)
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+
+		cloudSpec, err := machineconversions.GetAPIV2NodeCloudSpec(md.Spec.Template.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read providerSpec of MachineDeployment %q: %w", md.Name, err)
+		}
+
+		mdEstimate, err := EstimateMachineDeployment(ctx, catalog, md.Name, *cloudSpec, replicas)
+		if err != nil {
+			if !errors.Is(err, ErrUnknownMachineType) && !errors.Is(err, ErrNoPrice) {
+				return nil, err
+			}
+			estimate.MachineDeployments = append(estimate.MachineDeployments, MachineDeploymentEstimate{
+				Name:        md.Name,
+				MachineType: MachineType(*cloudSpec),
+				Replicas:    replicas,
+			})
+			continue
+		}
+
+		if err := add(*mdEstimate.PricePerMachine, mdEstimate.Monthly); err != nil {
+			return nil, err
+		}
+		estimate.MachineDeployments = append(estimate.MachineDeployments, *mdEstimate)
+	}
+
+	return estimate, nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/hetzner"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// hetznerCurrency is the currency of all Hetzner prices; the server type
+// prices returned by the API do not carry it.
+const hetznerCurrency = "EUR"
+
+// hetznerCatalog fetches the net prices of server types in the location of the datacenter.
+type hetznerCatalog struct {
+	dc        *kubermaticv1.DatacenterSpecHetzner
+	cache     *cache.Expiring
+	newClient func() (*hcloud.Client, error)
+}
+
+func newHetznerCatalog(dc *kubermaticv1.DatacenterSpecHetzner, cloud kubermaticv1.CloudSpec, secretKeyGetter provider.SecretKeySelectorValueFunc) *hetznerCatalog {
+	return &hetznerCatalog{
+		dc:    dc,
+		cache: priceListCache,
+		newClient: func() (*hcloud.Client, error) {
+			token, err := hetzner.GetCredentialsForCluster(cloud, secretKeyGetter)
+			if err != nil {
+				return nil, err
+			}
+			return hcloud.NewClient(hcloud.WithToken(token)), nil
+		},
+	}
+}
+
+func (c *hetznerCatalog) MachinePrice(ctx context.Context, machineType string) (*Price, error) {
+	prices, err := cachedPriceList(c.cache, "hetzner/"+c.dc.Datacenter, func() (priceList, error) {
+		return c.fetchPriceList(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	monthly, ok := prices[machineType]
+	if !ok {
+		return nil, nil
+	}
+
+	return &Price{Currency: hetznerCurrency, Monthly: monthly}, nil
+}
+
+// fetchPriceList fetches the prices of all server types in the location of the datacenter.
+func (c *hetznerCatalog) fetchPriceList(ctx context.Context) (priceList, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	datacenter, _, err := client.Datacenter.GetByName(ctx, c.dc.Datacenter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get datacenter %q: %w", c.dc.Datacenter, err)
+	}
+	if datacenter == nil || datacenter.Location == nil {
+		return nil, fmt.Errorf("datacenter %q does not exist", c.dc.Datacenter)
+	}
+
+	serverTypes, err := client.ServerType.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server types: %w", err)
+	}
+
+	prices := priceList{}
+	for _, serverType := range serverTypes {
+		for _, pricing := range serverType.Pricings {
+			if pricing.Location == nil || pricing.Location.Name != datacenter.Location.Name {
+				continue
+			}
+
+			monthly, err := strconv.ParseFloat(pricing.Monthly.Net, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid price %q for server type %q: %w", pricing.Monthly.Net, serverType.Name, err)
+			}
+			prices[serverType.Name] = monthly
+		}
+	}
+
+	return prices, nil
+}
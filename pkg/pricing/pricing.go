@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing estimates the monthly cost of clusters and MachineDeployments.
+// Prices are looked up in catalogs: the static prices configured on the
+// datacenter take precedence over prices fetched from the cloud provider API,
+// which are cached for priceListTTL.
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// priceListTTL is how long the price lists fetched from the cloud provider APIs
+// are cached. Prices rarely change, but cluster costs are estimated on every
+// reconciliation of the resource quotas.
+const priceListTTL = 6 * time.Hour
+
+// priceListCache holds the price lists of the provider API catalogs. Prices do
+// not depend on the account, so they are cached by provider and location.
+var priceListCache = cache.NewExpiring()
+
+// priceList maps machine types to their monthly price.
+type priceList map[string]float64
+
+// cachedPriceList returns the cached price list for the key or fetches and caches it.
+func cachedPriceList(c *cache.Expiring, key string, fetch func() (priceList, error)) (priceList, error) {
+	if prices, ok := c.Get(key); ok {
+		return prices.(priceList), nil
+	}
+
+	prices, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.Set(key, prices, priceListTTL)
+
+	return prices, nil
+}
+
+// Price is the monthly price of a single machine or control plane.
+type Price struct {
+	Currency string
+	Monthly  float64
+}
+
+// Catalog provides the prices of machine types.
+type Catalog interface {
+	// MachinePrice returns the monthly price of a machine of the given type,
+	// or nil if the catalog does not know the type.
+	MachinePrice(ctx context.Context, machineType string) (*Price, error)
+}
+
+// NewCatalog returns the catalog for the datacenter of the given cluster.
+func NewCatalog(dc *kubermaticv1.Datacenter, cluster *kubermaticv1.Cluster, secretKeyGetter provider.SecretKeySelectorValueFunc) Catalog {
+	catalogs := chainedCatalog{}
+
+	if dc.Spec.Pricing != nil {
+		catalogs = append(catalogs, &staticCatalog{pricing: dc.Spec.Pricing})
+	}
+
+	// Prices in different currencies can not be summed up, so the prices of the provider API are only used if they
+	// are in the currency of the static prices of the datacenter.
+	switch {
+	case dc.Spec.Hetzner != nil && cluster.Spec.Cloud.Hetzner != nil && matchesCurrency(dc, hetznerCurrency):
+		catalogs = append(catalogs, newHetznerCatalog(dc.Spec.Hetzner, cluster.Spec.Cloud, secretKeyGetter))
+	case dc.Spec.Digitalocean != nil && cluster.Spec.Cloud.Digitalocean != nil && matchesCurrency(dc, digitaloceanCurrency):
+		catalogs = append(catalogs, newDigitaloceanCatalog(dc.Spec.Digitalocean, cluster.Spec.Cloud, secretKeyGetter))
+	}
+
+	return catalogs
+}
+
+func matchesCurrency(dc *kubermaticv1.Datacenter, currency string) bool {
+	return dc.Spec.Pricing == nil || dc.Spec.Pricing.Currency == currency
+}
+
+// ControlPlanePrice returns the monthly price of the control plane of a cluster
+// in the given datacenter, or nil if none is configured.
+func ControlPlanePrice(dc *kubermaticv1.Datacenter) (*Price, error) {
+	if dc.Spec.Pricing == nil || dc.Spec.Pricing.ControlPlane == "" {
+		return nil, nil
+	}
+
+	if dc.Spec.Pricing.Currency == "" {
+		return nil, fmt.Errorf("no currency is configured for the control plane price")
+	}
+
+	monthly, err := parsePrice(dc.Spec.Pricing.ControlPlane)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control plane price: %w", err)
+	}
+
+	return &Price{Currency: dc.Spec.Pricing.Currency, Monthly: monthly}, nil
+}
+
+func parsePrice(price string) (float64, error) {
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("price %q must not be negative", price)
+	}
+
+	return value, nil
+}
+
+// chainedCatalog returns the price from the first catalog that knows the machine type.
+type chainedCatalog []Catalog
+
+func (c chainedCatalog) MachinePrice(ctx context.Context, machineType string) (*Price, error) {
+	for _, catalog := range c {
+		price, err := catalog.MachinePrice(ctx, machineType)
+		if err != nil {
+			return nil, err
+		}
+		if price != nil {
+			return price, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// staticCatalog provides the prices configured on the datacenter.
+type staticCatalog struct {
+	pricing *kubermaticv1.DatacenterPricing
+}
+
+func (c *staticCatalog) MachinePrice(_ context.Context, machineType string) (*Price, error) {
+	price, ok := c.pricing.MachineTypes[machineType]
+	if !ok {
+		return nil, nil
+	}
+
+	monthly, err := parsePrice(price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price for machine type %q: %w", machineType, err)
+	}
+
+	return &Price{Currency: c.pricing.Currency, Monthly: monthly}, nil
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/pointer"
+)
+
+func hetznerMachineDeployment(name string, serverType string, replicas int32) clusterv1alpha1.MachineDeployment {
+	md := clusterv1alpha1.MachineDeployment{}
+	md.Name = name
+	md.Spec.Replicas = pointer.Int32(replicas)
+	md.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{
+		Raw: []byte(`{"cloudProvider":"hetzner","cloudProviderSpec":{"serverType":"` + serverType + `"},"operatingSystem":"ubuntu","operatingSystemSpec":{}}`),
+	}
+
+	return md
+}
+
+func TestEstimateCluster(t *testing.T) {
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Pricing: &kubermaticv1.DatacenterPricing{
+				Currency:     "EUR",
+				ControlPlane: "10",
+				MachineTypes: map[string]string{
+					"cx21": "5.83",
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		catalog            Catalog
+		machineDeployments []clusterv1alpha1.MachineDeployment
+		expectedMonthly    float64
+		expectedPriced     []bool
+		expectErr          bool
+	}{
+		{
+			name:    "static prices",
+			catalog: &staticCatalog{pricing: dc.Spec.Pricing},
+			machineDeployments: []clusterv1alpha1.MachineDeployment{
+				hetznerMachineDeployment("workers", "cx21", 3),
+			},
+			expectedMonthly: 27.49,
+			expectedPriced:  []bool{true},
+		},
+		{
+			name:    "unknown machine types are not part of the total",
+			catalog: &staticCatalog{pricing: dc.Spec.Pricing},
+			machineDeployments: []clusterv1alpha1.MachineDeployment{
+				hetznerMachineDeployment("workers", "cx21", 1),
+				hetznerMachineDeployment("big-workers", "cx51", 2),
+			},
+			expectedMonthly: 15.83,
+			expectedPriced:  []bool{true, false},
+		},
+		{
+			name: "static prices take precedence",
+			catalog: chainedCatalog{
+				&staticCatalog{pricing: dc.Spec.Pricing},
+				&staticCatalog{pricing: &kubermaticv1.DatacenterPricing{
+					Currency:     "EUR",
+					MachineTypes: map[string]string{"cx21": "100", "cx51": "20"},
+				}},
+			},
+			machineDeployments: []clusterv1alpha1.MachineDeployment{
+				hetznerMachineDeployment("workers", "cx21", 1),
+				hetznerMachineDeployment("big-workers", "cx51", 2),
+			},
+			expectedMonthly: 55.83,
+			expectedPriced:  []bool{true, true},
+		},
+		{
+			name: "mixed currencies",
+			catalog: &staticCatalog{pricing: &kubermaticv1.DatacenterPricing{
+				Currency:     "USD",
+				MachineTypes: map[string]string{"cx21": "6"},
+			}},
+			machineDeployments: []clusterv1alpha1.MachineDeployment{
+				hetznerMachineDeployment("workers", "cx21", 1),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			estimate, err := EstimateCluster(context.Background(), test.catalog, dc, test.machineDeployments)
+			if test.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to estimate cluster: %v", err)
+			}
+
+			if estimate.Currency != "EUR" {
+				t.Errorf("expected currency EUR, got %q", estimate.Currency)
+			}
+			if estimate.ControlPlane != 10 {
+				t.Errorf("expected control plane cost of 10, got %v", estimate.ControlPlane)
+			}
+			if estimate.Monthly != test.expectedMonthly {
+				t.Errorf("expected monthly cost of %v, got %v", test.expectedMonthly, estimate.Monthly)
+			}
+			if len(estimate.MachineDeployments) != len(test.expectedPriced) {
+				t.Fatalf("expected %d MachineDeployment estimates, got %d", len(test.expectedPriced), len(estimate.MachineDeployments))
+			}
+			for i, priced := range test.expectedPriced {
+				if (estimate.MachineDeployments[i].PricePerMachine != nil) != priced {
+					t.Errorf("expected MachineDeployment %q to be priced: %v", estimate.MachineDeployments[i].Name, priced)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateMachineDeployment(t *testing.T) {
+	catalog := &staticCatalog{pricing: &kubermaticv1.DatacenterPricing{
+		Currency:     "EUR",
+		MachineTypes: map[string]string{"cx21": "5.83"},
+	}}
+
+	_, err := EstimateMachineDeployment(context.Background(), catalog, "workers", apiv1.NodeCloudSpec{Hetzner: &apiv1.HetznerNodeSpec{Type: "cx51"}}, 1)
+	if !errors.Is(err, ErrNoPrice) {
+		t.Errorf("expected ErrNoPrice for an unknown machine type, got %v", err)
+	}
+
+	_, err = EstimateMachineDeployment(context.Background(), catalog, "workers", apiv1.NodeCloudSpec{VSphere: &apiv1.VSphereNodeSpec{}}, 1)
+	if !errors.Is(err, ErrUnknownMachineType) {
+		t.Errorf("expected ErrUnknownMachineType for a provider without machine types, got %v", err)
+	}
+}
+
+func TestHetznerCatalog(t *testing.T) {
+	serverTypeRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+
+		switch r.URL.Path {
+		case "/datacenters":
+			response = map[string]interface{}{
+				"datacenters": []interface{}{
+					map[string]interface{}{
+						"id":       1,
+						"name":     "fsn1-dc14",
+						"location": map[string]interface{}{"id": 1, "name": "fsn1"},
+					},
+				},
+			}
+		case "/server_types":
+			serverTypeRequests++
+			response = map[string]interface{}{
+				"server_types": []interface{}{
+					map[string]interface{}{
+						"id":   1,
+						"name": "cx21",
+						"prices": []interface{}{
+							map[string]interface{}{
+								"location":      "nbg1",
+								"price_monthly": map[string]string{"net": "4.00", "gross": "4.76"},
+							},
+							map[string]interface{}{
+								"location":      "fsn1",
+								"price_monthly": map[string]string{"net": "5.83", "gross": "6.94"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"id":   2,
+						"name": "cx31",
+						"prices": []interface{}{
+							map[string]interface{}{
+								"location":      "nbg1",
+								"price_monthly": map[string]string{"net": "8.00", "gross": "9.52"},
+							},
+						},
+					},
+				},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	catalog := &hetznerCatalog{
+		dc:    &kubermaticv1.DatacenterSpecHetzner{Datacenter: "fsn1-dc14"},
+		cache: cache.NewExpiring(),
+		newClient: func() (*hcloud.Client, error) {
+			return hcloud.NewClient(hcloud.WithEndpoint(server.URL)), nil
+		},
+	}
+
+	price, err := catalog.MachinePrice(context.Background(), "cx21")
+	if err != nil {
+		t.Fatalf("failed to get price: %v", err)
+	}
+	if price == nil || price.Monthly != 5.83 || price.Currency != "EUR" {
+		t.Errorf("expected a price of 5.83 EUR, got %+v", price)
+	}
+
+	price, err = catalog.MachinePrice(context.Background(), "cx51")
+	if err != nil {
+		t.Fatalf("failed to get price: %v", err)
+	}
+	if price != nil {
+		t.Errorf("expected no price for an unknown server type, got %+v", price)
+	}
+
+	price, err = catalog.MachinePrice(context.Background(), "cx31")
+	if err != nil {
+		t.Fatalf("failed to get price: %v", err)
+	}
+	if price != nil {
+		t.Errorf("expected no price for a server type that is not available in the location, got %+v", price)
+	}
+
+	if serverTypeRequests != 1 {
+		t.Errorf("expected the server types to be fetched once, but they were fetched %d times", serverTypeRequests)
+	}
+}
+
+func TestNewCatalog(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{}
+	cluster.Spec.Cloud.Hetzner = &kubermaticv1.HetznerCloudSpec{}
+
+	testCases := []struct {
+		name             string
+		pricing          *kubermaticv1.DatacenterPricing
+		expectedCatalogs int
+	}{
+		{
+			name:             "provider prices without static prices",
+			expectedCatalogs: 1,
+		},
+		{
+			name:             "static prices in the currency of the provider",
+			pricing:          &kubermaticv1.DatacenterPricing{Currency: "EUR"},
+			expectedCatalogs: 2,
+		},
+		{
+			name:             "provider prices are not used with static prices in another currency",
+			pricing:          &kubermaticv1.DatacenterPricing{Currency: "USD"},
+			expectedCatalogs: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			dc := &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{
+					Hetzner: &kubermaticv1.DatacenterSpecHetzner{Datacenter: "fsn1-dc14"},
+					Pricing: test.pricing,
+				},
+			}
+
+			catalogs, ok := NewCatalog(dc, cluster, nil).(chainedCatalog)
+			if !ok {
+				t.Fatal("expected a chained catalog")
+			}
+			if len(catalogs) != test.expectedCatalogs {
+				t.Errorf("expected %d catalogs, got %d", test.expectedCatalogs, len(catalogs))
+			}
+		})
+	}
+}
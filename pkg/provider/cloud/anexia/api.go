@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anexia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.anx.io/go-anxcloud/pkg/client"
+	"go.anx.io/go-anxcloud/pkg/core/tags"
+	"go.anx.io/go-anxcloud/pkg/ipam/prefix"
+	"go.anx.io/go-anxcloud/pkg/vlan"
+)
+
+const (
+	statusActive = "Active"
+
+	prefixIPVersion4 = 4
+	defaultListLimit = 100
+)
+
+// networkAPI bundles the parts of the Anexia Engine API that are needed to
+// manage the network resources of a cluster.
+type networkAPI struct {
+	vlan   vlan.API
+	prefix prefix.API
+	tags   tags.API
+}
+
+func newNetworkAPI(cli client.Client) *networkAPI {
+	return &networkAPI{
+		vlan:   vlan.NewAPI(cli),
+		prefix: prefix.NewAPI(cli),
+		tags:   tags.NewAPI(cli),
+	}
+}
+
+// findVLAN returns the ID of the VLAN whose customer description matches
+// exactly, or an empty string if there is none.
+func (a *networkAPI) findVLAN(ctx context.Context, description string) (string, error) {
+	vlans, err := a.vlan.List(ctx, 1, defaultListLimit, description)
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range vlans {
+		if v.Description == description {
+			return v.Identifier, nil
+		}
+	}
+
+	return "", nil
+}
+
+// findPrefix returns the ID of the prefix whose customer description matches
+// exactly, or an empty string if there is none. Prefixes can not be searched,
+// so all pages are listed.
+func (a *networkAPI) findPrefix(ctx context.Context, description string) (string, error) {
+	for page := 1; ; page++ {
+		prefixes, err := a.prefix.List(ctx, page, defaultListLimit)
+		if err != nil {
+			return "", err
+		}
+
+		for _, p := range prefixes {
+			if p.Description == description {
+				return p.ID, nil
+			}
+		}
+
+		if len(prefixes) < defaultListLimit {
+			return "", nil
+		}
+	}
+}
+
+func (a *networkAPI) tag(ctx context.Context, name, serviceID string) error {
+	_, err := a.tags.Create(ctx, tags.Create{Name: name, ServiceID: serviceID})
+	return err
+}
+
+func isNotFound(err error) bool {
+	var responseErr *client.ResponseError
+	return errors.As(err, &responseErr) && responseErr.Response != nil && responseErr.Response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anexia
+
+import (
+	"context"
+	"fmt"
+
+	"go.anx.io/go-anxcloud/pkg/ipam/prefix"
+	"go.anx.io/go-anxcloud/pkg/vlan"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func resourceName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name
+}
+
+func reconcileVLAN(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, api *networkAPI, locationID string) (*kubermaticv1.Cluster, error) {
+	vlanID := cluster.Spec.Cloud.Anexia.VLANID
+
+	if vlanID != "" {
+		info, err := api.vlan.Get(ctx, vlanID)
+		switch {
+		case err == nil:
+			return cluster, ensureActive("VLAN", vlanID, info.Status)
+		case !isNotFound(err):
+			return nil, fmt.Errorf("failed to get VLAN %s: %w", vlanID, err)
+		case !kuberneteshelper.HasFinalizer(cluster, VLANCleanupFinalizer):
+			return nil, fmt.Errorf("VLAN %s does not exist", vlanID)
+		}
+		// the VLAN we created for this cluster is gone, create a new one
+	}
+
+	name := resourceName(cluster)
+
+	// a previous reconciliation might have created the VLAN, but failed to record its ID
+	vlanID, err := api.findVLAN(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VLANs: %w", err)
+	}
+
+	if vlanID == "" {
+		created, err := api.vlan.Create(ctx, vlan.CreateDefinition{
+			Location:            locationID,
+			VMProvisioning:      true,
+			CustomerDescription: name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create VLAN: %w", err)
+		}
+		vlanID = created.Identifier
+
+		if err := api.tag(ctx, name, vlanID); err != nil {
+			return nil, fmt.Errorf("failed to tag VLAN %s: %w", vlanID, err)
+		}
+	}
+
+	cluster, err = update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Anexia.VLANID = vlanID
+		kuberneteshelper.AddFinalizer(cluster, VLANCleanupFinalizer)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add VLAN ID to cluster: %w", err)
+	}
+
+	info, err := api.vlan.Get(ctx, vlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VLAN %s: %w", vlanID, err)
+	}
+
+	return cluster, ensureActive("VLAN", vlanID, info.Status)
+}
+
+func reconcilePrefix(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, api *networkAPI, locationID string, netmask int) (*kubermaticv1.Cluster, error) {
+	prefixID := cluster.Spec.Cloud.Anexia.PrefixID
+
+	if prefixID != "" {
+		info, err := api.prefix.Get(ctx, prefixID)
+		switch {
+		case err == nil:
+			return cluster, ensureActive("prefix", prefixID, info.Status)
+		case !isNotFound(err):
+			return nil, fmt.Errorf("failed to get prefix %s: %w", prefixID, err)
+		case !kuberneteshelper.HasFinalizer(cluster, PrefixCleanupFinalizer):
+			return nil, fmt.Errorf("prefix %s does not exist", prefixID)
+		}
+		// the prefix we created for this cluster is gone, create a new one
+	} else if !kuberneteshelper.HasFinalizer(cluster, VLANCleanupFinalizer) {
+		// the VLAN was provided by the user, who is then also responsible
+		// for assigning a prefix to it
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+
+	prefixID, err := api.findPrefix(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prefixes: %w", err)
+	}
+
+	if prefixID == "" {
+		create := prefix.NewCreate(locationID, cluster.Spec.Cloud.Anexia.VLANID, prefixIPVersion4, prefix.TypePrivate, netmask)
+		create.EnableVMProvisioning = true
+		create.CustomerDescription = name
+
+		created, err := api.prefix.Create(ctx, create)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prefix: %w", err)
+		}
+		prefixID = created.ID
+
+		if err := api.tag(ctx, name, prefixID); err != nil {
+			return nil, fmt.Errorf("failed to tag prefix %s: %w", prefixID, err)
+		}
+	}
+
+	cluster, err = update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Anexia.PrefixID = prefixID
+		kuberneteshelper.AddFinalizer(cluster, PrefixCleanupFinalizer)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add prefix ID to cluster: %w", err)
+	}
+
+	info, err := api.prefix.Get(ctx, prefixID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prefix %s: %w", prefixID, err)
+	}
+
+	return cluster, ensureActive("prefix", prefixID, info.Status)
+}
+
+func deletePrefix(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, api *networkAPI) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, PrefixCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	if prefixID := cluster.Spec.Cloud.Anexia.PrefixID; prefixID != "" {
+		if err := api.prefix.Delete(ctx, prefixID); err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("failed to delete prefix %s: %w", prefixID, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, PrefixCleanupFinalizer)
+	})
+}
+
+func deleteVLAN(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, api *networkAPI) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, VLANCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	if vlanID := cluster.Spec.Cloud.Anexia.VLANID; vlanID != "" {
+		if err := api.vlan.Delete(ctx, vlanID); err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("failed to delete VLAN %s: %w", vlanID, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, VLANCleanupFinalizer)
+	})
+}
+
+// ensureActive returns an error if the resource is still being provisioned,
+// so that the reconciliation is retried later.
+func ensureActive(kind, id, status string) error {
+	if status != "" && status != statusActive {
+		return fmt.Errorf("%s %s is not active yet (status %q)", kind, id, status)
+	}
+
+	return nil
+}
@@ -19,21 +19,33 @@ package anexia
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.anx.io/go-anxcloud/pkg/client"
 	"go.anx.io/go-anxcloud/pkg/vsphere/provisioning/templates"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+const (
+	VLANCleanupFinalizer   = "kubermatic.k8c.io/cleanup-anexia-vlan"
+	PrefixCleanupFinalizer = "kubermatic.k8c.io/cleanup-anexia-prefix"
+
+	resourceNamePrefix   = "kubernetes-cluster-"
+	defaultPrefixNetmask = 24
+)
+
 type Anexia struct {
 	dc                *kubermaticv1.DatacenterSpecAnexia
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	// newClient creates the Anexia API client for a token; overridden in tests.
+	newClient func(token string) (client.Client, error)
 }
 
-var _ provider.CloudProvider = &Anexia{}
+var _ provider.ReconcilingCloudProvider = &Anexia{}
 
 func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (*Anexia, error) {
 	if dc.Spec.Anexia == nil {
@@ -42,6 +54,7 @@ func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.Secr
 	return &Anexia{
 		dc:                dc.Spec.Anexia,
 		secretKeySelector: secretKeyGetter,
+		newClient:         getClient,
 	}, nil
 }
 
@@ -55,12 +68,67 @@ func (a *Anexia) ValidateCloudSpec(_ context.Context, spec kubermaticv1.CloudSpe
 	return err
 }
 
-func (a *Anexia) InitializeCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+// InitializeCloudProvider creates the VLAN and prefix for the cluster.
+func (a *Anexia) InitializeCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return a.reconcileCluster(ctx, cluster, update)
+}
+
+// ReconcileCluster enforces the existence of the VLAN and prefix of the cluster.
+func (a *Anexia) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return a.reconcileCluster(ctx, cluster, update)
+}
+
+func (a *Anexia) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	api, err := a.getNetworkAPI(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err = reconcileVLAN(ctx, cluster, update, api, a.dc.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	netmask := a.dc.PrefixNetmask
+	if netmask == 0 {
+		netmask = defaultPrefixNetmask
+	}
+
+	return reconcilePrefix(ctx, cluster, update, api, a.dc.LocationID, netmask)
 }
 
-func (a *Anexia) CleanUpCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+// CleanUpCloudProvider removes the prefix and VLAN that were created for the cluster.
+func (a *Anexia) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasAnyFinalizer(cluster, PrefixCleanupFinalizer, VLANCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	api, err := a.getNetworkAPI(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	// the prefix has to be removed before the VLAN it is assigned to
+	cluster, err = deletePrefix(ctx, cluster, update, api)
+	if err != nil {
+		return nil, err
+	}
+
+	return deleteVLAN(ctx, cluster, update, api)
+}
+
+func (a *Anexia) getNetworkAPI(cloud kubermaticv1.CloudSpec) (*networkAPI, error) {
+	token, err := GetCredentialsForCluster(cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := a.newClient(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Anexia client: %w", err)
+	}
+
+	return newNetworkAPI(cli), nil
 }
 
 func (a *Anexia) ValidateCloudSpecUpdate(_ context.Context, _ kubermaticv1.CloudSpec, _ kubermaticv1.CloudSpec) error {
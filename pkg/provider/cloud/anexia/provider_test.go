@@ -0,0 +1,462 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anexia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.anx.io/go-anxcloud/pkg/client"
+	"go.anx.io/go-anxcloud/pkg/core/tags"
+	"go.anx.io/go-anxcloud/pkg/ipam/prefix"
+	"go.anx.io/go-anxcloud/pkg/vlan"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testLocationID = "location-1"
+	testToken      = "test-token"
+
+	vlanEndpoint   = "/api/vlan/v1/vlan.json"
+	prefixEndpoint = "/api/ipam/v1/prefix.json"
+	tagsEndpoint   = "/api/core/v1/tags.json"
+)
+
+// engineResource is the subset of fields shared by VLANs and prefixes
+// in the responses of the Anexia Engine API.
+type engineResource struct {
+	Identifier          string `json:"identifier"`
+	CustomerDescription string `json:"description_customer"`
+	Status              string `json:"status"`
+}
+
+// fakeEngine is a minimal in-memory implementation of the Anexia Engine API
+// endpoints for VLANs, prefixes and tags.
+type fakeEngine struct {
+	lock sync.Mutex
+
+	// status is assigned to newly created resources
+	status   string
+	counter  int
+	vlans    map[string]*engineResource
+	prefixes map[string]*prefix.Create
+	tags     map[string]string
+	requests map[string]int
+}
+
+func newFakeEngine(status string) *fakeEngine {
+	return &fakeEngine{
+		status:   status,
+		vlans:    map[string]*engineResource{},
+		prefixes: map[string]*prefix.Create{},
+		tags:     map[string]string{},
+		requests: map[string]int{},
+	}
+}
+
+func (f *fakeEngine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Token "+testToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f.requests[r.Method+" "+r.URL.Path]++
+
+	switch {
+	case r.URL.Path == vlanEndpoint:
+		f.handleCollection(w, r, func(body []byte) (*engineResource, error) {
+			req := vlan.CreateDefinition{}
+			if err := json.Unmarshal(body, &req); err != nil {
+				return nil, err
+			}
+			created := &engineResource{CustomerDescription: req.CustomerDescription}
+			f.vlans[f.nextID(created, "vlan")] = created
+			return created, nil
+		}, f.vlanList())
+
+	case strings.HasPrefix(r.URL.Path, vlanEndpoint+"/"):
+		id := strings.TrimPrefix(r.URL.Path, vlanEndpoint+"/")
+		resource, ok := f.vlans[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.vlans, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, resource)
+
+	case r.URL.Path == prefixEndpoint:
+		f.handleCollection(w, r, func(body []byte) (*engineResource, error) {
+			req := &prefix.Create{}
+			if err := json.Unmarshal(body, req); err != nil {
+				return nil, err
+			}
+			if _, ok := f.vlans[req.VLANID]; !ok {
+				return nil, fmt.Errorf("unknown VLAN %q", req.VLANID)
+			}
+			created := &engineResource{CustomerDescription: req.CustomerDescription}
+			f.prefixes[f.nextID(created, "prefix")] = req
+			return created, nil
+		}, f.prefixList())
+
+	case strings.HasPrefix(r.URL.Path, prefixEndpoint+"/"):
+		id := strings.TrimPrefix(r.URL.Path, prefixEndpoint+"/")
+		req, ok := f.prefixes[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.prefixes, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, engineResource{Identifier: id, CustomerDescription: req.CustomerDescription, Status: f.status})
+
+	case r.URL.Path == tagsEndpoint && r.Method == http.MethodPost:
+		req := tags.Create{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.tags[req.ServiceID] = req.Name
+		writeJSON(w, req)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeEngine) handleCollection(w http.ResponseWriter, r *http.Request, create func([]byte) (*engineResource, error), list []engineResource) {
+	switch r.Method {
+	case http.MethodGet:
+		search := r.URL.Query().Get("search")
+		result := []engineResource{}
+		for _, resource := range list {
+			if strings.Contains(resource.CustomerDescription, search) {
+				result = append(result, resource)
+			}
+		}
+		writeJSON(w, result)
+
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resource, err := create(body)
+		if err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		writeJSON(w, resource)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeEngine) nextID(resource *engineResource, kind string) string {
+	f.counter++
+	resource.Identifier = fmt.Sprintf("%s-%d", kind, f.counter)
+	resource.Status = f.status
+	return resource.Identifier
+}
+
+func (f *fakeEngine) vlanList() []engineResource {
+	list := []engineResource{}
+	for _, resource := range f.vlans {
+		list = append(list, *resource)
+	}
+	return list
+}
+
+func (f *fakeEngine) prefixList() []engineResource {
+	list := []engineResource{}
+	for id, req := range f.prefixes {
+		list = append(list, engineResource{Identifier: id, CustomerDescription: req.CustomerDescription, Status: f.status})
+	}
+	return list
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type fakeClusterUpdater struct {
+	c *kubermaticv1.Cluster
+}
+
+func (f *fakeClusterUpdater) update(_ context.Context, _ string, updateFn func(c *kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error) {
+	updateFn(f.c)
+	return f.c, nil
+}
+
+func newTestProvider(t *testing.T, engine *fakeEngine) *Anexia {
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	return &Anexia{
+		dc: &kubermaticv1.DatacenterSpecAnexia{
+			LocationID: testLocationID,
+		},
+		newClient: func(token string) (client.Client, error) {
+			return client.New(client.TokenFromString(token), client.BaseURL(server.URL), client.HTTPClient(server.Client()))
+		},
+	}
+}
+
+func newTestCluster(spec *kubermaticv1.AnexiaCloudSpec, finalizers ...string) *kubermaticv1.Cluster {
+	spec.Token = testToken
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "xyz",
+			Finalizers: finalizers,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Anexia: spec,
+			},
+		},
+	}
+}
+
+func TestReconcileCluster(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         string
+		existingVLANs  []engineResource
+		spec           *kubermaticv1.AnexiaCloudSpec
+		finalizers     []string
+		wantErr        bool
+		wantVLANID     string
+		wantPrefixID   string
+		wantFinalizers []string
+		wantCreated    map[string]int
+	}{
+		{
+			name:           "creates VLAN and prefix",
+			status:         statusActive,
+			spec:           &kubermaticv1.AnexiaCloudSpec{},
+			wantVLANID:     "vlan-1",
+			wantPrefixID:   "prefix-2",
+			wantFinalizers: []string{VLANCleanupFinalizer, PrefixCleanupFinalizer},
+			wantCreated: map[string]int{
+				vlanEndpoint:   1,
+				prefixEndpoint: 1,
+				tagsEndpoint:   2,
+			},
+		},
+		{
+			name:   "adopts VLAN created by previous reconciliation",
+			status: statusActive,
+			existingVLANs: []engineResource{
+				{Identifier: "vlan-0", CustomerDescription: "kubernetes-cluster-xyz", Status: statusActive},
+			},
+			spec:           &kubermaticv1.AnexiaCloudSpec{},
+			wantVLANID:     "vlan-0",
+			wantPrefixID:   "prefix-1",
+			wantFinalizers: []string{VLANCleanupFinalizer, PrefixCleanupFinalizer},
+			wantCreated: map[string]int{
+				vlanEndpoint:   0,
+				prefixEndpoint: 1,
+				tagsEndpoint:   1,
+			},
+		},
+		{
+			name:   "keeps VLAN provided by the user",
+			status: statusActive,
+			existingVLANs: []engineResource{
+				{Identifier: "my-vlan", Status: statusActive},
+			},
+			spec:       &kubermaticv1.AnexiaCloudSpec{VLANID: "my-vlan"},
+			wantVLANID: "my-vlan",
+			wantCreated: map[string]int{
+				vlanEndpoint:   0,
+				prefixEndpoint: 0,
+				tagsEndpoint:   0,
+			},
+		},
+		{
+			name:    "fails for missing VLAN provided by the user",
+			status:  statusActive,
+			spec:    &kubermaticv1.AnexiaCloudSpec{VLANID: "my-vlan"},
+			wantErr: true,
+			wantCreated: map[string]int{
+				vlanEndpoint:   0,
+				prefixEndpoint: 0,
+				tagsEndpoint:   0,
+			},
+			wantVLANID: "my-vlan",
+		},
+		{
+			name:           "recreates deleted VLAN",
+			status:         statusActive,
+			spec:           &kubermaticv1.AnexiaCloudSpec{VLANID: "vlan-0", PrefixID: "prefix-0"},
+			finalizers:     []string{VLANCleanupFinalizer, PrefixCleanupFinalizer},
+			wantVLANID:     "vlan-1",
+			wantPrefixID:   "prefix-2",
+			wantFinalizers: []string{VLANCleanupFinalizer, PrefixCleanupFinalizer},
+			wantCreated: map[string]int{
+				vlanEndpoint:   1,
+				prefixEndpoint: 1,
+				tagsEndpoint:   2,
+			},
+		},
+		{
+			name:           "waits for VLAN to become active",
+			status:         "Pending",
+			spec:           &kubermaticv1.AnexiaCloudSpec{},
+			wantErr:        true,
+			wantVLANID:     "vlan-1",
+			wantFinalizers: []string{VLANCleanupFinalizer},
+			wantCreated: map[string]int{
+				vlanEndpoint:   1,
+				prefixEndpoint: 0,
+				tagsEndpoint:   1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := newFakeEngine(tc.status)
+			for i := range tc.existingVLANs {
+				engine.vlans[tc.existingVLANs[i].Identifier] = &tc.existingVLANs[i]
+			}
+
+			prov := newTestProvider(t, engine)
+			updater := &fakeClusterUpdater{c: newTestCluster(tc.spec, tc.finalizers...)}
+
+			_, err := prov.ReconcileCluster(context.Background(), updater.c, updater.update)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReconcileCluster() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			for endpoint, count := range tc.wantCreated {
+				if got := engine.requests[http.MethodPost+" "+endpoint]; got != count {
+					t.Errorf("expected %d POST requests to %s, got %d", count, endpoint, got)
+				}
+			}
+
+			// the updater modifies the cluster in place, so changes persisted
+			// before an error occurred are visible as well
+			cluster := updater.c
+			spec := cluster.Spec.Cloud.Anexia
+			if spec.VLANID != tc.wantVLANID {
+				t.Errorf("expected VLAN ID %q, got %q", tc.wantVLANID, spec.VLANID)
+			}
+			if spec.PrefixID != tc.wantPrefixID {
+				t.Errorf("expected prefix ID %q, got %q", tc.wantPrefixID, spec.PrefixID)
+			}
+			for _, finalizer := range tc.wantFinalizers {
+				if !kuberneteshelper.HasFinalizer(cluster, finalizer) {
+					t.Errorf("expected finalizer %q, got %v", finalizer, cluster.Finalizers)
+				}
+			}
+			if len(cluster.Finalizers) != len(tc.wantFinalizers) {
+				t.Errorf("expected finalizers %v, got %v", tc.wantFinalizers, cluster.Finalizers)
+			}
+
+			if tc.wantPrefixID != "" {
+				created := engine.prefixes[tc.wantPrefixID]
+				if created.VLANID != tc.wantVLANID || created.Location != testLocationID || created.NetworkMask != defaultPrefixNetmask {
+					t.Errorf("prefix was created with unexpected parameters: %+v", created)
+				}
+				if engine.tags[tc.wantPrefixID] != "kubernetes-cluster-xyz" {
+					t.Errorf("expected prefix to be tagged, got tags %v", engine.tags)
+				}
+			}
+		})
+	}
+}
+
+func TestCleanUpCloudProvider(t *testing.T) {
+	testCases := []struct {
+		name         string
+		spec         *kubermaticv1.AnexiaCloudSpec
+		finalizers   []string
+		wantVLANs    int
+		wantPrefixes int
+	}{
+		{
+			name:       "deletes VLAN and prefix",
+			spec:       &kubermaticv1.AnexiaCloudSpec{VLANID: "vlan-1", PrefixID: "prefix-1"},
+			finalizers: []string{VLANCleanupFinalizer, PrefixCleanupFinalizer},
+		},
+		{
+			name:       "ignores resources that are already gone",
+			spec:       &kubermaticv1.AnexiaCloudSpec{VLANID: "vlan-2", PrefixID: "prefix-2"},
+			finalizers: []string{VLANCleanupFinalizer, PrefixCleanupFinalizer},
+			// the existing resources do not belong to the cluster
+			wantVLANs:    1,
+			wantPrefixes: 1,
+		},
+		{
+			name:         "keeps resources provided by the user",
+			spec:         &kubermaticv1.AnexiaCloudSpec{VLANID: "vlan-1", PrefixID: "prefix-1"},
+			wantVLANs:    1,
+			wantPrefixes: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := newFakeEngine(statusActive)
+			engine.vlans["vlan-1"] = &engineResource{Identifier: "vlan-1", Status: statusActive}
+			engine.prefixes["prefix-1"] = &prefix.Create{VLANID: "vlan-1"}
+
+			prov := newTestProvider(t, engine)
+			cluster := newTestCluster(tc.spec, tc.finalizers...)
+
+			cluster, err := prov.CleanUpCloudProvider(context.Background(), cluster, (&fakeClusterUpdater{c: cluster}).update)
+			if err != nil {
+				t.Fatalf("CleanUpCloudProvider() failed: %v", err)
+			}
+
+			if len(cluster.Finalizers) != 0 {
+				t.Errorf("expected all finalizers to be removed, got %v", cluster.Finalizers)
+			}
+			if len(engine.vlans) != tc.wantVLANs {
+				t.Errorf("expected %d VLANs to remain, got %d", tc.wantVLANs, len(engine.vlans))
+			}
+			if len(engine.prefixes) != tc.wantPrefixes {
+				t.Errorf("expected %d prefixes to remain, got %d", tc.wantPrefixes, len(engine.prefixes))
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// securityGroupResourceKind is the kind of security groups reported by the resource audit.
+const securityGroupResourceKind = "security-group"
+
+var _ provider.CloudResourceAuditor = &AmazonEC2{}
+
+// ListClusterResources returns all security groups in the region of the datacenter
+// that carry a KKP ownership tag, together with the datacenter they are tagged with.
+func (a *AmazonEC2) ListClusterResources(ctx context.Context, spec kubermaticv1.CloudSpec) ([]provider.CloudResource, error) {
+	client, err := a.getClientSet(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	var resources []provider.CloudResource
+
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: aws.StringSlice([]string{ownershipTagPrefix + "*"}),
+			},
+		},
+	}
+
+	err = client.EC2.DescribeSecurityGroupsPagesWithContext(ctx, input, func(page *ec2.DescribeSecurityGroupsOutput, _ bool) bool {
+		for _, group := range page.SecurityGroups {
+			var datacenter string
+			for _, tag := range group.Tags {
+				if aws.StringValue(tag.Key) == datacenterTagKey {
+					datacenter = aws.StringValue(tag.Value)
+				}
+			}
+
+			for _, tag := range group.Tags {
+				key := aws.StringValue(tag.Key)
+				if !strings.HasPrefix(key, ownershipTagPrefix) {
+					continue
+				}
+
+				resources = append(resources, provider.CloudResource{
+					Kind:        securityGroupResourceKind,
+					ID:          aws.StringValue(group.GroupId),
+					Name:        aws.StringValue(group.GroupName),
+					ClusterName: strings.TrimPrefix(key, ownershipTagPrefix),
+					Datacenter:  datacenter,
+				})
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
+	}
+
+	return resources, nil
+}
+
+// DeleteClusterResource deletes a security group returned by ListClusterResources.
+func (a *AmazonEC2) DeleteClusterResource(ctx context.Context, spec kubermaticv1.CloudSpec, resource provider.CloudResource) error {
+	if resource.Kind != securityGroupResourceKind {
+		return fmt.Errorf("unsupported resource kind %q", resource.Kind)
+	}
+
+	client, err := a.getClientSet(spec)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	_, err = client.EC2.DeleteSecurityGroupWithContext(ctx, &ec2.DeleteSecurityGroupInput{
+		GroupId: aws.String(resource.ID),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete security group %s: %w", resource.ID, err)
+	}
+
+	return nil
+}
@@ -30,6 +30,9 @@ const (
 	resourceNamePrefix         = "kubernetes-"
 	kubernetesClusterTagPrefix = "kubernetes.io/cluster/"
 	ownershipTagPrefix         = "owned-by.kubermatic.k8c.io/"
+	// datacenterTagKey marks the security group with the datacenter of its cluster,
+	// so that the resource audit can tell apart clusters of other Seeds.
+	datacenterTagKey = "kubermatic.k8c.io/datacenter"
 
 	regionAnnotationKey = "kubermatic.io/aws-region"
 
@@ -111,6 +111,7 @@ func reconcileSecurityGroup(ctx context.Context, client ec2iface.EC2API, cluster
 				ResourceType: aws.String("security-group"),
 				Tags: []*ec2.Tag{
 					ec2OwnershipTag(cluster.Name),
+					ec2DatacenterTag(cluster.Spec.Cloud.DatacenterName),
 				},
 			}},
 		})
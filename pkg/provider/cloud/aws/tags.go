@@ -43,6 +43,13 @@ func ec2OwnershipTag(clusterName string) *ec2.Tag {
 	}
 }
 
+func ec2DatacenterTag(datacenterName string) *ec2.Tag {
+	return &ec2.Tag{
+		Key:   aws.String(datacenterTagKey),
+		Value: aws.String(datacenterName),
+	}
+}
+
 func iamOwnershipTag(clusterName string) *iam.Tag {
 	return &iam.Tag{
 		Key:   aws.String(ownershipTagPrefix + clusterName),
@@ -79,6 +86,16 @@ func reconcileClusterTags(ctx context.Context, client ec2iface.EC2API, cluster *
 			cluster.Spec.Cloud.AWS.SecurityGroupID, cluster.Spec.Cloud.AWS.RouteTableID, subnetIDs, err)
 	}
 
+	// security groups created before they were marked with the datacenter
+	// would otherwise never be considered by the resource audit
+	_, err = client.CreateTagsWithContext(ctx, &ec2.CreateTagsInput{
+		Resources: aws.StringSlice([]string{cluster.Spec.Cloud.AWS.SecurityGroupID}),
+		Tags:      []*ec2.Tag{ec2DatacenterTag(cluster.Spec.Cloud.DatacenterName)},
+	})
+	if err != nil {
+		return cluster, fmt.Errorf("failed to tag security group %s: %w", cluster.Spec.Cloud.AWS.SecurityGroupID, err)
+	}
+
 	return cluster, nil
 }
 
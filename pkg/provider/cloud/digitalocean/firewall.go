@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const allPorts = "all"
+
+func reconcileFirewall(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *godo.Client) (*kubermaticv1.Cluster, error) {
+	firewallID := cluster.Spec.Cloud.Digitalocean.FirewallID
+
+	// firewalls chosen by the user are not managed by us
+	if firewallID != "" && !kuberneteshelper.HasFinalizer(cluster, FirewallCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+	request := firewallRequest(cluster)
+
+	var firewall *godo.Firewall
+	if firewallID != "" {
+		var err error
+		firewall, _, err = client.Firewalls.Get(ctx, firewallID)
+		if err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("failed to get firewall %q: %w", firewallID, err)
+		}
+	}
+
+	if firewall == nil {
+		var err error
+		firewall, err = getFirewallByName(ctx, client, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if firewall == nil {
+		var err error
+		firewall, _, err = client.Firewalls.Create(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create firewall %q: %w", name, err)
+		}
+	} else if !firewallMatchesRequest(firewall, request) {
+		if _, _, err := client.Firewalls.Update(ctx, firewall.ID, request); err != nil {
+			return nil, fmt.Errorf("failed to update firewall %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Digitalocean.FirewallID = firewall.ID
+		kuberneteshelper.AddFinalizer(cluster, FirewallCleanupFinalizer)
+	})
+}
+
+// firewallRequest returns the desired firewall for the cluster. It is applied
+// to all droplets carrying the cluster tag. As DigitalOcean firewalls deny
+// everything that is not explicitly allowed, all traffic between the cluster
+// droplets and all outbound traffic are permitted; from the outside only ICMP,
+// SSH and the NodePort range are reachable.
+func firewallRequest(cluster *kubermaticv1.Cluster) *godo.FirewallRequest {
+	tag := ClusterTag(cluster)
+
+	nodePortRangeLow, nodePortRangeHigh := resources.NewTemplateDataBuilder().
+		WithNodePortRange(cluster.Spec.ComponentsOverride.Apiserver.NodePortRange).
+		WithCluster(cluster).
+		Build().
+		NodePorts()
+	nodePorts := fmt.Sprintf("%d-%d", nodePortRangeLow, nodePortRangeHigh)
+
+	anywhere := []string{resources.IPv4MatchAnyCIDR, resources.IPv6MatchAnyCIDR}
+	nodePortSources := resources.GetNodePortsAllowedIPRanges(cluster, nil, "").CIDRBlocks
+
+	return &godo.FirewallRequest{
+		Name: resourceName(cluster),
+		Tags: []string{tag},
+		InboundRules: []godo.InboundRule{
+			{Protocol: "icmp", Sources: &godo.Sources{Addresses: anywhere}},
+			{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: anywhere}},
+			{Protocol: "tcp", PortRange: nodePorts, Sources: &godo.Sources{Addresses: nodePortSources}},
+			{Protocol: "udp", PortRange: nodePorts, Sources: &godo.Sources{Addresses: nodePortSources}},
+			{Protocol: "icmp", Sources: &godo.Sources{Tags: []string{tag}}},
+			{Protocol: "tcp", PortRange: allPorts, Sources: &godo.Sources{Tags: []string{tag}}},
+			{Protocol: "udp", PortRange: allPorts, Sources: &godo.Sources{Tags: []string{tag}}},
+		},
+		OutboundRules: []godo.OutboundRule{
+			{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: anywhere}},
+			{Protocol: "tcp", PortRange: allPorts, Destinations: &godo.Destinations{Addresses: anywhere}},
+			{Protocol: "udp", PortRange: allPorts, Destinations: &godo.Destinations{Addresses: anywhere}},
+		},
+	}
+}
+
+// firewallMatchesRequest compares the rules and tags by their effective values,
+// as the API might return them in a different order or representation.
+func firewallMatchesRequest(firewall *godo.Firewall, request *godo.FirewallRequest) bool {
+	if !sets.NewString(firewall.Tags...).Equal(sets.NewString(request.Tags...)) {
+		return false
+	}
+
+	inbound := func(rules []godo.InboundRule) sets.String {
+		result := sets.NewString()
+		for _, rule := range rules {
+			var addresses, tags []string
+			if rule.Sources != nil {
+				addresses, tags = rule.Sources.Addresses, rule.Sources.Tags
+			}
+			result.Insert(firewallRuleKey(rule.Protocol, rule.PortRange, addresses, tags))
+		}
+		return result
+	}
+
+	outbound := func(rules []godo.OutboundRule) sets.String {
+		result := sets.NewString()
+		for _, rule := range rules {
+			var addresses, tags []string
+			if rule.Destinations != nil {
+				addresses, tags = rule.Destinations.Addresses, rule.Destinations.Tags
+			}
+			result.Insert(firewallRuleKey(rule.Protocol, rule.PortRange, addresses, tags))
+		}
+		return result
+	}
+
+	return inbound(firewall.InboundRules).Equal(inbound(request.InboundRules)) &&
+		outbound(firewall.OutboundRules).Equal(outbound(request.OutboundRules))
+}
+
+func firewallRuleKey(protocol, ports string, addresses, tags []string) string {
+	// the API reports "0" for rules that cover all ports and ports are
+	// meaningless for ICMP
+	if ports == "0" || ports == "" || protocol == "icmp" {
+		ports = allPorts
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s", protocol, ports, strings.Join(sets.NewString(addresses...).List(), ","), strings.Join(sets.NewString(tags...).List(), ","))
+}
+
+func getFirewallByName(ctx context.Context, client *godo.Client, name string) (*godo.Firewall, error) {
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		firewalls, resp, err := client.Firewalls.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list firewalls: %w", err)
+		}
+
+		for i := range firewalls {
+			if firewalls[i].Name == name {
+				return &firewalls[i], nil
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+		opts.Page++
+	}
+}
+
+func deleteFirewall(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *godo.Client) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FirewallCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	firewallID := cluster.Spec.Cloud.Digitalocean.FirewallID
+
+	if _, err := client.Firewalls.Delete(ctx, firewallID); err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("failed to delete firewall %q: %w", firewallID, err)
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, FirewallCleanupFinalizer)
+	})
+}
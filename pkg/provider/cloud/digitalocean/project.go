@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	projectPurpose     = "Kubernetes cluster"
+	projectEnvironment = "Production"
+)
+
+func reconcileProject(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *godo.Client) (*kubermaticv1.Cluster, error) {
+	projectID := cluster.Spec.Cloud.Digitalocean.ProjectID
+
+	// projects chosen by the user are not managed by us
+	if projectID != "" && !kuberneteshelper.HasFinalizer(cluster, ProjectCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	if projectID != "" {
+		_, _, err := client.Projects.Get(ctx, projectID)
+		if err == nil {
+			return cluster, nil
+		}
+		if !isNotFound(err) {
+			return nil, fmt.Errorf("failed to get project %q: %w", projectID, err)
+		}
+		// the project is gone, recreate it below
+	}
+
+	name := resourceName(cluster)
+
+	project, err := getProjectByName(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if project == nil {
+		project, _, err = client.Projects.Create(ctx, &godo.CreateProjectRequest{
+			Name:        name,
+			Description: fmt.Sprintf("Resources of Kubernetes cluster %s", cluster.Name),
+			Purpose:     projectPurpose,
+			Environment: projectEnvironment,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create project %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Digitalocean.ProjectID = project.ID
+		kuberneteshelper.AddFinalizer(cluster, ProjectCleanupFinalizer)
+	})
+}
+
+func getProjectByName(ctx context.Context, client *godo.Client, name string) (*godo.Project, error) {
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		projects, resp, err := client.Projects.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		for i := range projects {
+			if projects[i].Name == name {
+				return &projects[i], nil
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+		opts.Page++
+	}
+}
+
+// assignProjectResources assigns all droplets carrying the cluster tag and the
+// volumes attached to them to the cluster project. Droplets are created by the
+// machine-controller and volumes by the CSI driver, neither of which know about
+// the project, so new resources are picked up on the next reconciliation.
+func assignProjectResources(ctx context.Context, cluster *kubermaticv1.Cluster, client *godo.Client) error {
+	projectID := cluster.Spec.Cloud.Digitalocean.ProjectID
+	if projectID == "" {
+		return nil
+	}
+
+	urns := []interface{}{}
+
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		droplets, resp, err := client.Droplets.ListByTag(ctx, ClusterTag(cluster), opts)
+		if err != nil {
+			return fmt.Errorf("failed to list droplets: %w", err)
+		}
+
+		for _, droplet := range droplets {
+			urns = append(urns, droplet.URN())
+			for _, volumeID := range droplet.VolumeIDs {
+				urns = append(urns, godo.Volume{ID: volumeID}.URN())
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opts.Page++
+	}
+
+	if len(urns) == 0 {
+		return nil
+	}
+
+	// assigning resources that already belong to the project is a no-op
+	if _, _, err := client.Projects.AssignResources(ctx, projectID, urns...); err != nil {
+		return fmt.Errorf("failed to assign resources to project %q: %w", projectID, err)
+	}
+
+	return nil
+}
+
+func deleteProject(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *godo.Client) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, ProjectCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	projectID := cluster.Spec.Cloud.Digitalocean.ProjectID
+
+	// this fails as long as the project still contains resources
+	if _, err := client.Projects.Delete(ctx, projectID); err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("failed to delete project %q: %w", projectID, err)
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, ProjectCleanupFinalizer)
+	})
+}
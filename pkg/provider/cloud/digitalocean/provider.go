@@ -19,35 +19,68 @@ package digitalocean
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 
 	"github.com/digitalocean/godo"
 	"golang.org/x/oauth2"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+const (
+	FirewallCleanupFinalizer = "kubermatic.k8c.io/cleanup-digitalocean-firewall"
+	ProjectCleanupFinalizer  = "kubermatic.k8c.io/cleanup-digitalocean-project"
+
+	resourceNamePrefix = "kubernetes-cluster-"
+)
+
 type digitalocean struct {
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	// newClient creates the DigitalOcean API client for a token; overridden in tests.
+	newClient func(ctx context.Context, token string) *godo.Client
 }
 
 // NewCloudProvider creates a new digitalocean provider.
 func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
 	return &digitalocean{
 		secretKeySelector: secretKeyGetter,
+		newClient:         newClient,
 	}
 }
 
-var _ provider.CloudProvider = &digitalocean{}
+var _ provider.ReconcilingCloudProvider = &digitalocean{}
+
+func newClient(ctx context.Context, token string) *godo.Client {
+	static := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return godo.NewClient(oauth2.NewClient(ctx, static))
+}
+
+// ClusterTag returns the tag that all droplets of the cluster carry. The
+// cluster firewall is applied to this tag and it is used to find the droplets
+// that are assigned to the cluster project.
+func ClusterTag(cluster *kubermaticv1.Cluster) string {
+	return resourceName(cluster)
+}
+
+func resourceName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name
+}
+
+func isNotFound(err error) bool {
+	var errResponse *godo.ErrorResponse
+	return errors.As(err, &errResponse) && errResponse.Response != nil && errResponse.Response.StatusCode == http.StatusNotFound
+}
 
 func (do *digitalocean) DefaultCloudSpec(ctx context.Context, spec *kubermaticv1.CloudSpec) error {
 	return nil
 }
 
 func ValidateCredentials(ctx context.Context, token string) error {
-	static := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	client := godo.NewClient(oauth2.NewClient(ctx, static))
+	client := newClient(ctx, token)
 
 	_, _, err := client.Regions.List(ctx, nil)
 	return err
@@ -62,11 +95,67 @@ func (do *digitalocean) ValidateCloudSpec(ctx context.Context, spec kubermaticv1
 	return ValidateCredentials(ctx, token)
 }
 
-func (do *digitalocean) InitializeCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+// InitializeCloudProvider creates the firewall and project for the cluster.
+// No VPC is created, as the machine-controller can not place droplets into
+// a VPC yet, so droplets join the default VPC of the region.
+func (do *digitalocean) InitializeCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return do.reconcileCluster(ctx, cluster, update)
+}
+
+// ReconcileCluster enforces the existence of the firewall and project of the
+// cluster and assigns all droplets and volumes of the cluster to the project.
+func (do *digitalocean) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return do.reconcileCluster(ctx, cluster, update)
+}
+
+func (do *digitalocean) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	token, err := GetCredentialsForCluster(cluster.Spec.Cloud, do.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := do.newClient(ctx, token)
+
+	cluster, err = reconcileFirewall(ctx, cluster, update, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile firewall: %w", err)
+	}
+
+	cluster, err = reconcileProject(ctx, cluster, update, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile project: %w", err)
+	}
+
+	if err := assignProjectResources(ctx, cluster, client); err != nil {
+		return nil, fmt.Errorf("failed to assign resources to project: %w", err)
+	}
+
 	return cluster, nil
 }
 
-func (do *digitalocean) CleanUpCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+// CleanUpCloudProvider removes the firewall and project that were created for the cluster.
+func (do *digitalocean) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasAnyFinalizer(cluster, FirewallCleanupFinalizer, ProjectCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	token, err := GetCredentialsForCluster(cluster.Spec.Cloud, do.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := do.newClient(ctx, token)
+
+	cluster, err = deleteFirewall(ctx, cluster, update, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete firewall: %w", err)
+	}
+
+	cluster, err = deleteProject(ctx, cluster, update, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete project: %w", err)
+	}
+
 	return cluster, nil
 }
 
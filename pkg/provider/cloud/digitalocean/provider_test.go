@@ -0,0 +1,444 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	testToken = "test-token"
+)
+
+// fakeDigitalocean is a minimal in-memory implementation of the DigitalOcean
+// API endpoints for firewalls, projects and droplets.
+type fakeDigitalocean struct {
+	lock sync.Mutex
+
+	lastID    int
+	firewalls map[string]godo.Firewall
+	projects  map[string]godo.Project
+	droplets  []godo.Droplet
+	// assigned maps resource URNs to project IDs
+	assigned map[string]string
+	requests map[string]int
+}
+
+func newFakeDigitalocean() *fakeDigitalocean {
+	return &fakeDigitalocean{
+		firewalls: map[string]godo.Firewall{},
+		projects:  map[string]godo.Project{},
+		assigned:  map[string]string{},
+		requests:  map[string]int{},
+	}
+}
+
+func (f *fakeDigitalocean) nextID() string {
+	f.lastID++
+	return fmt.Sprintf("id-%d", f.lastID)
+}
+
+func (f *fakeDigitalocean) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// paths look like /v2/<collection>[/<id>[/<subresource>]]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")[1:]
+	collection := parts[0]
+	request := r.Method + " /" + collection
+	if len(parts) > 2 {
+		request += "/" + strings.Join(parts[2:], "/")
+	}
+	f.requests[request]++
+
+	id := ""
+	if len(parts) > 1 {
+		id = parts[1]
+	}
+
+	switch {
+	case collection == "firewalls" && r.Method == http.MethodGet && id == "":
+		firewalls := []godo.Firewall{}
+		for _, firewall := range f.firewalls {
+			firewalls = append(firewalls, firewall)
+		}
+		writeJSON(w, map[string]interface{}{"firewalls": firewalls})
+
+	case collection == "firewalls" && r.Method == http.MethodGet:
+		firewall, ok := f.firewalls[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		writeJSON(w, map[string]interface{}{"firewall": firewall})
+
+	case collection == "firewalls" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		req := godo.FirewallRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if r.Method == http.MethodPost {
+			id = f.nextID()
+		} else if _, ok := f.firewalls[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		firewall := godo.Firewall{ID: id, Name: req.Name, InboundRules: req.InboundRules, OutboundRules: req.OutboundRules, Tags: req.Tags}
+		f.firewalls[id] = firewall
+		writeJSON(w, map[string]interface{}{"firewall": firewall})
+
+	case collection == "firewalls" && r.Method == http.MethodDelete:
+		if _, ok := f.firewalls[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		delete(f.firewalls, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case collection == "projects" && r.Method == http.MethodGet && id == "":
+		projects := []godo.Project{}
+		for _, project := range f.projects {
+			projects = append(projects, project)
+		}
+		writeJSON(w, map[string]interface{}{"projects": projects})
+
+	case collection == "projects" && r.Method == http.MethodGet:
+		project, ok := f.projects[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		writeJSON(w, map[string]interface{}{"project": project})
+
+	case collection == "projects" && r.Method == http.MethodPost && id == "":
+		req := godo.CreateProjectRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		project := godo.Project{ID: f.nextID(), Name: req.Name, Description: req.Description, Purpose: req.Purpose, Environment: req.Environment}
+		f.projects[project.ID] = project
+		writeJSON(w, map[string]interface{}{"project": project})
+
+	case collection == "projects" && r.Method == http.MethodPost:
+		if _, ok := f.projects[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		req := struct {
+			Resources []string `json:"resources"`
+		}{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		resources := []godo.ProjectResource{}
+		for _, urn := range req.Resources {
+			f.assigned[urn] = id
+			resources = append(resources, godo.ProjectResource{URN: urn, Status: "ok"})
+		}
+		writeJSON(w, map[string]interface{}{"resources": resources})
+
+	case collection == "projects" && r.Method == http.MethodDelete:
+		if _, ok := f.projects[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		for _, projectID := range f.assigned {
+			if projectID == id {
+				writeError(w, http.StatusPreconditionFailed, "precondition_failed")
+				return
+			}
+		}
+		delete(f.projects, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case collection == "droplets" && r.Method == http.MethodGet:
+		tag := r.URL.Query().Get("tag_name")
+		droplets := []godo.Droplet{}
+		for _, droplet := range f.droplets {
+			if sets.NewString(droplet.Tags...).Has(tag) {
+				droplets = append(droplets, droplet)
+			}
+		}
+		writeJSON(w, map[string]interface{}{"droplets": droplets})
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found")
+	}
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, id string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"id": id, "message": id})
+}
+
+type fakeClusterUpdater struct {
+	c *kubermaticv1.Cluster
+}
+
+func (f *fakeClusterUpdater) update(_ context.Context, _ string, updateFn func(c *kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error) {
+	updateFn(f.c)
+	return f.c, nil
+}
+
+func newTestProvider(t *testing.T, api *fakeDigitalocean) *digitalocean {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return &digitalocean{
+		newClient: func(ctx context.Context, token string) *godo.Client {
+			client := newClient(ctx, token)
+			client.BaseURL, _ = url.Parse(server.URL + "/")
+			return client
+		},
+	}
+}
+
+func newTestCluster(spec kubermaticv1.DigitaloceanCloudSpec, finalizers ...string) *kubermaticv1.Cluster {
+	spec.Token = testToken
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "xyz",
+			Finalizers: finalizers,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+			},
+			Cloud: kubermaticv1.CloudSpec{
+				Digitalocean: &spec,
+			},
+		},
+	}
+}
+
+func TestReconcileCluster(t *testing.T) {
+	allFinalizers := []string{FirewallCleanupFinalizer, ProjectCleanupFinalizer}
+
+	testCases := []struct {
+		name           string
+		spec           kubermaticv1.DigitaloceanCloudSpec
+		finalizers     []string
+		existing       func(api *fakeDigitalocean)
+		wantSpec       kubermaticv1.DigitaloceanCloudSpec
+		wantFinalizers []string
+		wantRequests   map[string]int
+	}{
+		{
+			name: "creates all resources",
+			wantSpec: kubermaticv1.DigitaloceanCloudSpec{
+				FirewallID: "id-1",
+				ProjectID:  "id-2",
+			},
+			wantFinalizers: allFinalizers,
+			wantRequests: map[string]int{
+				"POST /firewalls": 1,
+				"POST /projects":  1,
+			},
+		},
+		{
+			name: "keeps resources provided by the user",
+			spec: kubermaticv1.DigitaloceanCloudSpec{
+				FirewallID: "my-firewall",
+				ProjectID:  "my-project",
+			},
+			existing: func(api *fakeDigitalocean) {
+				api.projects["my-project"] = godo.Project{ID: "my-project", Name: "my-project"}
+			},
+			wantSpec: kubermaticv1.DigitaloceanCloudSpec{
+				FirewallID: "my-firewall",
+				ProjectID:  "my-project",
+			},
+			wantRequests: map[string]int{
+				"POST /firewalls": 0,
+				"POST /projects":  0,
+			},
+		},
+		{
+			name: "adopts resources found by name",
+			existing: func(api *fakeDigitalocean) {
+				api.firewalls["firewall"] = godo.Firewall{ID: "firewall", Name: "kubernetes-cluster-xyz"}
+				api.projects["project"] = godo.Project{ID: "project", Name: "kubernetes-cluster-xyz"}
+			},
+			wantSpec: kubermaticv1.DigitaloceanCloudSpec{
+				FirewallID: "firewall",
+				ProjectID:  "project",
+			},
+			wantFinalizers: allFinalizers,
+			wantRequests: map[string]int{
+				"POST /firewalls": 0,
+				"PUT /firewalls":  1,
+				"POST /projects":  0,
+			},
+		},
+		{
+			name: "recreates deleted resources",
+			spec: kubermaticv1.DigitaloceanCloudSpec{
+				FirewallID: "firewall",
+				ProjectID:  "project",
+			},
+			finalizers: allFinalizers,
+			wantSpec: kubermaticv1.DigitaloceanCloudSpec{
+				FirewallID: "id-1",
+				ProjectID:  "id-2",
+			},
+			wantFinalizers: allFinalizers,
+			wantRequests: map[string]int{
+				"POST /firewalls": 1,
+				"POST /projects":  1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newFakeDigitalocean()
+			if tc.existing != nil {
+				tc.existing(api)
+			}
+
+			prov := newTestProvider(t, api)
+			updater := &fakeClusterUpdater{c: newTestCluster(tc.spec, tc.finalizers...)}
+
+			cluster, err := prov.ReconcileCluster(context.Background(), updater.c, updater.update)
+			if err != nil {
+				t.Fatalf("ReconcileCluster() failed: %v", err)
+			}
+
+			tc.wantSpec.Token = testToken
+			if *cluster.Spec.Cloud.Digitalocean != tc.wantSpec {
+				t.Errorf("expected spec %+v, got %+v", tc.wantSpec, *cluster.Spec.Cloud.Digitalocean)
+			}
+
+			if !sets.NewString(cluster.Finalizers...).Equal(sets.NewString(tc.wantFinalizers...)) {
+				t.Errorf("expected finalizers %v, got %v", tc.wantFinalizers, cluster.Finalizers)
+			}
+
+			for request, count := range tc.wantRequests {
+				if got := api.requests[request]; got != count {
+					t.Errorf("expected %d %q requests, got %d", count, request, got)
+				}
+			}
+
+			// reconciling again must not change anything
+			modifications := modifyingRequests(api)
+			if _, err := prov.ReconcileCluster(context.Background(), cluster, updater.update); err != nil {
+				t.Fatalf("second ReconcileCluster() failed: %v", err)
+			}
+			if got := modifyingRequests(api) - modifications; got != 0 {
+				t.Errorf("expected second reconciliation to not modify any resources, but %d modifying requests were sent", got)
+			}
+		})
+	}
+}
+
+func modifyingRequests(api *fakeDigitalocean) int {
+	return api.requests["POST /firewalls"] + api.requests["PUT /firewalls"] + api.requests["POST /projects"]
+}
+
+func TestAssignProjectResources(t *testing.T) {
+	api := newFakeDigitalocean()
+	api.droplets = []godo.Droplet{
+		{ID: 1, Tags: []string{"kubernetes", "kubernetes-cluster-xyz"}, VolumeIDs: []string{"volume-1"}},
+		{ID: 2, Tags: []string{"kubernetes-cluster-xyz"}},
+		{ID: 3, Tags: []string{"kubernetes-cluster-other"}, VolumeIDs: []string{"volume-3"}},
+	}
+
+	prov := newTestProvider(t, api)
+	updater := &fakeClusterUpdater{c: newTestCluster(kubermaticv1.DigitaloceanCloudSpec{})}
+
+	cluster, err := prov.ReconcileCluster(context.Background(), updater.c, updater.update)
+	if err != nil {
+		t.Fatalf("ReconcileCluster() failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"do:droplet:1":       cluster.Spec.Cloud.Digitalocean.ProjectID,
+		"do:volume:volume-1": cluster.Spec.Cloud.Digitalocean.ProjectID,
+		"do:droplet:2":       cluster.Spec.Cloud.Digitalocean.ProjectID,
+	}
+	if len(api.assigned) != len(expected) {
+		t.Fatalf("expected assignments %v, got %v", expected, api.assigned)
+	}
+	for urn, projectID := range expected {
+		if api.assigned[urn] != projectID {
+			t.Errorf("expected %q to be assigned to project %q, got %q", urn, projectID, api.assigned[urn])
+		}
+	}
+}
+
+func TestCleanUpCloudProvider(t *testing.T) {
+	api := newFakeDigitalocean()
+	api.firewalls["firewall"] = godo.Firewall{ID: "firewall", Name: "my-firewall"}
+	api.projects["project"] = godo.Project{ID: "project", Name: "kubernetes-cluster-xyz"}
+
+	prov := newTestProvider(t, api)
+	updater := &fakeClusterUpdater{c: newTestCluster(kubermaticv1.DigitaloceanCloudSpec{
+		FirewallID: "firewall",
+		ProjectID:  "project",
+	}, ProjectCleanupFinalizer)}
+
+	cluster, err := prov.CleanUpCloudProvider(context.Background(), updater.c, updater.update)
+	if err != nil {
+		t.Fatalf("CleanUpCloudProvider() failed: %v", err)
+	}
+
+	if kuberneteshelper.HasAnyFinalizer(cluster, FirewallCleanupFinalizer, ProjectCleanupFinalizer) {
+		t.Errorf("expected all finalizers to be removed, got %v", cluster.Finalizers)
+	}
+	if len(api.projects) != 0 {
+		t.Errorf("expected project to be deleted, got %v", api.projects)
+	}
+	if _, ok := api.firewalls["firewall"]; !ok {
+		t.Error("expected the firewall of the user to be kept")
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+)
+
+func reconcileFirewall(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *hcloud.Client, dc *kubermaticv1.DatacenterSpecHetzner) (*kubermaticv1.Cluster, error) {
+	managed := kuberneteshelper.HasFinalizer(cluster, FirewallCleanupFinalizer)
+
+	// firewalls chosen by the user are not managed by us
+	if cluster.Spec.Cloud.Hetzner.Firewall != "" && !managed {
+		return cluster, nil
+	}
+
+	var (
+		rules []hcloud.FirewallRule
+		err   error
+	)
+
+	if hasPrivateNetwork(cluster, dc) {
+		rules, err = firewallRules(cluster)
+	} else {
+		// Nodes without a private network reach each other through their public interfaces, whose addresses
+		// are not known in advance, so a firewall would block the traffic within the cluster. Firewalls that
+		// were already created for such clusters are referenced by their machines, so they are opened up
+		// instead of being removed.
+		if !managed {
+			return cluster, nil
+		}
+		rules, err = allowAllFirewallRules()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name := resourceName(cluster)
+
+	firewall, _, err := client.Firewall.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall %q: %w", name, err)
+	}
+
+	if firewall == nil {
+		_, _, err = client.Firewall.Create(ctx, hcloud.FirewallCreateOpts{
+			Name:   name,
+			Rules:  rules,
+			Labels: clusterLabels(cluster),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create firewall %q: %w", name, err)
+		}
+	} else if !firewallRulesEqual(firewall.Rules, rules) {
+		_, _, err = client.Firewall.SetRules(ctx, firewall, hcloud.FirewallSetRulesOpts{Rules: rules})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update rules of firewall %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Hetzner.Firewall = name
+		kuberneteshelper.AddFinalizer(cluster, FirewallCleanupFinalizer)
+	})
+}
+
+// firewallRules returns the inbound rules for the public interfaces of the
+// cluster nodes. Traffic within the private network is not filtered by
+// Hetzner firewalls, and the control plane reaches the nodes through the
+// tunnel the nodes establish, so only ICMP, SSH and the NodePort range are
+// opened.
+func firewallRules(cluster *kubermaticv1.Cluster) ([]hcloud.FirewallRule, error) {
+	nodePortRangeLow, nodePortRangeHigh := resources.NewTemplateDataBuilder().
+		WithNodePortRange(cluster.Spec.ComponentsOverride.Apiserver.NodePortRange).
+		WithCluster(cluster).
+		Build().
+		NodePorts()
+	nodePorts := fmt.Sprintf("%d-%d", nodePortRangeLow, nodePortRangeHigh)
+
+	anywhere, err := parseCIDRs(resources.IPv4MatchAnyCIDR, resources.IPv6MatchAnyCIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePortsAllowedIPRanges := resources.GetNodePortsAllowedIPRanges(cluster, nil, "")
+	nodePortSources, err := parseCIDRs(nodePortsAllowedIPRanges.CIDRBlocks...)
+	if err != nil {
+		return nil, err
+	}
+
+	return []hcloud.FirewallRule{
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolICMP,
+			SourceIPs:   anywhere,
+			Description: hcloud.String("ICMP"),
+		},
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolTCP,
+			Port:        hcloud.String("22"),
+			SourceIPs:   anywhere,
+			Description: hcloud.String("SSH"),
+		},
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolTCP,
+			Port:        hcloud.String(nodePorts),
+			SourceIPs:   nodePortSources,
+			Description: hcloud.String("NodePorts (TCP)"),
+		},
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolUDP,
+			Port:        hcloud.String(nodePorts),
+			SourceIPs:   nodePortSources,
+			Description: hcloud.String("NodePorts (UDP)"),
+		},
+	}, nil
+}
+
+// hasPrivateNetwork returns true if the nodes of the cluster are attached to a private network, either the network
+// of the cluster or of the datacenter.
+func hasPrivateNetwork(cluster *kubermaticv1.Cluster, dc *kubermaticv1.DatacenterSpecHetzner) bool {
+	return cluster.Spec.Cloud.Hetzner.Network != "" || dc.Network != ""
+}
+
+// allowAllFirewallRules returns inbound rules that allow all traffic.
+func allowAllFirewallRules() ([]hcloud.FirewallRule, error) {
+	anywhere, err := parseCIDRs(resources.IPv4MatchAnyCIDR, resources.IPv6MatchAnyCIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	return []hcloud.FirewallRule{
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolICMP,
+			SourceIPs:   anywhere,
+			Description: hcloud.String("ICMP"),
+		},
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolTCP,
+			Port:        hcloud.String("1-65535"),
+			SourceIPs:   anywhere,
+			Description: hcloud.String("TCP"),
+		},
+		{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolUDP,
+			Port:        hcloud.String("1-65535"),
+			SourceIPs:   anywhere,
+			Description: hcloud.String("UDP"),
+		},
+	}, nil
+}
+
+func parseCIDRs(cidrs ...string) ([]net.IPNet, error) {
+	result := []net.IPNet{}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		result = append(result, *ipNet)
+	}
+
+	return result, nil
+}
+
+// firewallRulesEqual compares the rules by their effective values, as the API
+// might return them in a different order or representation.
+func firewallRulesEqual(a, b []hcloud.FirewallRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	keys := func(rules []hcloud.FirewallRule) []string {
+		result := []string{}
+		for _, rule := range rules {
+			result = append(result, firewallRuleKey(rule))
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	aKeys, bKeys := keys(a), keys(b)
+	for i := range aKeys {
+		if aKeys[i] != bKeys[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func firewallRuleKey(rule hcloud.FirewallRule) string {
+	ips := func(nets []net.IPNet) string {
+		result := []string{}
+		for _, ipNet := range nets {
+			result = append(result, ipNet.String())
+		}
+		sort.Strings(result)
+		return strings.Join(result, ",")
+	}
+
+	port := ""
+	if rule.Port != nil {
+		port = *rule.Port
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s/%s", rule.Direction, rule.Protocol, port, ips(rule.SourceIPs), ips(rule.DestinationIPs))
+}
+
+func deleteFirewall(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *hcloud.Client) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, FirewallCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	name := cluster.Spec.Cloud.Hetzner.Firewall
+
+	firewall, _, err := client.Firewall.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall %q: %w", name, err)
+	}
+
+	if firewall != nil {
+		if _, err := client.Firewall.Delete(ctx, firewall); err != nil && !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return nil, fmt.Errorf("failed to delete firewall %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, FirewallCleanupFinalizer)
+	})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	networkIPRange = "192.168.0.0/16"
+	subnetIPRange  = "192.168.0.0/18"
+)
+
+// reconcileNetwork ensures the network managed for the cluster exists. Unless create is set, a network is only
+// ensured for clusters that are already using a managed network.
+func reconcileNetwork(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *hcloud.Client, dc *kubermaticv1.DatacenterSpecHetzner, create bool) (*kubermaticv1.Cluster, error) {
+	networkName := cluster.Spec.Cloud.Hetzner.Network
+	managed := kuberneteshelper.HasFinalizer(cluster, NetworkCleanupFinalizer)
+
+	// networks chosen by the user or configured for the whole datacenter are not managed by us
+	if networkName != "" && !managed {
+		return cluster, nil
+	}
+	if networkName == "" && dc.Network != "" {
+		return cluster, nil
+	}
+
+	// existing clusters without a network must not get one, as their machines would be rolled out again
+	if !create && !managed {
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+
+	network, _, err := client.Network.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network %q: %w", name, err)
+	}
+
+	if network == nil {
+		zone, err := getNetworkZone(ctx, client, dc.Datacenter)
+		if err != nil {
+			return nil, err
+		}
+
+		_, ipRange, _ := net.ParseCIDR(networkIPRange)
+		_, subnetRange, _ := net.ParseCIDR(subnetIPRange)
+
+		network, _, err = client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+			Name:    name,
+			IPRange: ipRange,
+			Subnets: []hcloud.NetworkSubnet{
+				{
+					Type:        hcloud.NetworkSubnetTypeCloud,
+					IPRange:     subnetRange,
+					NetworkZone: zone,
+				},
+			},
+			Labels: clusterLabels(cluster),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create network %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Hetzner.Network = network.Name
+		kuberneteshelper.AddFinalizer(cluster, NetworkCleanupFinalizer)
+	})
+}
+
+func deleteNetwork(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *hcloud.Client) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, NetworkCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	name := cluster.Spec.Cloud.Hetzner.Network
+
+	network, _, err := client.Network.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network %q: %w", name, err)
+	}
+
+	if network != nil {
+		if _, err := client.Network.Delete(ctx, network); err != nil && !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return nil, fmt.Errorf("failed to delete network %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, NetworkCleanupFinalizer)
+	})
+}
+
+// getNetworkZone returns the network zone (e.g. "eu-central") that the given
+// datacenter belongs to.
+func getNetworkZone(ctx context.Context, client *hcloud.Client, datacenterName string) (hcloud.NetworkZone, error) {
+	datacenter, _, err := client.Datacenter.GetByName(ctx, datacenterName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get datacenter %q: %w", datacenterName, err)
+	}
+	if datacenter == nil || datacenter.Location == nil {
+		return "", fmt.Errorf("datacenter %q does not exist", datacenterName)
+	}
+
+	return datacenter.Location.NetworkZone, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// reconcilePlacementGroup ensures a spread placement group for the cluster.
+// The machine-controller uses the name as a prefix and creates additional
+// groups once a group holds the maximum of 10 servers.
+func reconcilePlacementGroup(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *hcloud.Client) (*kubermaticv1.Cluster, error) {
+	if cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix != "" && !kuberneteshelper.HasFinalizer(cluster, PlacementGroupCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+
+	placementGroup, _, err := client.PlacementGroup.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement group %q: %w", name, err)
+	}
+
+	if placementGroup == nil {
+		_, _, err = client.PlacementGroup.Create(ctx, hcloud.PlacementGroupCreateOpts{
+			Name:   name,
+			Type:   hcloud.PlacementGroupTypeSpread,
+			Labels: clusterLabels(cluster),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create placement group %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix = name
+		kuberneteshelper.AddFinalizer(cluster, PlacementGroupCleanupFinalizer)
+	})
+}
+
+// deletePlacementGroups removes all placement groups that belong to the cluster,
+// including those created by the machine-controller.
+func deletePlacementGroups(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, client *hcloud.Client) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, PlacementGroupCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	prefix := cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix
+
+	placementGroups, err := client.PlacementGroup.AllWithOpts(ctx, hcloud.PlacementGroupListOpts{
+		Type: hcloud.PlacementGroupTypeSpread,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list placement groups: %w", err)
+	}
+
+	for _, placementGroup := range placementGroups {
+		if placementGroup.Name != prefix && !strings.HasPrefix(placementGroup.Name, prefix+"-") {
+			continue
+		}
+
+		if _, err := client.PlacementGroup.Delete(ctx, placementGroup); err != nil && !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return nil, fmt.Errorf("failed to delete placement group %q: %w", placementGroup.Name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, PlacementGroupCleanupFinalizer)
+	})
+}
@@ -19,27 +19,60 @@ package hetzner
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/hcloud"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+const (
+	NetworkCleanupFinalizer        = "kubermatic.k8c.io/cleanup-hetzner-network"
+	FirewallCleanupFinalizer       = "kubermatic.k8c.io/cleanup-hetzner-firewall"
+	PlacementGroupCleanupFinalizer = "kubermatic.k8c.io/cleanup-hetzner-placement-group"
+
+	resourceNamePrefix = "kubernetes-cluster-"
+	clusterLabelKey    = "kubernetes-cluster"
+)
+
 type hetzner struct {
+	dc                *kubermaticv1.DatacenterSpecHetzner
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	// newClient creates the Hetzner API client for a token; overridden in tests.
+	newClient func(token string) *hcloud.Client
 }
 
 // NewCloudProvider creates a new hetzner provider.
-func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
+func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+	if dc.Spec.Hetzner == nil {
+		return nil, errors.New("datacenter is not a Hetzner datacenter")
+	}
 	return &hetzner{
+		dc:                dc.Spec.Hetzner,
 		secretKeySelector: secretKeyGetter,
-	}
+		newClient:         newClient,
+	}, nil
 }
 
-var _ provider.CloudProvider = &hetzner{}
+var _ provider.ReconcilingCloudProvider = &hetzner{}
+
+func newClient(token string) *hcloud.Client {
+	return hcloud.NewClient(hcloud.WithToken(token))
+}
+
+func resourceName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name
+}
+
+func clusterLabels(cluster *kubermaticv1.Cluster) map[string]string {
+	return map[string]string{
+		clusterLabelKey: cluster.Name,
+	}
+}
 
 // DefaultCloudSpec.
 func (h *hetzner) DefaultCloudSpec(_ context.Context, _ *kubermaticv1.CloudSpec) error {
@@ -53,7 +86,7 @@ func (h *hetzner) ValidateCloudSpec(ctx context.Context, spec kubermaticv1.Cloud
 		return err
 	}
 
-	client := hcloud.NewClient(hcloud.WithToken(hetznerToken))
+	client := h.newClient(hetznerToken)
 
 	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -69,16 +102,70 @@ func (h *hetzner) ValidateCloudSpec(ctx context.Context, spec kubermaticv1.Cloud
 	return err
 }
 
-// InitializeCloudProvider.
-func (h *hetzner) InitializeCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+// InitializeCloudProvider creates the network, firewall and placement group for the cluster.
+func (h *hetzner) InitializeCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return h.reconcileCluster(ctx, cluster, update, true)
 }
 
-// CleanUpCloudProvider.
-func (h *hetzner) CleanUpCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+// ReconcileCluster enforces the existence of the network, firewall and placement group of the cluster. A network is
+// only recreated if it was created by InitializeCloudProvider, as the nodes of existing clusters without a private
+// network would otherwise be replaced to be attached to it.
+func (h *hetzner) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return h.reconcileCluster(ctx, cluster, update, false)
+}
+
+func (h *hetzner) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, initialize bool) (*kubermaticv1.Cluster, error) {
+	hetznerToken, err := GetCredentialsForCluster(cluster.Spec.Cloud, h.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := h.newClient(hetznerToken)
+
+	cluster, err = reconcileNetwork(ctx, cluster, update, client, h.dc, initialize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile network: %w", err)
+	}
+
+	cluster, err = reconcileFirewall(ctx, cluster, update, client, h.dc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile firewall: %w", err)
+	}
+
+	cluster, err = reconcilePlacementGroup(ctx, cluster, update, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile placement group: %w", err)
+	}
+
 	return cluster, nil
 }
 
+// CleanUpCloudProvider removes all resources that were created for the cluster.
+func (h *hetzner) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasAnyFinalizer(cluster, NetworkCleanupFinalizer, FirewallCleanupFinalizer, PlacementGroupCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	hetznerToken, err := GetCredentialsForCluster(cluster.Spec.Cloud, h.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := h.newClient(hetznerToken)
+
+	cluster, err = deletePlacementGroups(ctx, cluster, update, client)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err = deleteFirewall(ctx, cluster, update, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return deleteNetwork(ctx, cluster, update, client)
+}
+
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted.
 func (h *hetzner) ValidateCloudSpecUpdate(_ context.Context, _ kubermaticv1.CloudSpec, _ kubermaticv1.CloudSpec) error {
 	return nil
@@ -0,0 +1,496 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	testToken      = "test-token"
+	testDatacenter = "nbg1-dc3"
+)
+
+// fakeHCloud is a minimal in-memory implementation of the Hetzner Cloud API
+// endpoints for networks, firewalls and placement groups.
+type fakeHCloud struct {
+	lock sync.Mutex
+
+	lastID          int
+	networks        map[int]schema.Network
+	firewalls       map[int]schema.Firewall
+	placementGroups map[int]schema.PlacementGroup
+	requests        map[string]int
+}
+
+func newFakeHCloud() *fakeHCloud {
+	return &fakeHCloud{
+		networks:        map[int]schema.Network{},
+		firewalls:       map[int]schema.Firewall{},
+		placementGroups: map[int]schema.PlacementGroup{},
+		requests:        map[string]int{},
+	}
+}
+
+func (f *fakeHCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	collection := parts[0]
+	if len(parts) <= 2 {
+		f.requests[r.Method+" /"+collection]++
+	}
+
+	var id int
+	if len(parts) > 1 {
+		var err error
+		if id, err = strconv.Atoi(parts[1]); err != nil {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+	}
+
+	name := r.URL.Query().Get("name")
+
+	switch {
+	case collection == "datacenters" && r.Method == http.MethodGet:
+		writeJSON(w, schema.DatacenterListResponse{
+			Datacenters: []schema.Datacenter{{
+				ID:       1,
+				Name:     testDatacenter,
+				Location: schema.Location{ID: 1, Name: "nbg1", NetworkZone: string(hcloud.NetworkZoneEUCentral)},
+			}},
+		})
+
+	case collection == "networks" && r.Method == http.MethodGet:
+		result := schema.NetworkListResponse{Networks: []schema.Network{}}
+		for _, network := range f.networks {
+			if name == "" || network.Name == name {
+				result.Networks = append(result.Networks, network)
+			}
+		}
+		writeJSON(w, result)
+
+	case collection == "networks" && r.Method == http.MethodPost:
+		req := schema.NetworkCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		f.lastID++
+		f.networks[f.lastID] = schema.Network{ID: f.lastID, Name: req.Name, IPRange: req.IPRange, Subnets: req.Subnets}
+		writeJSON(w, schema.NetworkCreateResponse{Network: f.networks[f.lastID]})
+
+	case collection == "networks" && r.Method == http.MethodDelete:
+		if _, ok := f.networks[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		delete(f.networks, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case collection == "firewalls" && r.Method == http.MethodGet:
+		result := schema.FirewallListResponse{Firewalls: []schema.Firewall{}}
+		for _, firewall := range f.firewalls {
+			if name == "" || firewall.Name == name {
+				result.Firewalls = append(result.Firewalls, firewall)
+			}
+		}
+		writeJSON(w, result)
+
+	case collection == "firewalls" && r.Method == http.MethodPost && len(parts) == 1:
+		req := schema.FirewallCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		f.lastID++
+		f.firewalls[f.lastID] = schema.Firewall{ID: f.lastID, Name: req.Name, Rules: req.Rules}
+		writeJSON(w, schema.FirewallCreateResponse{Firewall: f.firewalls[f.lastID]})
+
+	case collection == "firewalls" && r.Method == http.MethodPost && len(parts) == 4 && parts[3] == "set_rules":
+		firewall, ok := f.firewalls[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		req := schema.FirewallActionSetRulesRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		f.requests["POST /firewalls/set_rules"]++
+		firewall.Rules = req.Rules
+		f.firewalls[id] = firewall
+		writeJSON(w, schema.FirewallActionSetRulesResponse{Actions: []schema.Action{}})
+
+	case collection == "firewalls" && r.Method == http.MethodDelete:
+		if _, ok := f.firewalls[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		delete(f.firewalls, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case collection == "placement_groups" && r.Method == http.MethodGet:
+		result := schema.PlacementGroupListResponse{PlacementGroups: []schema.PlacementGroup{}}
+		for _, placementGroup := range f.placementGroups {
+			if name == "" || placementGroup.Name == name {
+				result.PlacementGroups = append(result.PlacementGroups, placementGroup)
+			}
+		}
+		writeJSON(w, result)
+
+	case collection == "placement_groups" && r.Method == http.MethodPost:
+		req := schema.PlacementGroupCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		f.lastID++
+		f.placementGroups[f.lastID] = schema.PlacementGroup{ID: f.lastID, Name: req.Name, Type: req.Type}
+		writeJSON(w, schema.PlacementGroupCreateResponse{PlacementGroup: f.placementGroups[f.lastID]})
+
+	case collection == "placement_groups" && r.Method == http.MethodDelete:
+		if _, ok := f.placementGroups[id]; !ok {
+			writeError(w, http.StatusNotFound, "not_found")
+			return
+		}
+		delete(f.placementGroups, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found")
+	}
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(schema.ErrorResponse{Error: schema.Error{Code: code, Message: code}})
+}
+
+type fakeClusterUpdater struct {
+	c *kubermaticv1.Cluster
+}
+
+func (f *fakeClusterUpdater) update(_ context.Context, _ string, updateFn func(c *kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error) {
+	updateFn(f.c)
+	return f.c, nil
+}
+
+func newTestProvider(t *testing.T, api *fakeHCloud, dc *kubermaticv1.DatacenterSpecHetzner) *hetzner {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return &hetzner{
+		dc: dc,
+		newClient: func(token string) *hcloud.Client {
+			return hcloud.NewClient(hcloud.WithToken(token), hcloud.WithEndpoint(server.URL))
+		},
+	}
+}
+
+func newTestCluster(spec kubermaticv1.HetznerCloudSpec, finalizers ...string) *kubermaticv1.Cluster {
+	spec.Token = testToken
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "xyz",
+			Finalizers: finalizers,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+			},
+			Cloud: kubermaticv1.CloudSpec{
+				Hetzner: &spec,
+			},
+		},
+	}
+}
+
+func TestReconcileCluster(t *testing.T) {
+	allFinalizers := []string{NetworkCleanupFinalizer, FirewallCleanupFinalizer, PlacementGroupCleanupFinalizer}
+
+	testCases := []struct {
+		name           string
+		dc             *kubermaticv1.DatacenterSpecHetzner
+		spec           kubermaticv1.HetznerCloudSpec
+		finalizers     []string
+		initialize     bool
+		existing       func(api *fakeHCloud)
+		wantSpec       kubermaticv1.HetznerCloudSpec
+		wantFinalizers []string
+		wantRequests   map[string]int
+	}{
+		{
+			name:       "creates all resources",
+			dc:         &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter},
+			initialize: true,
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				Network:              "kubernetes-cluster-xyz",
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			wantFinalizers: allFinalizers,
+			wantRequests: map[string]int{
+				"POST /networks":            1,
+				"POST /firewalls":           1,
+				"POST /placement_groups":    1,
+				"POST /firewalls/set_rules": 0,
+			},
+		},
+		{
+			name: "does not create a network or firewall for existing clusters",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter},
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			wantFinalizers: []string{PlacementGroupCleanupFinalizer},
+			wantRequests: map[string]int{
+				"POST /networks":  0,
+				"POST /firewalls": 0,
+			},
+		},
+		{
+			name: "opens up the firewall of clusters without a private network",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter},
+			spec: kubermaticv1.HetznerCloudSpec{
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			finalizers: []string{FirewallCleanupFinalizer, PlacementGroupCleanupFinalizer},
+			existing: func(api *fakeHCloud) {
+				api.firewalls[1] = schema.Firewall{ID: 1, Name: "kubernetes-cluster-xyz", Rules: []schema.FirewallRule{{
+					Direction: "in",
+					Protocol:  "tcp",
+					Port:      hcloud.String("22"),
+					SourceIPs: []string{"0.0.0.0/0"},
+				}}}
+				api.placementGroups[2] = schema.PlacementGroup{ID: 2, Name: "kubernetes-cluster-xyz", Type: "spread"}
+				api.lastID = 2
+			},
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			wantFinalizers: []string{FirewallCleanupFinalizer, PlacementGroupCleanupFinalizer},
+			wantRequests: map[string]int{
+				"POST /firewalls":           0,
+				"POST /firewalls/set_rules": 1,
+			},
+		},
+		{
+			name: "recreates the managed network",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter},
+			spec: kubermaticv1.HetznerCloudSpec{
+				Network: "kubernetes-cluster-xyz",
+			},
+			finalizers: []string{NetworkCleanupFinalizer},
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				Network:              "kubernetes-cluster-xyz",
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			wantFinalizers: allFinalizers,
+			wantRequests: map[string]int{
+				"POST /networks": 1,
+			},
+		},
+		{
+			name: "uses network of the datacenter",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter, Network: "dc-network"},
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			wantFinalizers: []string{FirewallCleanupFinalizer, PlacementGroupCleanupFinalizer},
+			wantRequests: map[string]int{
+				"POST /networks": 0,
+			},
+		},
+		{
+			name: "keeps resources provided by the user",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter},
+			spec: kubermaticv1.HetznerCloudSpec{
+				Network:              "my-network",
+				Firewall:             "my-firewall",
+				PlacementGroupPrefix: "my-group",
+			},
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				Network:              "my-network",
+				Firewall:             "my-firewall",
+				PlacementGroupPrefix: "my-group",
+			},
+			wantRequests: map[string]int{
+				"POST /networks":         0,
+				"POST /firewalls":        0,
+				"POST /placement_groups": 0,
+			},
+		},
+		{
+			name: "fixes outdated firewall rules",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter},
+			spec: kubermaticv1.HetznerCloudSpec{
+				Network:              "kubernetes-cluster-xyz",
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			finalizers: allFinalizers,
+			existing: func(api *fakeHCloud) {
+				api.networks[1] = schema.Network{ID: 1, Name: "kubernetes-cluster-xyz"}
+				api.firewalls[2] = schema.Firewall{ID: 2, Name: "kubernetes-cluster-xyz", Rules: []schema.FirewallRule{{
+					Direction: "in",
+					Protocol:  "tcp",
+					Port:      hcloud.String("1-65535"),
+					SourceIPs: []string{"0.0.0.0/0"},
+				}}}
+				api.placementGroups[3] = schema.PlacementGroup{ID: 3, Name: "kubernetes-cluster-xyz", Type: "spread"}
+				api.lastID = 3
+			},
+			wantSpec: kubermaticv1.HetznerCloudSpec{
+				Network:              "kubernetes-cluster-xyz",
+				Firewall:             "kubernetes-cluster-xyz",
+				PlacementGroupPrefix: "kubernetes-cluster-xyz",
+			},
+			wantFinalizers: allFinalizers,
+			wantRequests: map[string]int{
+				"POST /networks":            0,
+				"POST /firewalls":           0,
+				"POST /placement_groups":    0,
+				"POST /firewalls/set_rules": 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newFakeHCloud()
+			if tc.existing != nil {
+				tc.existing(api)
+			}
+
+			prov := newTestProvider(t, api, tc.dc)
+			updater := &fakeClusterUpdater{c: newTestCluster(tc.spec, tc.finalizers...)}
+
+			reconcile := prov.ReconcileCluster
+			if tc.initialize {
+				reconcile = prov.InitializeCloudProvider
+			}
+
+			cluster, err := reconcile(context.Background(), updater.c, updater.update)
+			if err != nil {
+				t.Fatalf("failed to reconcile cluster: %v", err)
+			}
+
+			tc.wantSpec.Token = testToken
+			if *cluster.Spec.Cloud.Hetzner != tc.wantSpec {
+				t.Errorf("expected spec %+v, got %+v", tc.wantSpec, *cluster.Spec.Cloud.Hetzner)
+			}
+
+			if !sets.NewString(cluster.Finalizers...).Equal(sets.NewString(tc.wantFinalizers...)) {
+				t.Errorf("expected finalizers %v, got %v", tc.wantFinalizers, cluster.Finalizers)
+			}
+
+			for request, count := range tc.wantRequests {
+				if got := api.requests[request]; got != count {
+					t.Errorf("expected %d %q requests, got %d", count, request, got)
+				}
+			}
+
+			// reconciling again must not change anything
+			modifications := modifyingRequests(api)
+			if _, err := prov.ReconcileCluster(context.Background(), cluster, updater.update); err != nil {
+				t.Fatalf("second ReconcileCluster() failed: %v", err)
+			}
+			if got := modifyingRequests(api) - modifications; got != 0 {
+				t.Errorf("expected second reconciliation to not modify any resources, but %d modifying requests were sent", got)
+			}
+		})
+	}
+}
+
+func modifyingRequests(api *fakeHCloud) int {
+	return api.requests["POST /networks"] + api.requests["POST /firewalls"] + api.requests["POST /placement_groups"] + api.requests["POST /firewalls/set_rules"]
+}
+
+func TestCleanUpCloudProvider(t *testing.T) {
+	api := newFakeHCloud()
+	api.networks[1] = schema.Network{ID: 1, Name: "kubernetes-cluster-xyz"}
+	api.firewalls[2] = schema.Firewall{ID: 2, Name: "kubernetes-cluster-xyz"}
+	api.placementGroups[3] = schema.PlacementGroup{ID: 3, Name: "kubernetes-cluster-xyz", Type: "spread"}
+	api.placementGroups[4] = schema.PlacementGroup{ID: 4, Name: "kubernetes-cluster-xyz-abcde", Type: "spread"}
+	api.placementGroups[5] = schema.PlacementGroup{ID: 5, Name: "kubernetes-cluster-other", Type: "spread"}
+	api.firewalls[6] = schema.Firewall{ID: 6, Name: "my-firewall"}
+
+	prov := newTestProvider(t, api, &kubermaticv1.DatacenterSpecHetzner{Datacenter: testDatacenter})
+	updater := &fakeClusterUpdater{c: newTestCluster(kubermaticv1.HetznerCloudSpec{
+		Network:              "kubernetes-cluster-xyz",
+		Firewall:             "my-firewall",
+		PlacementGroupPrefix: "kubernetes-cluster-xyz",
+	}, NetworkCleanupFinalizer, PlacementGroupCleanupFinalizer)}
+
+	cluster, err := prov.CleanUpCloudProvider(context.Background(), updater.c, updater.update)
+	if err != nil {
+		t.Fatalf("CleanUpCloudProvider() failed: %v", err)
+	}
+
+	if kuberneteshelper.HasAnyFinalizer(cluster, NetworkCleanupFinalizer, FirewallCleanupFinalizer, PlacementGroupCleanupFinalizer) {
+		t.Errorf("expected all finalizers to be removed, got %v", cluster.Finalizers)
+	}
+	if len(api.networks) != 0 {
+		t.Errorf("expected network to be deleted, got %v", api.networks)
+	}
+	if _, ok := api.firewalls[6]; !ok || len(api.firewalls) != 2 {
+		t.Errorf("expected the firewalls without cleanup finalizer to be kept, got %v", api.firewalls)
+	}
+	if _, ok := api.placementGroups[5]; !ok || len(api.placementGroups) != 1 {
+		t.Errorf("expected only the placement group of the other cluster to be kept, got %v", api.placementGroups)
+	}
+}
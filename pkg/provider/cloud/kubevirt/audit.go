@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceResourceKind is the kind of namespaces reported by the resource audit.
+const namespaceResourceKind = "namespace"
+
+var _ provider.CloudResourceAuditor = &kubevirt{}
+
+// ListClusterResources returns the dedicated namespaces of all clusters in the underlying
+// KubeVirt cluster. Only namespaces labelled with the cluster name are taken into account.
+func (k *kubevirt) ListClusterResources(ctx context.Context, spec kubermaticv1.CloudSpec) ([]provider.CloudResource, error) {
+	client, err := k.getClientForSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := client.List(ctx, namespaces, ctrlruntimeclient.HasLabels{resources.ClusterLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	var result []provider.CloudResource
+	for _, ns := range namespaces.Items {
+		clusterName := ns.Labels[resources.ClusterLabelKey]
+		if ns.Name != fmt.Sprintf("cluster-%s", clusterName) {
+			continue
+		}
+
+		result = append(result, provider.CloudResource{
+			Kind:        namespaceResourceKind,
+			ID:          ns.Name,
+			Name:        ns.Name,
+			ClusterName: clusterName,
+			Datacenter:  ns.Labels[datacenterLabelKey],
+		})
+	}
+
+	return result, nil
+}
+
+// DeleteClusterResource deletes a namespace returned by ListClusterResources.
+func (k *kubevirt) DeleteClusterResource(ctx context.Context, spec kubermaticv1.CloudSpec, resource provider.CloudResource) error {
+	if resource.Kind != namespaceResourceKind {
+		return fmt.Errorf("unsupported resource kind %q", resource.Kind)
+	}
+
+	client, err := k.getClientForSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	return deleteNamespace(ctx, resource.ID, client)
+}
+
+func (k *kubevirt) getClientForSpec(spec kubermaticv1.CloudSpec) (ctrlruntimeclient.Client, error) {
+	client, _, err := k.GetClientWithRestConfigForCluster(&kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{Cloud: spec},
+	})
+
+	return client, err
+}
@@ -23,6 +23,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,9 +31,22 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NamespaceCreator(name string) reconciling.NamedNamespaceCreatorGetter {
+// datacenterLabelKey is the label that marks the dedicated namespace with the datacenter
+// of its cluster, so that the resource audit can tell apart clusters of other Seeds.
+const datacenterLabelKey = "kubermatic.k8c.io/datacenter"
+
+// NamespaceCreator returns the creator for the dedicated namespace of the given cluster.
+// The namespace is labelled with the cluster name and datacenter, so that it can be found
+// by the resource audit once the cluster is gone.
+func NamespaceCreator(name string, clusterName string, datacenterName string) reconciling.NamedNamespaceCreatorGetter {
 	return func() (string, reconciling.NamespaceCreator) {
 		return name, func(n *corev1.Namespace) (*corev1.Namespace, error) {
+			if n.Labels == nil {
+				n.Labels = map[string]string{}
+			}
+			n.Labels[resources.ClusterLabelKey] = clusterName
+			n.Labels[datacenterLabelKey] = datacenterName
+
 			return n, nil
 		}
 	}
@@ -48,7 +62,7 @@ func reconcileNamespace(ctx context.Context, name string, cluster *kubermaticv1.
 	}
 
 	creators := []reconciling.NamedNamespaceCreatorGetter{
-		NamespaceCreator(name),
+		NamespaceCreator(name, cluster.Name, cluster.Spec.Cloud.DatacenterName),
 	}
 
 	if err := reconciling.ReconcileNamespaces(ctx, creators, "", client); err != nil {
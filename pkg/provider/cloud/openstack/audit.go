@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+
+	osrouters "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	osnetworks "github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// networkResourceKind is the kind of networks reported by the resource audit.
+const networkResourceKind = "network"
+
+var _ provider.CloudResourceAuditor = &Provider{}
+
+// ListClusterResources returns all networks that carry the ownership tag of a cluster.
+// Networks created before they were tagged are not reported, as they cannot be told
+// apart from networks that were not created by KKP.
+func (os *Provider) ListClusterResources(ctx context.Context, spec kubermaticv1.CloudSpec) ([]provider.CloudResource, error) {
+	netClient, err := os.getClientFunc(ctx, spec, os.dc, os.secretKeySelector, os.caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := getAllNetworks(netClient, osnetworks.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var resources []provider.CloudResource
+	for _, network := range networks {
+		clusterName, ok := clusterNameFromTags(network.Tags)
+		if network.External || !ok {
+			continue
+		}
+
+		resources = append(resources, provider.CloudResource{
+			Kind:        networkResourceKind,
+			ID:          network.ID,
+			Name:        network.Name,
+			ClusterName: clusterName,
+			Datacenter:  datacenterFromTags(network.Tags),
+		})
+	}
+
+	return resources, nil
+}
+
+// DeleteClusterResource deletes a network returned by ListClusterResources. Its subnets
+// are detached from their routers first, and routers that carry the ownership tag of the
+// same cluster are deleted as well.
+func (os *Provider) DeleteClusterResource(ctx context.Context, spec kubermaticv1.CloudSpec, resource provider.CloudResource) error {
+	if resource.Kind != networkResourceKind {
+		return fmt.Errorf("unsupported resource kind %q", resource.Kind)
+	}
+
+	netClient, err := os.getClientFunc(ctx, spec, os.dc, os.secretKeySelector, os.caBundle)
+	if err != nil {
+		return err
+	}
+
+	network, err := osnetworks.Get(netClient, resource.ID).Extract()
+	if err != nil {
+		if isNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get network %s: %w", resource.ID, err)
+	}
+
+	if clusterName, ok := clusterNameFromTags(network.Tags); !ok || clusterName != resource.ClusterName {
+		return fmt.Errorf("network %s is not owned by cluster %s", resource.ID, resource.ClusterName)
+	}
+
+	for _, subnetID := range network.Subnets {
+		routerID, err := getRouterIDForSubnet(netClient, subnetID)
+		if err != nil {
+			return err
+		}
+		if routerID == "" {
+			continue
+		}
+
+		if _, err := detachSubnetFromRouter(netClient, subnetID, routerID); err != nil && !isNotFoundErr(err) {
+			return fmt.Errorf("failed to detach subnet %s from router: %w", subnetID, err)
+		}
+
+		router, err := osrouters.Get(netClient, routerID).Extract()
+		if err != nil {
+			if isNotFoundErr(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get router %s: %w", routerID, err)
+		}
+
+		if clusterName, ok := clusterNameFromTags(router.Tags); ok && clusterName == resource.ClusterName {
+			if err := deleteRouter(netClient, routerID); err != nil && !isNotFoundErr(err) {
+				return fmt.Errorf("failed to delete router %s: %w", routerID, err)
+			}
+		}
+	}
+
+	if err := osnetworks.Delete(netClient, resource.ID).ExtractErr(); err != nil && !isNotFoundErr(err) {
+		return fmt.Errorf("failed to delete network %s: %w", resource.ID, err)
+	}
+
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	osprojects "github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 	ostokens "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	osusers "github.com/gophercloud/gophercloud/openstack/identity/v3/users"
+	osattributestags "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
 	osextnetwork "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/external"
 	osrouters "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
 	ossecuritygroups "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
@@ -54,8 +55,54 @@ const (
 	defaultIPv6SubnetCIDR = "fd00::/64"
 
 	resourceNamePrefix = "kubernetes-"
+
+	// ownershipTagPrefix is the prefix of the tag that marks networks and routers as created for the cluster whose
+	// name follows the prefix. Neutron does not allow slashes in tags, so it differs from the AWS ownership tag.
+	ownershipTagPrefix = "owned-by.kubermatic.k8c.io:"
+
+	// datacenterTagPrefix is the prefix of the tag that marks networks and routers with the datacenter
+	// of their cluster, so that the resource audit can tell apart clusters of other Seeds.
+	datacenterTagPrefix = "datacenter.kubermatic.k8c.io:"
 )
 
+func ownershipTag(clusterName string) string {
+	return ownershipTagPrefix + clusterName
+}
+
+func datacenterTag(datacenterName string) string {
+	return datacenterTagPrefix + datacenterName
+}
+
+// datacenterFromTags returns the datacenter of the cluster that the resource with the given tags was created for.
+func datacenterFromTags(tags []string) string {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, datacenterTagPrefix) {
+			return strings.TrimPrefix(tag, datacenterTagPrefix)
+		}
+	}
+	return ""
+}
+
+// tagResource marks a network or router as created for the given cluster.
+func tagResource(netClient *gophercloud.ServiceClient, resourceType, id, clusterName, datacenterName string) error {
+	for _, tag := range []string{ownershipTag(clusterName), datacenterTag(datacenterName)} {
+		if err := osattributestags.Add(netClient, resourceType, id, tag).ExtractErr(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clusterNameFromTags returns the name of the cluster that the resource with the given tags was created for.
+func clusterNameFromTags(tags []string) (string, bool) {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, ownershipTagPrefix) {
+			return strings.TrimPrefix(tag, ownershipTagPrefix), true
+		}
+	}
+	return "", false
+}
+
 func getSecurityGroups(netClient *gophercloud.ServiceClient, opts ossecuritygroups.ListOpts) ([]ossecuritygroups.SecGroup, error) {
 	page, err := ossecuritygroups.List(netClient, opts).AllPages()
 	if err != nil {
@@ -311,7 +358,7 @@ func createKubermaticSecurityGroup(netClient *gophercloud.ServiceClient, req cre
 	return secGroupName, nil
 }
 
-func createKubermaticNetwork(netClient *gophercloud.ServiceClient, clusterName string) (*osnetworks.Network, error) {
+func createKubermaticNetwork(netClient *gophercloud.ServiceClient, clusterName, datacenterName string) (*osnetworks.Network, error) {
 	iTrue := true
 	res := osnetworks.Create(netClient, osnetworks.CreateOpts{
 		Name:         resourceNamePrefix + clusterName,
@@ -320,7 +367,15 @@ func createKubermaticNetwork(netClient *gophercloud.ServiceClient, clusterName s
 	if res.Err != nil {
 		return nil, res.Err
 	}
-	return res.Extract()
+	network, err := res.Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tagResource(netClient, "networks", network.ID, clusterName, datacenterName); err != nil {
+		return nil, fmt.Errorf("failed to tag network %s: %w", network.ID, err)
+	}
+	return network, nil
 }
 
 func deleteNetworkByName(netClient *gophercloud.ServiceClient, networkName string) error {
@@ -461,7 +516,7 @@ func getAllSubnetPools(netClient *gophercloud.ServiceClient, listOpts subnetpool
 	return allSubnetPools, nil
 }
 
-func createKubermaticRouter(netClient *gophercloud.ServiceClient, clusterName, extNetworkName string) (*osrouters.Router, error) {
+func createKubermaticRouter(netClient *gophercloud.ServiceClient, clusterName, datacenterName, extNetworkName string) (*osrouters.Router, error) {
 	extNetwork, err := getNetworkByName(netClient, extNetworkName, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get external network %q: %w", extNetworkName, err)
@@ -480,7 +535,15 @@ func createKubermaticRouter(netClient *gophercloud.ServiceClient, clusterName, e
 	if res.Err != nil {
 		return nil, res.Err
 	}
-	return res.Extract()
+	router, err := res.Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tagResource(netClient, "routers", router.ID, clusterName, datacenterName); err != nil {
+		return nil, fmt.Errorf("failed to tag router %s: %w", router.ID, err)
+	}
+	return router, nil
 }
 
 func attachSubnetToRouter(netClient *gophercloud.ServiceClient, subnetID, routerID string) (*osrouters.InterfaceInfo, error) {
@@ -22,6 +22,7 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -476,7 +477,9 @@ func NewSimulator(t *testing.T) *Simulator {
 		Register(func() Resource { return &SecGroup{} }).
 		Register(func() Resource { return &SecGroupRule{} }).
 		Register(func() Resource { return &Router{} }).
-		Register(func() Resource { return &Port{} })
+		Register(func() Resource { return &Port{} }).
+		RegisterTags("/networks").
+		RegisterTags("/routers")
 }
 
 func (s *Simulator) TearDown() {
@@ -492,6 +495,13 @@ func (s *Simulator) Register(builder ResourceBuilder) *Simulator {
 	return s
 }
 
+// RegisterTags handles requests adding a tag to resources of the given collection,
+// i.e. PUT <collection>/<id>/tags/<tag>.
+func (s *Simulator) RegisterTags(collection string) *Simulator {
+	ostesthelper.Mux.HandleFunc(collection+"/", s.HandleTags)
+	return s
+}
+
 func (s *Simulator) Add(res ...Resource) *Simulator {
 	for _, r := range res {
 		if b, ok := s.builders[r.GetPath()]; !ok || reflect.TypeOf(b()) != reflect.TypeOf(r) {
@@ -604,3 +614,32 @@ func (s *Simulator) Handle(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotImplemented)
 	}
 }
+
+func (s *Simulator) HandleTags(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	s.reqCount[Request{Path: r.URL.Path, Method: r.Method}]++
+	ostesthelper.TestHeader(s.T, r, "X-Auth-Token", osfakeclient.TokenID)
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodPut || len(parts) != 4 || parts[2] != "tags" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	for _, res := range s.resources["/"+parts[0]] {
+		if res.GetID() != parts[1] {
+			continue
+		}
+		switch res := res.(type) {
+		case *Network:
+			res.Tags = append(res.Tags, parts[3])
+		case *Router:
+			res.Tags = append(res.Tags, parts[3])
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
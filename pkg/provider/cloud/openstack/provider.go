@@ -314,7 +314,7 @@ func (os *Provider) InitializeCloudProvider(ctx context.Context, cluster *kuberm
 	}
 
 	if cluster.Spec.Cloud.Openstack.Network == "" {
-		network, err := createKubermaticNetwork(netClient, cluster.Name)
+		network, err := createKubermaticNetwork(netClient, cluster.Name, cluster.Spec.Cloud.DatacenterName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create the kubermatic network: %w", err)
 		}
@@ -360,7 +360,7 @@ func (os *Provider) InitializeCloudProvider(ctx context.Context, cluster *kuberm
 	if cluster.Spec.Cloud.Openstack.RouterID == "" {
 		if routerID == "" {
 			// No Router exists -> Create a router
-			router, err := createKubermaticRouter(netClient, cluster.Name, cluster.Spec.Cloud.Openstack.FloatingIPPool)
+			router, err := createKubermaticRouter(netClient, cluster.Name, cluster.Spec.Cloud.DatacenterName, cluster.Spec.Cloud.Openstack.FloatingIPPool)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create the kubermatic router: %w", err)
 			}
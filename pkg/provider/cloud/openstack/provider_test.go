@@ -129,11 +129,13 @@ func TestInitializeCloudProvider(t *testing.T) {
 			},
 			wantErr: false,
 			wantRequests: map[ostesting.Request]int{
-				{Method: http.MethodPost, Path: ostesting.SecurityGroupsEndpoint}:                        1,
-				{Method: http.MethodPost, Path: ostesting.NetworksEndpoint}:                              1,
-				{Method: http.MethodPost, Path: ostesting.SubnetsEndpoint}:                               1,
-				{Method: http.MethodPost, Path: ostesting.RoutersEndpoint}:                               1,
-				{Method: http.MethodPut, Path: ostesting.AddRouterInterfaceEndpoint(ostesting.RouterID)}: 1,
+				{Method: http.MethodPost, Path: ostesting.SecurityGroupsEndpoint}:                                                               1,
+				{Method: http.MethodPost, Path: ostesting.NetworksEndpoint}:                                                                     1,
+				{Method: http.MethodPost, Path: ostesting.SubnetsEndpoint}:                                                                      1,
+				{Method: http.MethodPost, Path: ostesting.RoutersEndpoint}:                                                                      1,
+				{Method: http.MethodPut, Path: ostesting.AddRouterInterfaceEndpoint(ostesting.RouterID)}:                                        1,
+				{Method: http.MethodPut, Path: ostesting.NetworksEndpoint + "/" + ostesting.NetworkID + "/tags/" + ownershipTag("cluster-xyz")}: 1,
+				{Method: http.MethodPut, Path: ostesting.RoutersEndpoint + "/" + ostesting.RouterID + "/tags/" + ownershipTag("cluster-xyz")}:   1,
 			},
 		},
 		{
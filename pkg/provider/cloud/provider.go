@@ -63,7 +63,7 @@ func Provider(
 		return packet.NewCloudProvider(secretKeyGetter), nil
 	}
 	if datacenter.Spec.Hetzner != nil {
-		return hetzner.NewCloudProvider(secretKeyGetter), nil
+		return hetzner.NewCloudProvider(datacenter, secretKeyGetter)
 	}
 	if datacenter.Spec.VMwareCloudDirector != nil {
 		return vmwareclouddirector.NewCloudProvider(datacenter, secretKeyGetter)
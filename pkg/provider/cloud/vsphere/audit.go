@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/vmware/govmomi/vapi/tags"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// folderResourceKind is the kind of VM folders reported by the resource audit.
+	folderResourceKind = "folder"
+	// tagCategoryResourceKind is the kind of tag categories reported by the resource audit.
+	tagCategoryResourceKind = "tag-category"
+)
+
+var _ provider.CloudResourceAuditor = &Provider{}
+
+// ListClusterResources returns the tag categories that carry the ownership description of a
+// cluster and, for each of them, the empty VM folder that was created for the same cluster
+// below the root path. Categories created before they were marked and folders without a
+// matching category are not reported, as they cannot be told apart from resources that
+// were not created by KKP. Folders that still contain anything are never reported.
+func (v *Provider) ListClusterResources(ctx context.Context, spec kubermaticv1.CloudSpec) ([]provider.CloudResource, error) {
+	username, password, err := GetCredentialsForCluster(spec, v.secretKeySelector, v.dc)
+	if err != nil {
+		return nil, err
+	}
+
+	restSession, err := newRESTSession(ctx, v.dc, username, password, v.caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST client session: %w", err)
+	}
+	defer restSession.Logout(ctx)
+
+	categories, err := tags.NewManager(restSession.Client).GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag categories: %w", err)
+	}
+
+	session, err := newSession(ctx, v.dc, username, password, v.caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vCenter session: %w", err)
+	}
+	defer session.Logout(ctx)
+
+	rootPath := getVMRootPath(v.dc)
+
+	var folders, tagCategories []provider.CloudResource
+	for _, category := range categories {
+		clusterName, datacenter, ok := ownerFromCategory(category)
+		if !ok {
+			continue
+		}
+
+		tagCategories = append(tagCategories, provider.CloudResource{
+			Kind:        tagCategoryResourceKind,
+			ID:          category.ID,
+			Name:        category.Name,
+			ClusterName: clusterName,
+			Datacenter:  datacenter,
+		})
+
+		folderPath := path.Join(rootPath, clusterName)
+		folder, err := session.Finder.Folder(ctx, folderPath)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get folder %q: %w", folderPath, err)
+		}
+
+		children, err := folder.Children(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list children of folder %q: %w", folderPath, err)
+		}
+		if len(children) > 0 {
+			continue
+		}
+
+		folders = append(folders, provider.CloudResource{
+			Kind:        folderResourceKind,
+			ID:          folderPath,
+			Name:        clusterName,
+			ClusterName: clusterName,
+			Datacenter:  datacenter,
+		})
+	}
+
+	// folders are listed first, so that they are deleted before the tag categories
+	// that mark them as belonging to a cluster
+	return append(folders, tagCategories...), nil
+}
+
+// DeleteClusterResource deletes a folder or tag category returned by ListClusterResources.
+func (v *Provider) DeleteClusterResource(ctx context.Context, spec kubermaticv1.CloudSpec, resource provider.CloudResource) error {
+	username, password, err := GetCredentialsForCluster(spec, v.secretKeySelector, v.dc)
+	if err != nil {
+		return err
+	}
+
+	switch resource.Kind {
+	case folderResourceKind:
+		session, err := newSession(ctx, v.dc, username, password, v.caBundle)
+		if err != nil {
+			return fmt.Errorf("failed to create vCenter session: %w", err)
+		}
+		defer session.Logout(ctx)
+
+		return deleteEmptyVMFolder(ctx, session, resource.ID)
+
+	case tagCategoryResourceKind:
+		restSession, err := newRESTSession(ctx, v.dc, username, password, v.caBundle)
+		if err != nil {
+			return fmt.Errorf("failed to create REST client session: %w", err)
+		}
+		defer restSession.Logout(ctx)
+
+		if err := tags.NewManager(restSession.Client).DeleteCategory(ctx, &tags.Category{ID: resource.ID}); err != nil {
+			return fmt.Errorf("failed to delete tag category %s: %w", resource.Name, err)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported resource kind %q", resource.Kind)
+	}
+}
+
+// deleteEmptyVMFolder deletes the specified folder, unless it contains any VMs or other
+// objects that would be destroyed along with it.
+func deleteEmptyVMFolder(ctx context.Context, session *Session, path string) error {
+	folder, err := session.Finder.Folder(ctx, path)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("couldn't open folder %q: %w", path, err)
+	}
+
+	children, err := folder.Children(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list children of folder %q: %w", path, err)
+	}
+	if len(children) > 0 {
+		return fmt.Errorf("folder %q is not empty", path)
+	}
+
+	return deleteVMFolder(ctx, session, path)
+}
@@ -19,16 +19,47 @@ package vsphere
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/vmware/govmomi/vapi/tags"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 )
 
+// ownershipDescriptionPrefix is the prefix of the description that marks a tag category
+// as created for a cluster. It is followed by the datacenter and the name of the cluster,
+// separated by a slash.
+const ownershipDescriptionPrefix = "owned-by.kubermatic.k8c.io/"
+
+func ownershipDescription(cluster *kubermaticv1.Cluster) string {
+	return ownershipDescriptionPrefix + cluster.Spec.Cloud.DatacenterName + "/" + cluster.Name
+}
+
 func categoryName(cluster *kubermaticv1.Cluster) string {
 	return defaultCategory + cluster.Name
 }
 
+// ownerFromCategory returns the name and the datacenter of the cluster that the tag category
+// was created for. The datacenter is empty for categories that were marked before it was
+// part of the description.
+func ownerFromCategory(category tags.Category) (clusterName string, datacenter string, ok bool) {
+	if !strings.HasPrefix(category.Description, ownershipDescriptionPrefix) {
+		return "", "", false
+	}
+
+	owner := strings.TrimPrefix(category.Description, ownershipDescriptionPrefix)
+	clusterName = owner
+	if i := strings.LastIndex(owner, "/"); i >= 0 {
+		datacenter, clusterName = owner[:i], owner[i+1:]
+	}
+
+	if clusterName == "" || category.Name != defaultCategory+clusterName {
+		return "", "", false
+	}
+
+	return clusterName, datacenter, true
+}
+
 // createTagCategory creates the specified tag category if it does not exist yet.
 func createTagCategory(ctx context.Context, restSession *RESTSession, cluster *kubermaticv1.Cluster) (string, error) {
 	tagManager := tags.NewManager(restSession.Client)
@@ -47,6 +78,7 @@ func createTagCategory(ctx context.Context, restSession *RESTSession, cluster *k
 
 	return tagManager.CreateCategory(ctx, &tags.Category{
 		Name:        defaultCategoryName,
+		Description: ownershipDescription(cluster),
 		Cardinality: "MULTIPLE",
 	})
 }
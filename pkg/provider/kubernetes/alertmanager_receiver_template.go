@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PrivilegedAlertmanagerReceiverTemplateProvider struct that holds required components in order to manage Alertmanager receiver templates.
+type PrivilegedAlertmanagerReceiverTemplateProvider struct {
+	clientPrivileged ctrlruntimeclient.Client
+}
+
+var _ provider.PrivilegedAlertmanagerReceiverTemplateProvider = &PrivilegedAlertmanagerReceiverTemplateProvider{}
+
+// NewPrivilegedAlertmanagerReceiverTemplateProvider returns an Alertmanager receiver template provider.
+func NewPrivilegedAlertmanagerReceiverTemplateProvider(client ctrlruntimeclient.Client) *PrivilegedAlertmanagerReceiverTemplateProvider {
+	return &PrivilegedAlertmanagerReceiverTemplateProvider{
+		clientPrivileged: client,
+	}
+}
+
+// CreateUnsecured creates a receiver template.
+func (p *PrivilegedAlertmanagerReceiverTemplateProvider) CreateUnsecured(ctx context.Context, template *kubermaticv1.AlertmanagerReceiverTemplate) (*kubermaticv1.AlertmanagerReceiverTemplate, error) {
+	if err := p.clientPrivileged.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetUnsecured gets a receiver template.
+func (p *PrivilegedAlertmanagerReceiverTemplateProvider) GetUnsecured(ctx context.Context, name string) (*kubermaticv1.AlertmanagerReceiverTemplate, error) {
+	template := &kubermaticv1.AlertmanagerReceiverTemplate{}
+	err := p.clientPrivileged.Get(ctx, types.NamespacedName{Name: name}, template)
+	return template, err
+}
+
+// ListUnsecured lists all receiver templates.
+func (p *PrivilegedAlertmanagerReceiverTemplateProvider) ListUnsecured(ctx context.Context) (*kubermaticv1.AlertmanagerReceiverTemplateList, error) {
+	templateList := &kubermaticv1.AlertmanagerReceiverTemplateList{}
+	err := p.clientPrivileged.List(ctx, templateList)
+	return templateList, err
+}
+
+// UpdateUnsecured updates a receiver template.
+func (p *PrivilegedAlertmanagerReceiverTemplateProvider) UpdateUnsecured(ctx context.Context, template *kubermaticv1.AlertmanagerReceiverTemplate) (*kubermaticv1.AlertmanagerReceiverTemplate, error) {
+	if err := p.clientPrivileged.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteUnsecured deletes a receiver template.
+func (p *PrivilegedAlertmanagerReceiverTemplateProvider) DeleteUnsecured(ctx context.Context, name string) error {
+	template := &kubermaticv1.AlertmanagerReceiverTemplate{}
+	template.Name = name
+	return p.clientPrivileged.Delete(ctx, template)
+}
@@ -29,6 +29,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
@@ -430,7 +431,13 @@ func (p *ClusterProvider) GetClientForUserCluster(ctx context.Context, userInfo
 }
 
 func (p *ClusterProvider) GetTokenForUserCluster(ctx context.Context, userInfo *provider.UserInfo, cluster *kubermaticv1.Cluster) (string, error) {
-	if userInfo.Roles.Has("viewers") && userInfo.Roles.Len() == 1 {
+	isViewer := userInfo.Roles.Has("viewers") && userInfo.Roles.Len() == 1
+	// users that are only bound to ProjectRoles get the viewer token if they are allowed to get kubeconfigs
+	if userInfo.Roles.Len() == 0 && userInfo.IsAllowedByProjectRoles(kubermaticv1.ProjectRoleResourceKubeconfig, kubermaticv1.ProjectRoleVerbGet) {
+		isViewer = true
+	}
+
+	if isViewer {
 		s := &corev1.Secret{}
 		name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ViewerTokenSecretName}
 
@@ -461,14 +468,30 @@ func (p *ClusterProvider) GetSeedClusterAdminClient() kubernetes.Interface {
 
 func (p *ClusterProvider) withImpersonation(userInfo *provider.UserInfo) k8cuserclusterclient.ConfigOption {
 	return func(cfg *restclient.Config) *restclient.Config {
+		groups := append(userInfo.Roles.List(), "system:authenticated")
+		groups = append(groups, machineDeploymentsGroupsFor(userInfo)...)
+
 		cfg.Impersonate = restclient.ImpersonationConfig{
 			UserName: userInfo.Email,
-			Groups:   append(userInfo.Roles.List(), "system:authenticated"),
+			Groups:   groups,
 		}
 		return cfg
 	}
 }
 
+// machineDeploymentsGroupsFor returns the user cluster groups for the verbs on MachineDeployments
+// that the user's ProjectRoles grant. Every group only allows its own verb.
+func machineDeploymentsGroupsFor(userInfo *provider.UserInfo) []string {
+	groups := []string{}
+	for _, verb := range rbac.MachineDeploymentsGroupVerbs {
+		if userInfo.IsAllowedByProjectRoles(kubermaticv1.ProjectRoleResourceMachineDeployments, kubermaticv1.ProjectRoleVerb(verb)) {
+			groups = append(groups, rbac.GenerateMachineDeploymentsGroupNameFor(verb))
+		}
+	}
+
+	return groups
+}
+
 // GetUnsecured returns a cluster for the project and given name.
 //
 // Note that the admin privileges are used to get cluster.
@@ -178,13 +178,18 @@ func (p *ProjectMemberProvider) MapUserToGroup(ctx context.Context, userEmail st
 func (p *ProjectMemberProvider) MapUserToGroups(ctx context.Context, user *kubermaticv1.User, projectID string) (sets.String, error) {
 	groups := sets.NewString()
 
-	userBindingGroup, err := getUserBindingRole(ctx, user.Spec.Email, projectID, p.clientPrivileged)
+	userBinding, err := getUserBinding(ctx, user.Spec.Email, projectID, p.clientPrivileged)
 	if err != nil {
 		return nil, err
 	}
 
-	if userBindingGroup != "" {
-		groups.Insert(userBindingGroup)
+	if userBinding != nil {
+		if userBinding.Spec.Group != "" {
+			groups.Insert(userBinding.Spec.Group)
+		}
+		if userBinding.Spec.ProjectRole != "" {
+			groups.Insert(rbac.GenerateProjectRoleGroupNameFor(projectID, userBinding.Spec.ProjectRole))
+		}
 	}
 
 	idpGroups := user.Spec.Groups
@@ -197,6 +202,10 @@ func (p *ProjectMemberProvider) MapUserToGroups(ctx context.Context, user *kuber
 		if binding.Spec.ProjectID == projectID {
 			suffixedGroupName := fmt.Sprintf("%s-%s", binding.Spec.Group, projectID)
 			groups.Insert(suffixedGroupName)
+
+			if binding.Spec.ProjectRole != "" {
+				groups.Insert(rbac.GenerateProjectRoleGroupNameFor(projectID, binding.Spec.ProjectRole))
+			}
 		}
 	}
 
@@ -212,17 +221,26 @@ func (p *ProjectMemberProvider) MapUserToGroups(ctx context.Context, user *kuber
 }
 
 func getUserBindingRole(ctx context.Context, userEmail, projectID string, client ctrlruntimeclient.Client) (string, error) {
+	binding, err := getUserBinding(ctx, userEmail, projectID, client)
+	if err != nil || binding == nil {
+		return "", err
+	}
+
+	return binding.Spec.Group, nil
+}
+
+func getUserBinding(ctx context.Context, userEmail, projectID string, client ctrlruntimeclient.Client) (*kubermaticv1.UserProjectBinding, error) {
 	allMembers := &kubermaticv1.UserProjectBindingList{}
 	if err := client.List(ctx, allMembers); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	for _, member := range allMembers.Items {
 		if strings.EqualFold(member.Spec.UserEmail, userEmail) && member.Spec.ProjectID == projectID {
-			return member.Spec.Group, nil
+			return member.DeepCopy(), nil
 		}
 	}
-	return "", nil
+	return nil, nil
 }
 
 // MappingsFor returns the list of projects (bindings) for the given user
@@ -280,7 +298,7 @@ func (p *ProjectMemberProvider) MapUserToRoles(ctx context.Context, user *kuberm
 
 	roles := sets.NewString()
 	for _, gpb := range groupProjectBindings.Items {
-		if slice.ContainsString(user.Spec.Groups, gpb.Spec.Group, nil) && gpb.Spec.ProjectID == projectID {
+		if slice.ContainsString(user.Spec.Groups, gpb.Spec.Group, nil) && gpb.Spec.ProjectID == projectID && gpb.Spec.Role != "" {
 			roles.Insert(gpb.Spec.Role)
 		}
 	}
@@ -300,6 +318,46 @@ func (p *ProjectMemberProvider) MapUserToRoles(ctx context.Context, user *kuberm
 	return roles, nil
 }
 
+// MapUserToProjectRoles returns the ProjectRoles the user is bound to in the project, either directly through
+// the user project binding or through the group project bindings of the user's groups. Bindings that
+// reference non-existing ProjectRoles are ignored.
+// This function is unsafe in a sense that it uses privileged account to list all userProjectBindings and groupProjectBindings in the system.
+func (p *ProjectMemberProvider) MapUserToProjectRoles(ctx context.Context, user *kubermaticv1.User, projectID string) ([]kubermaticv1.ProjectRole, error) {
+	roleNames := sets.NewString()
+
+	userBinding, err := getUserBinding(ctx, user.Spec.Email, projectID, p.clientPrivileged)
+	if err != nil {
+		return nil, err
+	}
+	if userBinding != nil && userBinding.Spec.ProjectRole != "" {
+		roleNames.Insert(userBinding.Spec.ProjectRole)
+	}
+
+	groupBindings, err := p.GroupMappingsFor(ctx, user.Spec.Groups)
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range groupBindings {
+		if binding.Spec.ProjectID == projectID && binding.Spec.ProjectRole != "" {
+			roleNames.Insert(binding.Spec.ProjectRole)
+		}
+	}
+
+	var projectRoles []kubermaticv1.ProjectRole
+	for _, roleName := range roleNames.List() {
+		projectRole := kubermaticv1.ProjectRole{}
+		if err := p.clientPrivileged.Get(ctx, ctrlruntimeclient.ObjectKey{Name: roleName}, &projectRole); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		projectRoles = append(projectRoles, projectRole)
+	}
+
+	return projectRoles, nil
+}
+
 // CreateUnsecured creates a binding for the given member and the given project
 // This function is unsafe in a sense that it uses privileged account to create the resource.
 func (p *ProjectMemberProvider) CreateUnsecured(ctx context.Context, project *kubermaticv1.Project, memberEmail, group string) (*kubermaticv1.UserProjectBinding, error) {
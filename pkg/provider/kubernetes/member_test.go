@@ -222,6 +222,19 @@ func TestRoleMapper(t *testing.T) {
 			existingGroupProjectBindings: []*kubermaticv1.GroupProjectBinding{},
 			expectedRoles:                genRoles(),
 		},
+		{
+			name:              "scenario 5: bindings to ProjectRoles are not mapped to builtin roles",
+			authenticatedUser: createUserWithGroups("admins", "editors"),
+			projectID:         "my-first-project-ID",
+			existingUserProjectBindings: []*kubermaticv1.UserProjectBinding{
+				withProjectRole(createBinding("userBinding", "my-first-project-ID", "john@acme.com", ""), "operators"),
+			},
+			existingGroupProjectBindings: []*kubermaticv1.GroupProjectBinding{
+				genGroupProjectBinding("adminsBinding", "my-first-project-ID", "admins", "viewers"),
+				withGroupProjectRole(genGroupProjectBinding("devsBinding", "my-first-project-ID", "editors", ""), "operators"),
+			},
+			expectedRoles: genRoles("viewers"),
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -255,6 +268,62 @@ func TestRoleMapper(t *testing.T) {
 	}
 }
 
+func TestProjectRoleMapper(t *testing.T) {
+	operators := &kubermaticv1.ProjectRole{}
+	operators.Name = "operators"
+	operators.Spec.Rules = []kubermaticv1.ProjectRoleRule{
+		{
+			Resources: []kubermaticv1.ProjectRoleResource{kubermaticv1.ProjectRoleResourceMachineDeployments},
+			Verbs:     []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbAll},
+		},
+	}
+
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			operators,
+			withProjectRole(createBinding("userBinding", "my-first-project-ID", "john@acme.com", ""), "operators"),
+			withGroupProjectRole(genGroupProjectBinding("devsBinding", "my-first-project-ID", "editors", ""), "auditors"),
+		).
+		Build()
+
+	fakeImpersonationClient := func(impCfg restclient.ImpersonationConfig) (ctrlruntimeclient.Client, error) {
+		return fakeClient, nil
+	}
+	pmp := kubernetes.NewProjectMemberProvider(fakeImpersonationClient, fakeClient)
+	user := createUserWithGroups("editors")
+
+	projectRoles, err := pmp.MapUserToProjectRoles(context.Background(), user, "my-first-project-ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the non-existing "auditors" role is ignored
+	if len(projectRoles) != 1 || projectRoles[0].Name != "operators" {
+		t.Fatalf("expected to be mapped to the operators ProjectRole, got %v", projectRoles)
+	}
+
+	groups, err := pmp.MapUserToGroups(context.Background(), user, "my-first-project-ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedGroups := sets.NewString("editors-my-first-project-ID", "projectrole:operators-my-first-project-ID", "projectrole:auditors-my-first-project-ID")
+	if !groups.Equal(expectedGroups) {
+		t.Fatalf(" diff: %s", diff.ObjectGoPrintSideBySide(groups, expectedGroups))
+	}
+}
+
+func withProjectRole(binding *kubermaticv1.UserProjectBinding, projectRole string) *kubermaticv1.UserProjectBinding {
+	binding.Spec.Group = ""
+	binding.Spec.ProjectRole = projectRole
+	return binding
+}
+
+func withGroupProjectRole(binding *kubermaticv1.GroupProjectBinding, projectRole string) *kubermaticv1.GroupProjectBinding {
+	binding.Spec.ProjectRole = projectRole
+	return binding
+}
+
 func genRoles(roles ...string) sets.String {
 	roleSet := sets.String{}
 	roleSet.Insert(roles...)
@@ -133,7 +133,7 @@ func (p *ProjectProvider) Get(ctx context.Context, userInfo *provider.UserInfo,
 	if err := masterImpersonatedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: projectInternalName}, existingProject); err != nil {
 		return nil, err
 	}
-	if !options.IncludeUninitialized && existingProject.Status.Phase != kubermaticv1.ProjectActive {
+	if !options.IncludeUninitialized && !isProjectInitialized(existingProject) {
 		return nil, apierrors.NewServiceUnavailable("Project is not initialized yet")
 	}
 
@@ -150,12 +150,18 @@ func (p *PrivilegedProjectProvider) GetUnsecured(ctx context.Context, projectInt
 	if err := p.clientPrivileged.Get(ctx, ctrlruntimeclient.ObjectKey{Name: projectInternalName}, project); err != nil {
 		return nil, err
 	}
-	if !options.IncludeUninitialized && project.Status.Phase != kubermaticv1.ProjectActive {
+	if !options.IncludeUninitialized && !isProjectInitialized(project) {
 		return nil, apierrors.NewServiceUnavailable("Project is not initialized yet")
 	}
 	return project, nil
 }
 
+// isProjectInitialized returns true if the project has been fully set up. Suspended
+// projects are initialized, only their clusters are paused.
+func isProjectInitialized(project *kubermaticv1.Project) bool {
+	return project.Status.Phase == kubermaticv1.ProjectActive || project.Status.Phase == kubermaticv1.ProjectSuspended
+}
+
 // DeleteUnsecured deletes any given project
 // This function is unsafe in a sense that it uses privileged account to delete project with the given name.
 func (p *PrivilegedProjectProvider) DeleteUnsecured(ctx context.Context, projectInternalName string) error {
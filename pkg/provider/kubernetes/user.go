@@ -193,6 +193,53 @@ func (p *UserProvider) InvalidateToken(ctx context.Context, user *kubermaticv1.U
 	return nil
 }
 
+// InvalidateAllTokens invalidates all tokens of the user until the given expiry.
+func (p *UserProvider) InvalidateAllTokens(ctx context.Context, user *kubermaticv1.User, expiry apiv1.Time) error {
+	return p.InvalidateToken(ctx, user, resources.TokenBlacklistAllTokens, expiry)
+}
+
+// ClearAllTokensInvalidation removes the invalidation of all tokens of the user. Tokens that
+// were invalidated individually, e.g. on logout, stay invalid.
+func (p *UserProvider) ClearAllTokensInvalidation(ctx context.Context, user *kubermaticv1.User) error {
+	if user == nil {
+		return apierrors.NewBadRequest("user cannot be nil")
+	}
+	if user.Spec.InvalidTokensReference == nil {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: user.Spec.InvalidTokensReference.Namespace, Name: user.Spec.InvalidTokensReference.Name}
+	if err := p.runtimeClient.Get(ctx, key, secret); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	blockedTokens := make([]blacklistToken, 0)
+	if tokenList := secret.Data[resources.TokenBlacklist]; len(tokenList) > 0 {
+		if err := json.Unmarshal(tokenList, &blockedTokens); err != nil {
+			return err
+		}
+	}
+
+	remainingTokens := make([]blacklistToken, 0, len(blockedTokens))
+	for _, blockedToken := range clearExpiredTokens(blockedTokens) {
+		if blockedToken.Token != resources.TokenBlacklistAllTokens {
+			remainingTokens = append(remainingTokens, blockedToken)
+		}
+	}
+
+	tokenJSON, err := json.Marshal(&remainingTokens)
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{
+		resources.TokenBlacklist: tokenJSON,
+	}
+
+	return p.runtimeClient.Update(ctx, secret)
+}
+
 func (p *UserProvider) GetInvalidatedTokens(ctx context.Context, user *kubermaticv1.User) ([]string, error) {
 	result := make([]string, 0)
 	if user == nil {
@@ -72,6 +72,34 @@ type ReconcilingCloudProvider interface {
 	ReconcileCluster(context.Context, *kubermaticv1.Cluster, ClusterUpdater) (*kubermaticv1.Cluster, error)
 }
 
+// CloudResourceAuditor is a cloud provider that can find the resources it created for
+// clusters, which allows to detect and remove resources of clusters that no longer exist,
+// e.g. because their cleanup finalizers were removed by force.
+type CloudResourceAuditor interface {
+	// ListClusterResources returns all resources created for any cluster that are
+	// accessible with the credentials of the given cloud spec.
+	ListClusterResources(ctx context.Context, spec kubermaticv1.CloudSpec) ([]CloudResource, error)
+	// DeleteClusterResource deletes a resource returned by ListClusterResources.
+	DeleteClusterResource(ctx context.Context, spec kubermaticv1.CloudSpec, resource CloudResource) error
+}
+
+// CloudResource is a resource at a cloud provider that was created for a cluster.
+type CloudResource struct {
+	// Kind is the provider-specific type of the resource, e.g. "security-group".
+	Kind string
+	// ID uniquely identifies the resource at the provider.
+	ID string
+	// Name is the human-readable name of the resource.
+	Name string
+	// ClusterName is the name of the cluster the resource was created for.
+	ClusterName string
+	// Datacenter is the name of the datacenter of the cluster the resource was created for.
+	// Datacenter names are unique across all Seeds, so it tells apart the resources of clusters
+	// of other Seeds that use the same cloud account. It is empty for resources that were
+	// created before they were marked with their datacenter.
+	Datacenter string
+}
+
 // ClusterUpdater defines a function to persist an update to a cluster.
 type ClusterUpdater func(context.Context, string, func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error)
 
@@ -295,6 +323,11 @@ type UserProvider interface {
 	UserByID(ctx context.Context, id string) (*kubermaticv1.User, error)
 	InvalidateToken(ctx context.Context, user *kubermaticv1.User, token string, expiry apiv1.Time) error
 	GetInvalidatedTokens(ctx context.Context, user *kubermaticv1.User) ([]string, error)
+	// InvalidateAllTokens invalidates all tokens of the user until the given expiry.
+	InvalidateAllTokens(ctx context.Context, user *kubermaticv1.User, expiry apiv1.Time) error
+	// ClearAllTokensInvalidation reverts InvalidateAllTokens, tokens that were invalidated
+	// individually stay invalid.
+	ClearAllTokensInvalidation(ctx context.Context, user *kubermaticv1.User) error
 	List(ctx context.Context) ([]kubermaticv1.User, error)
 }
 
@@ -344,6 +377,19 @@ type UserInfo struct {
 	Groups  []string
 	Roles   sets.String
 	IsAdmin bool
+	// ProjectRoles are the custom ProjectRoles the user is bound to in the project.
+	ProjectRoles []kubermaticv1.ProjectRole
+}
+
+// IsAllowedByProjectRoles returns true if any of the user's ProjectRoles allows the verb on the resource.
+func (u *UserInfo) IsAllowedByProjectRoles(resource kubermaticv1.ProjectRoleResource, verb kubermaticv1.ProjectRoleVerb) bool {
+	for i := range u.ProjectRoles {
+		if u.ProjectRoles[i].Allows(resource, verb) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ProjectMemberListOptions allows to set filters that will be applied to filter the result.
@@ -418,6 +464,11 @@ type ProjectMemberMapper interface {
 	// avoid leaking permissions among projects having binding with the same group but different roles.
 	// This function is unsafe in a sense that it uses privileged account to list all userProjectBindings in the system.
 	MapUserToGroups(ctx context.Context, user *kubermaticv1.User, projectID string) (sets.String, error)
+
+	// MapUserToProjectRoles returns the ProjectRoles the user is bound to in the project, either directly or
+	// through the group project bindings of the user's identity provider groups.
+	// This function is unsafe in a sense that it uses privileged account to list all userProjectBindings and groupProjectBindings in the system.
+	MapUserToProjectRoles(ctx context.Context, user *kubermaticv1.User, projectID string) ([]kubermaticv1.ProjectRole, error)
 }
 
 // ExternalClusterCloudProviderName returns the provider name for the given ExternalClusterCloudSpec.
@@ -1127,6 +1178,39 @@ type PrivilegedAllowedRegistryProvider interface {
 	DeleteUnsecured(ctx context.Context, name string) error
 }
 
+// PrivilegedAlertmanagerReceiverTemplateProvider declares the set of method for interacting with Alertmanager receiver templates.
+type PrivilegedAlertmanagerReceiverTemplateProvider interface {
+	// CreateUnsecured creates the given receiver template
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to create the resource
+	CreateUnsecured(ctx context.Context, template *kubermaticv1.AlertmanagerReceiverTemplate) (*kubermaticv1.AlertmanagerReceiverTemplate, error)
+
+	// GetUnsecured gets the given receiver template
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to get the resource
+	GetUnsecured(ctx context.Context, name string) (*kubermaticv1.AlertmanagerReceiverTemplate, error)
+
+	// ListUnsecured gets a list of all receiver templates
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to get the resources
+	ListUnsecured(ctx context.Context) (*kubermaticv1.AlertmanagerReceiverTemplateList, error)
+
+	// UpdateUnsecured updates the receiver template
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to update the resource
+	UpdateUnsecured(ctx context.Context, template *kubermaticv1.AlertmanagerReceiverTemplate) (*kubermaticv1.AlertmanagerReceiverTemplate, error)
+
+	// DeleteUnsecured deletes the receiver template with the given name
+	//
+	// Note that this function:
+	// is unsafe in a sense that it uses privileged account to delete the resource
+	DeleteUnsecured(ctx context.Context, name string) error
+}
+
 // EtcdBackupConfigProvider declares the set of method for interacting with etcd backup configs.
 type EtcdBackupConfigProvider interface {
 	// Create creates the given etcdBackupConfig
@@ -1405,6 +1489,9 @@ type PrivilegedIPAMPoolProvider interface {
 type ApplicationDefinitionProvider interface {
 	// List returns a list of ApplicationDefinitions for the KKP installation.
 	ListUnsecured(context.Context) (*appskubermaticv1.ApplicationDefinitionList, error)
+
+	// GetUnsecured returns the ApplicationDefinition with the given name.
+	GetUnsecured(ctx context.Context, appDefName string) (*appskubermaticv1.ApplicationDefinition, error)
 }
 
 type PrivilegedOperatingSystemProfileProvider interface {
@@ -39,6 +39,7 @@ func UserInfoGetterFactory(userProjectMapper ProjectMemberMapper) (UserInfoGette
 
 		groups := sets.NewString()
 		roles := sets.NewString()
+		var projectRoles []kubermaticv1.ProjectRole
 
 		if projectID != "" {
 			var err error
@@ -51,6 +52,11 @@ func UserInfoGetterFactory(userProjectMapper ProjectMemberMapper) (UserInfoGette
 			if err != nil {
 				return nil, err
 			}
+
+			projectRoles, err = userProjectMapper.MapUserToProjectRoles(ctx, user, projectID)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			for _, group := range user.Spec.Groups {
 				groupName := group
@@ -61,6 +67,6 @@ func UserInfoGetterFactory(userProjectMapper ProjectMemberMapper) (UserInfoGette
 			}
 		}
 
-		return &UserInfo{Email: user.Spec.Email, Groups: groups.List(), IsAdmin: user.Spec.IsAdmin, Roles: roles}, nil
+		return &UserInfo{Email: user.Spec.Email, Groups: groups.List(), IsAdmin: user.Spec.IsAdmin, Roles: roles, ProjectRoles: projectRoles}, nil
 	}, nil
 }
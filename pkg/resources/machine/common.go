@@ -381,6 +381,14 @@ func GetHetznerProviderConfig(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec,
 		ServerType: providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Hetzner.Type},
 	}
 
+	if c.Spec.Cloud.Hetzner.Firewall != "" {
+		config.Firewalls = []providerconfig.ConfigVarString{{Value: c.Spec.Cloud.Hetzner.Firewall}}
+	}
+
+	if c.Spec.Cloud.Hetzner.PlacementGroupPrefix != "" {
+		config.PlacementGroupPrefix = providerconfig.ConfigVarString{Value: c.Spec.Cloud.Hetzner.PlacementGroupPrefix}
+	}
+
 	return config, nil
 }
 
@@ -609,9 +617,14 @@ func getAlibabaProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc
 	return EncodeAsRawExtension(config)
 }
 
-func GetAnexiaProviderConfig(_ *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*anexia.RawConfig, error) {
+func GetAnexiaProviderConfig(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*anexia.RawConfig, error) {
+	vlanID := nodeSpec.Cloud.Anexia.VlanID
+	if vlanID == "" && c != nil && c.Spec.Cloud.Anexia != nil {
+		vlanID = c.Spec.Cloud.Anexia.VLANID
+	}
+
 	config := &anexia.RawConfig{
-		VlanID:     providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Anexia.VlanID},
+		VlanID:     providerconfig.ConfigVarString{Value: vlanID},
 		TemplateID: providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Anexia.TemplateID},
 		CPUs:       nodeSpec.Cloud.Anexia.CPUs,
 		Memory:     int(nodeSpec.Cloud.Anexia.Memory),
@@ -622,8 +635,8 @@ func GetAnexiaProviderConfig(_ *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, d
 	return config, nil
 }
 
-func getAnexiaProviderSpec(nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*runtime.RawExtension, error) {
-	config, err := GetAnexiaProviderConfig(nil, nodeSpec, dc)
+func getAnexiaProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *kubermaticv1.Datacenter) (*runtime.RawExtension, error) {
+	config, err := GetAnexiaProviderConfig(c, nodeSpec, dc)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +231,7 @@ func getProviderConfig(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *ku
 		}
 	case nd.Spec.Template.Cloud.Anexia != nil && dc.Spec.Anexia != nil:
 		config.CloudProvider = providerconfig.CloudProviderAnexia
-		cloudExt, err = getAnexiaProviderSpec(nd.Spec.Template, dc)
+		cloudExt, err = getAnexiaProviderSpec(c, nd.Spec.Template, dc)
 		if err != nil {
 			return nil, err
 		}
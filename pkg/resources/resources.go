@@ -708,6 +708,8 @@ const (
 
 const (
 	TokenBlacklist = "token-blacklist"
+	// TokenBlacklistAllTokens is a token blacklist entry that invalidates all tokens of a user.
+	TokenBlacklistAllTokens = "*"
 )
 
 const (
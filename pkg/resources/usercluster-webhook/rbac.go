@@ -62,6 +62,15 @@ func ClusterRole() reconciling.NamedClusterRoleCreatorGetter {
 						"watch",
 					},
 				},
+				{
+					APIGroups: []string{kubermaticv1.GroupName},
+					Resources: []string{"clusters"},
+					Verbs: []string{
+						"get",
+						"list",
+						"watch",
+					},
+				},
 			}
 			return r, nil
 		}
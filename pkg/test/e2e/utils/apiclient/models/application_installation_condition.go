@@ -29,7 +29,9 @@ type ApplicationInstallationCondition struct {
 	// Type of ApplicationInstallation condition.
 	// ManifestsRetrieved ManifestsRetrieved  ManifestsRetrieved indicates all necessary manifests have been fetched from the external source.
 	// Ready Ready  Ready describes all components have been successfully rolled out and are ready.
-	// Enum: [ManifestsRetrieved Ready]
+	// Drifted Drifted  Drifted indicates that live objects in the user cluster differ from the desired state of the application.
+	// The message lists the drifted objects.
+	// Enum: [ManifestsRetrieved Ready Drifted]
 	Type string `json:"type,omitempty"`
 
 	// last heartbeat time
@@ -74,7 +76,7 @@ var applicationInstallationConditionTypeTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["ManifestsRetrieved","Ready"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["ManifestsRetrieved","Ready","Drifted"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -89,6 +91,9 @@ const (
 
 	// ApplicationInstallationConditionTypeReady captures enum value "Ready"
 	ApplicationInstallationConditionTypeReady string = "Ready"
+
+	// ApplicationInstallationConditionTypeDrifted captures enum value "Drifted"
+	ApplicationInstallationConditionTypeDrifted string = "Drifted"
 )
 
 // prop value enum
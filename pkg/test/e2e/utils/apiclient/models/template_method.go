@@ -11,7 +11,7 @@ import (
 	"github.com/go-openapi/strfmt"
 )
 
-// TemplateMethod +kubebuilder:validation:Enum=helm
+// TemplateMethod +kubebuilder:validation:Enum=helm;kustomize;manifests
 //
 // swagger:model TemplateMethod
 type TemplateMethod string
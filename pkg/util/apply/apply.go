@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apply server-side applies sets of objects into a cluster and prunes the
+// objects of the previously applied set (the inventory) that are not part of the
+// set anymore. It is used for addons and for 'kustomize' and 'manifests' applications.
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectKey identifies an applied object.
+type ObjectKey struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// KeyForObject returns the key of the object.
+func KeyForObject(obj *unstructured.Unstructured) ObjectKey {
+	gvk := obj.GroupVersionKind()
+	return ObjectKey{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// Identity returns the key without the version, as the same object can be served in several versions and e.g. a
+// manifest moving to a newer API version must not prune the object applied in the former version.
+func (k ObjectKey) Identity() ObjectKey {
+	k.Version = ""
+	return k
+}
+
+func (k ObjectKey) String() string {
+	if k.Namespace == "" {
+		return fmt.Sprintf("%s %s", k.Kind, k.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", k.Kind, k.Namespace, k.Name)
+}
+
+// Phase is the outcome of applying or pruning an object.
+type Phase string
+
+const (
+	// PhaseApplied means that the object has been applied into the cluster.
+	PhaseApplied Phase = "Applied"
+	// PhaseFailed means that the object could not be applied or pruned.
+	PhaseFailed Phase = "Failed"
+	// PhasePruned means that the object has been pruned. It's only reported for dry-runs.
+	PhasePruned Phase = "Pruned"
+)
+
+// Result is the result of applying or pruning a single object.
+type Result struct {
+	ObjectKey
+
+	Phase Phase
+	// Err is set if the object could not be applied or pruned.
+	Err error
+}
+
+// Options configure how objects are applied.
+type Options struct {
+	// FieldManager is the field manager of the applied fields.
+	FieldManager string
+	// DefaultNamespace is set on namespaced objects that do not define a namespace.
+	DefaultNamespace string
+	// DryRun applies and prunes the objects with a server-side dry-run.
+	DryRun bool
+}
+
+// Apply server-side applies the objects into the cluster and prunes the objects of the inventory that are not part
+// of objects anymore. Objects that fail to be applied do not prevent the others from being applied and are never
+// pruned. It returns the result of every object and an aggregated error of all failures. Pruned objects are only
+// reported for dry-runs, see Inventory for the objects that remain in the cluster.
+func Apply(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, objects []*unstructured.Unstructured, inventory []ObjectKey, opts Options) ([]Result, error) {
+	var (
+		results []Result
+		errs    []error
+	)
+
+	failed := func(key ObjectKey, action string, err error) {
+		results = append(results, Result{ObjectKey: key, Phase: PhaseFailed, Err: err})
+		errs = append(errs, fmt.Errorf("failed to %s %s: %w", action, key, err))
+	}
+
+	desired := make(map[ObjectKey]struct{}, len(objects))
+	for _, obj := range objects {
+		if err := DefaultNamespace(client.RESTMapper(), obj, opts.DefaultNamespace); err != nil {
+			// the object can still be applied once its type is served (e.g. its CRD is created by the same set),
+			// so it must not be pruned.
+			key := KeyForObject(obj)
+			desired[key.Identity()] = struct{}{}
+			failed(key, "apply", err)
+			continue
+		}
+
+		key := KeyForObject(obj)
+		if _, duplicate := desired[key.Identity()]; duplicate {
+			continue
+		}
+		desired[key.Identity()] = struct{}{}
+
+		// server-side apply does not accept managedFields and resourceVersion is only used for optimistic locking.
+		obj.SetManagedFields(nil)
+		obj.SetResourceVersion("")
+
+		patchOpts := []ctrlruntimeclient.PatchOption{ctrlruntimeclient.FieldOwner(opts.FieldManager), ctrlruntimeclient.ForceOwnership}
+		if opts.DryRun {
+			patchOpts = append(patchOpts, ctrlruntimeclient.DryRunAll)
+		}
+
+		log.Debugw("Applying object", "object", key, "dry-run", opts.DryRun)
+		if err := client.Patch(ctx, obj, ctrlruntimeclient.Apply, patchOpts...); err != nil {
+			failed(key, "apply", err)
+			continue
+		}
+		results = append(results, Result{ObjectKey: key, Phase: PhaseApplied})
+	}
+
+	// prune the objects in reverse order, so that e.g. custom resources are removed before their CRDs.
+	for i := len(inventory) - 1; i >= 0; i-- {
+		key := inventory[i]
+		if _, ok := desired[key.Identity()]; ok {
+			continue
+		}
+
+		log.Debugw("Pruning object", "object", key, "dry-run", opts.DryRun)
+		if err := deleteObject(ctx, client, key, opts.DryRun); err != nil {
+			failed(key, "prune", err)
+			continue
+		}
+
+		if opts.DryRun {
+			results = append(results, Result{ObjectKey: key, Phase: PhasePruned})
+		}
+	}
+
+	return results, kerrors.NewAggregate(errs)
+}
+
+// Inventory returns the keys of the objects that remain in the cluster after Apply, i.e. of all objects that have
+// not been pruned. This includes objects that failed to be applied or pruned, so that they are retried.
+func Inventory(results []Result) []ObjectKey {
+	var keys []ObjectKey
+	for _, result := range results {
+		if result.Phase != PhasePruned {
+			keys = append(keys, result.ObjectKey)
+		}
+	}
+	return keys
+}
+
+// Delete deletes the objects in reverse order. Objects that do not exist (or whose type is not served anymore) are
+// ignored. It returns the keys of the objects that could not be deleted.
+func Delete(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, keys []ObjectKey) ([]ObjectKey, error) {
+	var (
+		remaining []ObjectKey
+		errs      []error
+	)
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		log.Debugw("Deleting object", "object", keys[i])
+		if err := deleteObject(ctx, client, keys[i], false); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", keys[i], err))
+			remaining = append([]ObjectKey{keys[i]}, remaining...)
+		}
+	}
+
+	return remaining, kerrors.NewAggregate(errs)
+}
+
+func deleteObject(ctx context.Context, client ctrlruntimeclient.Client, key ObjectKey, dryRun bool) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: key.Group, Version: key.Version, Kind: key.Kind})
+	obj.SetNamespace(key.Namespace)
+	obj.SetName(key.Name)
+
+	opts := []ctrlruntimeclient.DeleteOption{ctrlruntimeclient.PropagationPolicy(metav1.DeletePropagationBackground)}
+	if dryRun {
+		opts = append(opts, ctrlruntimeclient.DryRunAll)
+	}
+
+	if err := client.Delete(ctx, obj, opts...); err != nil && !apierrors.IsNotFound(err) && !IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// DefaultNamespace sets the namespace of namespaced objects that do not define one to namespace and clears the
+// namespace of cluster-scoped objects. If namespace is empty, the "default" namespace is used (like kubectl does).
+func DefaultNamespace(mapper meta.RESTMapper, obj *unstructured.Unstructured, namespace string) error {
+	gvk := obj.GroupVersionKind()
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("failed to get REST mapping for %s: %w", gvk, err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			if namespace == "" {
+				namespace = metav1.NamespaceDefault
+			}
+			obj.SetNamespace(namespace)
+		}
+	} else {
+		obj.SetNamespace("")
+	}
+
+	return nil
+}
+
+// IsNoMatchError returns true if the (wrapped) error is a meta.NoKindMatchError or meta.NoResourceMatchError, i.e.
+// the kind is not known by the API server (e.g. the CRD has been deleted).
+func IsNoMatchError(err error) bool {
+	var noKindMatch *meta.NoKindMatchError
+	var noResourceMatch *meta.NoResourceMatchError
+	return errors.As(err, &noKindMatch) || errors.As(err, &noResourceMatch)
+}
+
+// installOrder defines the kinds that must be applied before the others because other objects depend on them.
+var installOrder = map[string]int{
+	"Namespace":                0,
+	"CustomResourceDefinition": 1,
+	"ServiceAccount":           2,
+	"ClusterRole":              3,
+	"ClusterRoleBinding":       4,
+	"Role":                     5,
+	"RoleBinding":              6,
+}
+
+// SortByInstallOrder sorts the objects so that Namespaces, CRDs and RBAC are applied first. The order of the
+// other objects is preserved.
+func SortByInstallOrder(objects []*unstructured.Unstructured) {
+	weight := func(obj *unstructured.Unstructured) int {
+		if w, ok := installOrder[obj.GetKind()]; ok {
+			return w
+		}
+		return len(installOrder)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return weight(objects[i]) < weight(objects[j])
+	})
+}
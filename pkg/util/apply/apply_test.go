@@ -0,0 +1,287 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// applyClient emulates server-side apply, which is not supported by the fake client, with creates and updates.
+type applyClient struct {
+	ctrlruntimeclient.Client
+
+	// failing holds the names of the objects that can not be applied.
+	failing map[string]struct{}
+	// deleted records the names of the deleted objects.
+	deleted []string
+}
+
+func (c *applyClient) Delete(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	c.deleted = append(c.deleted, obj.GetName())
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	if patch != ctrlruntimeclient.Apply {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	if _, ok := c.failing[obj.GetName()]; ok {
+		return errors.New("admission webhook denied the request")
+	}
+
+	patchOpts := &ctrlruntimeclient.PatchOptions{}
+	patchOpts.ApplyOptions(opts)
+	if len(patchOpts.DryRun) > 0 {
+		return nil
+	}
+
+	existing := &metav1unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(obj), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.Create(ctx, obj)
+		}
+		return err
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func newTestRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), meta.RESTScopeRoot)
+	return mapper
+}
+
+func newUnstructured(apiVersion, kind, namespace, name string) *metav1unstructured.Unstructured {
+	obj := &metav1unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestApply(t *testing.T) {
+	configMapKey := func(namespace, name string) ObjectKey {
+		return ObjectKey{Version: "v1", Kind: "ConfigMap", Namespace: namespace, Name: name}
+	}
+	configMapResult := func(namespace, name string, phase Phase) Result {
+		return Result{ObjectKey: configMapKey(namespace, name), Phase: phase}
+	}
+
+	testCases := []struct {
+		name             string
+		objects          []*metav1unstructured.Unstructured
+		existing         []ctrlruntimeclient.Object
+		inventory        []ObjectKey
+		failing          []string
+		dryRun           bool
+		expectedResults  []Result
+		expectedError    bool
+		expectedExisting []types.NamespacedName
+		expectedDeleted  []types.NamespacedName
+		expectedPruned   []string
+	}{
+		{
+			name: "objects are applied and the namespace is defaulted",
+			objects: []*metav1unstructured.Unstructured{
+				newUnstructured("v1", "ConfigMap", "kube-system", "first"),
+				newUnstructured("v1", "ConfigMap", "", "second"),
+				newUnstructured("rbac.authorization.k8s.io/v1", "ClusterRole", "kube-system", "role"),
+			},
+			expectedResults: []Result{
+				configMapResult("kube-system", "first", PhaseApplied),
+				configMapResult("default", "second", PhaseApplied),
+				{ObjectKey: ObjectKey{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "role"}, Phase: PhaseApplied},
+			},
+			expectedExisting: []types.NamespacedName{{Namespace: "kube-system", Name: "first"}, {Namespace: "default", Name: "second"}},
+		},
+		{
+			name:    "objects that are not part of the manifests anymore are pruned",
+			objects: []*metav1unstructured.Unstructured{newUnstructured("v1", "ConfigMap", "kube-system", "first")},
+			existing: []ctrlruntimeclient.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "first"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "removed"}},
+			},
+			inventory: []ObjectKey{
+				configMapKey("kube-system", "first"),
+				configMapKey("kube-system", "removed"),
+				configMapKey("kube-system", "already-gone"),
+			},
+			expectedResults: []Result{
+				configMapResult("kube-system", "first", PhaseApplied),
+			},
+			expectedExisting: []types.NamespacedName{{Namespace: "kube-system", Name: "first"}},
+			expectedDeleted:  []types.NamespacedName{{Namespace: "kube-system", Name: "removed"}},
+			expectedPruned:   []string{"already-gone", "removed"},
+		},
+		{
+			name:    "objects moved to another API version are not pruned",
+			objects: []*metav1unstructured.Unstructured{newUnstructured("rbac.authorization.k8s.io/v1", "ClusterRole", "", "role")},
+			inventory: []ObjectKey{
+				{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRole", Name: "role"},
+			},
+			expectedResults: []Result{
+				{ObjectKey: ObjectKey{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "role"}, Phase: PhaseApplied},
+			},
+		},
+		{
+			name: "all objects are pruned if the manifests are empty",
+			existing: []ctrlruntimeclient.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "removed"}},
+			},
+			inventory: []ObjectKey{
+				configMapKey("kube-system", "removed"),
+			},
+			expectedDeleted: []types.NamespacedName{{Namespace: "kube-system", Name: "removed"}},
+			expectedPruned:  []string{"removed"},
+		},
+		{
+			name: "failing objects are reported and do not prevent the others from being applied",
+			objects: []*metav1unstructured.Unstructured{
+				newUnstructured("v1", "ConfigMap", "kube-system", "failing"),
+				newUnstructured("example.com/v1", "Unknown", "kube-system", "unknown"),
+				newUnstructured("v1", "ConfigMap", "kube-system", "second"),
+			},
+			existing: []ctrlruntimeclient.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "failing"}},
+			},
+			inventory: []ObjectKey{
+				configMapKey("kube-system", "failing"),
+			},
+			failing: []string{"failing"},
+			expectedResults: []Result{
+				{ObjectKey: ObjectKey{Version: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "failing"}, Phase: PhaseFailed, Err: errors.New("admission webhook denied the request")},
+				{ObjectKey: ObjectKey{Group: "example.com", Version: "v1", Kind: "Unknown", Namespace: "kube-system", Name: "unknown"}, Phase: PhaseFailed, Err: errors.New(`failed to get REST mapping for example.com/v1, Kind=Unknown: no matches for kind "Unknown" in version "example.com/v1"`)},
+				configMapResult("kube-system", "second", PhaseApplied),
+			},
+			expectedError:    true,
+			expectedExisting: []types.NamespacedName{{Namespace: "kube-system", Name: "failing"}, {Namespace: "kube-system", Name: "second"}},
+		},
+		{
+			name:    "dry-run does not change the cluster",
+			objects: []*metav1unstructured.Unstructured{newUnstructured("v1", "ConfigMap", "kube-system", "new")},
+			existing: []ctrlruntimeclient.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "removed"}},
+			},
+			inventory: []ObjectKey{
+				configMapKey("kube-system", "removed"),
+			},
+			dryRun: true,
+			expectedResults: []Result{
+				configMapResult("kube-system", "new", PhaseApplied),
+				configMapResult("kube-system", "removed", PhasePruned),
+			},
+			expectedExisting: []types.NamespacedName{{Namespace: "kube-system", Name: "removed"}},
+			expectedDeleted:  []types.NamespacedName{{Namespace: "kube-system", Name: "new"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+			failing := map[string]struct{}{}
+			for _, name := range tc.failing {
+				failing[name] = struct{}{}
+			}
+			client := &applyClient{
+				Client:  fakectrlruntimeclient.NewClientBuilder().WithRESTMapper(newTestRESTMapper()).WithObjects(tc.existing...).Build(),
+				failing: failing,
+			}
+
+			results, err := Apply(context.Background(), log, client, tc.objects, tc.inventory, Options{FieldManager: "test", DryRun: tc.dryRun})
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got %v", tc.expectedError, err)
+			}
+
+			// errors are compared by their message
+			for i := range results {
+				if results[i].Err != nil {
+					results[i].Err = errors.New(results[i].Err.Error())
+				}
+			}
+			if diff := deep.Equal(tc.expectedResults, results); diff != nil {
+				t.Fatalf("unexpected results: %v", diff)
+			}
+
+			for _, key := range tc.expectedExisting {
+				if err := client.Get(context.Background(), key, &corev1.ConfigMap{}); err != nil {
+					t.Errorf("expected ConfigMap %s to exist: %v", key, err)
+				}
+			}
+			for _, key := range tc.expectedDeleted {
+				if err := client.Get(context.Background(), key, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+					t.Errorf("expected ConfigMap %s to not exist, got %v", key, err)
+				}
+			}
+			if !tc.dryRun {
+				if diff := deep.Equal(tc.expectedPruned, client.deleted); diff != nil {
+					t.Errorf("unexpected pruned objects: %v", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+	client := fakectrlruntimeclient.NewClientBuilder().WithRESTMapper(newTestRESTMapper()).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "first"}},
+	).Build()
+
+	keys := []ObjectKey{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "first"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "already-gone"},
+		{Group: "example.com", Version: "v1", Kind: "Unknown", Namespace: "kube-system", Name: "crd-gone"},
+	}
+	remaining, err := Delete(context.Background(), log, client, keys)
+	if err != nil {
+		t.Fatalf("failed to delete objects: %v", err)
+	}
+	if len(remaining) > 0 {
+		t.Fatalf("expected all objects to be deleted, got %v", remaining)
+	}
+
+	cm := &metav1unstructured.Unstructured{}
+	cm.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: "kube-system", Name: "first"}, cm); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected ConfigMap to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	tmpltext "text/template"
+
+	amconfig "github.com/prometheus/alertmanager/config"
+	amtemplate "github.com/prometheus/alertmanager/template"
+	"gopkg.in/yaml.v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+// cortexAlertmanagerConfig is the per-tenant configuration format of the Cortex Alertmanager,
+// which wraps the actual Alertmanager configuration together with its template files.
+type cortexAlertmanagerConfig struct {
+	TemplateFiles      map[string]string `yaml:"template_files"`
+	AlertmanagerConfig string            `yaml:"alertmanager_config"`
+}
+
+// alertmanagerReceiverConfigKeys maps the receiver template types to the
+// Alertmanager configuration key of the respective integration.
+var alertmanagerReceiverConfigKeys = map[kubermaticv1.AlertmanagerReceiverType]string{
+	kubermaticv1.AlertmanagerReceiverTypeSlack:     "slack_configs",
+	kubermaticv1.AlertmanagerReceiverTypeEmail:     "email_configs",
+	kubermaticv1.AlertmanagerReceiverTypeWebhook:   "webhook_configs",
+	kubermaticv1.AlertmanagerReceiverTypePagerDuty: "pagerduty_configs",
+}
+
+// ValidateAlertmanagerConfig validates a Cortex Alertmanager configuration using the
+// upstream Alertmanager parser, so that invalid configurations are rejected before
+// they are sent to Cortex.
+func ValidateAlertmanagerConfig(config []byte) error {
+	cfg := cortexAlertmanagerConfig{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(config))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil {
+		return fmt.Errorf("can not unmarshal yaml configuration: %w", err)
+	}
+
+	if strings.TrimSpace(cfg.AlertmanagerConfig) == "" {
+		return errors.New("alertmanager_config must not be empty")
+	}
+
+	if _, err := amconfig.Load(cfg.AlertmanagerConfig); err != nil {
+		return fmt.Errorf("invalid alertmanager_config: %w", err)
+	}
+
+	for name, content := range cfg.TemplateFiles {
+		if name == "" || filepath.Base(name) != name {
+			return fmt.Errorf("invalid template file name %q: must not contain a path", name)
+		}
+
+		if _, err := tmpltext.New(name).Funcs(tmpltext.FuncMap(amtemplate.DefaultFuncs)).Parse(content); err != nil {
+			return fmt.Errorf("invalid template file %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAlertmanagerReceiverTemplate validates that the receiver of the template is a valid
+// Alertmanager receiver that only configures the integration matching the template type.
+func ValidateAlertmanagerReceiverTemplate(name string, spec *kubermaticv1.AlertmanagerReceiverTemplateSpec) error {
+	expectedKey, ok := alertmanagerReceiverConfigKeys[spec.Type]
+	if !ok {
+		return fmt.Errorf("unsupported receiver type %q", spec.Type)
+	}
+
+	receiver, err := AlertmanagerReceiverFromTemplate(name, spec)
+	if err != nil {
+		return err
+	}
+
+	for key := range receiver {
+		if strings.HasSuffix(key, "_configs") && key != expectedKey {
+			return fmt.Errorf("receiver of type %q must not contain %s", spec.Type, key)
+		}
+	}
+	if _, ok := receiver[expectedKey]; !ok {
+		return fmt.Errorf("receiver of type %q must contain %s", spec.Type, expectedKey)
+	}
+
+	config, err := yaml.Marshal(map[string]interface{}{
+		"route": map[string]interface{}{
+			"receiver": name,
+		},
+		"receivers": []interface{}{receiver},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alertmanager configuration: %w", err)
+	}
+
+	if _, err := amconfig.Load(string(config)); err != nil {
+		return fmt.Errorf("invalid receiver: %w", err)
+	}
+
+	return nil
+}
+
+// AlertmanagerReceiverFromTemplate returns the receiver of the template with the given name,
+// as it is added to the receivers of an Alertmanager configuration.
+func AlertmanagerReceiverFromTemplate(name string, spec *kubermaticv1.AlertmanagerReceiverTemplateSpec) (map[string]interface{}, error) {
+	receiver := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(spec.Receiver), &receiver); err != nil {
+		return nil, fmt.Errorf("can not unmarshal receiver: %w", err)
+	}
+	if receiver == nil {
+		receiver = map[string]interface{}{}
+	}
+
+	if _, ok := receiver["name"]; ok {
+		return nil, errors.New("receiver must not contain a name")
+	}
+	receiver["name"] = name
+
+	return receiver, nil
+}
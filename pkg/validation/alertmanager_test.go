@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+)
+
+func TestValidateAlertmanagerConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		expectErr bool
+	}{
+		{
+			name:   "default config",
+			config: resources.DefaultAlertmanagerConfig,
+		},
+		{
+			name: "config with template",
+			config: `
+template_files:
+  custom.tmpl: '{{ define "custom.title" }}{{ .CommonLabels.alertname | toUpper }}{{ end }}'
+alertmanager_config: |
+  templates:
+    - custom.tmpl
+  route:
+    receiver: test
+  receivers:
+    - name: test
+      webhook_configs:
+        - url: http://example.com/alerts
+`,
+		},
+		{
+			name:      "not a yaml document",
+			config:    "bad-request",
+			expectErr: true,
+		},
+		{
+			name: "unknown top-level field",
+			config: `
+alertmanager_config: |
+  route:
+    receiver: test
+  receivers:
+    - name: test
+route: {}
+`,
+			expectErr: true,
+		},
+		{
+			name:      "missing alertmanager config",
+			config:    "template_files: {}",
+			expectErr: true,
+		},
+		{
+			name: "route references undefined receiver",
+			config: `
+alertmanager_config: |
+  route:
+    receiver: missing
+  receivers:
+    - name: test
+`,
+			expectErr: true,
+		},
+		{
+			name: "template with syntax error",
+			config: `
+template_files:
+  custom.tmpl: '{{ define "custom.title" }}'
+alertmanager_config: |
+  route:
+    receiver: test
+  receivers:
+    - name: test
+`,
+			expectErr: true,
+		},
+		{
+			name: "template file name with path",
+			config: `
+template_files:
+  ../custom.tmpl: ''
+alertmanager_config: |
+  route:
+    receiver: test
+  receivers:
+    - name: test
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAlertmanagerConfig([]byte(test.config))
+			if (err != nil) != test.expectErr {
+				t.Fatalf("Expected error = %v, but got %v", test.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAlertmanagerReceiverTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      kubermaticv1.AlertmanagerReceiverTemplateSpec
+		expectErr bool
+	}{
+		{
+			name: "valid slack receiver",
+			spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+				Type: kubermaticv1.AlertmanagerReceiverTypeSlack,
+				Receiver: `
+slack_configs:
+  - api_url: https://hooks.slack.com/services/test
+    channel: '#alerts'
+`,
+			},
+		},
+		{
+			name: "valid email receiver",
+			spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+				Type: kubermaticv1.AlertmanagerReceiverTypeEmail,
+				Receiver: `
+email_configs:
+  - to: team@example.com
+    from: alertmanager@example.com
+    smarthost: smtp.example.com:587
+`,
+			},
+		},
+		{
+			name: "unsupported type",
+			spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+				Type:     "telegram",
+				Receiver: "telegram_configs: []",
+			},
+			expectErr: true,
+		},
+		{
+			name: "integration does not match type",
+			spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+				Type: kubermaticv1.AlertmanagerReceiverTypeSlack,
+				Receiver: `
+webhook_configs:
+  - url: http://example.com/alerts
+`,
+			},
+			expectErr: true,
+		},
+		{
+			name: "receiver with name",
+			spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+				Type: kubermaticv1.AlertmanagerReceiverTypeWebhook,
+				Receiver: `
+name: other
+webhook_configs:
+  - url: http://example.com/alerts
+`,
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid integration config",
+			spec: kubermaticv1.AlertmanagerReceiverTemplateSpec{
+				Type: kubermaticv1.AlertmanagerReceiverTypeWebhook,
+				Receiver: `
+webhook_configs:
+  - url: not-a-url
+`,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAlertmanagerReceiverTemplate("test", &test.spec)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("Expected error = %v, but got %v", test.expectErr, err)
+			}
+		})
+	}
+}
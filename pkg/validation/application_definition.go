@@ -18,8 +18,12 @@ package validation
 
 import (
 	"fmt"
+	"strings"
+
+	semverlib "github.com/Masterminds/semver/v3"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/valuesschema"
 	"k8c.io/kubermatic/v2/pkg/validation/openapi"
 
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
@@ -35,6 +39,14 @@ func ValidateApplicationDefinitionSpec(ad appskubermaticv1.ApplicationDefinition
 
 	allErrs = append(allErrs, ValidateApplicationVersions(ad.Spec.Versions, parentFieldPath.Child("spec"))...)
 
+	allErrs = append(allErrs, validateSourcesForMethod(ad.Spec.Method, ad.Spec.Versions, parentFieldPath.Child("spec"))...)
+
+	allErrs = append(allErrs, validateApplicationDependencies(ad.Name, ad.Spec.Versions, parentFieldPath.Child("spec"))...)
+
+	allErrs = append(allErrs, validateValuesSchemas(ad.Spec.Method, ad.Spec.Versions, parentFieldPath.Child("spec"))...)
+
+	allErrs = append(allErrs, validateVerification(ad.Spec.Verification, ad.Spec.Versions, parentFieldPath.Child("spec"))...)
+
 	return allErrs
 }
 
@@ -76,8 +88,15 @@ func ValidateApplicationVersions(vs []appskubermaticv1.ApplicationVersion, paren
 func validateSource(source appskubermaticv1.ApplicationSource, f *field.Path) []*field.Error {
 	allErrs := field.ErrorList{}
 
+	sources := 0
+	for _, defined := range []bool{source.Helm != nil, source.Git != nil, source.OCI != nil, source.Tarball != nil} {
+		if defined {
+			sources++
+		}
+	}
+
 	switch {
-	case source.Helm != nil && source.Git != nil:
+	case sources > 1:
 		allErrs = append(allErrs, field.Forbidden(f, "only source type can be provided"))
 	case source.Git != nil:
 		allErrs = append(allErrs, validateGitSource(source.Git, f.Child("git"))...)
@@ -85,6 +104,10 @@ func validateSource(source appskubermaticv1.ApplicationSource, f *field.Path) []
 		if e := validateHelmCredentials(source.Helm.Credentials, f.Child("helm.credentials")); e != nil {
 			allErrs = append(allErrs, e)
 		}
+	case source.OCI != nil:
+		allErrs = append(allErrs, validateOCISource(source.OCI, f.Child("oci"))...)
+	case source.Tarball != nil:
+		allErrs = append(allErrs, validateTarballSource(source.Tarball, f.Child("tarball"))...)
 
 	default:
 		allErrs = append(allErrs, field.Required(f, "no source provided"))
@@ -93,6 +116,119 @@ func validateSource(source appskubermaticv1.ApplicationSource, f *field.Path) []
 	return allErrs
 }
 
+// validateSourcesForMethod ensures all versions provide a source that can be handled by the template method.
+// Kustomize and plain manifests can only be retrieved from a Git repository.
+func validateSourcesForMethod(method appskubermaticv1.TemplateMethod, vs []appskubermaticv1.ApplicationVersion, parentFieldPath *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	if method != appskubermaticv1.KustomizeTemplateMethod && method != appskubermaticv1.ManifestsTemplateMethod {
+		return allErrs
+	}
+
+	for i, v := range vs {
+		if v.Template.Source.Helm != nil {
+			allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child(fmt.Sprintf("versions[%d].template.source.helm", i)), fmt.Sprintf("helm source can not be used with template method '%s'", method)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateApplicationDependencies ensures that versions do not depend on their own application and that the version
+// constraints of the dependencies are valid.
+func validateApplicationDependencies(name string, vs []appskubermaticv1.ApplicationVersion, parentFieldPath *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	for i, v := range vs {
+		for j, dep := range v.DependsOn {
+			depPath := parentFieldPath.Child(fmt.Sprintf("versions[%d].dependsOn[%d]", i, j))
+
+			if dep.Name == name {
+				allErrs = append(allErrs, field.Invalid(depPath.Child("name"), dep.Name, "application can not depend on itself"))
+			}
+
+			if dep.Version != "" {
+				if _, err := semverlib.NewConstraint(dep.Version); err != nil {
+					allErrs = append(allErrs, field.Invalid(depPath.Child("version"), dep.Version, fmt.Sprintf("invalid version constraint: %v", err)))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateValuesSchemas ensures that inline schemas are valid JSON schemas and that schemas are only taken from the
+// chart when the application is installed from a Helm chart.
+func validateValuesSchemas(method appskubermaticv1.TemplateMethod, vs []appskubermaticv1.ApplicationVersion, parentFieldPath *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	for i, v := range vs {
+		if v.ValuesSchema == nil {
+			continue
+		}
+		schemaPath := parentFieldPath.Child(fmt.Sprintf("versions[%d].valuesSchema", i))
+
+		if v.ValuesSchema.Inline != nil && v.ValuesSchema.FromChart {
+			allErrs = append(allErrs, field.Forbidden(schemaPath, "inline and fromChart are mutually exclusive"))
+			continue
+		}
+
+		if v.ValuesSchema.FromChart {
+			if method != appskubermaticv1.HelmTemplateMethod || v.Template.Source.Helm == nil {
+				allErrs = append(allErrs, field.Forbidden(schemaPath.Child("fromChart"), "schema can only be taken from the chart with template method 'helm' and a helm source"))
+			}
+			continue
+		}
+
+		if v.ValuesSchema.Inline != nil {
+			if err := valuesschema.Compile(v.ValuesSchema.Inline.Raw); err != nil {
+				allErrs = append(allErrs, field.Invalid(schemaPath.Child("inline"), string(v.ValuesSchema.Inline.Raw), fmt.Sprintf("invalid JSON schema: %v", err)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateVerification ensures that the sources of all versions can be verified with the configured verification methods.
+func validateVerification(verification *appskubermaticv1.SourceVerification, vs []appskubermaticv1.ApplicationVersion, parentFieldPath *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+	if verification == nil {
+		return allErrs
+	}
+
+	verificationPath := parentFieldPath.Child("verification")
+	if verification.Cosign == nil && verification.Provenance == nil {
+		allErrs = append(allErrs, field.Required(verificationPath, "at least one of cosign or provenance must be defined"))
+		return allErrs
+	}
+
+	for i, v := range vs {
+		sourcePath := parentFieldPath.Child(fmt.Sprintf("versions[%d].template.source", i))
+		source := v.Template.Source
+		switch {
+		case source.Helm != nil && strings.HasPrefix(source.Helm.URL, "oci://"):
+			if verification.Cosign == nil {
+				allErrs = append(allErrs, field.Forbidden(sourcePath.Child("helm"), "charts from OCI registries can only be verified with cosign"))
+			}
+		case source.Helm != nil:
+			if verification.Provenance == nil {
+				allErrs = append(allErrs, field.Forbidden(sourcePath.Child("helm"), "charts from Helm HTTP repositories can only be verified with a provenance file"))
+			}
+		case source.OCI != nil:
+			if verification.Cosign == nil {
+				allErrs = append(allErrs, field.Forbidden(sourcePath.Child("oci"), "OCI artifacts can only be verified with cosign"))
+			}
+		case source.Git != nil:
+			allErrs = append(allErrs, field.Forbidden(sourcePath.Child("git"), "git sources can not be verified"))
+		case source.Tarball != nil:
+			allErrs = append(allErrs, field.Forbidden(sourcePath.Child("tarball"), "tarball sources can not be verified"))
+		}
+	}
+	return allErrs
+}
+
 func validateHelmCredentials(credential *appskubermaticv1.HelmCredentials, f *field.Path) *field.Error {
 	if credential != nil {
 		if credential.RegistryConfigFile != nil && (credential.Username != nil || credential.Password != nil) {
@@ -109,6 +245,67 @@ func validateHelmCredentials(credential *appskubermaticv1.HelmCredentials, f *fi
 	return nil
 }
 
+func validateOCISource(ociSource *appskubermaticv1.OCISource, f *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	if len(ociSource.Tag) == 0 && len(ociSource.Digest) == 0 {
+		allErrs = append(allErrs, field.Required(f, "a tag or a digest must be defined"))
+	}
+
+	if credentials := ociSource.Credentials; credentials != nil {
+		// OCI registries use the same authentication methods as Helm OCI registries.
+		if e := validateHelmCredentials(&appskubermaticv1.HelmCredentials{
+			Username:           credentials.Username,
+			Password:           credentials.Password,
+			RegistryConfigFile: credentials.RegistryConfigFile,
+		}, f.Child("credentials")); e != nil {
+			allErrs = append(allErrs, e)
+		}
+	}
+
+	return allErrs
+}
+
+func validateTarballSource(tarballSource *appskubermaticv1.TarballSource, f *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	isS3 := strings.HasPrefix(tarballSource.URL, "s3://")
+	if isS3 && len(tarballSource.Endpoint) == 0 {
+		allErrs = append(allErrs, field.Required(f.Child("endpoint"), "endpoint is required for s3 urls"))
+	}
+	if !isS3 && len(tarballSource.Endpoint) > 0 {
+		allErrs = append(allErrs, field.Forbidden(f.Child("endpoint"), "endpoint can only be used with s3 urls"))
+	}
+
+	credentials := tarballSource.Credentials
+	if credentials == nil {
+		return allErrs
+	}
+	credPath := f.Child("credentials")
+
+	if (credentials.Username == nil) != (credentials.Password == nil) {
+		allErrs = append(allErrs, field.Forbidden(credPath, "username and password must be specified together"))
+	}
+	if (credentials.AccessKeyID == nil) != (credentials.SecretAccessKey == nil) {
+		allErrs = append(allErrs, field.Forbidden(credPath, "accessKeyID and secretAccessKey must be specified together"))
+	}
+
+	if isS3 {
+		if credentials.Username != nil || credentials.Password != nil || credentials.Token != nil {
+			allErrs = append(allErrs, field.Forbidden(credPath, "only accessKeyID and secretAccessKey can be used with s3 urls"))
+		}
+	} else {
+		if credentials.AccessKeyID != nil || credentials.SecretAccessKey != nil {
+			allErrs = append(allErrs, field.Forbidden(credPath, "accessKeyID and secretAccessKey can only be used with s3 urls"))
+		}
+		if credentials.Token != nil && (credentials.Username != nil || credentials.Password != nil) {
+			allErrs = append(allErrs, field.Forbidden(credPath.Child("token"), "token can not be used in conjunction with username / password"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateGitSource(gitSource *appskubermaticv1.GitSource, f *field.Path) []*field.Error {
 	allErrs := field.ErrorList{}
 
@@ -23,6 +23,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var (
@@ -98,6 +99,49 @@ func TestValidateApplicationDefinitionSpec(t *testing.T) {
 			},
 			0,
 		},
+		"valid kustomize method with git source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.KustomizeTemplateMethod
+					s.Versions = []appskubermaticv1.ApplicationVersion{gitv}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid manifests method with helm source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.ManifestsTemplateMethod
+					return *s
+				}(),
+			},
+			1,
+		},
+		"valid dependency": {
+			appskubermaticv1.ApplicationDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "ingress-consumer"},
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].DependsOn = []appskubermaticv1.ApplicationDependency{{Name: "cert-manager", Version: ">= 1.8"}, {Name: "nginx"}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid dependency": {
+			appskubermaticv1.ApplicationDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].DependsOn = []appskubermaticv1.ApplicationDependency{{Name: "cert-manager"}, {Name: "nginx", Version: "not a constraint"}}
+					return *s
+				}(),
+			},
+			2,
+		},
 		"invalid missing source": {
 			appskubermaticv1.ApplicationDefinition{
 				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
@@ -178,6 +222,202 @@ func TestValidateApplicationDefinitionSpec(t *testing.T) {
 			},
 			1,
 		},
+		"valid oci source with digest": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.ManifestsTemplateMethod
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{OCI: &appskubermaticv1.OCISource{URL: "oci://localhost:5000/bundle", Digest: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid oci source without tag and digest": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{OCI: &appskubermaticv1.OCISource{URL: "oci://localhost:5000/bundle"}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid oci source with malformed digest": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{OCI: &appskubermaticv1.OCISource{URL: "oci://localhost:5000/bundle", Digest: "sha256:1234"}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid oci source and helm source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{OCI: &appskubermaticv1.OCISource{URL: "oci://localhost:5000/bundle", Tag: "1.0.0"}, Helm: validHelmSource()}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"valid tarball source over http": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{Tarball: &appskubermaticv1.TarballSource{URL: "https://localhost/bundle.tar.gz", Checksum: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", Credentials: &appskubermaticv1.TarballCredentials{Token: secretKeySelector}}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"valid tarball source from s3": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{Tarball: &appskubermaticv1.TarballSource{URL: "s3://bucket/bundle.tar.gz", Endpoint: "https://s3.amazonaws.com", Checksum: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", Credentials: &appskubermaticv1.TarballCredentials{AccessKeyID: secretKeySelector, SecretAccessKey: secretKeySelector}}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid tarball source from s3 without endpoint": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{Tarball: &appskubermaticv1.TarballSource{URL: "s3://bucket/bundle.tar.gz", Checksum: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid tarball source without checksum": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{Tarball: &appskubermaticv1.TarballSource{URL: "https://localhost/bundle.tar.gz"}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid tarball source with s3 credentials over http": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{Tarball: &appskubermaticv1.TarballSource{URL: "https://localhost/bundle.tar.gz", Checksum: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", Credentials: &appskubermaticv1.TarballCredentials{AccessKeyID: secretKeySelector, SecretAccessKey: secretKeySelector}}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"valid inline values schema": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].ValuesSchema = &appskubermaticv1.ValuesSchema{Inline: &runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"replicas":{"type":"integer","default":1}}}`)}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid inline values schema": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].ValuesSchema = &appskubermaticv1.ValuesSchema{Inline: &runtime.RawExtension{Raw: []byte(`{"type":"unknown"}`)}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"valid values schema from chart": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].ValuesSchema = &appskubermaticv1.ValuesSchema{FromChart: true}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid values schema from chart with git source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[1].ValuesSchema = &appskubermaticv1.ValuesSchema{FromChart: true}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid values schema both inline and from chart": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].ValuesSchema = &appskubermaticv1.ValuesSchema{FromChart: true, Inline: &runtime.RawExtension{Raw: []byte(`{"type":"object"}`)}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"valid provenance verification of helm http source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions = []appskubermaticv1.ApplicationVersion{helmv}
+					s.Verification = &appskubermaticv1.SourceVerification{Provenance: &appskubermaticv1.ProvenanceVerification{Keyring: secretKeySelector}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"valid cosign verification of helm oci and oci sources": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions[0].Template.Source = appskubermaticv1.ApplicationSource{Helm: &appskubermaticv1.HelmSource{URL: "oci://localhost:5000/charts", ChartName: "test", ChartVersion: "1.0.0"}}
+					s.Versions[1].Template.Source = appskubermaticv1.ApplicationSource{OCI: &appskubermaticv1.OCISource{URL: "oci://localhost:5000/bundle", Tag: "1.0.0"}}
+					s.Verification = &appskubermaticv1.SourceVerification{Cosign: &appskubermaticv1.CosignVerification{PublicKeys: []corev1.SecretKeySelector{*secretKeySelector}}}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid verification without method": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions = []appskubermaticv1.ApplicationVersion{helmv}
+					s.Verification = &appskubermaticv1.SourceVerification{}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid cosign verification of helm http source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Versions = []appskubermaticv1.ApplicationVersion{helmv}
+					s.Verification = &appskubermaticv1.SourceVerification{Cosign: &appskubermaticv1.CosignVerification{}}
+					return *s
+				}(),
+			},
+			1,
+		},
+		"invalid verification of git source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Verification = &appskubermaticv1.SourceVerification{Provenance: &appskubermaticv1.ProvenanceVerification{}}
+					return *s
+				}(),
+			},
+			1,
+		},
 	}
 
 	for name, tc := range tt {
@@ -18,21 +18,34 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/valuesschema"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ValidateApplicationInstallationSpec validates the ApplicationInstallation Spec.
 func ValidateApplicationInstallationSpec(ctx context.Context, client ctrlruntimeclient.Client, spec appskubermaticv1.ApplicationInstallationSpec) field.ErrorList {
+	return validateApplicationInstallationSpec(ctx, client, spec, true)
+}
+
+func validateApplicationInstallationSpec(ctx context.Context, client ctrlruntimeclient.Client, spec appskubermaticv1.ApplicationInstallationSpec, validateValues bool) field.ErrorList {
 	specPath := field.NewPath("spec")
 	allErrs := field.ErrorList{}
 
+	allErrs = append(allErrs, validateDeployOptions(spec.DeployOptions, specPath.Child("deployOptions"))...)
+	allErrs = append(allErrs, validateDriftDetection(spec.DriftDetection, specPath.Child("driftDetection"))...)
+
 	// Ensure that the referenced ApplicationDefinition exists
 	ad := &appskubermaticv1.ApplicationDefinition{}
 	err := client.Get(ctx, types.NamespacedName{Name: spec.ApplicationRef.Name}, ad)
@@ -56,6 +69,100 @@ func ValidateApplicationInstallationSpec(ctx context.Context, client ctrlruntime
 
 	if !exists {
 		allErrs = append(allErrs, field.NotFound(specPath.Child("applicationRef", "version"), spec.ApplicationRef.Version))
+		return allErrs
+	}
+
+	if validateValues {
+		allErrs = append(allErrs, validateValuesAgainstSchema(ad, desiredVersion, spec.Values, specPath.Child("values"))...)
+	}
+
+	return allErrs
+}
+
+// validateValuesAgainstSchema validates the values against the values schema of the application version, if any.
+func validateValuesAgainstSchema(ad *appskubermaticv1.ApplicationDefinition, version string, rawValues runtime.RawExtension, f *field.Path) field.ErrorList {
+	schema := valuesschema.ForVersion(ad, version)
+	if schema == nil {
+		return nil
+	}
+
+	values, err := valuesschema.ValuesFromRawExtension(rawValues)
+	if err != nil {
+		return field.ErrorList{field.Invalid(f, string(rawValues.Raw), err.Error())}
+	}
+
+	if err := valuesschema.Validate(schema, values); err != nil {
+		return field.ErrorList{field.Invalid(f, string(rawValues.Raw), fmt.Sprintf("values do not match the schema of %s %s: %v", ad.Name, version, err))}
+	}
+
+	return nil
+}
+
+func validateDeployOptions(deployOptions *appskubermaticv1.DeployOptions, f *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if deployOptions == nil || deployOptions.Helm == nil {
+		return allErrs
+	}
+
+	helmPath := f.Child("helm")
+	helm := deployOptions.Helm
+
+	if helm.Atomic && !helm.Wait {
+		allErrs = append(allErrs, field.Forbidden(helmPath.Child("wait"), "wait must be set when atomic is set"))
+	}
+
+	if helm.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(helmPath.Child("timeout"), helm.Timeout.Duration.String(), "timeout must not be negative"))
+	}
+
+	if helm.MaxHistory < 0 {
+		allErrs = append(allErrs, field.Invalid(helmPath.Child("maxHistory"), helm.MaxHistory, "maxHistory must not be negative"))
+	}
+
+	return allErrs
+}
+
+// minDriftDetectionInterval prevents to flood the user cluster API server with drift detections.
+const minDriftDetectionInterval = time.Minute
+
+func validateDriftDetection(driftDetection *appskubermaticv1.DriftDetection, f *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if driftDetection == nil {
+		return allErrs
+	}
+
+	// 0 means the default interval.
+	if interval := driftDetection.Interval.Duration; interval != 0 && interval < minDriftDetectionInterval {
+		allErrs = append(allErrs, field.Invalid(f.Child("interval"), interval.String(), fmt.Sprintf("interval must be at least %s", minDriftDetectionInterval)))
+	}
+
+	return allErrs
+}
+
+// ValidateApplicationInstallationDependencies ensures that the ApplicationInstallation does not depend on itself and
+// that dependencies are not listed twice.
+func ValidateApplicationInstallationDependencies(ai appskubermaticv1.ApplicationInstallation) field.ErrorList {
+	dependsOnPath := field.NewPath("spec", "dependsOn")
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, dep := range ai.Spec.DependsOn {
+		namespace := dep.Namespace
+		if namespace == "" {
+			namespace = ai.Namespace
+		}
+		key := types.NamespacedName{Namespace: namespace, Name: dep.Name}.String()
+
+		if namespace == ai.Namespace && dep.Name == ai.Name {
+			allErrs = append(allErrs, field.Invalid(dependsOnPath.Index(i), key, "ApplicationInstallation can not depend on itself"))
+		}
+
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(dependsOnPath.Index(i), key))
+		}
+		seen.Insert(key)
 	}
 
 	return allErrs
@@ -66,11 +173,20 @@ func ValidateApplicationInstallationUpdate(ctx context.Context, client ctrlrunti
 	specPath := field.NewPath("spec")
 	allErrs := field.ErrorList{}
 
+	// The values are only checked against the schema when they or the referenced application changed, so that
+	// unrelated updates (e.g. removing the finalizer) are not blocked by a schema added to the version afterwards.
+	validateValues := newAI.DeletionTimestamp == nil &&
+		(!equality.Semantic.DeepEqual(newAI.Spec.Values, oldAI.Spec.Values) ||
+			newAI.Spec.ApplicationRef.Name != oldAI.Spec.ApplicationRef.Name ||
+			newAI.Spec.ApplicationRef.Version.String() != oldAI.Spec.ApplicationRef.Version.String())
+
 	// Validation for new ApplicationInstallation Spec
-	if errs := ValidateApplicationInstallationSpec(ctx, client, newAI.Spec); len(errs) > 0 {
+	if errs := validateApplicationInstallationSpec(ctx, client, newAI.Spec, validateValues); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, ValidateApplicationInstallationDependencies(newAI)...)
+
 	// Validate .Spec.Namespace.Create for immutability
 	allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
 		newAI.Spec.Namespace.Create,
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	semverlib "github.com/Masterminds/semver/v3"
 
@@ -86,6 +87,68 @@ func TestValidateApplicationInstallationSpec(t *testing.T) {
 				}(),
 			}, expectedError: `[spec.applicationRef.version: Not found: 3.2.3]`,
 		},
+		{
+			name: "Create ApplicationInstallation Success - Helm deploy options",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.DeployOptions = &appskubermaticv1.DeployOptions{Helm: &appskubermaticv1.HelmDeployOptions{Atomic: true, Wait: true, Timeout: metav1.Duration{Duration: 10 * time.Minute}, MaxHistory: 5}}
+					return *spec
+				}(),
+			}, expectedError: "[]",
+		},
+		{
+			name: "Create ApplicationInstallation Failure - Helm atomic without wait",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.DeployOptions = &appskubermaticv1.DeployOptions{Helm: &appskubermaticv1.HelmDeployOptions{Atomic: true}}
+					return *spec
+				}(),
+			}, expectedError: `[spec.deployOptions.helm.wait: Forbidden: wait must be set when atomic is set]`,
+		},
+		{
+			name: "Create ApplicationInstallation Success - drift detection",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.DriftDetection = &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: 5 * time.Minute}, SelfHeal: true}
+					return *spec
+				}(),
+			}, expectedError: "[]",
+		},
+		{
+			name: "Create ApplicationInstallation Failure - drift detection interval too short",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.DriftDetection = &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: 10 * time.Second}}
+					return *spec
+				}(),
+			}, expectedError: `[spec.driftDetection.interval: Invalid value: "10s": interval must be at least 1m0s]`,
+		},
+		{
+			name: "Create ApplicationInstallation Success - values match schema",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.ApplicationRef.Version = appskubermaticv1.Version{Version: *semverlib.MustParse(defaultAppSecondaryVersion)}
+					spec.Values = runtime.RawExtension{Raw: []byte(`{"replicas": 3}`)}
+					return *spec
+				}(),
+			}, expectedError: "[]",
+		},
+		{
+			name: "Create ApplicationInstallation Failure - values do not match schema",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.ApplicationRef.Version = appskubermaticv1.Version{Version: *semverlib.MustParse(defaultAppSecondaryVersion)}
+					spec.Values = runtime.RawExtension{Raw: []byte(`{"replicas": "three"}`)}
+					return *spec
+				}(),
+			}, expectedError: `[spec.values: Invalid value: "{\"replicas\": \"three\"}": values do not match the schema of app 1.2.4: replicas: Invalid type. Expected: integer, given: string]`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -112,6 +175,7 @@ func TestValidateApplicationInstallationUpdate(t *testing.T) {
 		Build()
 
 	ai := getApplicationInstallation(defaultAppName, defaultAppName, defaultAppVersion)
+	invalidValuesAI := getApplicationInstallation(defaultAppName, defaultAppName, defaultAppSecondaryVersion)
 
 	testCases := []struct {
 		name          string
@@ -156,6 +220,32 @@ func TestValidateApplicationInstallationUpdate(t *testing.T) {
 			},
 			expectedError: `[spec.applicationRef.name: Invalid value: "app": field is immutable]`,
 		},
+		{
+			name: "Update ApplicationInstallation Success - unchanged values are not validated against the schema",
+			ai:   withValues(invalidValuesAI, `{"replicas": "three"}`),
+			updatedAI: func() *appskubermaticv1.ApplicationInstallation {
+				updated := withValues(invalidValuesAI, `{"replicas": "three"}`)
+				updated.Finalizers = []string{"test-finalizer"}
+				return updated
+			}(),
+			expectedError: "[]",
+		},
+		{
+			name:          "Update ApplicationInstallation Failure - changed values are validated against the schema",
+			ai:            withValues(invalidValuesAI, `{"replicas": "three"}`),
+			updatedAI:     withValues(invalidValuesAI, `{"replicas": 3}`),
+			expectedError: `[spec.values: Invalid value: "{\"replicas\": \"three\"}": values do not match the schema of app 1.2.4: replicas: Invalid type. Expected: integer, given: string]`,
+		},
+		{
+			name: "Update ApplicationInstallation Success - values are not validated when the ApplicationInstallation is being deleted",
+			ai: func() *appskubermaticv1.ApplicationInstallation {
+				deleted := withValues(invalidValuesAI, `{"replicas": "three"}`)
+				deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return deleted
+			}(),
+			updatedAI:     withValues(invalidValuesAI, `{"replicas": 3}`),
+			expectedError: "[]",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -171,6 +261,51 @@ func TestValidateApplicationInstallationUpdate(t *testing.T) {
 	}
 }
 
+// TestValidateApplicationInstallationDependencies tests the validation of ApplicationInstallation dependencies.
+func TestValidateApplicationInstallationDependencies(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dependsOn     []appskubermaticv1.ApplicationInstallationReference
+		expectedError string
+	}{
+		{
+			name: "Dependencies Success",
+			dependsOn: []appskubermaticv1.ApplicationInstallationReference{
+				{Name: "cert-manager"},
+				{Name: "cert-manager", Namespace: "default"},
+			},
+			expectedError: "[]",
+		},
+		{
+			name: "Dependencies Failure - Depends on itself",
+			dependsOn: []appskubermaticv1.ApplicationInstallationReference{
+				{Name: defaultAppName, Namespace: "kube-system"},
+			},
+			expectedError: `[spec.dependsOn[0]: Invalid value: "kube-system/app": ApplicationInstallation can not depend on itself]`,
+		},
+		{
+			name: "Dependencies Failure - Duplicate",
+			dependsOn: []appskubermaticv1.ApplicationInstallationReference{
+				{Name: "cert-manager"},
+				{Name: "cert-manager", Namespace: "kube-system"},
+			},
+			expectedError: `[spec.dependsOn[1]: Duplicate value: "kube-system/cert-manager"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ai := getApplicationInstallation(defaultAppName, defaultAppName, defaultAppVersion)
+			ai.Spec.DependsOn = testCase.dependsOn
+
+			err := ValidateApplicationInstallationDependencies(*ai)
+			if fmt.Sprint(err) != testCase.expectedError {
+				t.Fatalf("expected error to be %s but got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
 func getApplicationDefinition(name string) *appskubermaticv1.ApplicationDefinition {
 	return &appskubermaticv1.ApplicationDefinition{
 		ObjectMeta: metav1.ObjectMeta{
@@ -184,12 +319,21 @@ func getApplicationDefinition(name string) *appskubermaticv1.ApplicationDefiniti
 				},
 				{
 					Version: defaultAppSecondaryVersion,
+					ValuesSchema: &appskubermaticv1.ValuesSchema{
+						Inline: &runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"replicas":{"type":"integer","default":1}}}`)},
+					},
 				},
 			},
 		},
 	}
 }
 
+func withValues(ai *appskubermaticv1.ApplicationInstallation, values string) *appskubermaticv1.ApplicationInstallation {
+	ai = ai.DeepCopy()
+	ai.Spec.Values = runtime.RawExtension{Raw: []byte(values)}
+	return ai
+}
+
 func getApplicationInstallation(name string, appName string, appVersion string) *appskubermaticv1.ApplicationInstallation {
 	return &appskubermaticv1.ApplicationInstallation{
 		ObjectMeta: metav1.ObjectMeta{
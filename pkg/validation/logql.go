@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// logQLBrackets maps the closing brackets to their opening counterparts.
+var logQLBrackets = map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+// ValidateLogQLExpression checks a LogQL expression as used in Loki alerting and recording
+// rules. The LogQL parser of Loki cannot be imported without the Prometheus version it
+// depends on, so only what can be checked reliably without it is validated: strings and
+// brackets must be balanced, stream selectors must be valid label matchers, regular
+// expressions must compile, and as rules require metric queries, at least one stream
+// selector and one valid range must be given. The Loki ruler validates the rest.
+func ValidateLogQLExpression(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return errors.New("expression cannot be empty")
+	}
+
+	var (
+		// stack holds the positions of the open brackets.
+		stack     []int
+		selectors int
+		ranges    int
+		// regexOperator is true if the last token was a regular expression match.
+		regexOperator bool
+	)
+
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+
+		switch c {
+		case '"', '`':
+			end, value, err := scanLogQLString(expr, i)
+			if err != nil {
+				return err
+			}
+			if regexOperator {
+				if _, err := regexp.Compile(value); err != nil {
+					return fmt.Errorf("invalid regular expression %q at position %d: %w", value, i, err)
+				}
+			}
+			regexOperator = false
+			i = end
+			continue
+
+		case '(', '[', '{':
+			stack = append(stack, i)
+
+		case ')', ']', '}':
+			if len(stack) == 0 || expr[stack[len(stack)-1]] != logQLBrackets[c] {
+				return fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+			start := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			switch c {
+			case '}':
+				if err := validateLogQLStreamSelector(expr[start : i+1]); err != nil {
+					return fmt.Errorf("invalid stream selector %s: %w", expr[start:i+1], err)
+				}
+				selectors++
+			case ']':
+				if _, err := model.ParseDuration(strings.TrimSpace(expr[start+1 : i])); err != nil {
+					return fmt.Errorf("invalid range %s: %w", expr[start:i+1], err)
+				}
+				ranges++
+			}
+		}
+
+		if c != ' ' && c != '\t' && c != '\n' {
+			regexOperator = c == '~' && i > 0 && strings.ContainsRune("=!|", rune(expr[i-1]))
+		}
+	}
+
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q at position %d", expr[stack[len(stack)-1]], stack[len(stack)-1])
+	}
+	if selectors == 0 {
+		return errors.New("expression must contain a stream selector")
+	}
+	if ranges == 0 {
+		return errors.New("expression must be a metric query with a range, e.g. rate({app=\"foo\"}[5m])")
+	}
+
+	return nil
+}
+
+// validateLogQLStreamSelector parses the label matchers of a stream selector. Like Loki,
+// it requires at least one matcher that does not match the empty string.
+func validateLogQLStreamSelector(selector string) error {
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	for _, matcher := range matchers {
+		if !matcher.Matches("") {
+			return nil
+		}
+	}
+
+	return errors.New("at least one matcher must not match the empty string")
+}
+
+// scanLogQLString scans the string starting at the given position and returns the position
+// of its closing quote and its unquoted value.
+func scanLogQLString(expr string, start int) (int, string, error) {
+	quote := expr[start]
+
+	for i := start + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			value, err := strconv.Unquote(expr[start : i+1])
+			if err != nil {
+				return 0, "", fmt.Errorf("invalid string at position %d: %w", start, err)
+			}
+			return i, value, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("unterminated string at position %d", start)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+)
+
+func TestValidateLogQLExpression(t *testing.T) {
+	valid := []string{
+		`rate({app="foo"}[5m])`,
+		`count_over_time({app="foo", env=~"prod|dev"} |= "error" != "timeout" [1h]) > 10`,
+		`sum by (app) (rate({namespace="prod"} |~ "err(or)?" | json | level="error" [5m])) / sum by (app) (rate({namespace="prod"}[5m]))`,
+		`sum(rate({app="foo"} | json status="response.status", method | status >= 500 [5m])) without (pod)`,
+		`quantile_over_time(0.99, {app="api"} | logfmt | unwrap duration(latency) | __error__="" [1m]) by (app)`,
+		`avg_over_time({app="api"} | logfmt | unwrap bytes [1m] offset 1h)`,
+		`sum_over_time({app="api"} | pattern "<ip> - <_> <status>" | status > 400 and ip != ip("10.0.0.0/8") | unwrap size [5m])`,
+		`topk(5, sum by (path) (count_over_time({app="web"} | regexp "(?P<path>/\\w+)" [10m])))`,
+		`bytes_rate(({app="foo"} | line_format "{{.msg}}" | label_format level=lvl, msg="{{.message}}")[5m])`,
+		`absent_over_time({app="foo"} | drop level, method="GET" [5m])`,
+		`count_over_time({app="foo"} | duration > 10s or size >= 5MB [5m]) > bool on (app) group_left () vector(1)`,
+		`label_replace(rate({app="foo"}[5m]), "service", "$1", "app", "(.*)")`,
+		`-1 + 2 * count_over_time({app="foo"}[1d]) ^ 2`,
+	}
+	for _, expr := range valid {
+		if err := ValidateLogQLExpression(expr); err != nil {
+			t.Errorf("Expected %s to be valid, but got: %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		``,
+		`{app="foo"}`,
+		`{app="foo"} |= "error"`,
+		`rate({app=""}[5m])`,
+		`rate({app!~"(foo"}[5m])`,
+		`rate({app="foo"}[5x])`,
+		`rate({app="foo"})`,
+		`rate({app="foo"}[5m]`,
+		`rate({app="foo"}[5m]))`,
+		`rate({app="foo"[5m]})`,
+		`rate({app="foo"} |~ "(" [5m])`,
+		`rate({app="foo"} | status !~ "[" [5m])`,
+		`rate({app="foo"} |= "error [5m])`,
+		`up == 0`,
+	}
+	for _, expr := range invalid {
+		if err := ValidateLogQLExpression(expr); err == nil {
+			t.Errorf("Expected %s to be invalid", expr)
+		}
+	}
+}
@@ -30,6 +30,25 @@ import (
 
 var reportTypes = []string{"cluster", "namespace"}
 
+var reportFormats = []string{
+	string(kubermaticv1.MeteringReportFormatCSV),
+	string(kubermaticv1.MeteringReportFormatJSON),
+	string(kubermaticv1.MeteringReportFormatParquet),
+}
+
+// MeteringReportMetrics is the list of metrics the metering tool is able to include in a report.
+var MeteringReportMetrics = []string{
+	"average-cluster-machines",
+	"max-cluster-machines",
+	"total-used-cpu-seconds",
+	"average-used-cpu-millicores",
+	"average-available-cpu-millicores",
+	"average-used-memory-bytes",
+	"average-available-memory-bytes",
+	"average-used-storage-bytes",
+	"average-available-storage-bytes",
+}
+
 func GetCronExpressionParser() cron.Parser {
 	return cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
 }
@@ -51,6 +70,38 @@ func ValidateMeteringConfiguration(configuration *kubermaticv1.MeteringConfigura
 					return fmt.Errorf("invalid report type: %s", t)
 				}
 			}
+
+			formats := make([]string, 0, len(reportConfig.Formats))
+			for _, f := range reportConfig.Formats {
+				formats = append(formats, string(f))
+			}
+			if err := ValidateMeteringReportFormats(formats); err != nil {
+				return err
+			}
+
+			if err := ValidateMeteringReportMetrics(reportConfig.Metrics); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateMeteringReportFormats returns an error if any of the given report formats is not supported.
+func ValidateMeteringReportFormats(formats []string) error {
+	for _, f := range formats {
+		if !slices.Contains(reportFormats, f) {
+			return fmt.Errorf("invalid report format: %s", f)
+		}
+	}
+	return nil
+}
+
+// ValidateMeteringReportMetrics returns an error if any of the given metrics is unknown to the metering tool.
+func ValidateMeteringReportMetrics(metrics []string) error {
+	for _, m := range metrics {
+		if !slices.Contains(MeteringReportMetrics, m) {
+			return fmt.Errorf("invalid report metric: %s", m)
 		}
 	}
 	return nil
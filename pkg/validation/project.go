@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8c.io/kubermatic/v2/pkg/apis/equality"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const lockedByProjectMessage = "this setting is locked by the project's cluster policy"
+
+// ValidateClusterProjectPolicy validates that a cluster complies with the cluster
+// policy of its project. The allowed datacenters are only checked for new clusters,
+// so that existing clusters are not blocked by later changes to the policy.
+func ValidateClusterProjectPolicy(cluster *kubermaticv1.Cluster, policy *kubermaticv1.ProjectClusterPolicy, isCreate bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy == nil {
+		return allErrs
+	}
+
+	specPath := field.NewPath("spec")
+	spec := &cluster.Spec
+
+	if isCreate && len(policy.AllowedDatacenters) > 0 {
+		if !sets.NewString(policy.AllowedDatacenters...).Has(spec.Cloud.DatacenterName) {
+			allErrs = append(allErrs, field.NotSupported(specPath.Child("cloud", "dc"), spec.Cloud.DatacenterName, policy.AllowedDatacenters))
+		}
+	}
+
+	locked := policy.Locked
+	if locked == nil {
+		return allErrs
+	}
+
+	if locked.AuditLogging != nil && !equality.Semantic.DeepEqual(spec.AuditLogging, locked.AuditLogging) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("auditLogging"), lockedByProjectMessage))
+	}
+
+	if locked.OPAIntegration != nil {
+		enabled := spec.OPAIntegration != nil && spec.OPAIntegration.Enabled
+		if enabled != *locked.OPAIntegration {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("opaIntegration", "enabled"), lockedByProjectMessage))
+		}
+	}
+
+	if locked.MonitoringEnabled != nil {
+		enabled := spec.MLA != nil && spec.MLA.MonitoringEnabled
+		if enabled != *locked.MonitoringEnabled {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("mla", "monitoringEnabled"), lockedByProjectMessage))
+		}
+	}
+
+	if locked.LoggingEnabled != nil {
+		enabled := spec.MLA != nil && spec.MLA.LoggingEnabled
+		if enabled != *locked.LoggingEnabled {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("mla", "loggingEnabled"), lockedByProjectMessage))
+		}
+	}
+
+	if missing := sets.NewString(locked.AdmissionPlugins...).Difference(sets.NewString(spec.AdmissionPlugins...)); missing.Len() > 0 {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("admissionPlugins"), fmt.Sprintf("admission plugins %s are required by the project's cluster policy", strings.Join(missing.List(), ", "))))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/utils/pointer"
+)
+
+func TestValidateClusterProjectPolicy(t *testing.T) {
+	policy := &kubermaticv1.ProjectClusterPolicy{
+		AllowedDatacenters: []string{"allowed-dc"},
+		Locked: &kubermaticv1.ProjectClusterSettings{
+			OPAIntegration:    pointer.BoolPtr(true),
+			MonitoringEnabled: pointer.BoolPtr(true),
+			AdmissionPlugins:  []string{"PodNodeSelector"},
+		},
+	}
+
+	compliantSpec := kubermaticv1.ClusterSpec{
+		Cloud: kubermaticv1.CloudSpec{
+			DatacenterName: "allowed-dc",
+		},
+		OPAIntegration:   &kubermaticv1.OPAIntegrationSettings{Enabled: true},
+		MLA:              &kubermaticv1.MLASettings{MonitoringEnabled: true},
+		AdmissionPlugins: []string{"EventRateLimit", "PodNodeSelector"},
+	}
+
+	tests := []struct {
+		name      string
+		spec      func() kubermaticv1.ClusterSpec
+		isCreate  bool
+		expectErr bool
+	}{
+		{
+			name:     "compliant cluster",
+			spec:     func() kubermaticv1.ClusterSpec { return compliantSpec },
+			isCreate: true,
+		},
+		{
+			name: "datacenter not allowed on create",
+			spec: func() kubermaticv1.ClusterSpec {
+				spec := compliantSpec.DeepCopy()
+				spec.Cloud.DatacenterName = "other-dc"
+				return *spec
+			},
+			isCreate:  true,
+			expectErr: true,
+		},
+		{
+			name: "datacenter not allowed is ignored on update",
+			spec: func() kubermaticv1.ClusterSpec {
+				spec := compliantSpec.DeepCopy()
+				spec.Cloud.DatacenterName = "other-dc"
+				return *spec
+			},
+		},
+		{
+			name: "locked OPA integration disabled",
+			spec: func() kubermaticv1.ClusterSpec {
+				spec := compliantSpec.DeepCopy()
+				spec.OPAIntegration = nil
+				return *spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "locked monitoring disabled",
+			spec: func() kubermaticv1.ClusterSpec {
+				spec := compliantSpec.DeepCopy()
+				spec.MLA.MonitoringEnabled = false
+				return *spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "locked admission plugin missing",
+			spec: func() kubermaticv1.ClusterSpec {
+				spec := compliantSpec.DeepCopy()
+				spec.AdmissionPlugins = []string{"EventRateLimit"}
+				return *spec
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: tc.spec(),
+			}
+
+			errs := ValidateClusterProjectPolicy(cluster, policy, tc.isCreate)
+			if (len(errs) > 0) != tc.expectErr {
+				t.Fatalf("Expected error: %v, but got: %v", tc.expectErr, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"gopkg.in/yaml.v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+// ValidateRuleGroup validates the rule group of the given type. The rule structure is
+// validated for all types, the expressions are parsed as PromQL for metrics and checked
+// as LogQL for logs.
+func ValidateRuleGroup(ruleGroupType kubermaticv1.RuleGroupType, data []byte) error {
+	group, err := decodeRuleGroup(data)
+	if err != nil {
+		return err
+	}
+
+	if group.Name == "" {
+		return errors.New("rule group name cannot be empty")
+	}
+
+	switch ruleGroupType {
+	case kubermaticv1.RuleGroupTypeMetrics:
+		var errs []string
+		for i := range group.Rules {
+			for _, err := range group.Rules[i].Validate() {
+				errs = append(errs, fmt.Sprintf("rule %d: %v", i, err.Error()))
+			}
+		}
+		if len(errs) > 0 {
+			return errors.New(strings.Join(errs, "; "))
+		}
+	case kubermaticv1.RuleGroupTypeLogs:
+		for i, rule := range group.Rules {
+			if err := validateLogRule(rule); err != nil {
+				return fmt.Errorf("rule %d: %w", i, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported rule group type %q", ruleGroupType)
+	}
+
+	return nil
+}
+
+// decodeRuleGroup decodes a single Prometheus-style rule group. Unknown fields are allowed,
+// as the Cortex and Loki rulers extend the format, e.g. with source_tenants.
+func decodeRuleGroup(data []byte) (*rulefmt.RuleGroup, error) {
+	group := &rulefmt.RuleGroup{}
+	if err := yaml.Unmarshal(data, group); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal rule group data in yaml: %w", err)
+	}
+
+	return group, nil
+}
+
+func validateLogRule(rule rulefmt.RuleNode) error {
+	switch {
+	case rule.Record.Value != "" && rule.Alert.Value != "":
+		return errors.New("only one of 'record' and 'alert' must be set")
+	case rule.Record.Value == "" && rule.Alert.Value == "":
+		return errors.New("one of 'record' or 'alert' must be set")
+	case rule.Expr.Value == "":
+		return errors.New("field 'expr' must be set in rule")
+	}
+
+	if rule.Record.Value != "" {
+		if len(rule.Annotations) > 0 {
+			return errors.New("invalid field 'annotations' in recording rule")
+		}
+		if rule.For != 0 {
+			return errors.New("invalid field 'for' in recording rule")
+		}
+		if !model.IsValidMetricName(model.LabelValue(rule.Record.Value)) {
+			return fmt.Errorf("invalid recording rule name: %s", rule.Record.Value)
+		}
+	}
+
+	for name := range rule.Labels {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("invalid label name: %s", name)
+		}
+	}
+	for name := range rule.Annotations {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("invalid annotation name: %s", name)
+		}
+	}
+
+	if err := ValidateLogQLExpression(rule.Expr.Value); err != nil {
+		return fmt.Errorf("invalid expression %q: %w", rule.Expr.Value, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+func TestValidateRuleGroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		ruleGroupType kubermaticv1.RuleGroupType
+		data          string
+		expectErr     bool
+	}{
+		{
+			name:          "valid metrics rule group",
+			ruleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			data: `
+name: test
+rules:
+- record: job:up:sum
+  expr: sum by (job) (up)
+- alert: InstanceDown
+  expr: up == 0
+  for: 5m
+  labels:
+    severity: page
+  annotations:
+    summary: "Instance {{ $labels.instance }} down"
+`,
+		},
+		{
+			name:          "invalid PromQL expression",
+			ruleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			data: `
+name: test
+rules:
+- alert: InstanceDown
+  expr: sum(up[5m)
+`,
+			expectErr: true,
+		},
+		{
+			name:          "invalid annotation template",
+			ruleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			data: `
+name: test
+rules:
+- alert: InstanceDown
+  expr: up == 0
+  annotations:
+    summary: "{{ $labels.instance "
+`,
+			expectErr: true,
+		},
+		{
+			name:          "rule is both recording and alerting rule",
+			ruleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			data: `
+name: test
+rules:
+- alert: InstanceDown
+  record: instance:down
+  expr: up == 0
+`,
+			expectErr: true,
+		},
+		{
+			name:          "ruler extension fields",
+			ruleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			data: `
+name: test
+source_tenants: [tenant-a, tenant-b]
+rules:
+- alert: InstanceDown
+  expr: up == 0
+`,
+		},
+		{
+			name:          "missing name",
+			ruleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			data: `
+rules:
+- alert: InstanceDown
+  expr: up == 0
+`,
+			expectErr: true,
+		},
+		{
+			name:          "valid logs rule group",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: HighErrorRate
+  expr: sum by (app) (rate({namespace="prod", app=~"api|web"} |= "error" | json | line_format "{{.msg}}" [5m])) > 10
+  for: 10m
+- record: app:latency:p99
+  expr: quantile_over_time(0.99, {app="api"} | logfmt | unwrap duration(latency) [1m]) by (app)
+`,
+		},
+		{
+			name:          "LogQL expression without range aggregation",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: Errors
+  expr: '{app="api"} |= "error"'
+`,
+			expectErr: true,
+		},
+		{
+			name:          "LogQL expression with invalid regular expression",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: Errors
+  expr: sum(count_over_time({app="api"} |~ "err(or" [5m])) > 0
+`,
+			expectErr: true,
+		},
+		{
+			name:          "LogQL expression with invalid stream selector",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: Errors
+  expr: sum(count_over_time({app=""}[5m])) > 0
+`,
+			expectErr: true,
+		},
+		{
+			name:          "LogQL expression with invalid range",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: Errors
+  expr: sum(count_over_time({app="api"}[5x])) > 0
+`,
+			expectErr: true,
+		},
+		{
+			name:          "LogQL expression with unbalanced brackets",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: Errors
+  expr: sum(count_over_time({app="api"}[5m]) > 0
+`,
+			expectErr: true,
+		},
+		{
+			name:          "PromQL expression in logs rule group",
+			ruleGroupType: kubermaticv1.RuleGroupTypeLogs,
+			data: `
+name: test
+rules:
+- alert: InstanceDown
+  expr: up == 0
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateRuleGroup(test.ruleGroupType, []byte(test.data))
+			if (err != nil) != test.expectErr {
+				t.Fatalf("Expected err=%v, but got: %v", test.expectErr, err)
+			}
+		})
+	}
+}
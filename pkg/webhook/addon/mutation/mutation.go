@@ -25,6 +25,7 @@ import (
 
 	"github.com/go-logr/logr"
 
+	addonutils "k8c.io/kubermatic/v2/pkg/addon"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
@@ -33,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -83,6 +85,11 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("addon mutation request %s failed: %w", req.UID, err))
 		}
 
+		if err := h.defaultVariables(ctx, addon); err != nil {
+			h.log.Error(err, "addon mutation failed")
+			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("addon mutation request %s failed: %w", req.UID, err))
+		}
+
 	case admissionv1.Update:
 		oldAddon := &kubermaticv1.Addon{}
 
@@ -100,6 +107,11 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("addon mutation request %s failed: %w", req.UID, err))
 		}
 
+		if err := h.defaultVariables(ctx, addon); err != nil {
+			h.log.Error(err, "addon mutation failed")
+			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("addon mutation request %s failed: %w", req.UID, err))
+		}
+
 	case admissionv1.Delete:
 		return webhook.Allowed(fmt.Sprintf("no mutation done for request %s", req.UID))
 
@@ -115,18 +127,27 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 	return admission.PatchResponseFromRaw(req.Object.Raw, mutatedAddon)
 }
 
-func (h *AdmissionHandler) ensureClusterReference(ctx context.Context, addon *kubermaticv1.Addon) error {
+func (h *AdmissionHandler) getSeedClient() (ctrlruntimeclient.Client, error) {
 	seed, err := h.seedGetter()
 	if err != nil {
-		return fmt.Errorf("failed to get current Seed: %w", err)
+		return nil, fmt.Errorf("failed to get current Seed: %w", err)
 	}
 	if seed == nil {
-		return errors.New("webhook not configured for a Seed cluster, cannot validate Addon resources")
+		return nil, errors.New("webhook not configured for a Seed cluster, cannot validate Addon resources")
 	}
 
 	client, err := h.seedClientGetter(seed)
 	if err != nil {
-		return fmt.Errorf("failed to get Seed client: %w", err)
+		return nil, fmt.Errorf("failed to get Seed client: %w", err)
+	}
+
+	return client, nil
+}
+
+func (h *AdmissionHandler) ensureClusterReference(ctx context.Context, addon *kubermaticv1.Addon) error {
+	client, err := h.getSeedClient()
+	if err != nil {
+		return err
 	}
 
 	cluster, err := kubernetes.ClusterFromNamespace(ctx, client, addon.Namespace)
@@ -168,3 +189,37 @@ func (h *AdmissionHandler) validateUpdate(ctx context.Context, oldAddon *kuberma
 
 	return nil
 }
+
+// defaultVariables sets the variables that are not set to the defaults of the controls of the AddonConfig.
+func (h *AdmissionHandler) defaultVariables(ctx context.Context, addon *kubermaticv1.Addon) error {
+	if addon.DeletionTimestamp != nil {
+		return nil
+	}
+
+	// default addons are managed by the addon installer based on the KubermaticConfiguration and rely on the
+	// defaults of their manifests, like in the validation.
+	if addon.Spec.IsDefault {
+		return nil
+	}
+
+	client, err := h.getSeedClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := addonutils.GetAddonConfig(ctx, client, addon.Spec.Name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return nil
+	}
+
+	variables, err := addonutils.DefaultVariables(config.Spec.Controls, addon.Spec.Variables)
+	if err != nil {
+		return fmt.Errorf("failed to default variables: %w", err)
+	}
+	addon.Spec.Variables = variables
+
+	return nil
+}
@@ -139,6 +139,84 @@ func TestHandle(t *testing.T) {
 				jsonpatch.NewOperation("replace", "/spec/cluster/uid", "12345"),
 			},
 		},
+		{
+			name: "Default variables from the AddonConfig",
+			clusters: []ctrlruntimeclient.Object{
+				cluster,
+				&kubermaticv1.AddonConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-addon",
+					},
+					Spec: kubermaticv1.AddonConfigSpec{
+						Controls: []kubermaticv1.AddonFormControl{
+							{InternalName: "enabled", Type: "boolean", Default: "true"},
+							{InternalName: "replicas", Type: "number", Default: "2"},
+							{InternalName: "name", Type: "text", Default: "default-name"},
+						},
+					},
+				},
+			},
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Addon",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawAddonGen{
+							Name:      "my-addon",
+							Namespace: cluster.Status.NamespaceName,
+							Cluster:   clusterRef,
+							Variables: `{"name":"custom-name"}`,
+						}.Do(),
+					},
+				},
+			},
+			wantError: false,
+			wantPatches: []jsonpatch.Operation{
+				jsonpatch.NewOperation("add", "/spec/variables/enabled", true),
+				jsonpatch.NewOperation("add", "/spec/variables/replicas", float64(2)),
+			},
+		},
+		{
+			name: "Do not default variables of default addons",
+			clusters: []ctrlruntimeclient.Object{
+				cluster,
+				&kubermaticv1.AddonConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-addon",
+					},
+					Spec: kubermaticv1.AddonConfigSpec{
+						Controls: []kubermaticv1.AddonFormControl{
+							{InternalName: "enabled", Type: "boolean", Default: "true"},
+						},
+					},
+				},
+			},
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Addon",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawAddonGen{
+							Name:      "my-addon",
+							Namespace: cluster.Status.NamespaceName,
+							Cluster:   clusterRef,
+							IsDefault: true,
+						}.Do(),
+					},
+				},
+			},
+			wantError: false,
+		},
 		{
 			name:     "Reject addons outside of cluster namespaces",
 			clusters: []ctrlruntimeclient.Object{cluster},
@@ -303,6 +381,8 @@ type rawAddonGen struct {
 	Namespace  string
 	Finalizers []string
 	Cluster    *corev1.ObjectReference
+	Variables  string
+	IsDefault  bool
 }
 
 func (r rawAddonGen) Do() []byte {
@@ -317,7 +397,8 @@ func (r rawAddonGen) Do() []byte {
 			Finalizers: r.Finalizers,
 		},
 		Spec: kubermaticv1.AddonSpec{
-			Name: r.Name,
+			Name:      r.Name,
+			IsDefault: r.IsDefault,
 		},
 	}
 
@@ -325,6 +406,10 @@ func (r rawAddonGen) Do() []byte {
 		addon.Spec.Cluster = *r.Cluster
 	}
 
+	if r.Variables != "" {
+		addon.Spec.Variables = &runtime.RawExtension{Raw: []byte(r.Variables)}
+	}
+
 	s := json.NewSerializerWithOptions(json.DefaultMetaFactory, testScheme, testScheme, json.SerializerOptions{Pretty: true})
 	buff := bytes.NewBuffer([]byte{})
 	_ = s.Encode(&addon, buff)
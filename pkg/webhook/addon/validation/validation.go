@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+
+	addonutils "k8c.io/kubermatic/v2/pkg/addon"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validator for validating Kubermatic Addon CRD.
+type validator struct {
+	client ctrlruntimeclient.Client
+}
+
+// NewValidator returns a new Addon validator.
+func NewValidator(client ctrlruntimeclient.Client) *validator {
+	return &validator{
+		client: client,
+	}
+}
+
+var _ admission.CustomValidator = &validator{}
+
+func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj)
+}
+
+func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldAddon, ok := oldObj.(*kubermaticv1.Addon)
+	if !ok {
+		return errors.New("existing object is not an Addon")
+	}
+	newAddon, ok := newObj.(*kubermaticv1.Addon)
+	if !ok {
+		return errors.New("updated object is not an Addon")
+	}
+
+	// Only changed variables are validated, so that other updates (e.g. of the status or the finalizers by the
+	// addon controller) are not blocked by an AddonConfig that has been changed after the addon was created.
+	if oldAddon.Spec.Name == newAddon.Spec.Name && equality.Semantic.DeepEqual(oldAddon.Spec.Variables, newAddon.Spec.Variables) {
+		return nil
+	}
+
+	return v.validate(ctx, newObj)
+}
+
+func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (v *validator) validate(ctx context.Context, obj runtime.Object) error {
+	addon, ok := obj.(*kubermaticv1.Addon)
+	if !ok {
+		return errors.New("object is not an Addon")
+	}
+
+	// addons being deleted must not be blocked by a changed AddonConfig
+	if addon.DeletionTimestamp != nil {
+		return nil
+	}
+
+	// default addons are managed by the addon installer based on the KubermaticConfiguration and commonly rely on
+	// the defaults of their manifests instead of variables.
+	if addon.Spec.IsDefault {
+		return nil
+	}
+
+	config, err := addonutils.GetAddonConfig(ctx, v.client, addon.Spec.Name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return nil
+	}
+
+	return addonutils.ValidateVariables(config.Spec.Controls, addon.Spec.Variables, field.NewPath("spec", "variables")).ToAggregate()
+}
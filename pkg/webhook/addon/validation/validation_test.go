@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testScheme = runtime.NewScheme()
+)
+
+func init() {
+	_ = kubermaticv1.AddToScheme(testScheme)
+}
+
+func TestValidator(t *testing.T) {
+	addonConfig := &kubermaticv1.AddonConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-addon",
+		},
+		Spec: kubermaticv1.AddonConfigSpec{
+			Controls: []kubermaticv1.AddonFormControl{
+				{InternalName: "hostname", Type: "text", Required: true},
+			},
+		},
+	}
+
+	newAddon := func(name string, variables string) *kubermaticv1.Addon {
+		addon := &kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "cluster-test",
+			},
+			Spec: kubermaticv1.AddonSpec{
+				Name: name,
+			},
+		}
+		if variables != "" {
+			addon.Spec.Variables = &runtime.RawExtension{Raw: []byte(variables)}
+		}
+		return addon
+	}
+
+	testCases := []struct {
+		name        string
+		addon       *kubermaticv1.Addon
+		objects     []ctrlruntimeclient.Object
+		expectedErr bool
+	}{
+		{
+			name:    "valid variables",
+			addon:   newAddon("test-addon", `{"hostname":"example.com"}`),
+			objects: []ctrlruntimeclient.Object{addonConfig},
+		},
+		{
+			name:        "invalid variables",
+			addon:       newAddon("test-addon", `{"hostname":true}`),
+			objects:     []ctrlruntimeclient.Object{addonConfig},
+			expectedErr: true,
+		},
+		{
+			name:        "missing required variables",
+			addon:       newAddon("test-addon", ""),
+			objects:     []ctrlruntimeclient.Object{addonConfig},
+			expectedErr: true,
+		},
+		{
+			name:  "addons without AddonConfig are not validated",
+			addon: newAddon("other-addon", `{"hostname":true}`),
+		},
+		{
+			name: "addons in deletion are not validated",
+			addon: func() *kubermaticv1.Addon {
+				addon := newAddon("test-addon", "")
+				now := metav1.Now()
+				addon.DeletionTimestamp = &now
+				return addon
+			}(),
+			objects: []ctrlruntimeclient.Object{addonConfig},
+		},
+		{
+			name: "default addons are not validated",
+			addon: func() *kubermaticv1.Addon {
+				addon := newAddon("test-addon", "")
+				addon.Spec.IsDefault = true
+				return addon
+			}(),
+			objects: []ctrlruntimeclient.Object{addonConfig},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := ctrlruntimefakeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(tc.objects...).Build()
+			v := NewValidator(client)
+
+			err := v.ValidateCreate(context.Background(), tc.addon)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %v, got %v", tc.expectedErr, err)
+			}
+
+			oldAddon := tc.addon.DeepCopy()
+			oldAddon.Spec.Variables = &runtime.RawExtension{Raw: []byte(`{"hostname":"old.example.com"}`)}
+
+			err = v.ValidateUpdate(context.Background(), oldAddon, tc.addon)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error on update: %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidatorUpdateWithUnchangedVariables(t *testing.T) {
+	addonConfig := &kubermaticv1.AddonConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-addon",
+		},
+		Spec: kubermaticv1.AddonConfigSpec{
+			Controls: []kubermaticv1.AddonFormControl{
+				{InternalName: "hostname", Type: "text", Required: true},
+			},
+		},
+	}
+
+	// the addon was created before the hostname became required
+	oldAddon := &kubermaticv1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-addon",
+			Namespace: "cluster-test",
+		},
+		Spec: kubermaticv1.AddonSpec{
+			Name: "test-addon",
+		},
+	}
+	newAddon := oldAddon.DeepCopy()
+	newAddon.Finalizers = []string{"cleanup-manifests"}
+
+	client := ctrlruntimefakeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(addonConfig).Build()
+	v := NewValidator(client)
+
+	if err := v.ValidateUpdate(context.Background(), oldAddon, newAddon); err != nil {
+		t.Fatalf("expected update without changed variables to be allowed, got %v", err)
+	}
+
+	newAddon.Spec.Variables = &runtime.RawExtension{Raw: []byte(`{"hostname":true}`)}
+	if err := v.ValidateUpdate(context.Background(), oldAddon, newAddon); err == nil {
+		t.Fatal("expected update with invalid variables to be rejected")
+	}
+}
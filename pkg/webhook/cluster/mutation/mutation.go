@@ -34,6 +34,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/version/cni"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
@@ -91,7 +93,13 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 
-		err := h.applyDefaults(ctx, cluster)
+		project, err := h.getProject(ctx, cluster)
+		if err != nil {
+			h.log.Error(err, "cluster mutation failed")
+			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("cluster mutation request %s failed: %w", req.UID, err))
+		}
+
+		err = h.applyDefaults(ctx, cluster, project)
 		if err != nil {
 			h.log.Error(err, "cluster mutation failed")
 			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("cluster mutation request %s failed: %w", req.UID, err))
@@ -102,6 +110,10 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 			return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("cluster mutation request %s failed: %w", req.UID, err))
 		}
 
+		if project != nil {
+			defaulting.EnforceProjectClusterPolicy(cluster, project)
+		}
+
 	case admissionv1.Update:
 		if err := h.decoder.Decode(req, cluster); err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
@@ -111,8 +123,14 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 		}
 
 		if cluster.DeletionTimestamp == nil {
+			project, err := h.getProject(ctx, cluster)
+			if err != nil {
+				h.log.Error(err, "cluster mutation failed")
+				return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("cluster mutation request %s failed: %w", req.UID, err))
+			}
+
 			// apply defaults to the existing clusters
-			err := h.applyDefaults(ctx, cluster)
+			err = h.applyDefaults(ctx, cluster, project)
 			if err != nil {
 				h.log.Error(err, "cluster mutation failed")
 				return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("cluster mutation request %s failed: %w", req.UID, err))
@@ -122,6 +140,10 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 				h.log.Error(err, "cluster mutation failed")
 				return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("cluster mutation request %s failed: %w", req.UID, err))
 			}
+
+			if project != nil {
+				defaulting.EnforceProjectClusterPolicy(cluster, project)
+			}
 		}
 
 	case admissionv1.Delete:
@@ -139,12 +161,37 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 	return admission.PatchResponseFromRaw(req.Object.Raw, mutatedCluster)
 }
 
-func (h *AdmissionHandler) applyDefaults(ctx context.Context, c *kubermaticv1.Cluster) error {
+// getProject returns the project the cluster belongs to. If the project does not
+// exist, nil is returned and the validation webhook takes care of rejecting the cluster.
+func (h *AdmissionHandler) getProject(ctx context.Context, c *kubermaticv1.Cluster) (*kubermaticv1.Project, error) {
+	projectID := c.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" {
+		return nil, nil
+	}
+
+	project := &kubermaticv1.Project{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: projectID}, project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (h *AdmissionHandler) applyDefaults(ctx context.Context, c *kubermaticv1.Cluster, project *kubermaticv1.Project) error {
 	seed, provider, fieldErr := h.buildDefaultingDependencies(ctx, c)
 	if fieldErr != nil {
 		return fieldErr
 	}
 
+	// project defaults take precedence over the seed-wide defaults
+	if project != nil {
+		defaulting.DefaultClusterSpecFromProjectPolicy(&c.Spec, project.Spec.ClusterPolicy)
+	}
+
 	config, err := h.configGetter(ctx)
 	if err != nil {
 		return err
@@ -95,10 +95,15 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) erro
 
 	errs := validation.ValidateNewClusterSpec(ctx, &cluster.Spec, datacenter, cloudProvider, versionManager, v.features, nil)
 
-	if err := v.validateProjectRelation(ctx, cluster, nil); err != nil {
+	project, err := v.validateProjectRelation(ctx, cluster, nil)
+	if err != nil {
 		errs = append(errs, err)
 	}
 
+	if project != nil {
+		errs = append(errs, validation.ValidateClusterProjectPolicy(cluster, project.Spec.ClusterPolicy, true)...)
+	}
+
 	return errs.ToAggregate()
 }
 
@@ -127,10 +132,16 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 
 	errs := validation.ValidateClusterUpdate(ctx, newCluster, oldCluster, datacenter, cloudProvider, updateManager, v.features)
 
-	if err := v.validateProjectRelation(ctx, newCluster, oldCluster); err != nil {
+	project, err := v.validateProjectRelation(ctx, newCluster, oldCluster)
+	if err != nil {
 		errs = append(errs, err)
 	}
 
+	// clusters in deletion must not be blocked by policy changes
+	if project != nil && newCluster.DeletionTimestamp == nil {
+		errs = append(errs, validation.ValidateClusterProjectPolicy(newCluster, project.Spec.ClusterPolicy, false)...)
+	}
+
 	return errs.ToAggregate()
 }
 
@@ -165,18 +176,20 @@ func (v *validator) buildValidationDependencies(ctx context.Context, c *kubermat
 	return datacenter, cloudProvider, nil
 }
 
-func (v *validator) validateProjectRelation(ctx context.Context, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) *field.Error {
+// validateProjectRelation ensures that the cluster belongs to an existing project and
+// returns that project. If the project does not exist (anymore), nil is returned.
+func (v *validator) validateProjectRelation(ctx context.Context, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) (*kubermaticv1.Project, *field.Error) {
 	label := kubermaticv1.ProjectIDLabelKey
 	fieldPath := field.NewPath("metadata", "labels")
 	isUpdate := oldCluster != nil
 
 	if isUpdate && cluster.Labels[label] != oldCluster.Labels[label] {
-		return field.Invalid(fieldPath, cluster.Labels[label], fmt.Sprintf("the %s label is immutable", label))
+		return nil, field.Invalid(fieldPath, cluster.Labels[label], fmt.Sprintf("the %s label is immutable", label))
 	}
 
 	projectID := cluster.Labels[label]
 	if projectID == "" {
-		return field.Required(fieldPath, fmt.Sprintf("Cluster resources must have a %q label", label))
+		return nil, field.Required(fieldPath, fmt.Sprintf("Cluster resources must have a %q label", label))
 	}
 
 	project := &kubermaticv1.Project{}
@@ -188,13 +201,13 @@ func (v *validator) validateProjectRelation(ctx context.Context, cluster *kuberm
 			// the cluster cleanup can still progress and is not blocked by the webhook rejecting
 			// the stale label
 			if isUpdate {
-				return nil
+				return nil, nil
 			}
 
-			return field.Invalid(fieldPath, projectID, "no such project exists")
+			return nil, field.Invalid(fieldPath, projectID, "no such project exists")
 		}
 
-		return field.InternalError(fieldPath, fmt.Errorf("failed to get project: %w", err))
+		return nil, field.InternalError(fieldPath, fmt.Errorf("failed to get project: %w", err))
 	}
 
 	// Do not check the project phase, as projects only get Active after being successfully
@@ -203,8 +216,14 @@ func (v *validator) validateProjectRelation(ctx context.Context, cluster *kuberm
 	// GitOps. Instead we rely on _eventual_ consistency and only check that the project
 	// exists and is not being deleted.
 	if !isUpdate && project.DeletionTimestamp != nil {
-		return field.Invalid(fieldPath, projectID, "project is in deletion, cannot create new clusters in it")
+		return nil, field.Invalid(fieldPath, projectID, "project is in deletion, cannot create new clusters in it")
 	}
 
-	return nil
+	// Suspended projects on the other hand are explicitly paused by an administrator or
+	// because they expired, so no new clusters may be added to them.
+	if !isUpdate && project.Status.Phase == kubermaticv1.ProjectSuspended {
+		return nil, field.Invalid(fieldPath, projectID, "project is suspended, cannot create new clusters in it")
+	}
+
+	return project, nil
 }
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	digitaloceantypes "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/digitalocean/types"
+	providerconfigtypes "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/digitalocean"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// mutator for defaulting MachineDeployments with the cloud resources that
+// Kubermatic manages for the cluster.
+type mutator struct {
+	log         *zap.SugaredLogger
+	seedClient  ctrlruntimeclient.Client
+	clusterName string
+}
+
+// NewMutator returns a new MachineDeployment mutator.
+func NewMutator(seedClient ctrlruntimeclient.Client, log *zap.SugaredLogger, clusterName string) *mutator {
+	return &mutator{
+		log:         log,
+		seedClient:  seedClient,
+		clusterName: clusterName,
+	}
+}
+
+var _ admission.CustomDefaulter = &mutator{}
+
+func (m *mutator) Default(ctx context.Context, obj runtime.Object) error {
+	machineDeployment, ok := obj.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return errors.New("object is not a MachineDeployment")
+	}
+
+	// Changing the template rolls out new machines, so existing MachineDeployments are only defaulted
+	// when their template is changed anyway and e.g. scaling them does not replace all nodes.
+	templateChanged, err := m.templateChanged(ctx, machineDeployment)
+	if err != nil {
+		return err
+	}
+	if !templateChanged {
+		return nil
+	}
+
+	config, err := providerconfigtypes.GetConfig(machineDeployment.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		return fmt.Errorf("failed to read providerSpec: %w", err)
+	}
+
+	if config.CloudProvider != providerconfigtypes.CloudProviderDigitalocean {
+		return nil
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := m.seedClient.Get(ctx, types.NamespacedName{Name: m.clusterName}, cluster); err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	if cluster.Spec.Cloud.Digitalocean == nil {
+		return nil
+	}
+
+	m.log.Debugw("defaulting MachineDeployment", "machinedeployment", machineDeployment.Name)
+
+	rawConfig, err := digitaloceantypes.GetConfig(*config)
+	if err != nil {
+		return fmt.Errorf("failed to read DigitalOcean providerSpec: %w", err)
+	}
+
+	defaultDigitaloceanConfig(rawConfig, cluster)
+
+	config.CloudProviderSpec.Raw, err = json.Marshal(rawConfig)
+	if err != nil {
+		return fmt.Errorf("failed to encode DigitalOcean providerSpec: %w", err)
+	}
+
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode providerSpec: %w", err)
+	}
+	machineDeployment.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+
+	return nil
+}
+
+// templateChanged returns whether the MachineDeployment is created or its template is updated.
+func (m *mutator) templateChanged(ctx context.Context, machineDeployment *clusterv1alpha1.MachineDeployment) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Update {
+		return true, nil
+	}
+
+	oldMachineDeployment := &clusterv1alpha1.MachineDeployment{}
+	if err := json.Unmarshal(req.OldObject.Raw, oldMachineDeployment); err != nil {
+		return false, fmt.Errorf("failed to decode existing MachineDeployment: %w", err)
+	}
+
+	return !equality.Semantic.DeepEqual(oldMachineDeployment.Spec.Template, machineDeployment.Spec.Template), nil
+}
+
+// defaultDigitaloceanConfig enables private networking for the droplets and tags
+// them, so that the cluster firewall applies to them and they get assigned to the
+// cluster project. The machine-controller does not allow choosing a VPC yet, so
+// droplets join the default VPC of the region.
+func defaultDigitaloceanConfig(config *digitaloceantypes.RawConfig, cluster *kubermaticv1.Cluster) {
+	config.PrivateNetworking = providerconfigtypes.ConfigVarBool{Value: pointer.Bool(true)}
+
+	tag := digitalocean.ClusterTag(cluster)
+	for _, existing := range config.Tags {
+		if existing.Value == tag {
+			return
+		}
+	}
+	config.Tags = append(config.Tags, providerconfigtypes.ConfigVarString{Value: tag})
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	digitaloceantypes "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/digitalocean/types"
+	providerconfigtypes "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	testScheme = runtime.NewScheme()
+)
+
+func init() {
+	_ = kubermaticv1.AddToScheme(testScheme)
+}
+
+func newMachineDeployment(t *testing.T, cloudProvider providerconfigtypes.CloudProvider, cloudProviderSpec interface{}) *clusterv1alpha1.MachineDeployment {
+	spec, err := json.Marshal(cloudProviderSpec)
+	if err != nil {
+		t.Fatalf("failed to encode cloudProviderSpec: %v", err)
+	}
+
+	config, err := json.Marshal(providerconfigtypes.Config{
+		CloudProvider:     cloudProvider,
+		CloudProviderSpec: runtime.RawExtension{Raw: spec},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode providerSpec: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Name = "md"
+	md.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: config}
+
+	return md
+}
+
+func TestDefaultDigitalocean(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "xyz",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{},
+			},
+		},
+	}
+
+	seedClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(cluster).Build()
+	m := NewMutator(seedClient, kubermaticlog.Logger, cluster.Name)
+
+	md := newMachineDeployment(t, providerconfigtypes.CloudProviderDigitalocean, digitaloceantypes.RawConfig{
+		Region: providerconfigtypes.ConfigVarString{Value: "fra1"},
+		Size:   providerconfigtypes.ConfigVarString{Value: "s-1vcpu-1gb"},
+		Tags:   []providerconfigtypes.ConfigVarString{{Value: "custom"}},
+	})
+
+	if err := m.Default(context.Background(), md); err != nil {
+		t.Fatalf("Default() failed: %v", err)
+	}
+
+	// defaulting must be idempotent
+	if err := m.Default(context.Background(), md); err != nil {
+		t.Fatalf("second Default() failed: %v", err)
+	}
+
+	config, err := providerconfigtypes.GetConfig(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		t.Fatalf("failed to read providerSpec: %v", err)
+	}
+	rawConfig, err := digitaloceantypes.GetConfig(*config)
+	if err != nil {
+		t.Fatalf("failed to read DigitalOcean providerSpec: %v", err)
+	}
+
+	if rawConfig.PrivateNetworking.Value == nil || !*rawConfig.PrivateNetworking.Value {
+		t.Error("expected private networking to be enabled")
+	}
+
+	tags := []string{}
+	for _, tag := range rawConfig.Tags {
+		tags = append(tags, tag.Value)
+	}
+	if expected := sets.NewString("custom", "kubernetes-cluster-xyz"); !expected.Equal(sets.NewString(tags...)) || len(tags) != expected.Len() {
+		t.Errorf("expected tags %v, got %v", expected.List(), tags)
+	}
+	if rawConfig.Size.Value != "s-1vcpu-1gb" {
+		t.Errorf("expected size to be kept, got %q", rawConfig.Size.Value)
+	}
+}
+
+func TestDefaultDigitaloceanUpdate(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "xyz",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{},
+			},
+		},
+	}
+
+	seedClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(cluster).Build()
+	m := NewMutator(seedClient, kubermaticlog.Logger, cluster.Name)
+
+	testCases := []struct {
+		name            string
+		modify          func(md *clusterv1alpha1.MachineDeployment)
+		expectedDefault bool
+	}{
+		{
+			name: "scaling does not change the template",
+			modify: func(md *clusterv1alpha1.MachineDeployment) {
+				md.Spec.Replicas = pointer.Int32(5)
+			},
+			expectedDefault: false,
+		},
+		{
+			name: "changing the template defaults it",
+			modify: func(md *clusterv1alpha1.MachineDeployment) {
+				md.Spec.Template.Spec.Versions.Kubelet = "1.24.3"
+			},
+			expectedDefault: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldMD := newMachineDeployment(t, providerconfigtypes.CloudProviderDigitalocean, digitaloceantypes.RawConfig{
+				Region: providerconfigtypes.ConfigVarString{Value: "fra1"},
+			})
+			oldRaw, err := json.Marshal(oldMD)
+			if err != nil {
+				t.Fatalf("failed to encode MachineDeployment: %v", err)
+			}
+
+			md := oldMD.DeepCopy()
+			tc.modify(md)
+			original := md.Spec.Template.Spec.ProviderSpec.Value.DeepCopy()
+
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+				},
+			})
+			if err := m.Default(ctx, md); err != nil {
+				t.Fatalf("Default() failed: %v", err)
+			}
+
+			defaulted := string(md.Spec.Template.Spec.ProviderSpec.Value.Raw) != string(original.Raw)
+			if defaulted != tc.expectedDefault {
+				t.Errorf("expected defaulting: %v, got providerSpec %s", tc.expectedDefault, md.Spec.Template.Spec.ProviderSpec.Value.Raw)
+			}
+		})
+	}
+}
+
+func TestDefaultOtherProvider(t *testing.T) {
+	seedClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	m := NewMutator(seedClient, kubermaticlog.Logger, "xyz")
+
+	md := newMachineDeployment(t, providerconfigtypes.CloudProviderHetzner, map[string]string{"serverType": "cx21"})
+	original := md.Spec.Template.Spec.ProviderSpec.Value.DeepCopy()
+
+	if err := m.Default(context.Background(), md); err != nil {
+		t.Fatalf("Default() failed: %v", err)
+	}
+
+	if string(md.Spec.Template.Spec.ProviderSpec.Value.Raw) != string(original.Raw) {
+		t.Errorf("expected providerSpec to be unchanged, got %s", md.Spec.Template.Spec.ProviderSpec.Value.Raw)
+	}
+}
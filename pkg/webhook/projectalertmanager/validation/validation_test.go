@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	validConfig = `
+alertmanager_config: |
+  route:
+    receiver: test
+  receivers:
+    - name: test
+`
+	invalidConfig = `
+alertmanager_config: |
+  route:
+    receiver: missing
+  receivers:
+    - name: test
+`
+)
+
+func TestValidator(t *testing.T) {
+	testCases := []struct {
+		name         string
+		alertmanager *kubermaticv1.ProjectAlertmanager
+		old          *kubermaticv1.ProjectAlertmanager
+		wantErr      bool
+	}{
+		{
+			name:         "valid config",
+			alertmanager: genProjectAlertmanager(validConfig),
+		},
+		{
+			name:         "invalid config",
+			alertmanager: genProjectAlertmanager(invalidConfig),
+			wantErr:      true,
+		},
+		{
+			name: "valid override",
+			alertmanager: genProjectAlertmanager(validConfig,
+				kubermaticv1.ProjectAlertmanagerClusterOverride{ClusterName: "a", Config: []byte(validConfig)},
+				kubermaticv1.ProjectAlertmanagerClusterOverride{ClusterName: "b", Disabled: true},
+			),
+		},
+		{
+			name: "invalid override update",
+			alertmanager: genProjectAlertmanager(validConfig,
+				kubermaticv1.ProjectAlertmanagerClusterOverride{ClusterName: "a", Config: []byte(invalidConfig)},
+			),
+			old:     genProjectAlertmanager(validConfig),
+			wantErr: true,
+		},
+		{
+			name: "override without cluster name",
+			alertmanager: genProjectAlertmanager(validConfig,
+				kubermaticv1.ProjectAlertmanagerClusterOverride{Disabled: true},
+			),
+			wantErr: true,
+		},
+		{
+			name: "invalid config in deletion",
+			alertmanager: func() *kubermaticv1.ProjectAlertmanager {
+				am := genProjectAlertmanager(invalidConfig)
+				am.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return am
+			}(),
+			old: genProjectAlertmanager(invalidConfig),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewValidator()
+
+			var err error
+			if tc.old == nil {
+				err = v.ValidateCreate(context.Background(), tc.alertmanager)
+			} else {
+				err = v.ValidateUpdate(context.Background(), tc.old, tc.alertmanager)
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected err=%v, but got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func genProjectAlertmanager(config string, overrides ...kubermaticv1.ProjectAlertmanagerClusterOverride) *kubermaticv1.ProjectAlertmanager {
+	return &kubermaticv1.ProjectAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "project",
+		},
+		Spec: kubermaticv1.ProjectAlertmanagerSpec{
+			Config:           []byte(config),
+			ClusterOverrides: overrides,
+		},
+	}
+}
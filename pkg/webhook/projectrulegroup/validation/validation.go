@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/validation"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validator for validating ProjectRuleGroup CRD.
+type validator struct{}
+
+// NewValidator returns a new ProjectRuleGroup validator.
+func NewValidator() *validator {
+	return &validator{}
+}
+
+var _ admission.CustomValidator = &validator{}
+
+func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(obj)
+}
+
+func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	newRuleGroup, ok := newObj.(*kubermaticv1.ProjectRuleGroup)
+	if !ok {
+		return errors.New("object is not a ProjectRuleGroup")
+	}
+
+	// allow removing finalizers from ProjectRuleGroups which were created before they were validated
+	if newRuleGroup.DeletionTimestamp != nil {
+		return nil
+	}
+
+	return v.validate(newObj)
+}
+
+func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (v *validator) validate(obj runtime.Object) error {
+	ruleGroup, ok := obj.(*kubermaticv1.ProjectRuleGroup)
+	if !ok {
+		return errors.New("object is not a ProjectRuleGroup")
+	}
+
+	if ruleGroup.Spec.Project.Name == "" {
+		return errors.New("project name must not be empty")
+	}
+
+	if err := validation.ValidateRuleGroup(ruleGroup.Spec.RuleGroupType, ruleGroup.Spec.Data); err != nil {
+		return fmt.Errorf("invalid rule group data: %w", err)
+	}
+
+	clusters := map[string]struct{}{}
+	for _, override := range ruleGroup.Spec.ClusterOverrides {
+		if override.ClusterName == "" {
+			return errors.New("cluster name of override must not be empty")
+		}
+		if _, ok := clusters[override.ClusterName]; ok {
+			return fmt.Errorf("duplicate override for cluster %s", override.ClusterName)
+		}
+		clusters[override.ClusterName] = struct{}{}
+
+		if len(override.Data) == 0 {
+			continue
+		}
+		if err := validation.ValidateRuleGroup(ruleGroup.Spec.RuleGroupType, override.Data); err != nil {
+			return fmt.Errorf("invalid rule group data of override for cluster %s: %w", override.ClusterName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	validLogsRuleGroup = `
+name: test
+rules:
+- alert: Errors
+  expr: sum(rate({app="api"} |= "error" [5m])) > 10
+`
+	invalidLogsRuleGroup = `
+name: test
+rules:
+- alert: Errors
+  expr: sum(rate({app="api"} |= "error")) > 10
+`
+)
+
+func TestValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ruleGroup *kubermaticv1.ProjectRuleGroup
+		old       *kubermaticv1.ProjectRuleGroup
+		wantErr   bool
+	}{
+		{
+			name:      "valid rule group",
+			ruleGroup: genProjectRuleGroup(validLogsRuleGroup),
+		},
+		{
+			name:      "invalid rule group",
+			ruleGroup: genProjectRuleGroup(invalidLogsRuleGroup),
+			wantErr:   true,
+		},
+		{
+			name: "missing project",
+			ruleGroup: func() *kubermaticv1.ProjectRuleGroup {
+				rg := genProjectRuleGroup(validLogsRuleGroup)
+				rg.Spec.Project.Name = ""
+				return rg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "valid override",
+			ruleGroup: genProjectRuleGroup(validLogsRuleGroup,
+				kubermaticv1.ProjectRuleGroupClusterOverride{ClusterName: "a", Data: []byte(validLogsRuleGroup)},
+				kubermaticv1.ProjectRuleGroupClusterOverride{ClusterName: "b", Disabled: true},
+			),
+		},
+		{
+			name: "invalid override update",
+			ruleGroup: genProjectRuleGroup(validLogsRuleGroup,
+				kubermaticv1.ProjectRuleGroupClusterOverride{ClusterName: "a", Data: []byte(invalidLogsRuleGroup)},
+			),
+			old:     genProjectRuleGroup(validLogsRuleGroup),
+			wantErr: true,
+		},
+		{
+			name: "duplicate override",
+			ruleGroup: genProjectRuleGroup(validLogsRuleGroup,
+				kubermaticv1.ProjectRuleGroupClusterOverride{ClusterName: "a", Disabled: true},
+				kubermaticv1.ProjectRuleGroupClusterOverride{ClusterName: "a", Data: []byte(validLogsRuleGroup)},
+			),
+			wantErr: true,
+		},
+		{
+			name: "invalid rule group in deletion",
+			ruleGroup: func() *kubermaticv1.ProjectRuleGroup {
+				rg := genProjectRuleGroup(invalidLogsRuleGroup)
+				rg.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return rg
+			}(),
+			old: genProjectRuleGroup(invalidLogsRuleGroup),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewValidator()
+
+			var err error
+			if tc.old == nil {
+				err = v.ValidateCreate(context.Background(), tc.ruleGroup)
+			} else {
+				err = v.ValidateUpdate(context.Background(), tc.old, tc.ruleGroup)
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected err=%v, but got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func genProjectRuleGroup(data string, overrides ...kubermaticv1.ProjectRuleGroupClusterOverride) *kubermaticv1.ProjectRuleGroup {
+	return &kubermaticv1.ProjectRuleGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kubermaticv1.ProjectRuleGroupSpec{
+			Project:          corev1.ObjectReference{Name: "project"},
+			RuleGroupType:    kubermaticv1.RuleGroupTypeLogs,
+			Data:             []byte(data),
+			ClusterOverrides: overrides,
+		},
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	validMetricsRuleGroup = `
+name: test
+rules:
+- alert: InstanceDown
+  expr: up == 0
+  for: 5m
+`
+	invalidMetricsRuleGroup = `
+name: test
+rules:
+- alert: InstanceDown
+  expr: up = = 0
+`
+)
+
+func TestValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ruleGroup *kubermaticv1.RuleGroup
+		old       *kubermaticv1.RuleGroup
+		wantErr   bool
+	}{
+		{
+			name:      "valid rule group",
+			ruleGroup: genRuleGroup(validMetricsRuleGroup),
+		},
+		{
+			name:      "invalid rule group",
+			ruleGroup: genRuleGroup(invalidMetricsRuleGroup),
+			wantErr:   true,
+		},
+		{
+			name:      "invalid rule group update",
+			ruleGroup: genRuleGroup(invalidMetricsRuleGroup),
+			old:       genRuleGroup(validMetricsRuleGroup),
+			wantErr:   true,
+		},
+		{
+			name: "invalid rule group in deletion",
+			ruleGroup: func() *kubermaticv1.RuleGroup {
+				rg := genRuleGroup(invalidMetricsRuleGroup)
+				rg.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return rg
+			}(),
+			old: genRuleGroup(invalidMetricsRuleGroup),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewValidator()
+
+			var err error
+			if tc.old == nil {
+				err = v.ValidateCreate(context.Background(), tc.ruleGroup)
+			} else {
+				err = v.ValidateUpdate(context.Background(), tc.old, tc.ruleGroup)
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected err=%v, but got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func genRuleGroup(data string) *kubermaticv1.RuleGroup {
+	return &kubermaticv1.RuleGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "cluster-test",
+		},
+		Spec: kubermaticv1.RuleGroupSpec{
+			RuleGroupType: kubermaticv1.RuleGroupTypeMetrics,
+			Data:          []byte(data),
+		},
+	}
+}